@@ -45,9 +45,28 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	restoreControlPlaneLoadBalancerStatus(&restored.Status.Network.APIServerELB, &dst.Status.Network.APIServerELB)
 
 	dst.Spec.S3Bucket = restored.Spec.S3Bucket
+	dst.Spec.PlacementGroups = restored.Spec.PlacementGroups
+	dst.Spec.ControlPlaneTerminationProtection = restored.Spec.ControlPlaneTerminationProtection
+	dst.Spec.Hibernation = restored.Spec.Hibernation
 	if restored.Status.Bastion != nil {
 		dst.Status.Bastion.InstanceMetadataOptions = restored.Status.Bastion.InstanceMetadataOptions
 		dst.Status.Bastion.PlacementGroupName = restored.Status.Bastion.PlacementGroupName
+		dst.Status.Bastion.PlacementGroupPartition = restored.Status.Bastion.PlacementGroupPartition
+		dst.Status.Bastion.HostID = restored.Status.Bastion.HostID
+		dst.Status.Bastion.HostAffinity = restored.Status.Bastion.HostAffinity
+		dst.Status.Bastion.HostResourceGroupArn = restored.Status.Bastion.HostResourceGroupArn
+		dst.Status.Bastion.CPUOptions = restored.Status.Bastion.CPUOptions
+		dst.Status.Bastion.NitroEnclaveEnabled = restored.Status.Bastion.NitroEnclaveEnabled
+		dst.Status.Bastion.HibernationEnabled = restored.Status.Bastion.HibernationEnabled
+		dst.Status.Bastion.TerminationProtection = restored.Status.Bastion.TerminationProtection
+		dst.Status.Bastion.PrivateDNSName = restored.Status.Bastion.PrivateDNSName
+		dst.Status.Bastion.ManagedNetworkInterfaces = restored.Status.Bastion.ManagedNetworkInterfaces
+		dst.Status.Bastion.NetworkInterfaceType = restored.Status.Bastion.NetworkInterfaceType
+		dst.Status.Bastion.AttachedNetworkInterfaces = restored.Status.Bastion.AttachedNetworkInterfaces
+		dst.Status.Bastion.RootDeviceName = restored.Status.Bastion.RootDeviceName
+		dst.Status.Bastion.LaunchTemplate = restored.Status.Bastion.LaunchTemplate
+		restoreRootVolumeSnapshot(dst.Status.Bastion.RootVolume, restored.Status.Bastion.RootVolume)
+		restoreVolumeSnapshots(dst.Status.Bastion.NonRootVolumes, restored.Status.Bastion.NonRootVolumes)
 	}
 	dst.Spec.Partition = restored.Spec.Partition
 
@@ -92,14 +111,15 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.NetworkSpec.AdditionalControlPlaneIngressRules = restored.Spec.NetworkSpec.AdditionalControlPlaneIngressRules
 
-	// Restore SubnetSpec.ResourceID field, if any.
+	// Restore SubnetSpec.ResourceID and SubnetSpec.PrivateDNSNameOptionsOnLaunch fields, if any.
 	for _, subnet := range restored.Spec.NetworkSpec.Subnets {
-		if len(subnet.ResourceID) == 0 {
+		if len(subnet.ResourceID) == 0 && subnet.PrivateDNSNameOptionsOnLaunch == nil {
 			continue
 		}
 		for i, dstSubnet := range dst.Spec.NetworkSpec.Subnets {
 			if dstSubnet.ID == subnet.ID {
 				dstSubnet.ResourceID = subnet.ResourceID
+				dstSubnet.PrivateDNSNameOptionsOnLaunch = subnet.PrivateDNSNameOptionsOnLaunch
 				dstSubnet.DeepCopyInto(&dst.Spec.NetworkSpec.Subnets[i])
 			}
 		}
@@ -38,6 +38,33 @@ func (src *AWSMachine) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Ignition = restored.Spec.Ignition
 	dst.Spec.InstanceMetadataOptions = restored.Spec.InstanceMetadataOptions
 	dst.Spec.PlacementGroupName = restored.Spec.PlacementGroupName
+	dst.Spec.PlacementGroupPartition = restored.Spec.PlacementGroupPartition
+	dst.Spec.HostID = restored.Spec.HostID
+	dst.Spec.HostAffinity = restored.Spec.HostAffinity
+	dst.Spec.HostResourceGroupArn = restored.Spec.HostResourceGroupArn
+	dst.Spec.DynamicHostAllocation = restored.Spec.DynamicHostAllocation
+	dst.Spec.CPUOptions = restored.Spec.CPUOptions
+	dst.Spec.NitroEnclaveEnabled = restored.Spec.NitroEnclaveEnabled
+	dst.Spec.HibernationEnabled = restored.Spec.HibernationEnabled
+	dst.Spec.PrivateDNSName = restored.Spec.PrivateDNSName
+	dst.Spec.ManagedNetworkInterfaces = restored.Spec.ManagedNetworkInterfaces
+	dst.Spec.NetworkInterfaceType = restored.Spec.NetworkInterfaceType
+	dst.Spec.FallbackInstanceTypes = restored.Spec.FallbackInstanceTypes
+	dst.Spec.FallbackSubnets = restored.Spec.FallbackSubnets
+	dst.Spec.AMI.SSMParameter = restored.Spec.AMI.SSMParameter
+	dst.Spec.LaunchTemplate = restored.Spec.LaunchTemplate
+	dst.Spec.OSType = restored.Spec.OSType
+	dst.Spec.TerminationProtection = restored.Spec.TerminationProtection
+	dst.Spec.DriftPolicy = restored.Spec.DriftPolicy
+	restoreRootVolumeSnapshot(dst.Spec.RootVolume, restored.Spec.RootVolume)
+	restoreVolumeSnapshots(dst.Spec.NonRootVolumes, restored.Spec.NonRootVolumes)
+	dst.Status.DedicatedHost = restored.Status.DedicatedHost
+	dst.Status.InstanceType = restored.Status.InstanceType
+	dst.Status.SubnetID = restored.Status.SubnetID
+	dst.Status.ImageID = restored.Status.ImageID
+	dst.Status.LaunchTemplateVersion = restored.Status.LaunchTemplateVersion
+	dst.Status.TerminationProtection = restored.Status.TerminationProtection
+	dst.Status.Hibernated = restored.Status.Hibernated
 
 	return nil
 }
@@ -85,10 +112,46 @@ func (r *AWSMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Template.Spec.Ignition = restored.Spec.Template.Spec.Ignition
 	dst.Spec.Template.Spec.InstanceMetadataOptions = restored.Spec.Template.Spec.InstanceMetadataOptions
 	dst.Spec.Template.Spec.PlacementGroupName = restored.Spec.Template.Spec.PlacementGroupName
+	dst.Spec.Template.Spec.PlacementGroupPartition = restored.Spec.Template.Spec.PlacementGroupPartition
+	dst.Spec.Template.Spec.HostID = restored.Spec.Template.Spec.HostID
+	dst.Spec.Template.Spec.HostAffinity = restored.Spec.Template.Spec.HostAffinity
+	dst.Spec.Template.Spec.HostResourceGroupArn = restored.Spec.Template.Spec.HostResourceGroupArn
+	dst.Spec.Template.Spec.DynamicHostAllocation = restored.Spec.Template.Spec.DynamicHostAllocation
+	dst.Spec.Template.Spec.CPUOptions = restored.Spec.Template.Spec.CPUOptions
+	dst.Spec.Template.Spec.NitroEnclaveEnabled = restored.Spec.Template.Spec.NitroEnclaveEnabled
+	dst.Spec.Template.Spec.HibernationEnabled = restored.Spec.Template.Spec.HibernationEnabled
+	dst.Spec.Template.Spec.PrivateDNSName = restored.Spec.Template.Spec.PrivateDNSName
+	dst.Spec.Template.Spec.ManagedNetworkInterfaces = restored.Spec.Template.Spec.ManagedNetworkInterfaces
+	dst.Spec.Template.Spec.NetworkInterfaceType = restored.Spec.Template.Spec.NetworkInterfaceType
+	dst.Spec.Template.Spec.FallbackInstanceTypes = restored.Spec.Template.Spec.FallbackInstanceTypes
+	dst.Spec.Template.Spec.FallbackSubnets = restored.Spec.Template.Spec.FallbackSubnets
+	dst.Spec.Template.Spec.AMI.SSMParameter = restored.Spec.Template.Spec.AMI.SSMParameter
+	dst.Spec.Template.Spec.LaunchTemplate = restored.Spec.Template.Spec.LaunchTemplate
+	dst.Spec.Template.Spec.OSType = restored.Spec.Template.Spec.OSType
+	dst.Spec.Template.Spec.TerminationProtection = restored.Spec.Template.Spec.TerminationProtection
+	dst.Spec.Template.Spec.DriftPolicy = restored.Spec.Template.Spec.DriftPolicy
+	restoreRootVolumeSnapshot(dst.Spec.Template.Spec.RootVolume, restored.Spec.Template.Spec.RootVolume)
+	restoreVolumeSnapshots(dst.Spec.Template.Spec.NonRootVolumes, restored.Spec.Template.Spec.NonRootVolumes)
 
 	return nil
 }
 
+// restoreRootVolumeSnapshot restores the snapshot of a root volume, which does not exist in v1beta1.
+func restoreRootVolumeSnapshot(dst, restored *infrav1.Volume) {
+	if dst != nil && restored != nil {
+		dst.Snapshot = restored.Snapshot
+	}
+}
+
+// restoreVolumeSnapshots restores the snapshots of volumes, which do not exist in v1beta1.
+func restoreVolumeSnapshots(dst, restored []infrav1.Volume) {
+	for i := range dst {
+		if i < len(restored) {
+			dst[i].Snapshot = restored[i].Snapshot
+		}
+	}
+}
+
 // ConvertFrom converts the v1beta2 AWSCluster receiver to a v1beta1 AWSCluster.
 func (r *AWSMachineTemplate) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1.AWSMachineTemplate)
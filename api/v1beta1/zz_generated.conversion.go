@@ -45,11 +45,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.AMIReference)(nil), (*AMIReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_AMIReference_To_v1beta1_AMIReference(a.(*v1beta2.AMIReference), b.(*AMIReference), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*AWSCluster)(nil), (*v1beta2.AWSCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AWSCluster_To_v1beta2_AWSCluster(a.(*AWSCluster), b.(*v1beta2.AWSCluster), scope)
 	}); err != nil {
@@ -265,11 +260,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.AWSMachineStatus)(nil), (*AWSMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_AWSMachineStatus_To_v1beta1_AWSMachineStatus(a.(*v1beta2.AWSMachineStatus), b.(*AWSMachineStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*AWSMachineTemplate)(nil), (*v1beta2.AWSMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AWSMachineTemplate_To_v1beta2_AWSMachineTemplate(a.(*AWSMachineTemplate), b.(*v1beta2.AWSMachineTemplate), scope)
 	}); err != nil {
@@ -520,11 +510,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.Volume)(nil), (*Volume)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_Volume_To_v1beta1_Volume(a.(*v1beta2.Volume), b.(*Volume), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddConversionFunc((*AWSMachineSpec)(nil), (*v1beta2.AWSMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AWSMachineSpec_To_v1beta2_AWSMachineSpec(a.(*AWSMachineSpec), b.(*v1beta2.AWSMachineSpec), scope)
 	}); err != nil {
@@ -540,6 +525,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.AMIReference)(nil), (*AMIReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_AMIReference_To_v1beta1_AMIReference(a.(*v1beta2.AMIReference), b.(*AMIReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.AWSClusterSpec)(nil), (*AWSClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_AWSClusterSpec_To_v1beta1_AWSClusterSpec(a.(*v1beta2.AWSClusterSpec), b.(*AWSClusterSpec), scope)
 	}); err != nil {
@@ -555,6 +545,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.AWSMachineStatus)(nil), (*AWSMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_AWSMachineStatus_To_v1beta1_AWSMachineStatus(a.(*v1beta2.AWSMachineStatus), b.(*AWSMachineStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.IPv6)(nil), (*IPv6)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_IPv6_To_v1beta1_IPv6(a.(*v1beta2.IPv6), b.(*IPv6), scope)
 	}); err != nil {
@@ -600,6 +595,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.Volume)(nil), (*Volume)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_Volume_To_v1beta1_Volume(a.(*v1beta2.Volume), b.(*Volume), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -617,14 +617,10 @@ func Convert_v1beta1_AMIReference_To_v1beta2_AMIReference(in *AMIReference, out
 func autoConvert_v1beta2_AMIReference_To_v1beta1_AMIReference(in *v1beta2.AMIReference, out *AMIReference, s conversion.Scope) error {
 	out.ID = (*string)(unsafe.Pointer(in.ID))
 	out.EKSOptimizedLookupType = (*EKSAMILookupType)(unsafe.Pointer(in.EKSOptimizedLookupType))
+	// WARNING: in.SSMParameter requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_AMIReference_To_v1beta1_AMIReference is an autogenerated conversion function.
-func Convert_v1beta2_AMIReference_To_v1beta1_AMIReference(in *v1beta2.AMIReference, out *AMIReference, s conversion.Scope) error {
-	return autoConvert_v1beta2_AMIReference_To_v1beta1_AMIReference(in, out, s)
-}
-
 func autoConvert_v1beta1_AWSCluster_To_v1beta2_AWSCluster(in *AWSCluster, out *v1beta2.AWSCluster, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_AWSClusterSpec_To_v1beta2_AWSClusterSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -938,6 +934,9 @@ func autoConvert_v1beta2_AWSClusterSpec_To_v1beta1_AWSClusterSpec(in *v1beta2.AW
 	if err := Convert_v1beta2_Bastion_To_v1beta1_Bastion(&in.Bastion, &out.Bastion, s); err != nil {
 		return err
 	}
+	// WARNING: in.PlacementGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneTerminationProtection requires manual conversion: does not exist in peer-type
+	// WARNING: in.Hibernation requires manual conversion: does not exist in peer-type
 	out.IdentityRef = (*AWSIdentityReference)(unsafe.Pointer(in.IdentityRef))
 	if in.S3Bucket != nil {
 		in, out := &in.S3Bucket, &out.S3Bucket
@@ -1352,8 +1351,26 @@ func autoConvert_v1beta1_AWSMachineSpec_To_v1beta2_AWSMachineSpec(in *AWSMachine
 		out.Subnet = nil
 	}
 	out.SSHKeyName = (*string)(unsafe.Pointer(in.SSHKeyName))
-	out.RootVolume = (*v1beta2.Volume)(unsafe.Pointer(in.RootVolume))
-	out.NonRootVolumes = *(*[]v1beta2.Volume)(unsafe.Pointer(&in.NonRootVolumes))
+	if in.RootVolume != nil {
+		in, out := &in.RootVolume, &out.RootVolume
+		*out = new(v1beta2.Volume)
+		if err := Convert_v1beta1_Volume_To_v1beta2_Volume(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RootVolume = nil
+	}
+	if in.NonRootVolumes != nil {
+		in, out := &in.NonRootVolumes, &out.NonRootVolumes
+		*out = make([]v1beta2.Volume, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_Volume_To_v1beta2_Volume(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.NonRootVolumes = nil
+	}
 	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
 	out.UncompressedUserData = (*bool)(unsafe.Pointer(in.UncompressedUserData))
 	if err := Convert_v1beta1_CloudInit_To_v1beta2_CloudInit(&in.CloudInit, &out.CloudInit, s); err != nil {
@@ -1375,7 +1392,10 @@ func autoConvert_v1beta2_AWSMachineSpec_To_v1beta1_AWSMachineSpec(in *v1beta2.AW
 	out.ImageLookupFormat = in.ImageLookupFormat
 	out.ImageLookupOrg = in.ImageLookupOrg
 	out.ImageLookupBaseOS = in.ImageLookupBaseOS
+	// WARNING: in.OSType requires manual conversion: does not exist in peer-type
 	out.InstanceType = in.InstanceType
+	// WARNING: in.FallbackInstanceTypes requires manual conversion: does not exist in peer-type
+	// WARNING: in.FallbackSubnets requires manual conversion: does not exist in peer-type
 	out.AdditionalTags = *(*Tags)(unsafe.Pointer(&in.AdditionalTags))
 	out.IAMInstanceProfile = in.IAMInstanceProfile
 	out.PublicIP = (*bool)(unsafe.Pointer(in.PublicIP))
@@ -1400,9 +1420,29 @@ func autoConvert_v1beta2_AWSMachineSpec_To_v1beta1_AWSMachineSpec(in *v1beta2.AW
 		out.Subnet = nil
 	}
 	out.SSHKeyName = (*string)(unsafe.Pointer(in.SSHKeyName))
-	out.RootVolume = (*Volume)(unsafe.Pointer(in.RootVolume))
-	out.NonRootVolumes = *(*[]Volume)(unsafe.Pointer(&in.NonRootVolumes))
+	if in.RootVolume != nil {
+		in, out := &in.RootVolume, &out.RootVolume
+		*out = new(Volume)
+		if err := Convert_v1beta2_Volume_To_v1beta1_Volume(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RootVolume = nil
+	}
+	if in.NonRootVolumes != nil {
+		in, out := &in.NonRootVolumes, &out.NonRootVolumes
+		*out = make([]Volume, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta2_Volume_To_v1beta1_Volume(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.NonRootVolumes = nil
+	}
 	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
+	// WARNING: in.ManagedNetworkInterfaces requires manual conversion: does not exist in peer-type
+	// WARNING: in.NetworkInterfaceType requires manual conversion: does not exist in peer-type
 	out.UncompressedUserData = (*bool)(unsafe.Pointer(in.UncompressedUserData))
 	if err := Convert_v1beta2_CloudInit_To_v1beta1_CloudInit(&in.CloudInit, &out.CloudInit, s); err != nil {
 		return err
@@ -1410,7 +1450,19 @@ func autoConvert_v1beta2_AWSMachineSpec_To_v1beta1_AWSMachineSpec(in *v1beta2.AW
 	out.Ignition = (*Ignition)(unsafe.Pointer(in.Ignition))
 	out.SpotMarketOptions = (*SpotMarketOptions)(unsafe.Pointer(in.SpotMarketOptions))
 	// WARNING: in.PlacementGroupName requires manual conversion: does not exist in peer-type
+	// WARNING: in.PlacementGroupPartition requires manual conversion: does not exist in peer-type
 	out.Tenancy = in.Tenancy
+	// WARNING: in.HostID requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostAffinity requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostResourceGroupArn requires manual conversion: does not exist in peer-type
+	// WARNING: in.DynamicHostAllocation requires manual conversion: does not exist in peer-type
+	// WARNING: in.CPUOptions requires manual conversion: does not exist in peer-type
+	// WARNING: in.NitroEnclaveEnabled requires manual conversion: does not exist in peer-type
+	// WARNING: in.HibernationEnabled requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrivateDNSName requires manual conversion: does not exist in peer-type
+	// WARNING: in.TerminationProtection requires manual conversion: does not exist in peer-type
+	// WARNING: in.DriftPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.LaunchTemplate requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1438,14 +1490,16 @@ func autoConvert_v1beta2_AWSMachineStatus_To_v1beta1_AWSMachineStatus(in *v1beta
 	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
 	out.Conditions = *(*apiv1beta1.Conditions)(unsafe.Pointer(&in.Conditions))
+	// WARNING: in.DedicatedHost requires manual conversion: does not exist in peer-type
+	// WARNING: in.InstanceType requires manual conversion: does not exist in peer-type
+	// WARNING: in.SubnetID requires manual conversion: does not exist in peer-type
+	// WARNING: in.ImageID requires manual conversion: does not exist in peer-type
+	// WARNING: in.LaunchTemplateVersion requires manual conversion: does not exist in peer-type
+	// WARNING: in.TerminationProtection requires manual conversion: does not exist in peer-type
+	// WARNING: in.Hibernated requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_AWSMachineStatus_To_v1beta1_AWSMachineStatus is an autogenerated conversion function.
-func Convert_v1beta2_AWSMachineStatus_To_v1beta1_AWSMachineStatus(in *v1beta2.AWSMachineStatus, out *AWSMachineStatus, s conversion.Scope) error {
-	return autoConvert_v1beta2_AWSMachineStatus_To_v1beta1_AWSMachineStatus(in, out, s)
-}
-
 func autoConvert_v1beta1_AWSMachineTemplate_To_v1beta2_AWSMachineTemplate(in *AWSMachineTemplate, out *v1beta2.AWSMachineTemplate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_AWSMachineTemplateSpec_To_v1beta2_AWSMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1969,8 +2023,26 @@ func autoConvert_v1beta1_Instance_To_v1beta2_Instance(in *Instance, out *v1beta2
 	out.PublicIP = (*string)(unsafe.Pointer(in.PublicIP))
 	out.ENASupport = (*bool)(unsafe.Pointer(in.ENASupport))
 	out.EBSOptimized = (*bool)(unsafe.Pointer(in.EBSOptimized))
-	out.RootVolume = (*v1beta2.Volume)(unsafe.Pointer(in.RootVolume))
-	out.NonRootVolumes = *(*[]v1beta2.Volume)(unsafe.Pointer(&in.NonRootVolumes))
+	if in.RootVolume != nil {
+		in, out := &in.RootVolume, &out.RootVolume
+		*out = new(v1beta2.Volume)
+		if err := Convert_v1beta1_Volume_To_v1beta2_Volume(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RootVolume = nil
+	}
+	if in.NonRootVolumes != nil {
+		in, out := &in.NonRootVolumes, &out.NonRootVolumes
+		*out = make([]v1beta2.Volume, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_Volume_To_v1beta2_Volume(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.NonRootVolumes = nil
+	}
 	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
 	out.Tags = *(*map[string]string)(unsafe.Pointer(&in.Tags))
 	out.AvailabilityZone = in.AvailabilityZone
@@ -2000,16 +2072,48 @@ func autoConvert_v1beta2_Instance_To_v1beta1_Instance(in *v1beta2.Instance, out
 	out.PublicIP = (*string)(unsafe.Pointer(in.PublicIP))
 	out.ENASupport = (*bool)(unsafe.Pointer(in.ENASupport))
 	out.EBSOptimized = (*bool)(unsafe.Pointer(in.EBSOptimized))
-	out.RootVolume = (*Volume)(unsafe.Pointer(in.RootVolume))
-	out.NonRootVolumes = *(*[]Volume)(unsafe.Pointer(&in.NonRootVolumes))
+	if in.RootVolume != nil {
+		in, out := &in.RootVolume, &out.RootVolume
+		*out = new(Volume)
+		if err := Convert_v1beta2_Volume_To_v1beta1_Volume(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RootVolume = nil
+	}
+	if in.NonRootVolumes != nil {
+		in, out := &in.NonRootVolumes, &out.NonRootVolumes
+		*out = make([]Volume, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta2_Volume_To_v1beta1_Volume(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.NonRootVolumes = nil
+	}
 	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
+	// WARNING: in.ManagedNetworkInterfaces requires manual conversion: does not exist in peer-type
+	// WARNING: in.NetworkInterfaceType requires manual conversion: does not exist in peer-type
+	// WARNING: in.AttachedNetworkInterfaces requires manual conversion: does not exist in peer-type
 	out.Tags = *(*map[string]string)(unsafe.Pointer(&in.Tags))
 	out.AvailabilityZone = in.AvailabilityZone
 	out.SpotMarketOptions = (*SpotMarketOptions)(unsafe.Pointer(in.SpotMarketOptions))
 	// WARNING: in.PlacementGroupName requires manual conversion: does not exist in peer-type
+	// WARNING: in.PlacementGroupPartition requires manual conversion: does not exist in peer-type
 	out.Tenancy = in.Tenancy
+	// WARNING: in.HostID requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostAffinity requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostResourceGroupArn requires manual conversion: does not exist in peer-type
 	out.VolumeIDs = *(*[]string)(unsafe.Pointer(&in.VolumeIDs))
+	// WARNING: in.RootDeviceName requires manual conversion: does not exist in peer-type
 	// WARNING: in.InstanceMetadataOptions requires manual conversion: does not exist in peer-type
+	// WARNING: in.CPUOptions requires manual conversion: does not exist in peer-type
+	// WARNING: in.NitroEnclaveEnabled requires manual conversion: does not exist in peer-type
+	// WARNING: in.HibernationEnabled requires manual conversion: does not exist in peer-type
+	// WARNING: in.TerminationProtection requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrivateDNSName requires manual conversion: does not exist in peer-type
+	// WARNING: in.LaunchTemplate requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2240,6 +2344,7 @@ func autoConvert_v1beta2_SubnetSpec_To_v1beta1_SubnetSpec(in *v1beta2.SubnetSpec
 	out.RouteTableID = (*string)(unsafe.Pointer(in.RouteTableID))
 	out.NatGatewayID = (*string)(unsafe.Pointer(in.NatGatewayID))
 	out.Tags = *(*Tags)(unsafe.Pointer(&in.Tags))
+	// WARNING: in.PrivateDNSNameOptionsOnLaunch requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2311,10 +2416,6 @@ func autoConvert_v1beta2_Volume_To_v1beta1_Volume(in *v1beta2.Volume, out *Volum
 	out.Throughput = (*int64)(unsafe.Pointer(in.Throughput))
 	out.Encrypted = (*bool)(unsafe.Pointer(in.Encrypted))
 	out.EncryptionKey = in.EncryptionKey
+	// WARNING: in.Snapshot requires manual conversion: does not exist in peer-type
 	return nil
 }
-
-// Convert_v1beta2_Volume_To_v1beta1_Volume is an autogenerated conversion function.
-func Convert_v1beta2_Volume_To_v1beta1_Volume(in *v1beta2.Volume, out *Volume, s conversion.Scope) error {
-	return autoConvert_v1beta2_Volume_To_v1beta1_Volume(in, out, s)
-}
@@ -91,6 +91,24 @@ type AWSClusterSpec struct {
 	// +optional
 	Bastion Bastion `json:"bastion"`
 
+	// PlacementGroups are the placement groups to create with the cluster. They are deleted
+	// when they are removed from the list, once no instance runs in them, or when the cluster is deleted.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	PlacementGroups []PlacementGroupSpec `json:"placementGroups,omitempty"`
+
+	// ControlPlaneTerminationProtection enables termination and stop protection on the instances of the
+	// control plane machines that do not set terminationProtection themselves, so that they cannot be
+	// terminated or stopped through the EC2 API outside of a machine deletion. Defaults to true.
+	// +optional
+	ControlPlaneTerminationProtection *bool `json:"controlPlaneTerminationProtection,omitempty"`
+
+	// Hibernation stops the instances of the cluster while it is idle, for instance at night, and starts them
+	// again when it is resumed.
+	// +optional
+	Hibernation *Hibernation `json:"hibernation,omitempty"`
+
 	// IdentityRef is a reference to a identity to be used when reconciling this cluster
 	// +optional
 	IdentityRef *AWSIdentityReference `json:"identityRef,omitempty"`
@@ -156,6 +174,24 @@ type Bastion struct {
 	AMI string `json:"ami,omitempty"`
 }
 
+// Hibernation defines the hibernation of a cluster.
+type Hibernation struct {
+	// Hibernate stops the instances of the AWSMachines of the cluster and scales the Auto Scaling groups of its
+	// AWSMachinePools to zero when true. The instances are started again, and the Auto Scaling groups scaled
+	// back to their previous sizes, when it is set back to false.
+	Hibernate bool `json:"hibernate"`
+
+	// DeleteNatGateways deletes the NAT gateways of a managed VPC while the cluster is hibernated, and
+	// creates them again when it is resumed.
+	// +optional
+	DeleteNatGateways bool `json:"deleteNatGateways,omitempty"`
+
+	// DeleteBastion deletes the bastion host while the cluster is hibernated, and creates it again when it
+	// is resumed.
+	// +optional
+	DeleteBastion bool `json:"deleteBastion,omitempty"`
+}
+
 type LoadBalancerType string
 
 var (
@@ -59,6 +59,7 @@ func (r *AWSCluster) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.Spec.S3Bucket.Validate()...)
 	allErrs = append(allErrs, r.validateNetwork()...)
 	allErrs = append(allErrs, r.validateControlPlaneLB()...)
+	allErrs = append(allErrs, r.validatePlacementGroups()...)
 
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -167,9 +168,23 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 		)
 	}
 
+	// Placement groups cannot be modified, only created and deleted.
+	oldPlacementGroups := make(map[string]PlacementGroupSpec, len(oldC.Spec.PlacementGroups))
+	for _, pg := range oldC.Spec.PlacementGroups {
+		oldPlacementGroups[pg.Name] = pg
+	}
+	for i, pg := range r.Spec.PlacementGroups {
+		if oldPG, ok := oldPlacementGroups[pg.Name]; ok && !cmp.Equal(oldPG, pg) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "placementGroups").Index(i), pg, "placement groups are immutable, only their addition and removal are allowed"),
+			)
+		}
+	}
+
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.Spec.S3Bucket.Validate()...)
+	allErrs = append(allErrs, r.validatePlacementGroups()...)
 
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -246,6 +261,18 @@ func (r *AWSCluster) validateNetwork() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSCluster) validatePlacementGroups() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, pg := range r.Spec.PlacementGroups {
+		if pg.PartitionCount != nil && pg.Strategy != PlacementGroupStrategyPartition {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "placementGroups").Index(i).Child("partitionCount"), "can only be set with the partition strategy"))
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSCluster) validateControlPlaneLB() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -447,6 +447,29 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "rejects a partition count on a placement group without the partition strategy",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					PlacementGroups: []PlacementGroupSpec{
+						{Name: "hpc", Strategy: PlacementGroupStrategyCluster, PartitionCount: aws.Int64(2)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts placement groups",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					PlacementGroups: []PlacementGroupSpec{
+						{Name: "hpc", Strategy: PlacementGroupStrategyCluster},
+						{Name: "storage", Strategy: PlacementGroupStrategyPartition, PartitionCount: aws.Int64(3)},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -809,6 +832,34 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "placement groups cannot be modified",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					PlacementGroups: []PlacementGroupSpec{{Name: "hpc", Strategy: PlacementGroupStrategyCluster}},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					PlacementGroups: []PlacementGroupSpec{{Name: "hpc", Strategy: PlacementGroupStrategySpread}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "placement groups can be added and removed",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					PlacementGroups: []PlacementGroupSpec{{Name: "hpc", Strategy: PlacementGroupStrategyCluster}},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					PlacementGroups: []PlacementGroupSpec{{Name: "spread", Strategy: PlacementGroupStrategySpread}},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
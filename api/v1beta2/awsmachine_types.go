@@ -43,6 +43,17 @@ var (
 	SecretBackendSecretsManager = SecretBackend("secrets-manager")
 )
 
+// OSType defines the operating system of an instance.
+type OSType string
+
+const (
+	// OSTypeLinux is the Linux operating system.
+	OSTypeLinux = OSType("linux")
+
+	// OSTypeWindows is the Windows operating system.
+	OSTypeWindows = OSType("windows")
+)
+
 // AWSMachineSpec defines the desired state of an Amazon EC2 instance.
 type AWSMachineSpec struct {
 	// ProviderID is the unique identifier as specified by the cloud provider.
@@ -79,10 +90,34 @@ type AWSMachineSpec struct {
 	// image lookup the AMI is not set.
 	ImageLookupBaseOS string `json:"imageLookupBaseOS,omitempty"`
 
+	// OSType is the operating system of the instance. It selects the format of the user data fetching the
+	// bootstrap data from the secure secrets backend, and the images looked up when the AMI is not set.
+	// Windows instances run the bootstrap data as a PowerShell script once it has been fetched.
+	// Defaults to linux.
+	// +kubebuilder:validation:Enum:=linux;windows
+	// +optional
+	OSType OSType `json:"osType,omitempty"`
+
 	// InstanceType is the type of instance to create. Example: m4.xlarge
-	// +kubebuilder:validation:Required
+	// Required unless LaunchTemplate is set, in which case the instance type of the launch template is used when omitted.
 	// +kubebuilder:validation:MinLength:=2
-	InstanceType string `json:"instanceType"`
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// FallbackInstanceTypes is an ordered list of instance types to launch the instance with when EC2 does not have
+	// enough capacity for InstanceType. Instance types the AMI, which is resolved for InstanceType, cannot be
+	// launched with are skipped and reported in the FallbackInstanceTypesCompatible condition. Instance types of
+	// families known to have another architecture than InstanceType are rejected.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	FallbackInstanceTypes []string `json:"fallbackInstanceTypes,omitempty"`
+
+	// FallbackSubnets is an ordered list of subnets to launch the instance in when EC2 does not have enough capacity
+	// for any of the instance types in the subnet selected for the machine. Filters, e.g. on availability-zone,
+	// are resolved in the cluster VPC. Subnets outside of the failure domain of the Machine are skipped.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	FallbackSubnets []AWSResourceReference `json:"fallbackSubnets,omitempty"`
 
 	// AdditionalTags is an optional set of tags to add to an instance, in addition to the ones added by default by the
 	// AWS provider. If both the AWSCluster and the AWSMachine specify the same tag name with different values, the
@@ -132,6 +167,22 @@ type AWSMachineSpec struct {
 	// +kubebuilder:validation:MaxItems=2
 	NetworkInterfaces []string `json:"networkInterfaces,omitempty"`
 
+	// ManagedNetworkInterfaces is a list of additional ENIs to create for the instance.
+	// They are created when the instance is launched and deleted when it is terminated.
+	// Their device indexes must not collide with the ones taken by NetworkInterfaces.
+	// Note that EC2 does not auto-assign a public IPv4 address to instances launched with multiple network interfaces.
+	// +optional
+	// +listType=map
+	// +listMapKey=deviceIndex
+	ManagedNetworkInterfaces []ManagedNetworkInterface `json:"managedNetworkInterfaces,omitempty"`
+
+	// NetworkInterfaceType is the type of the primary network interface of the instance.
+	// Set it to efa to attach an Elastic Fabric Adapter, which requires an instance type supporting EFA.
+	// Cannot be set together with NetworkInterfaces.
+	// +kubebuilder:validation:Enum:=interface;efa
+	// +optional
+	NetworkInterfaceType NetworkInterfaceType `json:"networkInterfaceType,omitempty"`
+
 	// UncompressedUserData specify whether the user data is gzip-compressed before it is sent to ec2 instance.
 	// cloud-init has built-in support for gzip-compressed user data
 	// user data stored in aws secret manager is always gzip-compressed.
@@ -156,10 +207,87 @@ type AWSMachineSpec struct {
 	// +optional
 	PlacementGroupName string `json:"placementGroupName,omitempty"`
 
+	// PlacementGroupPartition is the number of the partition in which to launch the instance.
+	// Only valid when PlacementGroupName refers to a placement group with the partition strategy.
+	// When omitted, EC2 distributes the instances across the partitions.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=7
+	// +optional
+	PlacementGroupPartition int64 `json:"placementGroupPartition,omitempty"`
+
 	// Tenancy indicates if instance should run on shared or single-tenant hardware.
 	// +optional
 	// +kubebuilder:validation:Enum:=default;dedicated;host
 	Tenancy string `json:"tenancy,omitempty"`
+
+	// HostID specifies the ID of the Dedicated Host on which the instance should be launched.
+	// Only valid when Tenancy is set to host. Cannot be set together with HostResourceGroupArn
+	// or DynamicHostAllocation.
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+
+	// HostAffinity specifies the Dedicated Host affinity setting for the instance.
+	// When set to host, an instance started onto a specific host always restarts on the same host if stopped.
+	// When set to default, a stopped instance can be restarted on any available host.
+	// Only valid when Tenancy is set to host.
+	// +optional
+	// +kubebuilder:validation:Enum:=default;host
+	HostAffinity *string `json:"hostAffinity,omitempty"`
+
+	// HostResourceGroupArn is the ARN of the host resource group in which to launch the instance.
+	// Only valid when Tenancy is set to host. Cannot be set together with HostID
+	// or DynamicHostAllocation.
+	// +optional
+	HostResourceGroupArn *string `json:"hostResourceGroupArn,omitempty"`
+
+	// DynamicHostAllocation, when set, makes CAPA allocate and release Dedicated Hosts on demand
+	// for the instance. Hosts are shared by the machines of the same MachineDeployment as long as
+	// they have free capacity for the instance type, and are released once empty.
+	// Only valid when Tenancy is set to host. Cannot be set together with HostID or HostResourceGroupArn.
+	// +optional
+	DynamicHostAllocation *DynamicHostAllocationSpec `json:"dynamicHostAllocation,omitempty"`
+
+	// CPUOptions defines the number of CPU cores and threads per core of the instance.
+	// When omitted, the defaults of the instance type are used.
+	// +optional
+	CPUOptions *CPUOptions `json:"cpuOptions,omitempty"`
+
+	// NitroEnclaveEnabled enables the instance for AWS Nitro Enclaves.
+	// Cannot be set together with HibernationEnabled.
+	// +optional
+	NitroEnclaveEnabled bool `json:"nitroEnclaveEnabled,omitempty"`
+
+	// HibernationEnabled configures the instance for hibernation.
+	// Hibernation requires an encrypted root volume large enough to store the instance memory.
+	// Cannot be set together with NitroEnclaveEnabled.
+	// +optional
+	HibernationEnabled bool `json:"hibernationEnabled,omitempty"`
+
+	// PrivateDNSName is the hostname configuration of the instance.
+	// When omitted, the defaults of the subnet are used.
+	// +optional
+	PrivateDNSName *PrivateDNSName `json:"privateDnsName,omitempty"`
+
+	// TerminationProtection enables termination and stop protection on the instance, so that it cannot be
+	// terminated or stopped through the EC2 API. The protection is only lifted when the machine is deleted.
+	// When omitted, control plane machines follow the controlPlaneTerminationProtection of the AWSCluster,
+	// and other machines are not protected.
+	// +optional
+	TerminationProtection *bool `json:"terminationProtection,omitempty"`
+
+	// DriftPolicy defines how the fields of the instance that are changed outside of CAPA are handled.
+	// Drifted fields that are not repaired are listed in the Drifted condition.
+	// +optional
+	DriftPolicy *DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// LaunchTemplate references an existing launch template to launch the instance from, so that settings
+	// it enforces, such as metadata options, monitoring or license configurations, apply to the instance.
+	// The fields managed by CAPA, the subnet, security groups, user data and tags, are set on top of the launch
+	// template and override it. Other settings, such as the AMI, instance type, SSH key or metadata options,
+	// only override the launch template when they are set on the machine. The version is resolved when the
+	// instance is launched; the LaunchTemplateUpToDate condition reports when it later resolves to another version.
+	// +optional
+	LaunchTemplate *LaunchTemplateReference `json:"launchTemplate,omitempty"`
 }
 
 // CloudInit defines options related to the bootstrapping systems where
@@ -258,6 +386,39 @@ type AWSMachineStatus struct {
 	// Conditions defines current service state of the AWSMachine.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// DedicatedHost tracks the Dedicated Host allocated by CAPA for this machine
+	// when DynamicHostAllocation is used.
+	// +optional
+	DedicatedHost *DedicatedHostStatus `json:"dedicatedHost,omitempty"`
+
+	// InstanceType is the instance type the instance was launched with, out of the spec instance type
+	// and its fallbacks. Once set, it is used for the whole lifetime of the machine.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// SubnetID is the subnet the instance was launched in, out of the subnet selected for the machine
+	// and the fallback subnets. Once set, it is used for the whole lifetime of the machine.
+	// +optional
+	SubnetID string `json:"subnetID,omitempty"`
+
+	// ImageID is the ID of the AMI the instance was launched from, as resolved from the AMI reference
+	// or the image lookup of the machine.
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
+
+	// LaunchTemplateVersion is the version of the referenced launch template the instance was launched from.
+	// +optional
+	LaunchTemplateVersion string `json:"launchTemplateVersion,omitempty"`
+
+	// TerminationProtection reports whether termination and stop protection are enabled on the instance.
+	// +optional
+	TerminationProtection bool `json:"terminationProtection,omitempty"`
+
+	// Hibernated reports that the instance was stopped because the cluster is hibernated. It is cleared once
+	// the instance is running again after the cluster was resumed.
+	// +optional
+	Hibernated bool `json:"hibernated,omitempty"`
 }
 
 // +kubebuilder:object:root=true
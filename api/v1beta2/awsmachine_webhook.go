@@ -17,6 +17,10 @@ limitations under the License.
 package v1beta2
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -56,6 +60,15 @@ func (r *AWSMachine) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.validateNonRootVolumes()...)
 	allErrs = append(allErrs, r.validateSSHKeyName()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, r.validateHostPlacement()...)
+	allErrs = append(allErrs, r.validateHibernation()...)
+	allErrs = append(allErrs, r.validateManagedNetworkInterfaces()...)
+	allErrs = append(allErrs, r.validateFallbacks()...)
+	allErrs = append(allErrs, r.validateWindows()...)
+	allErrs = append(allErrs, r.validatePlacement()...)
+	allErrs = append(allErrs, r.validateTerminationProtection()...)
+	allErrs = append(allErrs, r.Spec.AMI.Validate(field.NewPath("spec", "ami"))...)
+	allErrs = append(allErrs, r.validateLaunchTemplate()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
@@ -81,6 +94,10 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 	allErrs = append(allErrs, r.validateCloudInitSecret()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.validateRootVolume()...)
+	allErrs = append(allErrs, r.validateNonRootVolumes()...)
+	allErrs = append(allErrs, r.validateVolumeSizes(old.(*AWSMachine))...)
+	allErrs = append(allErrs, r.validateTerminationProtection()...)
 
 	newAWSMachineSpec := newAWSMachine["spec"].(map[string]interface{})
 	oldAWSMachineSpec := oldAWSMachine["spec"].(map[string]interface{})
@@ -101,6 +118,14 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 	delete(oldAWSMachineSpec, "additionalSecurityGroups")
 	delete(newAWSMachineSpec, "additionalSecurityGroups")
 
+	// allow changes to terminationProtection, it is toggled on the running instance
+	delete(oldAWSMachineSpec, "terminationProtection")
+	delete(newAWSMachineSpec, "terminationProtection")
+
+	// allow changes to driftPolicy
+	delete(oldAWSMachineSpec, "driftPolicy")
+	delete(newAWSMachineSpec, "driftPolicy")
+
 	// allow changes to secretPrefix, secretCount, and secureSecretsBackend
 	if cloudInit, ok := oldAWSMachineSpec["cloudInit"].(map[string]interface{}); ok {
 		delete(cloudInit, "secretPrefix")
@@ -114,6 +139,20 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 		delete(cloudInit, "secureSecretsBackend")
 	}
 
+	// allow changes to the size, type, IOPS and throughput of volumes, as they are modified in place
+	for _, spec := range []map[string]interface{}{oldAWSMachineSpec, newAWSMachineSpec} {
+		if rootVolume, ok := spec["rootVolume"].(map[string]interface{}); ok {
+			deleteModifiableVolumeFields(rootVolume)
+		}
+		if nonRootVolumes, ok := spec["nonRootVolumes"].([]interface{}); ok {
+			for _, volume := range nonRootVolumes {
+				if volume, ok := volume.(map[string]interface{}); ok {
+					deleteModifiableVolumeFields(volume)
+				}
+			}
+		}
+	}
+
 	if !cmp.Equal(oldAWSMachineSpec, newAWSMachineSpec) {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "cannot be modified"))
 	}
@@ -121,6 +160,34 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
+func deleteModifiableVolumeFields(volume map[string]interface{}) {
+	delete(volume, "size")
+	delete(volume, "type")
+	delete(volume, "iops")
+	delete(volume, "throughput")
+}
+
+// validateVolumeSizes ensures volumes are not shrunk, as EBS volumes can only grow.
+func (r *AWSMachine) validateVolumeSizes(old *AWSMachine) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.RootVolume != nil && old.Spec.RootVolume != nil && r.Spec.RootVolume.Size < old.Spec.RootVolume.Size {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "rootVolume", "size"), r.Spec.RootVolume.Size, "cannot be decreased"))
+	}
+
+	oldSizes := make(map[string]int64, len(old.Spec.NonRootVolumes))
+	for _, volume := range old.Spec.NonRootVolumes {
+		oldSizes[volume.DeviceName] = volume.Size
+	}
+	for i, volume := range r.Spec.NonRootVolumes {
+		if oldSize, ok := oldSizes[volume.DeviceName]; ok && volume.Size < oldSize {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "nonRootVolumes").Index(i).Child("size"), volume.Size, "cannot be decreased"))
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSMachine) validateCloudInitSecret() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -194,6 +261,10 @@ func (r *AWSMachine) validateRootVolume() field.ErrorList {
 		}
 	}
 
+	if r.Spec.RootVolume.Snapshot != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec.rootVolume.snapshot"), "snapshot is not supported for the root volume"))
+	}
+
 	if r.Spec.RootVolume.DeviceName != "" {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
@@ -221,6 +292,31 @@ func (r *AWSMachine) validateNonRootVolumes() field.ErrorList {
 		if volume.DeviceName == "" {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.deviceName"), "non root volume should have device name"))
 		}
+
+		if volume.Snapshot != nil && (volume.Snapshot.ID == nil) == (len(volume.Snapshot.Filters) == 0) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec.nonRootVolumes.snapshot"), volume.Snapshot, "exactly one of ID or Filters must be specified"))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachine) validateWindows() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.OSType != OSTypeWindows {
+		return allErrs
+	}
+
+	if r.ignitionEnabled() {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "ignition"), "cannot be set if spec.osType is windows"))
+	}
+
+	for i, volume := range r.Spec.NonRootVolumes {
+		if volume.DeviceName != "" && !windowsDeviceNameRegex.MatchString(volume.DeviceName) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "nonRootVolumes").Index(i).Child("deviceName"), volume.DeviceName,
+				"must be one of xvd[b-z], xvd[b-c][a-z] or /dev/sd[b-e] for windows instances"))
+		}
 	}
 
 	return allErrs
@@ -261,3 +357,221 @@ func (r *AWSMachine) validateAdditionalSecurityGroups() field.ErrorList {
 func (r *AWSMachine) validateSSHKeyName() field.ErrorList {
 	return validateSSHKeyName(r.Spec.SSHKeyName)
 }
+
+func (r *AWSMachine) validateHostPlacement() field.ErrorList {
+	return validateHostPlacement(r.Spec, field.NewPath("spec"))
+}
+
+func (r *AWSMachine) validateHibernation() field.ErrorList {
+	return validateHibernation(r.Spec, field.NewPath("spec"))
+}
+
+func (r *AWSMachine) validatePlacement() field.ErrorList {
+	return validatePlacement(r.Spec, field.NewPath("spec"))
+}
+
+func (r *AWSMachine) validateTerminationProtection() field.ErrorList {
+	return validateTerminationProtection(r.Spec, field.NewPath("spec"))
+}
+
+func (r *AWSMachine) validateLaunchTemplate() field.ErrorList {
+	return validateLaunchTemplate(r.Spec, field.NewPath("spec"))
+}
+
+func (r *AWSMachine) validateFallbacks() field.ErrorList {
+	return validateFallbacks(r.Spec, field.NewPath("spec"))
+}
+
+// windowsDeviceNameRegex matches the device names available for the non root volumes of windows instances.
+var windowsDeviceNameRegex = regexp.MustCompile(`^(xvd[b-z]|xvd[b-c][a-z]|/dev/sd[b-e])$`)
+
+// instanceFamilyRegex matches the instance types named after their family, generation and attributes, e.g.
+// m6gd.large, capturing the family and the attributes.
+var instanceFamilyRegex = regexp.MustCompile(`^([a-z]+[0-9]+)([a-z]*)(-flex)?\.`)
+
+// knownInstanceTypeArchitecture returns the architecture of the instance type when it can be told from its
+// name: a1 and the families with the g processor attribute are Graviton families, the other families are x86_64.
+// Mac instances and the instance types that do not follow the naming convention are unknown.
+func knownInstanceTypeArchitecture(instanceType string) (string, bool) {
+	match := instanceFamilyRegex.FindStringSubmatch(instanceType)
+	if match == nil || strings.HasPrefix(match[1], "mac") {
+		return "", false
+	}
+	if match[1] == "a1" || strings.Contains(match[2], "g") {
+		return "arm64", true
+	}
+	return "x86_64", true
+}
+
+// validateFallbacks checks that fallback instance types differ from the instance type and can run its AMI, and
+// that fallbacks are not combined with a Dedicated Host placement, which is bound to an instance type.
+// The architecture of the AMI is the one of the instance type, for which it is looked up or against which it is
+// validated. Fallbacks whose architecture is not known are checked by the controller before they are launched.
+func validateFallbacks(spec AWSMachineSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	architecture, knownArchitecture := knownInstanceTypeArchitecture(spec.InstanceType)
+	for i, instanceType := range spec.FallbackInstanceTypes {
+		if instanceType == spec.InstanceType {
+			allErrs = append(allErrs, field.Duplicate(path.Child("fallbackInstanceTypes").Index(i), instanceType))
+			continue
+		}
+		if !knownArchitecture {
+			continue
+		}
+		if fallbackArchitecture, ok := knownInstanceTypeArchitecture(instanceType); ok && fallbackArchitecture != architecture {
+			allErrs = append(allErrs, field.Invalid(path.Child("fallbackInstanceTypes").Index(i), instanceType,
+				fmt.Sprintf("has the %s architecture, the AMI of instanceType %q has the %s architecture", fallbackArchitecture, spec.InstanceType, architecture)))
+		}
+	}
+
+	if len(spec.FallbackInstanceTypes) > 0 && (spec.HostID != nil || spec.DynamicHostAllocation != nil) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("fallbackInstanceTypes"), "cannot be used together with hostID or dynamicHostAllocation"))
+	}
+
+	for i, subnet := range spec.FallbackSubnets {
+		if subnet.ID == nil && len(subnet.Filters) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("fallbackSubnets").Index(i), "either id or filters must be set"))
+		}
+		if subnet.ID != nil && len(subnet.Filters) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("fallbackSubnets").Index(i), "id and filters are mutually exclusive"))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachine) validateManagedNetworkInterfaces() field.ErrorList {
+	return validateManagedNetworkInterfaces(r.Spec, field.NewPath("spec"))
+}
+
+// validateManagedNetworkInterfaces checks that managed network interfaces do not take the device indexes
+// of the network interfaces attached by ID, which are attached in order starting at index 0.
+func validateManagedNetworkInterfaces(spec AWSMachineSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, ni := range spec.ManagedNetworkInterfaces {
+		if ni.DeviceIndex < int64(len(spec.NetworkInterfaces)) {
+			allErrs = append(allErrs, field.Invalid(path.Child("managedNetworkInterfaces").Index(i).Child("deviceIndex"), ni.DeviceIndex,
+				fmt.Sprintf("must be at least %d, lower device indexes are used by networkInterfaces", len(spec.NetworkInterfaces))))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePlacement checks that a partition is only requested in a placement group, and that the type of the
+// primary network interface is only set when it is created with the instance.
+func validatePlacement(spec AWSMachineSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.PlacementGroupPartition != 0 && spec.PlacementGroupName == "" {
+		allErrs = append(allErrs, field.Required(path.Child("placementGroupName"), "placementGroupName is required when placementGroupPartition is set"))
+	}
+
+	if spec.NetworkInterfaceType != "" && len(spec.NetworkInterfaces) > 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("networkInterfaceType"), "cannot be set together with networkInterfaces"))
+	}
+
+	return allErrs
+}
+
+// validateHibernation checks that hibernation is only enabled on machines with an encrypted root volume
+// and without Nitro Enclaves, as required by EC2.
+func validateHibernation(spec AWSMachineSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !spec.HibernationEnabled {
+		return allErrs
+	}
+
+	if spec.RootVolume == nil || spec.RootVolume.Encrypted == nil || !*spec.RootVolume.Encrypted {
+		allErrs = append(allErrs, field.Required(path.Child("rootVolume", "encrypted"), "root volume must be encrypted when hibernation is enabled"))
+	}
+	if spec.NitroEnclaveEnabled {
+		allErrs = append(allErrs, field.Forbidden(path.Child("nitroEnclaveEnabled"), "cannot be enabled together with hibernationEnabled"))
+	}
+
+	return allErrs
+}
+
+// validateLaunchTemplate checks that the instance type is only omitted when a launch template is referenced,
+// and that the settings needing the instance type or the image of the machine do not rely on the launch template.
+func validateLaunchTemplate(spec AWSMachineSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.LaunchTemplate == nil {
+		if spec.InstanceType == "" {
+			allErrs = append(allErrs, field.Required(path.Child("instanceType"), "must be set unless launchTemplate is set"))
+		}
+		return allErrs
+	}
+
+	allErrs = append(allErrs, spec.LaunchTemplate.Validate(path.Child("launchTemplate"))...)
+
+	if spec.InstanceType == "" {
+		if len(spec.FallbackInstanceTypes) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("fallbackInstanceTypes"), "cannot be set unless instanceType is set"))
+		}
+		if spec.DynamicHostAllocation != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("dynamicHostAllocation"), "cannot be set unless instanceType is set"))
+		}
+		if spec.AMI.SSMParameter != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("ami", "ssmParameter"), "cannot be set unless instanceType is set"))
+		}
+	}
+
+	if spec.RootVolume != nil && spec.AMI.ID == nil && spec.AMI.SSMParameter == nil {
+		allErrs = append(allErrs, field.Required(path.Child("ami"), "must be set to configure the root volume of an instance launched from a launch template"))
+	}
+
+	return allErrs
+}
+
+// validateTerminationProtection checks that termination protection is not requested for Spot instances,
+// which EC2 cannot protect against termination or stop.
+func validateTerminationProtection(spec AWSMachineSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.TerminationProtection != nil && *spec.TerminationProtection && spec.SpotMarketOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("terminationProtection"), "cannot be enabled together with spotMarketOptions"))
+	}
+
+	return allErrs
+}
+
+// validateHostPlacement checks that the Dedicated Host placement settings of a machine spec are consistent.
+func validateHostPlacement(spec AWSMachineSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.Tenancy != TenancyHost {
+		if spec.HostID != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("hostID"), *spec.HostID, "can only be set when tenancy is host"))
+		}
+		if spec.HostAffinity != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("hostAffinity"), *spec.HostAffinity, "can only be set when tenancy is host"))
+		}
+		if spec.HostResourceGroupArn != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("hostResourceGroupArn"), *spec.HostResourceGroupArn, "can only be set when tenancy is host"))
+		}
+		if spec.DynamicHostAllocation != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("dynamicHostAllocation"), "can only be set when tenancy is host"))
+		}
+	}
+
+	if spec.HostID != nil && spec.HostResourceGroupArn != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("hostResourceGroupArn"), "only one of hostID or hostResourceGroupArn may be specified"))
+	}
+
+	if spec.DynamicHostAllocation != nil {
+		if spec.HostID != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("hostID"), "cannot be set together with dynamicHostAllocation"))
+		}
+		if spec.HostResourceGroupArn != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("hostResourceGroupArn"), "cannot be set together with dynamicHostAllocation"))
+		}
+		allErrs = append(allErrs, spec.DynamicHostAllocation.Tags.Validate()...)
+	}
+
+	return allErrs
+}
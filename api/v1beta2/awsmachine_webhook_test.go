@@ -149,6 +149,54 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "non root volume may be restored from a snapshot",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []Volume{
+						{
+							DeviceName: "name",
+							Size:       100,
+							Snapshot:   &AWSResourceReference{ID: aws.String("snap-1")},
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "non root volume snapshot must not have both id and filters",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []Volume{
+						{
+							DeviceName: "name",
+							Size:       100,
+							Snapshot: &AWSResourceReference{
+								ID:      aws.String("snap-1"),
+								Filters: []Filter{{Name: "tag:dataset", Values: []string{"images"}}},
+							},
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "root volume can't be restored from a snapshot",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Size:     100,
+						Snapshot: &AWSResourceReference{ID: aws.String("snap-1")},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "additional security groups may have id",
 			machine: &AWSMachine{
@@ -248,6 +296,319 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "host placement is accepted with host tenancy",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					Tenancy:      "host",
+					HostID:       aws.String("h-0123456789abcdef0"),
+					HostAffinity: aws.String("host"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "host ID requires host tenancy",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					Tenancy:      "dedicated",
+					HostID:       aws.String("h-0123456789abcdef0"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "host ID and host resource group ARN are mutually exclusive",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:         "test",
+					Tenancy:              "host",
+					HostID:               aws.String("h-0123456789abcdef0"),
+					HostResourceGroupArn: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/hosts"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "dynamic host allocation is accepted with host tenancy",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "test",
+					Tenancy:               "host",
+					DynamicHostAllocation: &DynamicHostAllocationSpec{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "hibernation requires an encrypted root volume",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:       "test",
+					HibernationEnabled: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "hibernation is accepted with an encrypted root volume",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:       "test",
+					HibernationEnabled: true,
+					RootVolume: &Volume{
+						Size:      100,
+						Encrypted: aws.Bool(true),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "hibernation cannot be combined with Nitro Enclaves",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:        "test",
+					HibernationEnabled:  true,
+					NitroEnclaveEnabled: true,
+					RootVolume: &Volume{
+						Size:      100,
+						Encrypted: aws.Bool(true),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "termination protection cannot be enabled for Spot instances",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "test",
+					TerminationProtection: aws.Bool(true),
+					SpotMarketOptions:     &SpotMarketOptions{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "managed network interfaces cannot take the device index of a network interface",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:      "test",
+					NetworkInterfaces: []string{"eni-1", "eni-2"},
+					ManagedNetworkInterfaces: []ManagedNetworkInterface{
+						{DeviceIndex: 1},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "managed network interfaces are accepted after the network interfaces",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:      "test",
+					NetworkInterfaces: []string{"eni-1"},
+					ManagedNetworkInterfaces: []ManagedNetworkInterface{
+						{DeviceIndex: 1, IPv4PrefixCount: aws.Int64(1)},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fallback instance types cannot repeat the instance type",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "m5.large",
+					FallbackInstanceTypes: []string{"m5a.large", "m5.large"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fallback instance types must have the architecture of the instance type",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "m5.large",
+					FallbackInstanceTypes: []string{"m5a.large", "m6g.large"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fallback instance types must have the architecture of the instance type of an explicit AMI",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI:                   AMIReference{ID: aws.String("ami-0123456789abcdef0")},
+					InstanceType:          "c7g.large",
+					FallbackInstanceTypes: []string{"c7i-flex.large"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fallback instance types whose architecture is not known are accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "mac2.metal",
+					FallbackInstanceTypes: []string{"m5.metal", "u-6tb1.metal"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fallback instance types and subnets are accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "m6g.large",
+					FallbackInstanceTypes: []string{"c7gn.large", "t4g.large"},
+					FallbackSubnets: []AWSResourceReference{
+						{Filters: []Filter{{Name: "availability-zone", Values: []string{"us-east-1b"}}}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "dynamic host allocation cannot be combined with host ID",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "test",
+					Tenancy:               "host",
+					HostID:                aws.String("h-0123456789abcdef0"),
+					DynamicHostAllocation: &DynamicHostAllocationSpec{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "windows instances cannot use linux device names for non root volumes",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:   "m5.large",
+					OSType:         OSTypeWindows,
+					NonRootVolumes: []Volume{{DeviceName: "/dev/sdf", Size: 20}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "windows instances with windows device names are accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:   "m5.large",
+					OSType:         OSTypeWindows,
+					NonRootVolumes: []Volume{{DeviceName: "xvdf", Size: 20}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "placement group partition requires a placement group name",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:            "test",
+					PlacementGroupPartition: 2,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "network interface type cannot be set with existing network interfaces",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:         "test",
+					NetworkInterfaceType: NetworkInterfaceTypeEFAWithENAInterface,
+					NetworkInterfaces:    []string{"eni-1"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "efa instances in a placement group partition are accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:            "p4d.24xlarge",
+					NetworkInterfaceType:    NetworkInterfaceTypeEFAWithENAInterface,
+					PlacementGroupName:      "hpc",
+					PlacementGroupPartition: 2,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "launch template reference requires an ID or a name",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:   "test",
+					LaunchTemplate: &LaunchTemplateReference{Version: aws.String("2")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "launch template reference cannot set both an ID and a name",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					LaunchTemplate: &LaunchTemplateReference{
+						ID:   aws.String("lt-0123456789abcdef0"),
+						Name: aws.String("base"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "launch template reference by name is accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:   "test",
+					LaunchTemplate: &LaunchTemplateReference{Name: aws.String("base"), Version: aws.String("$Latest")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "instance type can be omitted with a launch template reference",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					LaunchTemplate: &LaunchTemplateReference{ID: aws.String("lt-0123456789abcdef0")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "instance type is required without a launch template reference",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fallback instance types need an instance type with a launch template reference",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					LaunchTemplate:        &LaunchTemplateReference{ID: aws.String("lt-0123456789abcdef0")},
+					FallbackInstanceTypes: []string{"m5.large"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "root volume needs an AMI with a launch template reference",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:   "test",
+					LaunchTemplate: &LaunchTemplateReference{ID: aws.String("lt-0123456789abcdef0")},
+					RootVolume:     &Volume{Size: 16},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -302,6 +663,39 @@ func TestAWSMachineUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "change in termination protection",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:          "test",
+					TerminationProtection: aws.Bool(false),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "change in drift policy",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					DriftPolicy: &DriftPolicy{
+						InstanceType: DriftActionReplace,
+						Tags:         DriftActionReport,
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "change in fields other than providerid, tags and securitygroups",
 			oldMachine: &AWSMachine{
@@ -356,6 +750,107 @@ func TestAWSMachineUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "grow volumes and change their type, iops and throughput",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 8, Type: VolumeTypeGP2},
+					NonRootVolumes: []Volume{
+						{DeviceName: "/dev/sdb", Size: 20, Type: VolumeTypeGP3},
+					},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 16, Type: VolumeTypeIO1, IOPS: 1000},
+					NonRootVolumes: []Volume{
+						{DeviceName: "/dev/sdb", Size: 40, Type: VolumeTypeGP3, IOPS: 4000, Throughput: pointer.Int64(250)},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "shrink root volume",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 16},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 8},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "shrink non root volume",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:   "test",
+					NonRootVolumes: []Volume{{DeviceName: "/dev/sdb", Size: 40}},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:   "test",
+					NonRootVolumes: []Volume{{DeviceName: "/dev/sdb", Size: 20}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "change volume type to io1 without iops",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 8, Type: VolumeTypeGP2},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 8, Type: VolumeTypeIO1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "add non root volume",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:   "test",
+					NonRootVolumes: []Volume{{DeviceName: "/dev/sdb", Size: 20}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "change volume encryption",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 8},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					RootVolume:   &Volume{Size: 8, Encrypted: pointer.Bool(true)},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		ctx := context.TODO()
@@ -69,6 +69,10 @@ func (r *AWSMachineTemplate) validateRootVolume() field.ErrorList {
 		}
 	}
 
+	if spec.RootVolume.Snapshot != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec.template.spec.rootVolume.snapshot"), "snapshot is not supported for the root volume"))
+	}
+
 	if spec.RootVolume.DeviceName != "" {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
@@ -98,6 +102,10 @@ func (r *AWSMachineTemplate) validateNonRootVolumes() field.ErrorList {
 		if volume.DeviceName == "" {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.deviceName"), "non root volume should have device name"))
 		}
+
+		if volume.Snapshot != nil && (volume.Snapshot.ID == nil) == (len(volume.Snapshot.Filters) == 0) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec.template.spec.nonRootVolumes.snapshot"), volume.Snapshot, "exactly one of ID or Filters must be specified"))
+		}
 	}
 
 	return allErrs
@@ -175,6 +183,34 @@ func (r *AWSMachineTemplate) validateSSHKeyName() field.ErrorList {
 	return validateSSHKeyName(r.Spec.Template.Spec.SSHKeyName)
 }
 
+func (r *AWSMachineTemplate) validateHibernation() field.ErrorList {
+	return validateHibernation(r.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+}
+
+func (r *AWSMachineTemplate) validateLaunchTemplate() field.ErrorList {
+	return validateLaunchTemplate(r.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+}
+
+func (r *AWSMachineTemplate) validateFallbacks() field.ErrorList {
+	return validateFallbacks(r.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+}
+
+func (r *AWSMachineTemplate) validateManagedNetworkInterfaces() field.ErrorList {
+	return validateManagedNetworkInterfaces(r.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+}
+
+func (r *AWSMachineTemplate) validatePlacement() field.ErrorList {
+	return validatePlacement(r.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+}
+
+func (r *AWSMachineTemplate) validateTerminationProtection() field.ErrorList {
+	return validateTerminationProtection(r.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+}
+
+func (r *AWSMachineTemplate) validateHostPlacement() field.ErrorList {
+	return validateHostPlacement(r.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (r *AWSMachineTemplateWebhook) ValidateCreate(_ context.Context, raw runtime.Object) (admission.Warnings, error) {
 	var allErrs field.ErrorList
@@ -203,6 +239,14 @@ func (r *AWSMachineTemplateWebhook) ValidateCreate(_ context.Context, raw runtim
 	allErrs = append(allErrs, obj.validateNonRootVolumes()...)
 	allErrs = append(allErrs, obj.validateSSHKeyName()...)
 	allErrs = append(allErrs, obj.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, obj.validateHostPlacement()...)
+	allErrs = append(allErrs, obj.validatePlacement()...)
+	allErrs = append(allErrs, obj.validateTerminationProtection()...)
+	allErrs = append(allErrs, obj.validateHibernation()...)
+	allErrs = append(allErrs, obj.validateManagedNetworkInterfaces()...)
+	allErrs = append(allErrs, obj.validateFallbacks()...)
+	allErrs = append(allErrs, obj.Spec.Template.Spec.AMI.Validate(field.NewPath("spec", "template", "spec", "ami"))...)
+	allErrs = append(allErrs, obj.validateLaunchTemplate()...)
 	allErrs = append(allErrs, obj.Spec.Template.Spec.AdditionalTags.Validate()...)
 
 	return nil, aggregateObjErrors(obj.GroupVersionKind().GroupKind(), obj.Name, allErrs)
@@ -77,6 +77,8 @@ const (
 	NatGatewaysCreationStartedReason = "NatGatewaysCreationStarted"
 	// NatGatewaysReconciliationFailedReason used when any errors occur during reconciliation of NAT gateways.
 	NatGatewaysReconciliationFailedReason = "NatGatewaysReconciliationFailed"
+	// NatGatewaysHibernatedReason used when the NAT gateways were deleted because the cluster is hibernated.
+	NatGatewaysHibernatedReason = "NatGatewaysHibernated"
 )
 
 const (
@@ -110,6 +112,25 @@ const (
 	BastionCreationStartedReason = "BastionCreationStarted"
 	// BastionHostFailedReason used when an error occurs during the creation of a bastion host.
 	BastionHostFailedReason = "BastionHostFailed"
+	// BastionHostHibernatedReason used when the bastion host was deleted because the cluster is hibernated.
+	BastionHostHibernatedReason = "BastionHostHibernated"
+)
+
+const (
+	// PlacementGroupsReadyCondition reports whether the placement groups of the cluster are ready. Depending on the
+	// configuration, a cluster may not have placement groups and this condition will be skipped.
+	PlacementGroupsReadyCondition clusterv1.ConditionType = "PlacementGroupsReady"
+	// PlacementGroupsReconciliationFailedReason used when an error occurs while creating or deleting the placement groups.
+	PlacementGroupsReconciliationFailedReason = "PlacementGroupsReconciliationFailed"
+	// PlacementGroupsInUseReason used when placement groups removed from the spec cannot be deleted yet because
+	// instances still run in them.
+	PlacementGroupsInUseReason = "PlacementGroupsInUse"
+)
+
+const (
+	// HibernatedCondition reports that the cluster is hibernated, or being hibernated. It is removed once the
+	// cluster is resumed.
+	HibernatedCondition clusterv1.ConditionType = "Hibernated"
 )
 
 const (
@@ -133,6 +154,8 @@ const (
 	InstanceTerminatedReason = "InstanceTerminated"
 	// InstanceStoppedReason instance is in a stopped state.
 	InstanceStoppedReason = "InstanceStopped"
+	// InstanceHibernatedReason used when the instance is stopped, or being stopped, because the cluster is hibernated.
+	InstanceHibernatedReason = "InstanceHibernated"
 	// InstanceNotReadyReason used when the instance is in a pending state.
 	InstanceNotReadyReason = "InstanceNotReady"
 	// InstanceProvisionStartedReason set when the provisioning of an instance started.
@@ -143,6 +166,25 @@ const (
 	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
 	// WaitingForBootstrapDataReason used when machine is waiting for bootstrap data to be ready before proceeding.
 	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
+	// ImageNotFoundReason used when the AMI does not exist, has been deregistered or is not shared with the account.
+	ImageNotFoundReason = "ImageNotFound"
+	// ImageNotAvailableReason used when the AMI is not in the available state.
+	ImageNotAvailableReason = "ImageNotAvailable"
+	// ImageDeprecatedReason used when the AMI is past its deprecation time.
+	ImageDeprecatedReason = "ImageDeprecated"
+	// ImageIncompatibleReason used when the architecture, boot mode, ENA support or root device of the AMI
+	// is not compatible with the instance type or root volume of the machine.
+	ImageIncompatibleReason = "ImageIncompatible"
+)
+
+const (
+	// FallbackInstanceTypesCompatibleCondition reports whether the fallback instance types of the AWSMachine can be
+	// launched with its AMI. It is only set once fallback instance types are tried, and incompatible ones are skipped.
+	FallbackInstanceTypesCompatibleCondition clusterv1.ConditionType = "FallbackInstanceTypesCompatible"
+
+	// FallbackInstanceTypesIncompatibleReason used when fallback instance types were skipped because the AMI cannot
+	// be launched with them.
+	FallbackInstanceTypesIncompatibleReason = "FallbackInstanceTypesIncompatible"
 )
 
 const (
@@ -153,6 +195,45 @@ const (
 	SecurityGroupsFailedReason = "SecurityGroupsSyncFailed"
 )
 
+const (
+	// VolumesReadyCondition reports whether the EBS volumes attached to the instance match the size, type, IOPS
+	// and throughput of the AWSMachine spec.
+	VolumesReadyCondition clusterv1.ConditionType = "VolumesReady"
+
+	// VolumeModificationInProgressReason used when the volumes are being modified.
+	VolumeModificationInProgressReason = "VolumeModificationInProgress"
+	// VolumeModificationCooldownReason used when a volume was modified less than six hours ago and cannot be
+	// modified again yet.
+	VolumeModificationCooldownReason = "VolumeModificationCooldown"
+	// VolumeModificationFailedReason used when the volumes could not be modified.
+	VolumeModificationFailedReason = "VolumeModificationFailed"
+)
+
+const (
+	// DriftedCondition lists the fields of the instance that were changed outside of CAPA and no longer match
+	// the AWSMachine spec. It is removed once no field has drifted anymore.
+	DriftedCondition clusterv1.ConditionType = "Drifted"
+
+	// InstanceDriftedReason used when fields of the instance have drifted and are reported.
+	InstanceDriftedReason = "InstanceDrifted"
+	// InstanceReplacementRequiredReason used when fields of the instance have drifted and the drift policy
+	// requires the machine to be replaced.
+	InstanceReplacementRequiredReason = "InstanceReplacementRequired"
+)
+
+const (
+	// LaunchTemplateUpToDateCondition reports whether the referenced launch template still resolves to the version
+	// the instance was launched from. Instances are not updated in place: the machine has to be replaced to apply
+	// another version.
+	LaunchTemplateUpToDateCondition clusterv1.ConditionType = "LaunchTemplateUpToDate"
+
+	// LaunchTemplateVersionChangedReason used when the referenced launch template resolves to another version
+	// than the instance was launched from.
+	LaunchTemplateVersionChangedReason = "LaunchTemplateVersionChanged"
+	// LaunchTemplateVersionCheckFailedReason used when the version of the referenced launch template could not be resolved.
+	LaunchTemplateVersionCheckFailedReason = "LaunchTemplateVersionCheckFailed"
+)
+
 const (
 	// ELBAttachedCondition will report true when a control plane is successfully registered with an ELB.
 	// When set to false, severity can be an Error if the subnet is not found or unavailable in the instance's AZ.
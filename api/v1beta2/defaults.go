@@ -82,8 +82,16 @@ func SetDefaults_Labels(obj *metav1.ObjectMeta) { //nolint:golint,stylecheck
 
 // SetDefaults_AWSMachineSpec is used by defaulter-gen.
 func SetDefaults_AWSMachineSpec(obj *AWSMachineSpec) { //nolint:golint,stylecheck
+	// The metadata options of a referenced launch template are used unless they are set explicitly.
+	if obj.InstanceMetadataOptions == nil && obj.LaunchTemplate != nil {
+		return
+	}
 	if obj.InstanceMetadataOptions == nil {
 		obj.InstanceMetadataOptions = &InstanceMetadataOptions{}
+		// Windows containers reach the instance metadata service through the NAT of the host, one hop further.
+		if obj.OSType == OSTypeWindows {
+			obj.InstanceMetadataOptions.HTTPPutResponseHopLimit = 2
+		}
 	}
 	obj.InstanceMetadataOptions.SetDefaults()
 }
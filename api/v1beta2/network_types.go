@@ -394,6 +394,11 @@ type SubnetSpec struct {
 
 	// Tags is a collection of tags describing the resource.
 	Tags Tags `json:"tags,omitempty"`
+
+	// PrivateDNSNameOptionsOnLaunch defines the default hostname options of instances launched into the subnet.
+	// Only applied when the subnet is managed by the provider.
+	// +optional
+	PrivateDNSNameOptionsOnLaunch *PrivateDNSName `json:"privateDnsNameOptionsOnLaunch,omitempty"`
 }
 
 // GetResourceID returns the identifier for this subnet,
@@ -170,6 +170,10 @@ const (
 	// dedicated to this cluster api provider implementation.
 	NameAWSSubnetAssociation = NameAWSProviderPrefix + "association"
 
+	// NameAWSDedicatedHostGroup is the tag name we use to mark the group of machines
+	// a dynamically allocated Dedicated Host is shared by.
+	NameAWSDedicatedHostGroup = NameAWSProviderPrefix + "dedicated-host-group"
+
 	// SecondarySubnetTagValue is the secondary subnet tag constant value.
 	SecondarySubnetTagValue = "secondary"
 
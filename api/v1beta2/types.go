@@ -18,6 +18,7 @@ package v1beta2
 
 import (
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -49,6 +50,34 @@ type AMIReference struct {
 	// +kubebuilder:validation:Enum:=AmazonLinux;AmazonLinuxGPU
 	// +optional
 	EKSOptimizedLookupType *EKSAMILookupType `json:"eksLookupType,omitempty"`
+
+	// SSMParameter is the name of an SSM parameter holding the ID of the AMI to use, such as the public
+	// parameter `/aws/service/canonical/ubuntu/server/22.04/stable/current/{{.Arch}}/hvm/ebs-gp2/ami-id`.
+	// The name is a Go template which can reference the Kubernetes version of the machine as
+	// `{{.K8sVersion}}` (e.g. 1.27.3) or `{{.K8sMinorVersion}}` (e.g. 1.27), and the architecture of its
+	// instance type as `{{.Arch}}` (amd64 or arm64) or `{{.Architecture}}` (x86_64 or arm64).
+	// +optional
+	SSMParameter *string `json:"ssmParameter,omitempty"`
+}
+
+// Validate checks that at most one source of AMI ID is specified.
+func (r *AMIReference) Validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.SSMParameter == nil {
+		return allErrs
+	}
+	if r.ID != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("ssmParameter"), "cannot be set together with id"))
+	}
+	if r.EKSOptimizedLookupType != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("ssmParameter"), "cannot be set together with eksLookupType"))
+	}
+	if *r.SSMParameter == "" {
+		allErrs = append(allErrs, field.Required(path.Child("ssmParameter"), "must not be empty"))
+	}
+
+	return allErrs
 }
 
 // Filter is a filter used to identify an AWS resource.
@@ -208,6 +237,18 @@ type Instance struct {
 	// Specifies ENIs attached to instance
 	NetworkInterfaces []string `json:"networkInterfaces,omitempty"`
 
+	// ManagedNetworkInterfaces are the additional ENIs created together with the instance.
+	// +optional
+	ManagedNetworkInterfaces []ManagedNetworkInterface `json:"managedNetworkInterfaces,omitempty"`
+
+	// NetworkInterfaceType is the type of the primary network interface of the instance.
+	// +optional
+	NetworkInterfaceType NetworkInterfaceType `json:"networkInterfaceType,omitempty"`
+
+	// AttachedNetworkInterfaces describes the ENIs attached to the instance.
+	// +optional
+	AttachedNetworkInterfaces []NetworkInterfaceStatus `json:"attachedNetworkInterfaces,omitempty"`
+
 	// The tags associated with the instance.
 	Tags map[string]string `json:"tags,omitempty"`
 
@@ -221,17 +262,61 @@ type Instance struct {
 	// +optional
 	PlacementGroupName string `json:"placementGroupName,omitempty"`
 
+	// PlacementGroupPartition is the number of the partition in which to launch the instance.
+	// +optional
+	PlacementGroupPartition int64 `json:"placementGroupPartition,omitempty"`
+
 	// Tenancy indicates if instance should run on shared or single-tenant hardware.
 	// +optional
 	Tenancy string `json:"tenancy,omitempty"`
 
+	// HostID is the ID of the Dedicated Host the instance runs on, if applicable.
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+
+	// HostAffinity is the Dedicated Host affinity setting of the instance.
+	// +optional
+	HostAffinity *string `json:"hostAffinity,omitempty"`
+
+	// HostResourceGroupArn is the ARN of the host resource group the instance was launched in.
+	// +optional
+	HostResourceGroupArn *string `json:"hostResourceGroupArn,omitempty"`
+
 	// IDs of the instance's volumes
 	// +optional
 	VolumeIDs []string `json:"volumeIDs,omitempty"`
 
+	// RootDeviceName is the device name of the instance's root volume.
+	// +optional
+	RootDeviceName string `json:"rootDeviceName,omitempty"`
+
 	// InstanceMetadataOptions is the metadata options for the EC2 instance.
 	// +optional
 	InstanceMetadataOptions *InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
+
+	// CPUOptions is the CPU configuration of the instance.
+	// +optional
+	CPUOptions *CPUOptions `json:"cpuOptions,omitempty"`
+
+	// NitroEnclaveEnabled indicates whether the instance is enabled for AWS Nitro Enclaves.
+	// +optional
+	NitroEnclaveEnabled bool `json:"nitroEnclaveEnabled,omitempty"`
+
+	// HibernationEnabled indicates whether the instance is configured for hibernation.
+	// +optional
+	HibernationEnabled bool `json:"hibernationEnabled,omitempty"`
+
+	// TerminationProtection indicates whether the instance is launched with termination and stop protection.
+	// +optional
+	TerminationProtection bool `json:"terminationProtection,omitempty"`
+
+	// PrivateDNSName is the hostname configuration of the instance.
+	// +optional
+	PrivateDNSName *PrivateDNSName `json:"privateDnsName,omitempty"`
+
+	// LaunchTemplate is the launch template the instance is launched from, with its version resolved to a number.
+	// +optional
+	LaunchTemplate *LaunchTemplateReference `json:"launchTemplate,omitempty"`
 }
 
 // InstanceMetadataState describes the state of InstanceMetadataOptions.HttpEndpoint and InstanceMetadataOptions.InstanceMetadataTags
@@ -356,6 +441,13 @@ type Volume struct {
 	// The key must already exist and be accessible by the controller.
 	// +optional
 	EncryptionKey string `json:"encryptionKey,omitempty"`
+
+	// Snapshot is the EBS snapshot to restore the volume from, either by ID or by filters.
+	// When filters are used, the newest completed snapshot matching them is used. Snapshots
+	// owned by the account are looked up, unless an owner-id or owner-alias filter is given.
+	// Size must be greater than or equal to the snapshot size. Only applicable to non root volumes.
+	// +optional
+	Snapshot *AWSResourceReference `json:"snapshot,omitempty"`
 }
 
 // VolumeType describes the EBS volume type.
@@ -398,6 +490,331 @@ type SpotMarketOptions struct {
 	MaxPrice *string `json:"maxPrice,omitempty"`
 }
 
+// CPUOptions defines the number of CPU cores and threads per core of an instance.
+// See https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/instance-optimize-cpu.html
+type CPUOptions struct {
+	// CoreCount is the number of CPU cores for the instance.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	CoreCount *int64 `json:"coreCount,omitempty"`
+
+	// ThreadsPerCore is the number of threads per CPU core.
+	// Set to 1 to disable simultaneous multithreading.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2
+	ThreadsPerCore *int64 `json:"threadsPerCore,omitempty"`
+}
+
+// PrivateDNSName defines the hostname options of an instance.
+// See https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ec2-instance-naming.html
+type PrivateDNSName struct {
+	// EnableResourceNameDNSAAAARecord indicates whether to respond to DNS queries for instance hostnames with DNS AAAA records.
+	// +optional
+	EnableResourceNameDNSAAAARecord *bool `json:"enableResourceNameDnsAAAARecord,omitempty"`
+
+	// EnableResourceNameDNSARecord indicates whether to respond to DNS queries for instance hostnames with DNS A records.
+	// +optional
+	EnableResourceNameDNSARecord *bool `json:"enableResourceNameDnsARecord,omitempty"`
+
+	// HostnameType is the type of hostname to assign to an instance.
+	// ip-name hostnames are based on the private IPv4 address, resource-name hostnames on the instance ID.
+	// +optional
+	// +kubebuilder:validation:Enum:=ip-name;resource-name
+	HostnameType *string `json:"hostnameType,omitempty"`
+}
+
+// ManagedNetworkInterface defines an additional elastic network interface that is created
+// together with the instance and deleted when the instance is terminated.
+type ManagedNetworkInterface struct {
+	// DeviceIndex is the position of the network interface in the attachment order of the instance.
+	// Index 0 is the primary network interface of the instance.
+	// +kubebuilder:validation:Minimum=1
+	DeviceIndex int64 `json:"deviceIndex"`
+
+	// Description is the description of the network interface.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Subnet is the subnet to create the network interface in. It must be in the availability zone of the instance.
+	// Defaults to the subnet of the instance.
+	// +optional
+	Subnet *AWSResourceReference `json:"subnet,omitempty"`
+
+	// SecurityGroups is the list of security groups applied to the network interface.
+	// Defaults to the security groups of the instance.
+	// +optional
+	SecurityGroups []AWSResourceReference `json:"securityGroups,omitempty"`
+
+	// SecondaryPrivateIPAddressCount is the number of secondary private IPv4 addresses to assign to the network interface.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SecondaryPrivateIPAddressCount *int64 `json:"secondaryPrivateIPAddressCount,omitempty"`
+
+	// IPv4PrefixCount is the number of /28 IPv4 prefixes delegated to the network interface.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	IPv4PrefixCount *int64 `json:"ipv4PrefixCount,omitempty"`
+
+	// IPv6PrefixCount is the number of /80 IPv6 prefixes delegated to the network interface.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	IPv6PrefixCount *int64 `json:"ipv6PrefixCount,omitempty"`
+
+	// InterfaceType is the type of the network interface. Set it to efa to create an Elastic Fabric Adapter.
+	// +kubebuilder:validation:Enum:=interface;efa
+	// +optional
+	InterfaceType NetworkInterfaceType `json:"interfaceType,omitempty"`
+}
+
+// NetworkInterfaceType is the type of an elastic network interface.
+type NetworkInterfaceType string
+
+const (
+	// NetworkInterfaceTypeENI is a regular elastic network interface.
+	NetworkInterfaceTypeENI = NetworkInterfaceType("interface")
+
+	// NetworkInterfaceTypeEFAWithENAInterface is an Elastic Fabric Adapter, with the ENA interface included.
+	NetworkInterfaceTypeEFAWithENAInterface = NetworkInterfaceType("efa")
+)
+
+// PlacementGroupStrategy is the strategy used to place the instances of a placement group.
+type PlacementGroupStrategy string
+
+const (
+	// PlacementGroupStrategyCluster packs instances close together inside an availability zone.
+	PlacementGroupStrategyCluster = PlacementGroupStrategy("cluster")
+
+	// PlacementGroupStrategySpread places each instance on distinct hardware.
+	PlacementGroupStrategySpread = PlacementGroupStrategy("spread")
+
+	// PlacementGroupStrategyPartition spreads instances across logical partitions that do not share hardware.
+	PlacementGroupStrategyPartition = PlacementGroupStrategy("partition")
+)
+
+// PlacementGroupSpec defines a placement group that is created with the cluster and deleted with it.
+type PlacementGroupSpec struct {
+	// Name is the name of the placement group, which machines reference with their placementGroupName.
+	// It must be unique within the account and region.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=255
+	Name string `json:"name"`
+
+	// Strategy is the strategy used to place the instances of the placement group.
+	// +kubebuilder:validation:Enum:=cluster;spread;partition
+	Strategy PlacementGroupStrategy `json:"strategy"`
+
+	// PartitionCount is the number of partitions of the placement group.
+	// Only valid with the partition strategy. When omitted, the default of EC2 is used.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=7
+	// +optional
+	PartitionCount *int64 `json:"partitionCount,omitempty"`
+}
+
+// NetworkInterfaceStatus describes an elastic network interface attached to an instance.
+type NetworkInterfaceStatus struct {
+	// ID is the ID of the network interface.
+	ID string `json:"id"`
+
+	// DeviceIndex is the position of the network interface in the attachment order of the instance.
+	DeviceIndex int64 `json:"deviceIndex"`
+
+	// SubnetID is the ID of the subnet the network interface is in.
+	// +optional
+	SubnetID string `json:"subnetID,omitempty"`
+
+	// PrivateIPAddresses are the private IPv4 addresses assigned to the network interface.
+	// +optional
+	PrivateIPAddresses []string `json:"privateIPAddresses,omitempty"`
+
+	// IPv4Prefixes are the IPv4 prefixes delegated to the network interface.
+	// +optional
+	IPv4Prefixes []string `json:"ipv4Prefixes,omitempty"`
+
+	// IPv6Prefixes are the IPv6 prefixes delegated to the network interface.
+	// +optional
+	IPv6Prefixes []string `json:"ipv6Prefixes,omitempty"`
+
+	// DeleteOnTermination indicates whether the network interface is deleted when the instance is terminated.
+	// +optional
+	DeleteOnTermination bool `json:"deleteOnTermination,omitempty"`
+}
+
+// DriftAction is the action taken when a field of an instance is changed outside of CAPA and no longer
+// matches the spec of its machine.
+type DriftAction string
+
+const (
+	// DriftActionRepair modifies the instance back to the spec of the machine.
+	DriftActionRepair = DriftAction("Repair")
+	// DriftActionReport only reports the drifted field in the Drifted condition of the machine.
+	DriftActionReport = DriftAction("Report")
+	// DriftActionReplace reports the drifted field and marks the machine as failed, so that it is replaced,
+	// for instance by a MachineHealthCheck.
+	DriftActionReplace = DriftAction("Replace")
+)
+
+// DriftField is a field of an instance whose drift from the spec of its machine is detected.
+type DriftField string
+
+const (
+	// DriftFieldInstanceType is the instance type, which drifts when the instance is resized.
+	DriftFieldInstanceType = DriftField("instanceType")
+	// DriftFieldInstanceMetadataOptions are the instance metadata options.
+	DriftFieldInstanceMetadataOptions = DriftField("instanceMetadataOptions")
+	// DriftFieldIAMInstanceProfile is the IAM instance profile associated with the instance.
+	DriftFieldIAMInstanceProfile = DriftField("iamInstanceProfile")
+	// DriftFieldSecurityGroups are the core and additional security groups of the instance.
+	DriftFieldSecurityGroups = DriftField("securityGroups")
+	// DriftFieldTags are the additional tags of the instance.
+	DriftFieldTags = DriftField("tags")
+	// DriftFieldVolumes are the root and non-root volumes of the instance.
+	DriftFieldVolumes = DriftField("volumes")
+	// DriftFieldNetworkInterfaces are the network interfaces attached to the instance.
+	DriftFieldNetworkInterfaces = DriftField("networkInterfaces")
+)
+
+// DriftPolicy defines the action taken for each field of an instance that is changed outside of CAPA,
+// for instance through the AWS console.
+type DriftPolicy struct {
+	// InstanceType is the action taken when the instance type changes. It cannot be repaired without
+	// stopping the instance. Defaults to Report.
+	// +kubebuilder:validation:Enum=Report;Replace
+	// +optional
+	InstanceType DriftAction `json:"instanceType,omitempty"`
+
+	// InstanceMetadataOptions is the action taken when the instance metadata options change. Defaults to Repair.
+	// +kubebuilder:validation:Enum=Repair;Report;Replace
+	// +optional
+	InstanceMetadataOptions DriftAction `json:"instanceMetadataOptions,omitempty"`
+
+	// IAMInstanceProfile is the action taken when another IAM instance profile than the one of the spec is
+	// associated with the instance, or when it is disassociated. Defaults to Report.
+	// +kubebuilder:validation:Enum=Repair;Report;Replace
+	// +optional
+	IAMInstanceProfile DriftAction `json:"iamInstanceProfile,omitempty"`
+
+	// SecurityGroups is the action taken when security groups are attached to or detached from the network
+	// interfaces of the instance. Defaults to Repair.
+	// +kubebuilder:validation:Enum=Repair;Report;Replace
+	// +optional
+	SecurityGroups DriftAction `json:"securityGroups,omitempty"`
+
+	// Tags is the action taken when additional tags are removed from the instance or changed. Tags added
+	// to the instance outside of CAPA are not considered a drift. Defaults to Repair.
+	// +kubebuilder:validation:Enum=Repair;Report;Replace
+	// +optional
+	Tags DriftAction `json:"tags,omitempty"`
+
+	// Volumes is the action taken when the size, type, IOPS or throughput of the volumes no longer match the
+	// spec. When volumes are not repaired, non-root volumes detached from the instance are detected as well.
+	// Defaults to Repair.
+	// +kubebuilder:validation:Enum=Repair;Report;Replace
+	// +optional
+	Volumes DriftAction `json:"volumes,omitempty"`
+
+	// NetworkInterfaces is the action taken when the network interfaces of the spec are detached from the
+	// instance. Network interfaces attached outside of CAPA, for instance by the VPC CNI, are not considered
+	// a drift. Defaults to Report.
+	// +kubebuilder:validation:Enum=Report;Replace
+	// +optional
+	NetworkInterfaces DriftAction `json:"networkInterfaces,omitempty"`
+}
+
+// ActionFor returns the action taken when the given field drifts, or the default action of the field
+// when the policy does not set one.
+func (p *DriftPolicy) ActionFor(field DriftField) DriftAction {
+	var action DriftAction
+	if p != nil {
+		switch field {
+		case DriftFieldInstanceType:
+			action = p.InstanceType
+		case DriftFieldInstanceMetadataOptions:
+			action = p.InstanceMetadataOptions
+		case DriftFieldIAMInstanceProfile:
+			action = p.IAMInstanceProfile
+		case DriftFieldSecurityGroups:
+			action = p.SecurityGroups
+		case DriftFieldTags:
+			action = p.Tags
+		case DriftFieldVolumes:
+			action = p.Volumes
+		case DriftFieldNetworkInterfaces:
+			action = p.NetworkInterfaces
+		}
+	}
+	if action != "" {
+		return action
+	}
+
+	switch field {
+	case DriftFieldInstanceMetadataOptions, DriftFieldSecurityGroups, DriftFieldTags, DriftFieldVolumes:
+		return DriftActionRepair
+	default:
+		return DriftActionReport
+	}
+}
+
+// DynamicHostAllocationSpec defines how CAPA allocates Dedicated Hosts for a machine.
+type DynamicHostAllocationSpec struct {
+	// Tags is a set of additional tags applied to the allocated Dedicated Hosts.
+	// +optional
+	Tags Tags `json:"tags,omitempty"`
+}
+
+// DedicatedHostStatus describes a Dedicated Host allocated by CAPA.
+type DedicatedHostStatus struct {
+	// ID is the ID of the Dedicated Host.
+	// +optional
+	ID *string `json:"id,omitempty"`
+}
+
+// LaunchTemplateReference references an existing EC2 launch template and one of its versions.
+type LaunchTemplateReference struct {
+	// ID of the launch template. Cannot be set together with Name.
+	// +optional
+	ID *string `json:"id,omitempty"`
+
+	// Name of the launch template. Cannot be set together with ID.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Version of the launch template: a version number, $Latest or $Default.
+	// Defaults to $Default.
+	// +kubebuilder:validation:Pattern=`^([1-9][0-9]*|\$Latest|\$Default)$`
+	// +optional
+	Version *string `json:"version,omitempty"`
+}
+
+// Validate checks that the launch template is referenced by exactly one of ID or Name.
+func (r *LaunchTemplateReference) Validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r == nil {
+		return allErrs
+	}
+	if r.ID == nil && r.Name == nil {
+		allErrs = append(allErrs, field.Required(path, "either id or name must be set"))
+	}
+	if r.ID != nil && r.Name != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("name"), "cannot be set together with id"))
+	}
+
+	return allErrs
+}
+
+const (
+	// HostAffinityDefault allows a stopped instance to restart on any available Dedicated Host.
+	HostAffinityDefault = "default"
+
+	// HostAffinityHost pins an instance to the Dedicated Host it was started on.
+	HostAffinityHost = "host"
+
+	// TenancyHost is the tenancy used by instances running on Dedicated Hosts.
+	TenancyHost = "host"
+)
+
 // EKSAMILookupType specifies which AWS AMI to use for a AWSMachine and AWSMachinePool.
 type EKSAMILookupType string
 
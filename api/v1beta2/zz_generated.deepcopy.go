@@ -42,6 +42,11 @@ func (in *AMIReference) DeepCopyInto(out *AMIReference) {
 		*out = new(EKSAMILookupType)
 		**out = **in
 	}
+	if in.SSMParameter != nil {
+		in, out := &in.SSMParameter, &out.SSMParameter
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMIReference.
@@ -310,6 +315,23 @@ func (in *AWSClusterSpec) DeepCopyInto(out *AWSClusterSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Bastion.DeepCopyInto(&out.Bastion)
+	if in.PlacementGroups != nil {
+		in, out := &in.PlacementGroups, &out.PlacementGroups
+		*out = make([]PlacementGroupSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ControlPlaneTerminationProtection != nil {
+		in, out := &in.ControlPlaneTerminationProtection, &out.ControlPlaneTerminationProtection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(Hibernation)
+		**out = **in
+	}
 	if in.IdentityRef != nil {
 		in, out := &in.IdentityRef, &out.IdentityRef
 		*out = new(AWSIdentityReference)
@@ -677,6 +699,18 @@ func (in *AWSMachineSpec) DeepCopyInto(out *AWSMachineSpec) {
 		**out = **in
 	}
 	in.AMI.DeepCopyInto(&out.AMI)
+	if in.FallbackInstanceTypes != nil {
+		in, out := &in.FallbackInstanceTypes, &out.FallbackInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FallbackSubnets != nil {
+		in, out := &in.FallbackSubnets, &out.FallbackSubnets
+		*out = make([]AWSResourceReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.AdditionalTags != nil {
 		in, out := &in.AdditionalTags, &out.AdditionalTags
 		*out = make(Tags, len(*in))
@@ -723,6 +757,13 @@ func (in *AWSMachineSpec) DeepCopyInto(out *AWSMachineSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ManagedNetworkInterfaces != nil {
+		in, out := &in.ManagedNetworkInterfaces, &out.ManagedNetworkInterfaces
+		*out = make([]ManagedNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.UncompressedUserData != nil {
 		in, out := &in.UncompressedUserData, &out.UncompressedUserData
 		*out = new(bool)
@@ -739,6 +780,51 @@ func (in *AWSMachineSpec) DeepCopyInto(out *AWSMachineSpec) {
 		*out = new(SpotMarketOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HostID != nil {
+		in, out := &in.HostID, &out.HostID
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostAffinity != nil {
+		in, out := &in.HostAffinity, &out.HostAffinity
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostResourceGroupArn != nil {
+		in, out := &in.HostResourceGroupArn, &out.HostResourceGroupArn
+		*out = new(string)
+		**out = **in
+	}
+	if in.DynamicHostAllocation != nil {
+		in, out := &in.DynamicHostAllocation, &out.DynamicHostAllocation
+		*out = new(DynamicHostAllocationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CPUOptions != nil {
+		in, out := &in.CPUOptions, &out.CPUOptions
+		*out = new(CPUOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateDNSName != nil {
+		in, out := &in.PrivateDNSName, &out.PrivateDNSName
+		*out = new(PrivateDNSName)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationProtection != nil {
+		in, out := &in.TerminationProtection, &out.TerminationProtection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(DriftPolicy)
+		**out = **in
+	}
+	if in.LaunchTemplate != nil {
+		in, out := &in.LaunchTemplate, &out.LaunchTemplate
+		*out = new(LaunchTemplateReference)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSMachineSpec.
@@ -781,6 +867,11 @@ func (in *AWSMachineStatus) DeepCopyInto(out *AWSMachineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DedicatedHost != nil {
+		in, out := &in.DedicatedHost, &out.DedicatedHost
+		*out = new(DedicatedHostStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSMachineStatus.
@@ -1193,6 +1284,31 @@ func (in *CNISpec) DeepCopy() *CNISpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUOptions) DeepCopyInto(out *CPUOptions) {
+	*out = *in
+	if in.CoreCount != nil {
+		in, out := &in.CoreCount, &out.CoreCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ThreadsPerCore != nil {
+		in, out := &in.ThreadsPerCore, &out.ThreadsPerCore
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUOptions.
+func (in *CPUOptions) DeepCopy() *CPUOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClassicELBAttributes) DeepCopyInto(out *ClassicELBAttributes) {
 	*out = *in
@@ -1253,6 +1369,63 @@ func (in *CloudInit) DeepCopy() *CloudInit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedHostStatus) DeepCopyInto(out *DedicatedHostStatus) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedHostStatus.
+func (in *DedicatedHostStatus) DeepCopy() *DedicatedHostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedHostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftPolicy) DeepCopyInto(out *DriftPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftPolicy.
+func (in *DriftPolicy) DeepCopy() *DriftPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicHostAllocationSpec) DeepCopyInto(out *DynamicHostAllocationSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(Tags, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicHostAllocationSpec.
+func (in *DynamicHostAllocationSpec) DeepCopy() *DynamicHostAllocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicHostAllocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Filter) DeepCopyInto(out *Filter) {
 	*out = *in
@@ -1273,6 +1446,21 @@ func (in *Filter) DeepCopy() *Filter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hibernation) DeepCopyInto(out *Hibernation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hibernation.
+func (in *Hibernation) DeepCopy() *Hibernation {
+	if in == nil {
+		return nil
+	}
+	out := new(Hibernation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAMPool) DeepCopyInto(out *IPAMPool) {
 	*out = *in
@@ -1444,6 +1632,20 @@ func (in *Instance) DeepCopyInto(out *Instance) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ManagedNetworkInterfaces != nil {
+		in, out := &in.ManagedNetworkInterfaces, &out.ManagedNetworkInterfaces
+		*out = make([]ManagedNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedNetworkInterfaces != nil {
+		in, out := &in.AttachedNetworkInterfaces, &out.AttachedNetworkInterfaces
+		*out = make([]NetworkInterfaceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(map[string]string, len(*in))
@@ -1456,6 +1658,21 @@ func (in *Instance) DeepCopyInto(out *Instance) {
 		*out = new(SpotMarketOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HostID != nil {
+		in, out := &in.HostID, &out.HostID
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostAffinity != nil {
+		in, out := &in.HostAffinity, &out.HostAffinity
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostResourceGroupArn != nil {
+		in, out := &in.HostResourceGroupArn, &out.HostResourceGroupArn
+		*out = new(string)
+		**out = **in
+	}
 	if in.VolumeIDs != nil {
 		in, out := &in.VolumeIDs, &out.VolumeIDs
 		*out = make([]string, len(*in))
@@ -1466,6 +1683,21 @@ func (in *Instance) DeepCopyInto(out *Instance) {
 		*out = new(InstanceMetadataOptions)
 		**out = **in
 	}
+	if in.CPUOptions != nil {
+		in, out := &in.CPUOptions, &out.CPUOptions
+		*out = new(CPUOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateDNSName != nil {
+		in, out := &in.PrivateDNSName, &out.PrivateDNSName
+		*out = new(PrivateDNSName)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LaunchTemplate != nil {
+		in, out := &in.LaunchTemplate, &out.LaunchTemplate
+		*out = new(LaunchTemplateReference)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Instance.
@@ -1493,6 +1725,36 @@ func (in *InstanceMetadataOptions) DeepCopy() *InstanceMetadataOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LaunchTemplateReference) DeepCopyInto(out *LaunchTemplateReference) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LaunchTemplateReference.
+func (in *LaunchTemplateReference) DeepCopy() *LaunchTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(LaunchTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Listener) DeepCopyInto(out *Listener) {
 	*out = *in
@@ -1579,6 +1841,78 @@ func (in *LoadBalancer) DeepCopy() *LoadBalancer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedNetworkInterface) DeepCopyInto(out *ManagedNetworkInterface) {
+	*out = *in
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(AWSResourceReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]AWSResourceReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecondaryPrivateIPAddressCount != nil {
+		in, out := &in.SecondaryPrivateIPAddressCount, &out.SecondaryPrivateIPAddressCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IPv4PrefixCount != nil {
+		in, out := &in.IPv4PrefixCount, &out.IPv4PrefixCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IPv6PrefixCount != nil {
+		in, out := &in.IPv6PrefixCount, &out.IPv6PrefixCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedNetworkInterface.
+func (in *ManagedNetworkInterface) DeepCopy() *ManagedNetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedNetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterfaceStatus) DeepCopyInto(out *NetworkInterfaceStatus) {
+	*out = *in
+	if in.PrivateIPAddresses != nil {
+		in, out := &in.PrivateIPAddresses, &out.PrivateIPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPv4Prefixes != nil {
+		in, out := &in.IPv4Prefixes, &out.IPv4Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPv6Prefixes != nil {
+		in, out := &in.IPv6Prefixes, &out.IPv6Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterfaceStatus.
+func (in *NetworkInterfaceStatus) DeepCopy() *NetworkInterfaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterfaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
@@ -1649,6 +1983,56 @@ func (in *NetworkStatus) DeepCopy() *NetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementGroupSpec) DeepCopyInto(out *PlacementGroupSpec) {
+	*out = *in
+	if in.PartitionCount != nil {
+		in, out := &in.PartitionCount, &out.PartitionCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementGroupSpec.
+func (in *PlacementGroupSpec) DeepCopy() *PlacementGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateDNSName) DeepCopyInto(out *PrivateDNSName) {
+	*out = *in
+	if in.EnableResourceNameDNSAAAARecord != nil {
+		in, out := &in.EnableResourceNameDNSAAAARecord, &out.EnableResourceNameDNSAAAARecord
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableResourceNameDNSARecord != nil {
+		in, out := &in.EnableResourceNameDNSARecord, &out.EnableResourceNameDNSARecord
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HostnameType != nil {
+		in, out := &in.HostnameType, &out.HostnameType
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateDNSName.
+func (in *PrivateDNSName) DeepCopy() *PrivateDNSName {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateDNSName)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTable) DeepCopyInto(out *RouteTable) {
 	*out = *in
@@ -1758,6 +2142,11 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.PrivateDNSNameOptionsOnLaunch != nil {
+		in, out := &in.PrivateDNSNameOptionsOnLaunch, &out.PrivateDNSNameOptionsOnLaunch
+		*out = new(PrivateDNSName)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
@@ -1937,6 +2326,11 @@ func (in *Volume) DeepCopyInto(out *Volume) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(AWSResourceReference)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Volume.
@@ -86,6 +86,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:AttachNetworkInterface",
 				"ec2:DetachNetworkInterface",
 				"ec2:AllocateAddress",
+				"ec2:AllocateHosts",
 				"ec2:AssignIpv6Addresses",
 				"ec2:AssignPrivateIpAddresses",
 				"ec2:UnassignPrivateIpAddresses",
@@ -96,6 +97,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:CreateEgressOnlyInternetGateway",
 				"ec2:CreateNatGateway",
 				"ec2:CreateNetworkInterface",
+				"ec2:CreatePlacementGroup",
 				"ec2:CreateRoute",
 				"ec2:CreateRouteTable",
 				"ec2:CreateSecurityGroup",
@@ -106,6 +108,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DeleteInternetGateway",
 				"ec2:DeleteEgressOnlyInternetGateway",
 				"ec2:DeleteNatGateway",
+				"ec2:DeletePlacementGroup",
 				"ec2:DeleteRouteTable",
 				"ec2:ReplaceRoute",
 				"ec2:DeleteSecurityGroup",
@@ -115,31 +118,43 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DescribeAccountAttributes",
 				"ec2:DescribeAddresses",
 				"ec2:DescribeAvailabilityZones",
+				"ec2:DescribeHosts",
 				"ec2:DescribeInstances",
 				"ec2:DescribeInstanceTypes",
 				"ec2:DescribeInternetGateways",
 				"ec2:DescribeEgressOnlyInternetGateways",
 				"ec2:DescribeInstanceTypes",
 				"ec2:DescribeImages",
+				"ec2:GetInstanceTypesFromInstanceRequirements",
 				"ec2:DescribeNatGateways",
 				"ec2:DescribeNetworkInterfaces",
 				"ec2:DescribeNetworkInterfaceAttribute",
+				"ec2:DescribePlacementGroups",
 				"ec2:DescribeRouteTables",
 				"ec2:DescribeSecurityGroups",
+				"ec2:DescribeSnapshots",
 				"ec2:DescribeSubnets",
 				"ec2:DescribeVpcs",
 				"ec2:DescribeVpcAttribute",
 				"ec2:DescribeVolumes",
+				"ec2:DescribeVolumesModifications",
 				"ec2:DescribeTags",
 				"ec2:DetachInternetGateway",
 				"ec2:DisassociateRouteTable",
 				"ec2:DisassociateAddress",
+				"ec2:DescribeIamInstanceProfileAssociations",
+				"ec2:AssociateIamInstanceProfile",
+				"ec2:ReplaceIamInstanceProfileAssociation",
 				"ec2:ModifyInstanceAttribute",
 				"ec2:ModifyNetworkInterfaceAttribute",
 				"ec2:ModifySubnetAttribute",
+				"ec2:ModifyVolume",
 				"ec2:ReleaseAddress",
+				"ec2:ReleaseHosts",
 				"ec2:RevokeSecurityGroupIngress",
 				"ec2:RunInstances",
+				"ec2:StartInstances",
+				"ec2:StopInstances",
 				"ec2:TerminateInstances",
 				"tag:GetResources",
 				"elasticloadbalancing:AddTags",
@@ -166,6 +181,10 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"elasticloadbalancing:DeleteListener",
 				"autoscaling:DescribeAutoScalingGroups",
 				"autoscaling:DescribeInstanceRefreshes",
+				"autoscaling:DescribeLifecycleHooks",
+				"autoscaling:DescribePolicies",
+				"autoscaling:DescribeScheduledActions",
+				"cloudwatch:DescribeAlarms",
 				"ec2:CreateLaunchTemplate",
 				"ec2:CreateLaunchTemplateVersion",
 				"ec2:DescribeLaunchTemplates",
@@ -188,6 +207,23 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"autoscaling:StartInstanceRefresh",
 				"autoscaling:DeleteAutoScalingGroup",
 				"autoscaling:DeleteTags",
+				"autoscaling:PutWarmPool",
+				"autoscaling:DeleteWarmPool",
+				"autoscaling:PutLifecycleHook",
+				"autoscaling:DeleteLifecycleHook",
+				"autoscaling:CompleteLifecycleAction",
+				"autoscaling:AttachLoadBalancerTargetGroups",
+				"autoscaling:DetachLoadBalancerTargetGroups",
+				"autoscaling:AttachLoadBalancers",
+				"autoscaling:DetachLoadBalancers",
+				"autoscaling:PutScalingPolicy",
+				"autoscaling:DeletePolicy",
+				"autoscaling:PutScheduledUpdateGroupAction",
+				"autoscaling:DeleteScheduledAction",
+				"autoscaling:TerminateInstanceInAutoScalingGroup",
+				"autoscaling:RollbackInstanceRefresh",
+				"autoscaling:SuspendProcesses",
+				"autoscaling:ResumeProcesses",
 			},
 		},
 		{
@@ -233,6 +269,15 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"iam:PassRole",
 			},
 		},
+		{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				"arn:*:ssm:*:*:parameter/aws/service/*",
+			},
+			Action: iamv1.Actions{
+				"ssm:GetParameter",
+			},
+		},
 	}
 	for _, secureSecretBackend := range t.Spec.SecureSecretsBackends {
 		switch secureSecretBackend {
@@ -24,6 +24,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -237,6 +238,10 @@ func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 		allErrs = append(allErrs, errors.Wrapf(err, "error deleting bastion"))
 	}
 
+	if err := ec2svc.DeletePlacementGroups(); err != nil {
+		allErrs = append(allErrs, errors.Wrap(err, "error deleting placement groups"))
+	}
+
 	if err := sgService.DeleteSecurityGroups(); err != nil {
 		allErrs = append(allErrs, errors.Wrap(err, "error deleting security groups"))
 	}
@@ -280,6 +285,17 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 	sgService := r.getSecurityGroupService(*clusterScope)
 	s3Service := s3.NewService(clusterScope)
 
+	// The AWSMachine and AWSMachinePool controllers watch the AWSCluster and stop or start their instances.
+	if h := awsCluster.Spec.Hibernation; h != nil && h.Hibernate {
+		if !conditions.IsTrue(awsCluster, infrav1.HibernatedCondition) {
+			r.Recorder.Eventf(awsCluster, corev1.EventTypeNormal, "ClusterHibernated", "Hibernating cluster")
+		}
+		conditions.MarkTrue(awsCluster, infrav1.HibernatedCondition)
+	} else if conditions.Has(awsCluster, infrav1.HibernatedCondition) {
+		r.Recorder.Eventf(awsCluster, corev1.EventTypeNormal, "ClusterResumed", "Resuming hibernated cluster")
+		conditions.Delete(awsCluster, infrav1.HibernatedCondition)
+	}
+
 	if err := networkSvc.ReconcileNetwork(); err != nil {
 		clusterScope.Error(err, "failed to reconcile network")
 		return reconcile.Result{}, err
@@ -297,6 +313,14 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		return reconcile.Result{}, err
 	}
 
+	if err := ec2Service.ReconcilePlacementGroups(); err != nil {
+		conditions.MarkFalse(awsCluster, infrav1.PlacementGroupsReadyCondition, infrav1.PlacementGroupsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(clusterScope.ClusterObj()), err.Error())
+		clusterScope.Error(err, "failed to reconcile placement groups")
+		return reconcile.Result{}, err
+	}
+	// Placement groups removed from the spec are deleted once no instance runs in them anymore.
+	placementGroupsInUse := conditions.GetReason(awsCluster, infrav1.PlacementGroupsReadyCondition) == infrav1.PlacementGroupsInUseReason
+
 	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
 		instancestateSvc := instancestate.NewService(clusterScope)
 		if err := instancestateSvc.ReconcileEC2Events(); err != nil {
@@ -351,6 +375,9 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 	}
 
 	awsCluster.Status.Ready = true
+	if placementGroupsInUse {
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
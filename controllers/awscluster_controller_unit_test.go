@@ -196,6 +196,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				g := NewWithT(t)
 				runningCluster := func() {
 					ec2Svc.EXPECT().ReconcileBastion().Return(nil)
+					ec2Svc.EXPECT().ReconcilePlacementGroups().Return(nil)
 					elbSvc.EXPECT().ReconcileLoadbalancers().Return(nil)
 					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
@@ -322,6 +323,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
 					ec2Svc.EXPECT().ReconcileBastion().Return(nil)
+					ec2Svc.EXPECT().ReconcilePlacementGroups().Return(nil)
 					elbSvc.EXPECT().ReconcileLoadbalancers().Return(expectedErr)
 				}
 				csClient := setup(t, &awsCluster)
@@ -346,6 +348,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
 					ec2Svc.EXPECT().ReconcileBastion().Return(nil)
+					ec2Svc.EXPECT().ReconcilePlacementGroups().Return(nil)
 					elbSvc.EXPECT().ReconcileLoadbalancers().Return(nil)
 				}
 				csClient := setup(t, &awsCluster)
@@ -370,6 +373,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
 					ec2Svc.EXPECT().ReconcileBastion().Return(nil)
+					ec2Svc.EXPECT().ReconcilePlacementGroups().Return(nil)
 					elbSvc.EXPECT().ReconcileLoadbalancers().Return(nil)
 				}
 				csClient := setup(t, &awsCluster)
@@ -394,6 +398,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 		t.Run("Reconcile success", func(t *testing.T) {
 			deleteCluster := func() {
 				ec2Svc.EXPECT().DeleteBastion().Return(nil)
+				ec2Svc.EXPECT().DeletePlacementGroups().Return(nil)
 				elbSvc.EXPECT().DeleteLoadbalancers().Return(nil)
 				networkSvc.EXPECT().DeleteNetwork().Return(nil)
 				sgSvc.EXPECT().DeleteSecurityGroups().Return(nil)
@@ -425,6 +430,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 					t.Helper()
 					elbSvc.EXPECT().DeleteLoadbalancers().Return(expectedErr)
 					ec2Svc.EXPECT().DeleteBastion().Return(nil)
+					ec2Svc.EXPECT().DeletePlacementGroups().Return(nil)
 					networkSvc.EXPECT().DeleteNetwork().Return(nil)
 					sgSvc.EXPECT().DeleteSecurityGroups().Return(nil)
 				}
@@ -449,6 +455,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				g := NewWithT(t)
 				deleteCluster := func() {
 					ec2Svc.EXPECT().DeleteBastion().Return(expectedErr)
+					ec2Svc.EXPECT().DeletePlacementGroups().Return(nil)
 					elbSvc.EXPECT().DeleteLoadbalancers().Return(nil)
 					networkSvc.EXPECT().DeleteNetwork().Return(nil)
 					sgSvc.EXPECT().DeleteSecurityGroups().Return(nil)
@@ -474,6 +481,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				g := NewWithT(t)
 				deleteCluster := func() {
 					ec2Svc.EXPECT().DeleteBastion().Return(nil)
+					ec2Svc.EXPECT().DeletePlacementGroups().Return(nil)
 					elbSvc.EXPECT().DeleteLoadbalancers().Return(nil)
 					sgSvc.EXPECT().DeleteSecurityGroups().Return(expectedErr)
 					networkSvc.EXPECT().DeleteNetwork().Return(nil)
@@ -499,6 +507,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				g := NewWithT(t)
 				deleteCluster := func() {
 					ec2Svc.EXPECT().DeleteBastion().Return(nil)
+					ec2Svc.EXPECT().DeletePlacementGroups().Return(nil)
 					elbSvc.EXPECT().DeleteLoadbalancers().Return(nil)
 					sgSvc.EXPECT().DeleteSecurityGroups().Return(nil)
 					networkSvc.EXPECT().DeleteNetwork().Return(expectedErr)
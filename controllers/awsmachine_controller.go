@@ -50,6 +50,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	asg "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/elb"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
@@ -73,6 +74,9 @@ const (
 
 	// DefaultReconcilerRequeue is the default value for the reconcile retry.
 	DefaultReconcilerRequeue = 30 * time.Second
+
+	// VolumeModificationRequeue is how often the progress of volume modifications is checked.
+	VolumeModificationRequeue = time.Minute
 )
 
 // AWSMachineReconciler reconciles a AwsMachine object.
@@ -81,6 +85,7 @@ type AWSMachineReconciler struct {
 	Log                          logr.Logger
 	Recorder                     record.EventRecorder
 	ec2ServiceFactory            func(scope.EC2Scope) services.EC2Interface
+	asgServiceFactory            func(cloud.ClusterScoper) services.ASGInterface
 	elbServiceFactory            func(scope.ELBScope) services.ELBInterface
 	secretsManagerServiceFactory func(cloud.ClusterScoper) services.SecretInterface
 	SSMServiceFactory            func(cloud.ClusterScoper) services.SecretInterface
@@ -88,6 +93,9 @@ type AWSMachineReconciler struct {
 	Endpoints                    []scope.ServiceEndpoint
 	WatchFilterValue             string
 	TagUnmanagedNetworkResources bool
+	// DescribeCachePeriod is how often the instances, network interfaces and security groups of a cluster are
+	// listed into the describe cache shared by its machines. The cache is disabled when it is zero.
+	DescribeCachePeriod time.Duration
 }
 
 const (
@@ -100,7 +108,17 @@ func (r *AWSMachineReconciler) getEC2Service(scope scope.EC2Scope) services.EC2I
 		return r.ec2ServiceFactory(scope)
 	}
 
-	return ec2.NewService(scope)
+	ec2svc := ec2.NewService(scope)
+	ec2svc.DescribeCache = ec2.DescribeCacheFor(scope, r.DescribeCachePeriod)
+	return ec2svc
+}
+
+func (r *AWSMachineReconciler) getASGService(scope cloud.ClusterScoper) services.ASGInterface {
+	if r.asgServiceFactory != nil {
+		return r.asgServiceFactory(scope)
+	}
+
+	return asg.NewService(scope)
 }
 
 func (r *AWSMachineReconciler) getSecretsManagerService(scope cloud.ClusterScoper) services.SecretInterface {
@@ -146,6 +164,8 @@ func (r *AWSMachineReconciler) getObjectStoreService(scope scope.S3Scope) servic
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
@@ -220,6 +240,15 @@ func (r *AWSMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}()
 
+	// The instances of an AWSMachinePool are launched by its ASG, their AWSMachines only reflect them.
+	if machineScope.IsMachinePoolMachine() {
+		if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
+			return r.reconcileDeleteMachinePoolMachine(ctx, machineScope, infraCluster, infraCluster)
+		}
+
+		return r.reconcileMachinePoolMachine(machineScope, infraCluster)
+	}
+
 	switch infraScope := infraCluster.(type) {
 	case *scope.ManagedControlPlaneScope:
 		if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
@@ -323,6 +352,13 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 		// 4. Scale controller deployment to 1
 		machineScope.Debug("Unable to locate EC2 instance by ID or tags")
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "NoInstanceFound", "Unable to find matching EC2 instance")
+		requeue, err := r.releaseDedicatedHost(machineScope, ec2Service)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if requeue {
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
 		controllerutil.RemoveFinalizer(machineScope.AWSMachine, infrav1.MachineFinalizer)
 		return ctrl.Result{}, nil
 	}
@@ -358,6 +394,13 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	case infrav1.InstanceStateTerminated:
 		machineScope.Info("EC2 instance terminated successfully", "instance-id", instance.ID)
+		requeue, err := r.releaseDedicatedHost(machineScope, ec2Service)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if requeue {
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
 		controllerutil.RemoveFinalizer(machineScope.AWSMachine, infrav1.MachineFinalizer)
 		return ctrl.Result{}, nil
 	default:
@@ -370,6 +413,15 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 			return ctrl.Result{}, err
 		}
 
+		// The machine is being deleted through Cluster API, lift the protection that prevents the instance from
+		// being terminated. The status may not know about a protection enabled by the launch template, or one
+		// enabled in the spec that has not been reconciled yet.
+		if machineScope.AWSMachine.Status.TerminationProtection || machineScope.TerminationProtection() || machineScope.AWSMachine.Spec.LaunchTemplate != nil {
+			if err := r.setInstanceProtection(ec2Service, machineScope, instance.ID, false); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
 		if err := ec2Service.TerminateInstance(instance.ID); err != nil {
 			machineScope.Error(err, "failed to terminate instance")
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
@@ -415,6 +467,43 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 	}
 }
 
+// releaseDedicatedHost releases the Dedicated Host dynamically allocated for the machine, if no other
+// instance is running on it anymore. It returns true while other instances of the host are terminating, so that
+// the last machine deleted from the host releases it.
+func (r *AWSMachineReconciler) releaseDedicatedHost(machineScope *scope.MachineScope, ec2Service services.EC2Interface) (bool, error) {
+	hostID := machineScope.GetDedicatedHostID()
+	if hostID == nil || machineScope.AWSMachine.Spec.DynamicHostAllocation == nil {
+		return false, nil
+	}
+
+	released, terminating, err := ec2Service.ReleaseDedicatedHostIfEmpty(*hostID)
+	if err != nil {
+		machineScope.Error(err, "failed to release dedicated host", "host-id", *hostID)
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedReleaseHost", "Failed to release dedicated host %q: %v", *hostID, err)
+		return false, err
+	}
+	if terminating {
+		machineScope.Info("Waiting for the other instances of the dedicated host to terminate", "host-id", *hostID)
+		return true, nil
+	}
+	if released {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulReleaseHost", "Released dedicated host %q", *hostID)
+	}
+
+	machineScope.SetDedicatedHostID(nil)
+	return false, nil
+}
+
+// isInstanceProvisionFailure returns whether the reason of the InstanceReady condition reports a failure to create the instance.
+func isInstanceProvisionFailure(reason string) bool {
+	switch reason {
+	case infrav1.InstanceProvisionFailedReason, infrav1.ImageNotFoundReason, infrav1.ImageNotAvailableReason, infrav1.ImageDeprecatedReason, infrav1.ImageIncompatibleReason:
+		return true
+	default:
+		return false
+	}
+}
+
 // findInstance queries the EC2 apis and retrieves the instance if it exists.
 // If providerID is empty, finds instance by tags and if it cannot be found, returns empty instance with nil error.
 // If providerID is set, either finds the instance by ID or returns error.
@@ -502,7 +591,7 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	// Create new instance since providerId is nil and instance could not be found by tags.
 	if instance == nil {
 		// Avoid a flickering condition between InstanceProvisionStarted and InstanceProvisionFailed if there's a persistent failure with createInstance
-		if conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition) != infrav1.InstanceProvisionFailedReason {
+		if !isInstanceProvisionFailure(conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition)) {
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionStartedReason, clusterv1.ConditionSeverityInfo, "")
 			if patchErr := machineScope.PatchObject(); err != nil {
 				machineScope.Error(patchErr, "failed to patch conditions")
@@ -519,7 +608,7 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		instance, err = r.createInstance(ec2svc, machineScope, clusterScope, objectStoreSvc)
 		if err != nil {
 			machineScope.Error(err, "unable to create instance")
-			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, ec2.ImageValidationReason(err, infrav1.InstanceProvisionFailedReason), clusterv1.ConditionSeverityError, err.Error())
 			return ctrl.Result{}, err
 		}
 	}
@@ -546,6 +635,11 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		machineScope.Info("EC2 instance state changed", "state", instance.State, "instance-id", *machineScope.GetInstanceID())
 	}
 
+	// Instances stopped while the cluster is hibernated are not failures, and are not reconciled any further.
+	if res, done, err := r.reconcileHibernation(ec2svc, machineScope, instance); done || err != nil {
+		return res, err
+	}
+
 	shouldRequeue := false
 	switch instance.State {
 	case infrav1.InstanceStatePending:
@@ -585,11 +679,20 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 
 	// tasks that can take place during all known instance states
 	if machineScope.InstanceIsInKnownState() {
-		_, err = r.ensureTags(ec2svc, machineScope.AWSMachine, machineScope.GetInstanceID(), machineScope.AdditionalTags())
+		tagsUpdated, err := r.ensureTags(ec2svc, machineScope.AWSMachine, machineScope.GetInstanceID(), machineScope.AdditionalTags())
 		if err != nil {
 			machineScope.Error(err, "failed to ensure tags")
 			return ctrl.Result{}, err
 		}
+		if tagsUpdated && instance != nil {
+			// The tags of the instance were described before the update, keep them current for drift detection.
+			if instance.Tags == nil {
+				instance.Tags = map[string]string{}
+			}
+			for key, value := range machineScope.AdditionalTags() {
+				instance.Tags[key] = value
+			}
+		}
 
 		if instance != nil {
 			r.ensureStorageTags(ec2svc, instance, machineScope.AWSMachine, machineScope.AdditionalTags())
@@ -602,11 +705,19 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	}
 
 	// tasks that can only take place during operational instance states
+	var volumesRequeueAfter time.Duration
 	if machineScope.InstanceIsOperational() {
 		err := r.reconcileOperationalState(ec2svc, machineScope, instance)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+
+		if machineScope.AWSMachine.Spec.DriftPolicy.ActionFor(infrav1.DriftFieldVolumes) == infrav1.DriftActionRepair {
+			volumesRequeueAfter, err = r.reconcileVolumes(ec2svc, machineScope, instance)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 	}
 
 	machineScope.Debug("done reconciling instance", "instance", instance)
@@ -614,7 +725,37 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		machineScope.Debug("but find the instance is pending, requeue", "instance", instance.ID)
 		return ctrl.Result{RequeueAfter: DefaultReconcilerRequeue}, nil
 	}
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: volumesRequeueAfter}, nil
+}
+
+// reconcileVolumes modifies the volumes of the instance in place to match the spec, and returns when to check
+// on the modifications again.
+func (r *AWSMachineReconciler) reconcileVolumes(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) (time.Duration, error) {
+	spec := machineScope.AWSMachine.Spec
+	if spec.RootVolume == nil && len(spec.NonRootVolumes) == 0 {
+		return 0, nil
+	}
+
+	inProgress, cooldown, err := ec2svc.ModifyInstanceVolumes(instance, spec.RootVolume, spec.NonRootVolumes)
+	if err != nil {
+		machineScope.Error(err, "failed to modify volumes")
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedModifyVolumes", "Failed to modify volumes: %v", err)
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.VolumesReadyCondition, infrav1.VolumeModificationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return 0, err
+	}
+
+	switch {
+	case inProgress:
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.VolumesReadyCondition, infrav1.VolumeModificationInProgressReason, clusterv1.ConditionSeverityInfo, "")
+		return VolumeModificationRequeue, nil
+	case cooldown > 0:
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.VolumesReadyCondition, infrav1.VolumeModificationCooldownReason, clusterv1.ConditionSeverityInfo,
+			"volumes can be modified again in %s", cooldown.Round(time.Minute))
+		return cooldown, nil
+	default:
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.VolumesReadyCondition)
+		return 0, nil
+	}
 }
 
 func (r *AWSMachineReconciler) reconcileOperationalState(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) error {
@@ -626,21 +767,85 @@ func (r *AWSMachineReconciler) reconcileOperationalState(ec2svc services.EC2Inte
 		return err
 	}
 
+	driftPolicy := machineScope.AWSMachine.Spec.DriftPolicy
+
 	// Ensure that the security groups are correct.
-	_, err = r.ensureSecurityGroups(ec2svc, machineScope, machineScope.AWSMachine.Spec.AdditionalSecurityGroups, existingSecurityGroups)
-	if err != nil {
-		conditions.MarkFalse(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition, infrav1.SecurityGroupsFailedReason, clusterv1.ConditionSeverityError, err.Error())
-		machineScope.Error(err, "unable to ensure security groups")
+	if driftPolicy.ActionFor(infrav1.DriftFieldSecurityGroups) == infrav1.DriftActionRepair {
+		_, err = r.ensureSecurityGroups(ec2svc, machineScope, machineScope.AWSMachine.Spec.AdditionalSecurityGroups, existingSecurityGroups)
+		if err != nil {
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition, infrav1.SecurityGroupsFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			machineScope.Error(err, "unable to ensure security groups")
+			return err
+		}
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition)
+	}
+
+	if driftPolicy.ActionFor(infrav1.DriftFieldInstanceMetadataOptions) == infrav1.DriftActionRepair {
+		err = r.ensureInstanceMetadataOptions(ec2svc, instance, machineScope.AWSMachine)
+		if err != nil {
+			machineScope.Error(err, "failed to ensure instance metadata options")
+			return err
+		}
+	}
+
+	if enabled := machineScope.TerminationProtection(); enabled != machineScope.AWSMachine.Status.TerminationProtection {
+		if err := r.setInstanceProtection(ec2svc, machineScope, instance.ID, enabled); err != nil {
+			return err
+		}
+	}
+
+	if err := r.reconcileDrift(ec2svc, machineScope, instance, existingSecurityGroups); err != nil {
 		return err
 	}
-	conditions.MarkTrue(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition)
 
-	err = r.ensureInstanceMetadataOptions(ec2svc, instance, machineScope.AWSMachine)
+	return r.reconcileLaunchTemplateVersion(ec2svc, machineScope)
+}
+
+// setInstanceProtection enables or disables the termination and stop protection of the instance, and records
+// the change in the status of the AWSMachine.
+func (r *AWSMachineReconciler) setInstanceProtection(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instanceID string, enabled bool) error {
+	if err := ec2svc.ModifyInstanceProtection(instanceID, enabled); err != nil {
+		machineScope.Error(err, "failed to modify instance protection", "enabled", enabled)
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedModifyInstanceProtection", "Failed to modify protection of instance %q: %v", instanceID, err)
+		return err
+	}
+
+	if enabled {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulEnableInstanceProtection", "Enabled termination and stop protection of instance %q", instanceID)
+	} else {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulDisableInstanceProtection", "Disabled termination and stop protection of instance %q", instanceID)
+	}
+	machineScope.AWSMachine.Status.TerminationProtection = enabled
+	return nil
+}
+
+// reconcileLaunchTemplateVersion reports whether the referenced launch template still resolves to the version
+// the instance was launched from. Instances are not replaced when it does not.
+func (r *AWSMachineReconciler) reconcileLaunchTemplateVersion(ec2svc services.EC2Interface, machineScope *scope.MachineScope) error {
+	ref := machineScope.AWSMachine.Spec.LaunchTemplate
+	if ref == nil || machineScope.AWSMachine.Status.LaunchTemplateVersion == "" {
+		return nil
+	}
+
+	version, err := ec2svc.ResolveLaunchTemplateVersion(ref)
 	if err != nil {
-		machineScope.Error(err, "failed to ensure instance metadata options")
+		machineScope.Error(err, "failed to resolve launch template version")
+		conditions.MarkUnknown(machineScope.AWSMachine, infrav1.LaunchTemplateUpToDateCondition, infrav1.LaunchTemplateVersionCheckFailedReason, err.Error())
 		return err
 	}
 
+	launchedVersion := machineScope.AWSMachine.Status.LaunchTemplateVersion
+	if version == launchedVersion {
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.LaunchTemplateUpToDateCondition)
+		return nil
+	}
+
+	if !conditions.IsFalse(machineScope.AWSMachine, infrav1.LaunchTemplateUpToDateCondition) {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "LaunchTemplateVersionChanged",
+			"Launch template resolves to version %s, instance was launched from version %s", version, launchedVersion)
+	}
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.LaunchTemplateUpToDateCondition, infrav1.LaunchTemplateVersionChangedReason, clusterv1.ConditionSeverityWarning,
+		"launch template resolves to version %s, instance was launched from version %s", version, launchedVersion)
 	return nil
 }
 
@@ -737,7 +942,13 @@ func (r *AWSMachineReconciler) cloudInitUserData(machineScope *scope.MachineScop
 		machineScope.Error(serviceErr, "Failed to create AWS Secret entry", "secretPrefix", prefix)
 		return nil, serviceErr
 	}
-	encryptedCloudInit, err := secretSvc.UserData(machineScope.GetSecretPrefix(), machineScope.GetSecretCount(), machineScope.InfraCluster.Region(), r.Endpoints)
+	var encryptedCloudInit []byte
+	var err error
+	if machineScope.IsWindows() {
+		encryptedCloudInit, err = secretSvc.PowerShellUserData(machineScope.GetSecretPrefix(), machineScope.GetSecretCount(), machineScope.InfraCluster.Region(), r.Endpoints)
+	} else {
+		encryptedCloudInit, err = secretSvc.UserData(machineScope.GetSecretPrefix(), machineScope.GetSecretCount(), machineScope.InfraCluster.Region(), r.Endpoints)
+	}
 	if err != nil {
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedGenerateAWSSecretsCloudInit", err.Error())
 		return nil, err
@@ -1178,7 +1389,8 @@ func (r *AWSMachineReconciler) ensureStorageTags(ec2svc services.EC2Interface, i
 }
 
 func (r *AWSMachineReconciler) ensureInstanceMetadataOptions(ec2svc services.EC2Interface, instance *infrav1.Instance, machine *infrav1.AWSMachine) error {
-	if cmp.Equal(machine.Spec.InstanceMetadataOptions, instance.InstanceMetadataOptions) {
+	// The metadata options are left to the launch template when they are not set.
+	if machine.Spec.InstanceMetadataOptions == nil || cmp.Equal(machine.Spec.InstanceMetadataOptions, instance.InstanceMetadataOptions) {
 		return nil
 	}
 
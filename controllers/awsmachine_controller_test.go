@@ -79,6 +79,9 @@ func TestAWSMachineReconcilerIntegrationTests(t *testing.T) {
 			mockedCreateInstanceCalls(m)
 			mockedCreateSecretCall(s)
 			mockedCreateLBCalls(t, e)
+			// Control plane instances are protected against termination and stop by default.
+			m.ModifyInstanceAttributeWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.ModifyInstanceAttributeInput{})).
+				Return(&ec2.ModifyInstanceAttributeOutput{}, nil).Times(2)
 		}
 		expect(ec2Mock.EXPECT(), secretMock.EXPECT(), elbMock.EXPECT())
 
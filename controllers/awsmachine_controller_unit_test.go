@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -33,6 +34,7 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
@@ -43,7 +45,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	ec2Service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
@@ -53,7 +57,9 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const providerID = "aws:////myMachine"
@@ -68,6 +74,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 		elbSvc         *mock_services.MockELBInterface
 		secretSvc      *mock_services.MockSecretInterface
 		objectStoreSvc *mock_services.MockObjectStoreInterface
+		asgSvc         *mock_services.MockASGInterface
 		recorder       *record.FakeRecorder
 	)
 
@@ -176,6 +183,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 		secretSvc = mock_services.NewMockSecretInterface(mockCtrl)
 		elbSvc = mock_services.NewMockELBInterface(mockCtrl)
 		objectStoreSvc = mock_services.NewMockObjectStoreInterface(mockCtrl)
+		asgSvc = mock_services.NewMockASGInterface(mockCtrl)
 
 		// If your test hangs for 9 minutes, increase the value here to the number of events during a reconciliation loop
 		recorder = record.NewFakeRecorder(2)
@@ -190,6 +198,9 @@ func TestAWSMachineReconciler(t *testing.T) {
 			objectStoreServiceFactory: func(cloud.ClusterScoper) services.ObjectStoreInterface {
 				return objectStoreSvc
 			},
+			asgServiceFactory: func(cloud.ClusterScoper) services.ASGInterface {
+				return asgSvc
+			},
 			Recorder: recorder,
 			Log:      klog.Background(),
 		}
@@ -607,6 +618,126 @@ func TestAWSMachineReconciler(t *testing.T) {
 					g.Expect(buf.String()).To(ContainSubstring(("EC2 instance state changed")))
 				})
 			})
+			t.Run("hibernating then resuming the cluster", func(t *testing.T) {
+				createInstance := func(t *testing.T, g *WithT) {
+					t.Helper()
+
+					instanceCreate(t, g)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+					secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
+				}
+
+				t.Run("should stop the running instance of a hibernated cluster", func(t *testing.T) {
+					g := NewWithT(t)
+					awsMachine := getAWSMachine()
+					setup(t, g, awsMachine)
+					defer teardown(t, g)
+					createInstance(t, g)
+					cs.AWSCluster.Spec.Hibernation = &infrav1.Hibernation{Hibernate: true}
+
+					instance.State = infrav1.InstanceStateRunning
+					ec2Svc.EXPECT().StopInstance("myMachine", false).Return(nil)
+					res, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+					g.Expect(err).To(BeNil())
+					g.Expect(res.RequeueAfter).To(Equal(DefaultReconcilerRequeue))
+					g.Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStateStopping)))
+					g.Expect(ms.AWSMachine.Status.Hibernated).To(BeTrue())
+					g.Expect(ms.AWSMachine.Status.Ready).To(BeFalse())
+					expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.InstanceHibernatedReason}})
+				})
+
+				t.Run("should only lift the stop protection of a protected instance of a hibernated cluster", func(t *testing.T) {
+					g := NewWithT(t)
+					awsMachine := getAWSMachine()
+					setup(t, g, awsMachine)
+					defer teardown(t, g)
+					createInstance(t, g)
+					cs.AWSCluster.Spec.Hibernation = &infrav1.Hibernation{Hibernate: true}
+					ms.AWSMachine.Status.TerminationProtection = true
+
+					instance.State = infrav1.InstanceStateRunning
+					ec2Svc.EXPECT().ModifyInstanceProtection(gomock.Any(), gomock.Any()).Times(0)
+					gomock.InOrder(
+						ec2Svc.EXPECT().ModifyInstanceStopProtection("myMachine", false).Return(nil),
+						ec2Svc.EXPECT().StopInstance("myMachine", false).Return(nil),
+					)
+					_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+					g.Expect(err).To(BeNil())
+					g.Expect(ms.AWSMachine.Status.Hibernated).To(BeTrue())
+					g.Expect(ms.AWSMachine.Status.TerminationProtection).To(BeTrue())
+				})
+
+				t.Run("should not report the stopped instance of a hibernated cluster as a failure", func(t *testing.T) {
+					g := NewWithT(t)
+					awsMachine := getAWSMachine()
+					setup(t, g, awsMachine)
+					defer teardown(t, g)
+					createInstance(t, g)
+					cs.AWSCluster.Spec.Hibernation = &infrav1.Hibernation{Hibernate: true}
+
+					instance.State = infrav1.InstanceStateStopped
+					res, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+					g.Expect(err).To(BeNil())
+					g.Expect(res.IsZero()).To(BeTrue())
+					g.Expect(ms.AWSMachine.Status.Hibernated).To(BeTrue())
+					g.Expect(ms.AWSMachine.Status.FailureReason).To(BeNil())
+					expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.InstanceHibernatedReason}})
+				})
+
+				t.Run("should start the stopped instance once the cluster is resumed", func(t *testing.T) {
+					g := NewWithT(t)
+					awsMachine := getAWSMachine()
+					setup(t, g, awsMachine)
+					defer teardown(t, g)
+					createInstance(t, g)
+					ms.AWSMachine.Status.Hibernated = true
+
+					instance.State = infrav1.InstanceStateStopped
+					ec2Svc.EXPECT().StartInstance("myMachine").Return(nil)
+					res, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+					g.Expect(err).To(BeNil())
+					g.Expect(res.RequeueAfter).To(Equal(DefaultReconcilerRequeue))
+					g.Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStatePending)))
+					g.Expect(ms.AWSMachine.Status.Hibernated).To(BeTrue())
+				})
+
+				t.Run("should enable the stop protection of a protected instance again before starting it", func(t *testing.T) {
+					g := NewWithT(t)
+					awsMachine := getAWSMachine()
+					setup(t, g, awsMachine)
+					defer teardown(t, g)
+					createInstance(t, g)
+					ms.AWSMachine.Status.Hibernated = true
+					ms.AWSMachine.Status.TerminationProtection = true
+
+					instance.State = infrav1.InstanceStateStopped
+					gomock.InOrder(
+						ec2Svc.EXPECT().ModifyInstanceStopProtection("myMachine", true).Return(nil),
+						ec2Svc.EXPECT().StartInstance("myMachine").Return(nil),
+					)
+					_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+					g.Expect(err).To(BeNil())
+					g.Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStatePending)))
+				})
+
+				t.Run("should reconcile the instance again once it is running", func(t *testing.T) {
+					g := NewWithT(t)
+					awsMachine := getAWSMachine()
+					setup(t, g, awsMachine)
+					defer teardown(t, g)
+					createInstance(t, g)
+					ms.AWSMachine.Status.Hibernated = true
+
+					ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil).Times(1)
+					ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
+					ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return(nil, nil)
+
+					instance.State = infrav1.InstanceStateRunning
+					_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+					g.Expect(ms.AWSMachine.Status.Hibernated).To(BeFalse())
+					g.Expect(ms.AWSMachine.Status.Ready).To(BeTrue())
+				})
+			})
 			t.Run("deleting the AWSMachine manually", func(t *testing.T) {
 				var buf *bytes.Buffer
 				deleteMachine := func(t *testing.T, g *WithT) {
@@ -669,10 +800,12 @@ func TestAWSMachineReconciler(t *testing.T) {
 				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil).Times(1)
 				ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
 				ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return(nil, nil)
+				ec2Svc.EXPECT().ModifyInstanceProtection("myMachine", true).Return(nil)
 
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 				g.Expect(err).To(BeNil())
 				g.Expect(ms.AWSMachine.Finalizers).To(ContainElement(infrav1.MachineFinalizer))
+				g.Expect(ms.AWSMachine.Status.TerminationProtection).To(BeTrue())
 				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityWarning, infrav1.InstanceNotReadyReason}})
 			})
 			t.Run("should attach control plane ELB to instance", func(t *testing.T) {
@@ -695,6 +828,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil).Times(1)
 				ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
 				ec2Svc.EXPECT().GetAdditionalSecurityGroupsIDs(gomock.Any()).Return(nil, nil)
+				ec2Svc.EXPECT().ModifyInstanceProtection("myMachine", true).Return(nil)
 
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
 				g.Expect(err).To(BeNil())
@@ -1615,6 +1749,57 @@ func TestAWSMachineReconciler(t *testing.T) {
 			g.Expect(buf.String()).To(ContainSubstring("EC2 instance terminated successfully"))
 			g.Expect(ms.AWSMachine.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
 		})
+		t.Run("should release the dedicated host of the machine once its instance is terminated", func(t *testing.T) {
+			g := NewWithT(t)
+			awsMachine := getAWSMachine()
+			awsMachine.Spec.DynamicHostAllocation = &infrav1.DynamicHostAllocationSpec{}
+			setup(t, g, awsMachine)
+			defer teardown(t, g)
+			finalizer(t, g)
+			ms.SetDedicatedHostID(aws.String("h-1"))
+
+			ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(&infrav1.Instance{
+				State: infrav1.InstanceStateTerminated,
+			}, nil)
+			ec2Svc.EXPECT().ReleaseDedicatedHostIfEmpty("h-1").Return(true, false, nil)
+			secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(ms.GetDedicatedHostID()).To(BeNil())
+			g.Expect(ms.AWSMachine.Finalizers).NotTo(ContainElement(infrav1.MachineFinalizer))
+			g.Eventually(recorder.Events).Should(Receive(ContainSubstring("SuccessfulReleaseHost")))
+		})
+		t.Run("should wait for the machines deleted concurrently from the dedicated host before releasing it", func(t *testing.T) {
+			g := NewWithT(t)
+			awsMachine := getAWSMachine()
+			awsMachine.Spec.DynamicHostAllocation = &infrav1.DynamicHostAllocationSpec{}
+			setup(t, g, awsMachine)
+			defer teardown(t, g)
+			finalizer(t, g)
+			ms.SetDedicatedHostID(aws.String("h-1"))
+
+			ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(&infrav1.Instance{
+				State: infrav1.InstanceStateTerminated,
+			}, nil).Times(2)
+			gomock.InOrder(
+				// The instance of the other machine of the host is still shutting down.
+				ec2Svc.EXPECT().ReleaseDedicatedHostIfEmpty("h-1").Return(false, true, nil),
+				ec2Svc.EXPECT().ReleaseDedicatedHostIfEmpty("h-1").Return(true, false, nil),
+			)
+			secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).AnyTimes()
+
+			result, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(result.RequeueAfter).NotTo(BeZero())
+			g.Expect(ms.GetDedicatedHostID()).To(PointTo(Equal("h-1")))
+			g.Expect(ms.AWSMachine.Finalizers).To(ContainElement(infrav1.MachineFinalizer))
+
+			_, err = reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(ms.GetDedicatedHostID()).To(BeNil())
+			g.Expect(ms.AWSMachine.Finalizers).NotTo(ContainElement(infrav1.MachineFinalizer))
+		})
 		t.Run("instance not shutting down yet", func(t *testing.T) {
 			id := "aws:////myid"
 			getRunningInstance := func(t *testing.T, g *WithT) {
@@ -1641,6 +1826,65 @@ func TestAWSMachineReconciler(t *testing.T) {
 				g.Expect(buf.String()).To(ContainSubstring("Terminating EC2 instance"))
 				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("FailedTerminate")))
 			})
+			t.Run("should lift the instance protection before terminating the instance", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				finalizer(t, g)
+				getRunningInstance(t, g)
+				recorder = record.NewFakeRecorder(10)
+				reconciler.Recorder = recorder
+
+				ms.AWSMachine.Status.TerminationProtection = true
+				gomock.InOrder(
+					ec2Svc.EXPECT().ModifyInstanceProtection(id, false).Return(nil),
+					ec2Svc.EXPECT().TerminateInstance(id).Return(nil),
+				)
+
+				_, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+				g.Expect(err).To(BeNil())
+				g.Expect(ms.AWSMachine.Status.TerminationProtection).To(BeFalse())
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("SuccessfulDisableInstanceProtection")))
+			})
+			t.Run("should lift the instance protection a launch template may have enabled", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				awsMachine.Spec.LaunchTemplate = &infrav1.LaunchTemplateReference{Name: aws.String("lt")}
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				finalizer(t, g)
+				getRunningInstance(t, g)
+				recorder = record.NewFakeRecorder(10)
+				reconciler.Recorder = recorder
+
+				gomock.InOrder(
+					ec2Svc.EXPECT().ModifyInstanceProtection(id, false).Return(nil),
+					ec2Svc.EXPECT().TerminateInstance(id).Return(nil),
+				)
+
+				_, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+				g.Expect(err).To(BeNil())
+			})
+			t.Run("should not terminate the instance when its protection cannot be lifted", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				finalizer(t, g)
+				getRunningInstance(t, g)
+				recorder = record.NewFakeRecorder(10)
+				reconciler.Recorder = recorder
+
+				ms.AWSMachine.Status.TerminationProtection = true
+				ec2Svc.EXPECT().ModifyInstanceProtection(id, false).Return(errors.New("unauthorized"))
+				ec2Svc.EXPECT().TerminateInstance(gomock.Any()).Times(0)
+
+				_, err := reconciler.reconcileDelete(ms, cs, cs, cs, cs)
+				g.Expect(err).ToNot(BeNil())
+				g.Expect(ms.AWSMachine.Status.TerminationProtection).To(BeTrue())
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring("FailedModifyInstanceProtection")))
+			})
 			t.Run("when instance can be shut down", func(t *testing.T) {
 				terminateInstance := func(t *testing.T, g *WithT) {
 					t.Helper()
@@ -1862,6 +2106,107 @@ func TestAWSMachineReconciler(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("AWSMachine of a MachinePool", func(t *testing.T) {
+		machinePoolMachine := func(t *testing.T, g *WithT) {
+			t.Helper()
+
+			awsMachine := getAWSMachine()
+			awsMachine.Spec.ProviderID = aws.String("aws:///us-east-1a/i-pool")
+			awsMachine.Spec.InstanceID = aws.String("i-pool")
+			setup(t, g, awsMachine)
+			ms.Machine.Labels = map[string]string{clusterv1.MachinePoolNameLabel: "mp"}
+		}
+
+		t.Run("should set a running instance to ready without creating it", func(t *testing.T) {
+			g := NewWithT(t)
+			machinePoolMachine(t, g)
+			defer teardown(t, g)
+			ec2Svc.EXPECT().InstanceIfExists(aws.String("i-pool")).Return(&infrav1.Instance{ID: "i-pool", State: infrav1.InstanceStateRunning}, nil)
+
+			_, err := reconciler.reconcileMachinePoolMachine(ms, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(ms.AWSMachine.Status.Ready).To(BeTrue())
+			g.Expect(ms.AWSMachine.Finalizers).To(ContainElement(infrav1.MachineFinalizer))
+			expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionTrue, "", ""}})
+		})
+		t.Run("should not fail when the instance is gone from the ASG", func(t *testing.T) {
+			g := NewWithT(t)
+			machinePoolMachine(t, g)
+			defer teardown(t, g)
+			ec2Svc.EXPECT().InstanceIfExists(aws.String("i-pool")).Return(nil, nil)
+
+			_, err := reconciler.reconcileMachinePoolMachine(ms, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(ms.AWSMachine.Status.Ready).To(BeFalse())
+			g.Expect(ms.AWSMachine.Status.FailureReason).To(BeNil())
+			g.Expect(ms.AWSMachine.Status.FailureMessage).To(BeNil())
+			expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.InstanceNotFoundReason}})
+		})
+		t.Run("should not fail when the ASG terminates the instance", func(t *testing.T) {
+			g := NewWithT(t)
+			machinePoolMachine(t, g)
+			defer teardown(t, g)
+			ec2Svc.EXPECT().InstanceIfExists(aws.String("i-pool")).Return(&infrav1.Instance{ID: "i-pool", State: infrav1.InstanceStateShuttingDown}, nil)
+
+			_, err := reconciler.reconcileMachinePoolMachine(ms, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(ms.AWSMachine.Status.Ready).To(BeFalse())
+			g.Expect(ms.AWSMachine.Status.FailureReason).To(BeNil())
+			g.Expect(ms.AWSMachine.Status.FailureMessage).To(BeNil())
+			expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.InstanceTerminatedReason}})
+			g.Consistently(recorder.Events).ShouldNot(Receive(ContainSubstring("InstanceUnexpectedTermination")))
+		})
+		t.Run("should terminate the instance through the ASG when deleted", func(t *testing.T) {
+			g := NewWithT(t)
+			machinePoolMachine(t, g)
+			defer teardown(t, g)
+			ec2Svc.EXPECT().InstanceIfExists(aws.String("i-pool")).Return(&infrav1.Instance{ID: "i-pool", State: infrav1.InstanceStateRunning}, nil)
+			asgSvc.EXPECT().TerminateASGInstance("i-pool", false).Return(nil).Times(1)
+
+			result, err := reconciler.reconcileDeleteMachinePoolMachine(context.TODO(), ms, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(result.RequeueAfter).NotTo(BeZero())
+			expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, clusterv1.DeletedReason}})
+			g.Eventually(recorder.Events).Should(Receive(ContainSubstring("SuccessfulTerminate")))
+		})
+		t.Run("should terminate the instance directly when it is not part of the ASG anymore", func(t *testing.T) {
+			g := NewWithT(t)
+			machinePoolMachine(t, g)
+			defer teardown(t, g)
+			ec2Svc.EXPECT().InstanceIfExists(aws.String("i-pool")).Return(&infrav1.Instance{ID: "i-pool", State: infrav1.InstanceStateRunning}, nil)
+			asgSvc.EXPECT().TerminateASGInstance("i-pool", false).Return(errors.Wrap(awserr.New(awserrors.ValidationError, "No managed instance found for instance ID: i-pool", nil), "failed to terminate instance \"i-pool\" of ASG"))
+			ec2Svc.EXPECT().TerminateInstanceAndWait("i-pool").Return(nil).Times(1)
+
+			result, err := reconciler.reconcileDeleteMachinePoolMachine(context.TODO(), ms, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(result.RequeueAfter).NotTo(BeZero())
+			expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, clusterv1.DeletedReason}})
+		})
+		t.Run("should not terminate the instance again while it is terminating", func(t *testing.T) {
+			g := NewWithT(t)
+			machinePoolMachine(t, g)
+			defer teardown(t, g)
+			conditions.MarkFalse(ms.AWSMachine, infrav1.InstanceReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+			ec2Svc.EXPECT().InstanceIfExists(aws.String("i-pool")).Return(&infrav1.Instance{ID: "i-pool", State: infrav1.InstanceStateRunning}, nil)
+			asgSvc.EXPECT().TerminateASGInstance(gomock.Any(), gomock.Any()).Times(0)
+
+			result, err := reconciler.reconcileDeleteMachinePoolMachine(context.TODO(), ms, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(result.RequeueAfter).NotTo(BeZero())
+		})
+		t.Run("should remove the finalizer once the instance is terminated", func(t *testing.T) {
+			g := NewWithT(t)
+			machinePoolMachine(t, g)
+			defer teardown(t, g)
+			ms.AWSMachine.Finalizers = []string{infrav1.MachineFinalizer}
+			ec2Svc.EXPECT().InstanceIfExists(aws.String("i-pool")).Return(&infrav1.Instance{ID: "i-pool", State: infrav1.InstanceStateTerminated}, nil)
+
+			_, err := reconciler.reconcileDeleteMachinePoolMachine(context.TODO(), ms, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(ms.AWSMachine.Finalizers).NotTo(ContainElement(infrav1.MachineFinalizer))
+		})
+	})
 }
 
 func TestAWSMachineReconcilerAWSClusterToAWSMachines(t *testing.T) {
@@ -2176,6 +2521,95 @@ func TestAWSMachineReconcilerIndexAWSMachineByInstanceID(t *testing.T) {
 	})
 }
 
+func TestAWSMachineReconcilerShouldDecrementASGCapacity(t *testing.T) {
+	machinePool := func(replicas int32, annotations map[string]string) *expclusterv1.MachinePool {
+		return &expclusterv1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "mp", Namespace: "default", Annotations: annotations},
+			Spec: expclusterv1.MachinePoolSpec{
+				Replicas: aws.Int32(replicas),
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						InfrastructureRef: corev1.ObjectReference{Name: "awsmp"},
+					},
+				},
+			},
+		}
+	}
+	awsMachinePool := func(scalingPolicies ...expinfrav1.AWSScalingPolicy) *expinfrav1.AWSMachinePool {
+		return &expinfrav1.AWSMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "awsmp", Namespace: "default"},
+			Spec:       expinfrav1.AWSMachinePoolSpec{ScalingPolicies: scalingPolicies},
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		objects         []client.Object
+		ownedByPool     bool
+		desiredCapacity *int32
+		expect          bool
+	}{
+		{
+			name:        "should not decrement the capacity of a Machine without MachinePool",
+			ownedByPool: false,
+			expect:      false,
+		},
+		{
+			name:            "should not decrement the capacity while the MachinePool keeps its replicas",
+			objects:         []client.Object{machinePool(3, nil), awsMachinePool()},
+			ownedByPool:     true,
+			desiredCapacity: aws.Int32(3),
+			expect:          false,
+		},
+		{
+			name:            "should decrement the capacity once the MachinePool was scaled down",
+			objects:         []client.Object{machinePool(2, nil), awsMachinePool()},
+			ownedByPool:     true,
+			desiredCapacity: aws.Int32(3),
+			expect:          true,
+		},
+		{
+			name:        "should decrement the capacity when the replicas are externally managed",
+			objects:     []client.Object{machinePool(3, map[string]string{scope.ReplicasManagedByAnnotation: scope.ExternalAutoscalerReplicasManagedByAnnotationValue}), awsMachinePool()},
+			ownedByPool: true,
+			expect:      true,
+		},
+		{
+			name:        "should decrement the capacity when the replicas are managed by scaling policies",
+			objects:     []client.Object{machinePool(3, nil), awsMachinePool(expinfrav1.AWSScalingPolicy{Name: "cpu"})},
+			ownedByPool: true,
+			expect:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+			if tc.desiredCapacity != nil {
+				asgSvc.EXPECT().ASGIfExists(aws.String("awsmp")).Return(&expinfrav1.AutoScalingGroup{DesiredCapacity: tc.desiredCapacity}, nil)
+			}
+
+			testScheme := runtime.NewScheme()
+			g.Expect(expclusterv1.AddToScheme(testScheme)).To(Succeed())
+			g.Expect(expinfrav1.AddToScheme(testScheme)).To(Succeed())
+			reconciler := &AWSMachineReconciler{
+				Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(tc.objects...).Build(),
+			}
+
+			machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "mp-1", Namespace: "default"}}
+			if tc.ownedByPool {
+				machine.OwnerReferences = []metav1.OwnerReference{{APIVersion: expclusterv1.GroupVersion.String(), Kind: "MachinePool", Name: "mp"}}
+			}
+			machineScope := &scope.MachineScope{Machine: machine}
+
+			decrement, err := reconciler.shouldDecrementASGCapacity(context.TODO(), machineScope, asgSvc)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(decrement).To(Equal(tc.expect))
+		})
+	}
+}
+
 func TestAWSMachineReconcilerReconcile(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -2653,6 +3087,8 @@ func TestAWSMachineReconcilerReconcileDefaultsToLoadBalancerTypeClassic(t *testi
 		Attribute:          aws.String("groupSet"),
 	})).Return(&ec2.DescribeNetworkInterfaceAttributeOutput{Groups: []*ec2.GroupIdentifier{{GroupId: aws.String("3")}}}, nil).MaxTimes(1)
 	ec2Mock.EXPECT().ModifyNetworkInterfaceAttributeWithContext(context.TODO(), gomock.Any()).AnyTimes()
+	ec2Mock.EXPECT().ModifyInstanceAttributeWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.ModifyInstanceAttributeInput{})).
+		Return(&ec2.ModifyInstanceAttributeOutput{}, nil).Times(2)
 
 	_, err = reconciler.Reconcile(ctx, ctrl.Request{
 		NamespacedName: client.ObjectKey{
@@ -2664,6 +3100,168 @@ func TestAWSMachineReconcilerReconcileDefaultsToLoadBalancerTypeClassic(t *testi
 	g.Expect(err).To(BeNil())
 }
 
+func TestAWSMachineReconcilerReconcileDrift(t *testing.T) {
+	testCases := []struct {
+		name            string
+		driftPolicy     *infrav1.DriftPolicy
+		instance        func(i *infrav1.Instance)
+		expect          func(m *mock_services.MockEC2InterfaceMockRecorder)
+		expectDrifted   []string
+		expectReason    string
+		expectFailure   bool
+		expectEvent     string
+		expectErrorText string
+	}{
+		{
+			name: "should not report a drift when the instance matches the spec",
+		},
+		{
+			name: "should repair additional tags removed from the instance",
+			instance: func(i *infrav1.Instance) {
+				delete(i.Tags, "team")
+			},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.UpdateResourceTags(aws.String("i-drift"), map[string]string{"team": "storage"}, nil).Return(nil)
+			},
+			expectEvent: "SuccessfulRepairDrift",
+		},
+		{
+			name: "should report a resized instance",
+			instance: func(i *infrav1.Instance) {
+				i.Type = "m5.xlarge"
+			},
+			expectDrifted: []string{"instanceType"},
+			expectReason:  infrav1.InstanceDriftedReason,
+			expectEvent:   "InstanceDrifted",
+		},
+		{
+			name:        "should mark the machine for replacement when the policy requires it",
+			driftPolicy: &infrav1.DriftPolicy{InstanceType: infrav1.DriftActionReplace},
+			instance: func(i *infrav1.Instance) {
+				i.Type = "m5.xlarge"
+			},
+			expectDrifted: []string{"instanceType"},
+			expectReason:  infrav1.InstanceReplacementRequiredReason,
+			expectFailure: true,
+		},
+		{
+			name:        "should only report security groups changes when they are not repaired",
+			driftPolicy: &infrav1.DriftPolicy{SecurityGroups: infrav1.DriftActionReport},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.GetCoreSecurityGroups(gomock.Any()).Return([]string{"sg-core"}, nil)
+				m.GetAdditionalSecurityGroupsIDs(gomock.Any()).Return(nil, nil)
+			},
+			expectDrifted: []string{"securityGroups"},
+			expectReason:  infrav1.InstanceDriftedReason,
+		},
+		{
+			name:        "should repair the IAM instance profile when the policy requires it",
+			driftPolicy: &infrav1.DriftPolicy{IAMInstanceProfile: infrav1.DriftActionRepair},
+			instance: func(i *infrav1.Instance) {
+				i.IAMProfile = "admin"
+			},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.ModifyInstanceIAMProfile("i-drift", "nodes").Return(nil)
+			},
+			expectEvent: "SuccessfulRepairDrift",
+		},
+		{
+			name: "should report network interfaces detached from the instance",
+			instance: func(i *infrav1.Instance) {
+				i.AttachedNetworkInterfaces = i.AttachedNetworkInterfaces[:1]
+			},
+			expectDrifted: []string{"networkInterfaces"},
+			expectReason:  infrav1.InstanceDriftedReason,
+		},
+		{
+			name:        "should report volumes when they are not repaired",
+			driftPolicy: &infrav1.DriftPolicy{Volumes: infrav1.DriftActionReport},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.DriftedVolumes(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"/dev/sdb"}, nil)
+			},
+			expectDrifted: []string{"volumes"},
+			expectReason:  infrav1.InstanceDriftedReason,
+		},
+		{
+			name: "should return an error when a drift cannot be repaired",
+			instance: func(i *infrav1.Instance) {
+				i.Tags = nil
+			},
+			expect: func(m *mock_services.MockEC2InterfaceMockRecorder) {
+				m.UpdateResourceTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("unauthorized"))
+			},
+			expectEvent:     "FailedRepairDrift",
+			expectErrorText: "unauthorized",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mock_services.NewMockEC2Interface(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			cs, err := getClusterScope(infrav1.AWSCluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}})
+			g.Expect(err).To(BeNil())
+			awsMachine := &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSMachineSpec{
+					InstanceType:       "m5.large",
+					IAMInstanceProfile: "nodes",
+					AdditionalTags:     infrav1.Tags{"team": "storage"},
+					NetworkInterfaces:  []string{"eni-1", "eni-2"},
+					DriftPolicy:        tc.driftPolicy,
+				},
+			}
+			ms, err := getMachineScope(cs, awsMachine)
+			g.Expect(err).To(BeNil())
+
+			instance := &infrav1.Instance{
+				ID:         "i-drift",
+				Type:       "m5.large",
+				IAMProfile: "nodes",
+				Tags:       map[string]string{"team": "storage"},
+				AttachedNetworkInterfaces: []infrav1.NetworkInterfaceStatus{
+					{ID: "eni-1", DeviceIndex: 0},
+					{ID: "eni-2", DeviceIndex: 1},
+				},
+			}
+			if tc.instance != nil {
+				tc.instance(instance)
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &AWSMachineReconciler{Recorder: recorder}
+
+			err = reconciler.reconcileDrift(ec2Mock, ms, instance, map[string][]string{"eni-1": {"sg-other"}})
+			if tc.expectErrorText != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectErrorText)))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+
+			if len(tc.expectDrifted) == 0 {
+				g.Expect(conditions.Has(ms.AWSMachine, infrav1.DriftedCondition)).To(BeFalse())
+			} else {
+				condition := conditions.Get(ms.AWSMachine, infrav1.DriftedCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+				g.Expect(condition.Reason).To(Equal(tc.expectReason))
+				for _, field := range tc.expectDrifted {
+					g.Expect(condition.Message).To(ContainSubstring(field))
+				}
+			}
+			g.Expect(ms.HasFailed()).To(Equal(tc.expectFailure))
+			if tc.expectEvent != "" {
+				g.Eventually(recorder.Events).Should(Receive(ContainSubstring(tc.expectEvent)))
+			}
+		})
+	}
+}
+
 func createObject(g *WithT, obj client.Object, namespace string) {
 	if obj.DeepCopyObject() != nil {
 		obj.SetNamespace(namespace)
@@ -0,0 +1,198 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// instanceDrift is a field of an instance that no longer matches the spec of its machine.
+type instanceDrift struct {
+	field infrav1.DriftField
+	// repair modifies the instance back to the spec. It is nil when the field cannot be repaired in place.
+	repair func() error
+}
+
+// reconcileDrift detects the fields of the instance that were changed outside of CAPA, and depending on the
+// drift policy of the machine repairs them, reports them in the Drifted condition, or marks the machine as
+// failed so that it is replaced.
+func (r *AWSMachineReconciler) reconcileDrift(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance, existingSecurityGroups map[string][]string) error {
+	drifts, err := r.detectDrift(ec2svc, machineScope, instance, existingSecurityGroups)
+	if err != nil {
+		machineScope.Error(err, "failed to detect instance drift")
+		return err
+	}
+
+	policy := machineScope.AWSMachine.Spec.DriftPolicy
+	var drifted, replace []string
+	for _, drift := range drifts {
+		action := policy.ActionFor(drift.field)
+		if action == infrav1.DriftActionRepair && drift.repair != nil {
+			if err := drift.repair(); err != nil {
+				machineScope.Error(err, "failed to repair instance drift", "field", drift.field)
+				r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedRepairDrift", "Failed to repair drifted %s of instance %q: %v", drift.field, instance.ID, err)
+				return err
+			}
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulRepairDrift", "Repaired drifted %s of instance %q", drift.field, instance.ID)
+			continue
+		}
+
+		drifted = append(drifted, string(drift.field))
+		if action == infrav1.DriftActionReplace {
+			replace = append(replace, string(drift.field))
+		}
+	}
+
+	if len(drifted) == 0 {
+		conditions.Delete(machineScope.AWSMachine, infrav1.DriftedCondition)
+		return nil
+	}
+
+	message := fmt.Sprintf("fields changed outside of Cluster API: %s", strings.Join(drifted, ", "))
+	if previous := conditions.Get(machineScope.AWSMachine, infrav1.DriftedCondition); previous == nil || previous.Message != message {
+		machineScope.Info("Instance drifted from the machine spec", "instance-id", instance.ID, "fields", drifted)
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "InstanceDrifted", "Instance %q drifted from the machine spec: %s", instance.ID, strings.Join(drifted, ", "))
+	}
+
+	reason := infrav1.InstanceDriftedReason
+	if len(replace) > 0 {
+		reason = infrav1.InstanceReplacementRequiredReason
+		machineScope.SetFailureReason(capierrors.UpdateMachineError)
+		machineScope.SetFailureMessage(errors.Errorf("instance %q drifted from the machine spec and must be replaced: %s", instance.ID, strings.Join(replace, ", ")))
+	}
+	conditions.Set(machineScope.AWSMachine, &clusterv1.Condition{
+		Type:    infrav1.DriftedCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+	return nil
+}
+
+// detectDrift compares the instance with the spec of its machine and returns the fields that differ.
+func (r *AWSMachineReconciler) detectDrift(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance, existingSecurityGroups map[string][]string) ([]instanceDrift, error) {
+	spec := machineScope.AWSMachine.Spec
+	policy := spec.DriftPolicy
+	var drifts []instanceDrift
+
+	// Instances launched with a fallback instance type are compared with it.
+	instanceType := machineScope.AWSMachine.Status.InstanceType
+	if instanceType == "" {
+		instanceType = spec.InstanceType
+	}
+	if instanceType != "" && instance.Type != "" && instance.Type != instanceType {
+		drifts = append(drifts, instanceDrift{field: infrav1.DriftFieldInstanceType})
+	}
+
+	// Metadata options, security groups and volumes are updated along with the spec when they are repaired,
+	// their drift only needs to be detected when it is not.
+	if policy.ActionFor(infrav1.DriftFieldInstanceMetadataOptions) != infrav1.DriftActionRepair &&
+		spec.InstanceMetadataOptions != nil && !cmp.Equal(spec.InstanceMetadataOptions, instance.InstanceMetadataOptions) {
+		drifts = append(drifts, instanceDrift{field: infrav1.DriftFieldInstanceMetadataOptions})
+	}
+
+	if profile := spec.IAMInstanceProfile; profile != "" && !iamProfileMatches(instance.IAMProfile, profile) {
+		drifts = append(drifts, instanceDrift{
+			field: infrav1.DriftFieldIAMInstanceProfile,
+			repair: func() error {
+				return ec2svc.ModifyInstanceIAMProfile(instance.ID, profile)
+			},
+		})
+	}
+
+	if policy.ActionFor(infrav1.DriftFieldSecurityGroups) != infrav1.DriftActionRepair {
+		drifted, err := r.securityGroupsDrifted(ec2svc, machineScope, spec.AdditionalSecurityGroups, existingSecurityGroups)
+		if err != nil {
+			return nil, err
+		}
+		if drifted {
+			drifts = append(drifts, instanceDrift{field: infrav1.DriftFieldSecurityGroups})
+		}
+	}
+
+	if missing := machineScope.AdditionalTags().Difference(instance.Tags); len(missing) > 0 {
+		drifts = append(drifts, instanceDrift{
+			field: infrav1.DriftFieldTags,
+			repair: func() error {
+				return ec2svc.UpdateResourceTags(aws.String(instance.ID), missing, nil)
+			},
+		})
+	}
+
+	if policy.ActionFor(infrav1.DriftFieldVolumes) != infrav1.DriftActionRepair {
+		volumes, err := ec2svc.DriftedVolumes(instance, spec.RootVolume, spec.NonRootVolumes)
+		if err != nil {
+			return nil, err
+		}
+		if len(volumes) > 0 {
+			drifts = append(drifts, instanceDrift{field: infrav1.DriftFieldVolumes})
+		}
+	}
+
+	if networkInterfacesDetached(instance, spec) {
+		drifts = append(drifts, instanceDrift{field: infrav1.DriftFieldNetworkInterfaces})
+	}
+
+	return drifts, nil
+}
+
+// iamProfileMatches reports whether the IAM instance profile of an instance, which includes the path of the
+// profile, is the given profile.
+func iamProfileMatches(actual, profile string) bool {
+	return actual == profile || strings.HasSuffix(actual, "/"+profile)
+}
+
+// networkInterfacesDetached reports whether network interfaces of the spec are not attached to the instance
+// anymore. Network interfaces attached outside of CAPA are ignored, the VPC CNI for instance attaches its own.
+func networkInterfacesDetached(instance *infrav1.Instance, spec infrav1.AWSMachineSpec) bool {
+	if len(instance.AttachedNetworkInterfaces) == 0 {
+		// The network interfaces of the instance are not known.
+		return false
+	}
+
+	attachedIDs := make(map[string]struct{}, len(instance.AttachedNetworkInterfaces))
+	attachedIndexes := make(map[int64]struct{}, len(instance.AttachedNetworkInterfaces))
+	for _, ni := range instance.AttachedNetworkInterfaces {
+		attachedIDs[ni.ID] = struct{}{}
+		attachedIndexes[ni.DeviceIndex] = struct{}{}
+	}
+
+	for _, id := range spec.NetworkInterfaces {
+		if _, ok := attachedIDs[id]; !ok {
+			return true
+		}
+	}
+	for _, ni := range spec.ManagedNetworkInterfaces {
+		if _, ok := attachedIndexes[ni.DeviceIndex]; !ok {
+			return true
+		}
+	}
+	return false
+}
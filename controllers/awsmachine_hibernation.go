@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileHibernation stops the instance of the machine while its cluster is hibernated, and starts it again
+// once the cluster is resumed. It returns done when the rest of the reconciliation must be skipped because the
+// instance is stopped, or being stopped or started, for the hibernation.
+func (r *AWSMachineReconciler) reconcileHibernation(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) (ctrl.Result, bool, error) {
+	if machineScope.ClusterHibernated() {
+		// Spot instances are left running, they cannot be stopped unless they were launched from a
+		// persistent request.
+		if machineScope.AWSMachine.Spec.SpotMarketOptions != nil {
+			return ctrl.Result{}, false, nil
+		}
+		return r.hibernateInstance(ec2svc, machineScope, instance)
+	}
+
+	if machineScope.AWSMachine.Status.Hibernated {
+		return r.resumeInstance(ec2svc, machineScope, instance)
+	}
+	return ctrl.Result{}, false, nil
+}
+
+func (r *AWSMachineReconciler) hibernateInstance(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) (ctrl.Result, bool, error) {
+	switch instance.State {
+	case infrav1.InstanceStateRunning:
+		// The stop protection enabled along with the termination protection would prevent the instance from
+		// being stopped, it is enabled again once the cluster is resumed. The termination protection is kept
+		// while the cluster is hibernated.
+		if machineScope.AWSMachine.Status.TerminationProtection {
+			if err := r.setInstanceStopProtection(ec2svc, machineScope, instance.ID, false); err != nil {
+				return ctrl.Result{}, true, err
+			}
+		}
+
+		if err := ec2svc.StopInstance(instance.ID, instance.HibernationEnabled); err != nil {
+			machineScope.Error(err, "failed to stop instance of hibernated cluster")
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedStopInstance", "Failed to stop instance %q of hibernated cluster: %v", instance.ID, err)
+			return ctrl.Result{}, true, err
+		}
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulStopInstance", "Stopped instance %q of hibernated cluster", instance.ID)
+		machineScope.SetInstanceState(infrav1.InstanceStateStopping)
+	case infrav1.InstanceStateStopping, infrav1.InstanceStateStopped:
+	default:
+		// Pending instances are stopped once they are running, terminated instances are handled by the
+		// rest of the reconciliation.
+		return ctrl.Result{}, false, nil
+	}
+
+	machineScope.AWSMachine.Status.Hibernated = true
+	machineScope.SetNotReady()
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceHibernatedReason, clusterv1.ConditionSeverityInfo, "")
+
+	// The machine is not reconciled any further until the cluster is resumed, which triggers a reconcile
+	// through the AWSCluster watch.
+	if *machineScope.GetInstanceState() == infrav1.InstanceStateStopped {
+		return ctrl.Result{}, true, nil
+	}
+	return ctrl.Result{RequeueAfter: DefaultReconcilerRequeue}, true, nil
+}
+
+func (r *AWSMachineReconciler) resumeInstance(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) (ctrl.Result, bool, error) {
+	switch instance.State {
+	case infrav1.InstanceStateStopped:
+		if machineScope.AWSMachine.Status.TerminationProtection {
+			if err := r.setInstanceStopProtection(ec2svc, machineScope, instance.ID, true); err != nil {
+				return ctrl.Result{}, true, err
+			}
+		}
+
+		if err := ec2svc.StartInstance(instance.ID); err != nil {
+			machineScope.Error(err, "failed to start instance of resumed cluster")
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedStartInstance", "Failed to start instance %q of resumed cluster: %v", instance.ID, err)
+			return ctrl.Result{}, true, err
+		}
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulStartInstance", "Started instance %q of resumed cluster", instance.ID)
+		machineScope.SetInstanceState(infrav1.InstanceStatePending)
+	case infrav1.InstanceStateStopping:
+		// The instance is started once it is stopped.
+	default:
+		// The instance is running again, or was terminated, and is handled by the rest of the reconciliation.
+		machineScope.AWSMachine.Status.Hibernated = false
+		return ctrl.Result{}, false, nil
+	}
+
+	machineScope.SetNotReady()
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceHibernatedReason, clusterv1.ConditionSeverityInfo, "")
+	return ctrl.Result{RequeueAfter: DefaultReconcilerRequeue}, true, nil
+}
+
+// setInstanceStopProtection enables or disables the stop protection of the instance of a protected machine, which
+// has to be lifted to stop the instance while the cluster is hibernated.
+func (r *AWSMachineReconciler) setInstanceStopProtection(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instanceID string, enabled bool) error {
+	if err := ec2svc.ModifyInstanceStopProtection(instanceID, enabled); err != nil {
+		machineScope.Error(err, "failed to modify instance stop protection", "enabled", enabled)
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedModifyInstanceProtection", "Failed to modify stop protection of instance %q: %v", instanceID, err)
+		return err
+	}
+
+	if enabled {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulEnableInstanceProtection", "Enabled stop protection of instance %q of resumed cluster", instanceID)
+	} else {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulDisableInstanceProtection", "Disabled stop protection of instance %q of hibernated cluster", instanceID)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	exputil "sigs.k8s.io/cluster-api/exp/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileMachinePoolMachine reflects the state of the instance of an AWSMachine created by the AWSMachinePool
+// controller for an instance of its ASG. The instance is launched, updated and replaced by the ASG, so it is only
+// observed here. Instances are terminated by the ASG when it scales in, refreshes its instances or completes its
+// lifecycle hooks, so a terminated instance is not a failure: the AWSMachinePool controller deletes its Machine.
+func (r *AWSMachineReconciler) reconcileMachinePoolMachine(machineScope *scope.MachineScope, ec2Scope scope.EC2Scope) (ctrl.Result, error) {
+	machineScope.Trace("Reconciling AWSMachine of MachinePool")
+
+	if machineScope.HasFailed() {
+		machineScope.Info("Error state detected, skipping reconciliation")
+		return ctrl.Result{}, nil
+	}
+
+	// The finalizer terminates the instance through its ASG when the Machine is deleted.
+	if controllerutil.AddFinalizer(machineScope.AWSMachine, infrav1.MachineFinalizer) {
+		if err := machineScope.PatchObject(); err != nil {
+			machineScope.Error(err, "unable to patch object")
+			return ctrl.Result{}, err
+		}
+	}
+
+	ec2svc := r.getEC2Service(ec2Scope)
+	instance, err := ec2svc.InstanceIfExists(machineScope.GetInstanceID())
+	if err != nil {
+		machineScope.Error(err, "unable to find instance")
+		conditions.MarkUnknown(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotFoundReason, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	if instance == nil {
+		machineScope.SetNotReady()
+		machineScope.Info("EC2 instance of the ASG is gone", "instance-id", aws.StringValue(machineScope.GetInstanceID()))
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotFoundReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{}, nil
+	}
+
+	existingInstanceState := machineScope.GetInstanceState()
+	machineScope.SetInstanceState(instance.State)
+	if existingInstanceState == nil || *existingInstanceState != instance.State {
+		machineScope.Info("EC2 instance state changed", "state", instance.State, "instance-id", instance.ID)
+	}
+	machineScope.SetAddresses(instance.Addresses)
+
+	switch instance.State {
+	case infrav1.InstanceStatePending:
+		machineScope.SetNotReady()
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotReadyReason, clusterv1.ConditionSeverityWarning, "")
+		return ctrl.Result{RequeueAfter: DefaultReconcilerRequeue}, nil
+	case infrav1.InstanceStateRunning:
+		machineScope.SetReady()
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.InstanceReadyCondition)
+	case infrav1.InstanceStateStopping, infrav1.InstanceStateStopped:
+		machineScope.SetNotReady()
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceStoppedReason, clusterv1.ConditionSeverityError, "")
+	case infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated:
+		machineScope.SetNotReady()
+		machineScope.Info("EC2 instance of the ASG is terminating", "state", instance.State, "instance-id", instance.ID)
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceTerminatedReason, clusterv1.ConditionSeverityInfo, "")
+	default:
+		machineScope.SetNotReady()
+		machineScope.Info("EC2 instance state is undefined", "state", instance.State, "instance-id", instance.ID)
+		conditions.MarkUnknown(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "", "")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileDeleteMachinePoolMachine terminates the instance of a deleted AWSMachine of an AWSMachinePool through
+// its ASG. The desired capacity of the ASG is only decremented when the MachinePool was scaled down for the
+// instance already, as the AWSMachinePool controller would restore it otherwise; the ASG then replaces the instance.
+// Instances detached from the ASG are terminated directly.
+func (r *AWSMachineReconciler) reconcileDeleteMachinePoolMachine(ctx context.Context, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) (ctrl.Result, error) {
+	machineScope.Info("Handling deleted AWSMachine of MachinePool")
+
+	ec2svc := r.getEC2Service(ec2Scope)
+	instance, err := ec2svc.InstanceIfExists(machineScope.GetInstanceID())
+	if err != nil {
+		machineScope.Error(err, "query to find instance failed")
+		return ctrl.Result{}, err
+	}
+
+	if instance == nil || instance.State == infrav1.InstanceStateTerminated {
+		machineScope.Info("EC2 instance of the ASG is already terminated", "instance-id", aws.StringValue(machineScope.GetInstanceID()))
+		controllerutil.RemoveFinalizer(machineScope.AWSMachine, infrav1.MachineFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	// The instance keeps running while the lifecycle hooks of the ASG complete, it is only terminated through the
+	// ASG once.
+	if instance.State == infrav1.InstanceStateShuttingDown || conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition) == clusterv1.DeletedReason {
+		machineScope.Info("EC2 instance of the ASG is terminating", "instance-id", instance.ID)
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	machineScope.Info("Terminating EC2 instance of the ASG", "instance-id", instance.ID)
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+	if err := machineScope.PatchObject(); err != nil {
+		machineScope.Error(err, "failed to patch object")
+		return ctrl.Result{}, err
+	}
+
+	asgsvc := r.getASGService(clusterScope)
+	decrementCapacity, err := r.shouldDecrementASGCapacity(ctx, machineScope, asgsvc)
+	if err == nil {
+		err = asgsvc.TerminateASGInstance(instance.ID, decrementCapacity)
+		if awserrors.IsInstanceNotInASG(errors.Cause(err)) {
+			machineScope.Info("EC2 instance is not part of the ASG anymore, terminating it directly", "instance-id", instance.ID)
+			err = ec2svc.TerminateInstanceAndWait(instance.ID)
+		}
+	}
+	if err != nil {
+		machineScope.Error(err, "failed to terminate instance")
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedTerminate", "Failed to terminate instance %q: %v", instance.ID, err)
+		return ctrl.Result{}, err
+	}
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulTerminate", "Terminated instance %q", instance.ID)
+
+	// requeue reconciliation until we observe termination (or the instance can no longer be looked up)
+	return ctrl.Result{RequeueAfter: time.Minute}, nil
+}
+
+// shouldDecrementASGCapacity returns whether the desired capacity of the ASG should be decremented when terminating
+// the instance of a MachinePool Machine. Unless the replicas of the MachinePool are externally managed or driven by
+// scaling policies, the AWSMachinePool controller sets the desired capacity of the ASG to the replicas of the
+// MachinePool, so it is only decremented when the replicas are already lower than the desired capacity.
+func (r *AWSMachineReconciler) shouldDecrementASGCapacity(ctx context.Context, machineScope *scope.MachineScope, asgsvc services.ASGInterface) (bool, error) {
+	machinePool, err := exputil.GetOwnerMachinePool(ctx, r.Client, machineScope.Machine.ObjectMeta)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "failed to get MachinePool of Machine")
+	}
+	if machinePool == nil {
+		return false, nil
+	}
+	if scope.ReplicasExternallyManaged(machinePool) {
+		return true, nil
+	}
+
+	awsMachinePool := &expinfrav1.AWSMachinePool{}
+	key := client.ObjectKey{Namespace: machinePool.Namespace, Name: machinePool.Spec.Template.Spec.InfrastructureRef.Name}
+	if err := r.Client.Get(ctx, key, awsMachinePool); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get AWSMachinePool %q", key.Name)
+	}
+	if scope.ReplicasManagedByScalingPolicies(awsMachinePool) {
+		return true, nil
+	}
+	if machinePool.Spec.Replicas == nil {
+		return false, nil
+	}
+
+	asg, err := asgsvc.ASGIfExists(aws.String(awsMachinePool.Name))
+	if err != nil {
+		return false, err
+	}
+	if asg == nil || asg.DesiredCapacity == nil {
+		return false, nil
+	}
+
+	return *machinePool.Spec.Replicas < *asg.DesiredCapacity, nil
+}
@@ -76,6 +76,27 @@ func (r *AWSMachineReconciler) ensureSecurityGroups(ec2svc service.EC2Interface,
 	return true, nil
 }
 
+// securityGroupsDrifted reports whether the security groups of the machine are not correct, without updating them.
+func (r *AWSMachineReconciler) securityGroupsDrifted(ec2svc service.EC2Interface, scope *scope.MachineScope, additional []infrav1.AWSResourceReference, existing map[string][]string) (bool, error) {
+	annotation, err := r.machineAnnotationJSON(scope.AWSMachine, SecurityGroupsLastAppliedAnnotation)
+	if err != nil {
+		return false, err
+	}
+
+	core, err := ec2svc.GetCoreSecurityGroups(scope)
+	if err != nil {
+		return false, err
+	}
+
+	additionalSecurityGroupsIDs, err := ec2svc.GetAdditionalSecurityGroupsIDs(additional)
+	if err != nil {
+		return false, err
+	}
+
+	changed, _ := r.securityGroupsChanged(annotation, core, additionalSecurityGroupsIDs, existing)
+	return changed, nil
+}
+
 // securityGroupsChanged determines which security groups to delete and which to add.
 func (r *AWSMachineReconciler) securityGroupsChanged(annotation map[string]interface{}, core []string, additional []string, existing map[string][]string) (bool, []string) {
 	state := map[string]bool{}
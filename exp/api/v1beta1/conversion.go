@@ -43,13 +43,37 @@ func (src *AWSMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 	}
 	if dst.Spec.RefreshPreferences != nil && restored.Spec.RefreshPreferences != nil {
 		dst.Spec.RefreshPreferences.Disable = restored.Spec.RefreshPreferences.Disable
+		dst.Spec.RefreshPreferences.CheckpointPercentages = restored.Spec.RefreshPreferences.CheckpointPercentages
+		dst.Spec.RefreshPreferences.CheckpointDelay = restored.Spec.RefreshPreferences.CheckpointDelay
+		dst.Spec.RefreshPreferences.SkipMatching = restored.Spec.RefreshPreferences.SkipMatching
+		dst.Spec.RefreshPreferences.AutoRollback = restored.Spec.RefreshPreferences.AutoRollback
+		dst.Spec.RefreshPreferences.AlarmSpecification = restored.Spec.RefreshPreferences.AlarmSpecification
 	}
 	if restored.Spec.AWSLaunchTemplate.InstanceMetadataOptions != nil {
 		dst.Spec.AWSLaunchTemplate.InstanceMetadataOptions = restored.Spec.AWSLaunchTemplate.InstanceMetadataOptions
 	}
+	restoreAWSLaunchTemplate(&restored.Spec.AWSLaunchTemplate, &dst.Spec.AWSLaunchTemplate)
 	if restored.Spec.AvailabilityZoneSubnetType != nil {
 		dst.Spec.AvailabilityZoneSubnetType = restored.Spec.AvailabilityZoneSubnetType
 	}
+	dst.Spec.WarmPool = restored.Spec.WarmPool
+	dst.Spec.LifecycleHooks = restored.Spec.LifecycleHooks
+	dst.Spec.NodeDrainLifecycleHook = restored.Spec.NodeDrainLifecycleHook
+	dst.Spec.TargetGroupARNs = restored.Spec.TargetGroupARNs
+	dst.Spec.LoadBalancerNames = restored.Spec.LoadBalancerNames
+	dst.Spec.HealthCheckType = restored.Spec.HealthCheckType
+	dst.Spec.HealthCheckGracePeriod = restored.Spec.HealthCheckGracePeriod
+	dst.Spec.ScalingPolicies = restored.Spec.ScalingPolicies
+	dst.Spec.ScheduledActions = restored.Spec.ScheduledActions
+	dst.Spec.LaunchTemplateRef = restored.Spec.LaunchTemplateRef
+	if dst.Spec.MixedInstancesPolicy != nil && restored.Spec.MixedInstancesPolicy != nil {
+		dst.Spec.MixedInstancesPolicy.InstanceRequirements = restored.Spec.MixedInstancesPolicy.InstanceRequirements
+	}
+	dst.Status.ImageID = restored.Status.ImageID
+	dst.Status.MatchedInstanceTypes = restored.Status.MatchedInstanceTypes
+	dst.Status.WarmPoolStatus = restored.Status.WarmPoolStatus
+	dst.Status.InstanceRefresh = restored.Status.InstanceRefresh
+	dst.Status.InfrastructureMachineKind = restored.Status.InfrastructureMachineKind
 
 	return nil
 }
@@ -95,14 +119,35 @@ func (src *AWSManagedMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 			dst.Spec.AWSLaunchTemplate = restored.Spec.AWSLaunchTemplate
 		}
 		dst.Spec.AWSLaunchTemplate.InstanceMetadataOptions = restored.Spec.AWSLaunchTemplate.InstanceMetadataOptions
+		restoreAWSLaunchTemplate(restored.Spec.AWSLaunchTemplate, dst.Spec.AWSLaunchTemplate)
 	}
 	if restored.Spec.AvailabilityZoneSubnetType != nil {
 		dst.Spec.AvailabilityZoneSubnetType = restored.Spec.AvailabilityZoneSubnetType
 	}
+	dst.Spec.LaunchTemplateRef = restored.Spec.LaunchTemplateRef
+	dst.Status.ImageID = restored.Status.ImageID
 
 	return nil
 }
 
+func restoreAWSLaunchTemplate(restored, dst *infrav1exp.AWSLaunchTemplate) {
+	dst.Tenancy = restored.Tenancy
+	dst.HostID = restored.HostID
+	dst.HostAffinity = restored.HostAffinity
+	dst.HostResourceGroupArn = restored.HostResourceGroupArn
+	dst.CPUOptions = restored.CPUOptions
+	dst.NitroEnclaveEnabled = restored.NitroEnclaveEnabled
+	dst.HibernationEnabled = restored.HibernationEnabled
+	dst.PrivateDNSName = restored.PrivateDNSName
+	dst.ManagedNetworkInterfaces = restored.ManagedNetworkInterfaces
+	dst.NetworkInterfaceType = restored.NetworkInterfaceType
+	dst.PlacementGroupName = restored.PlacementGroupName
+	dst.PlacementGroupPartition = restored.PlacementGroupPartition
+	dst.NonRootVolumes = restored.NonRootVolumes
+	dst.AMI.SSMParameter = restored.AMI.SSMParameter
+	dst.InstanceRequirements = restored.InstanceRequirements
+}
+
 // ConvertFrom converts the v1beta2 AWSManagedMachinePool receiver to v1beta1 AWSManagedMachinePool.
 func (r *AWSManagedMachinePool) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1exp.AWSManagedMachinePool)
@@ -200,8 +245,24 @@ func Convert_v1beta2_AutoScalingGroup_To_v1beta1_AutoScalingGroup(in *infrav1exp
 	return autoConvert_v1beta2_AutoScalingGroup_To_v1beta1_AutoScalingGroup(in, out, s)
 }
 
+// Convert_v1beta2_MixedInstancesPolicy_To_v1beta1_MixedInstancesPolicy converts the v1beta2 MixedInstancesPolicy receiver to a v1beta1 MixedInstancesPolicy.
+func Convert_v1beta2_MixedInstancesPolicy_To_v1beta1_MixedInstancesPolicy(in *infrav1exp.MixedInstancesPolicy, out *MixedInstancesPolicy, s apiconversion.Scope) error {
+	// spec.mixedInstancesPolicy.instanceRequirements has been added to v1beta2.
+	return autoConvert_v1beta2_MixedInstancesPolicy_To_v1beta1_MixedInstancesPolicy(in, out, s)
+}
+
 // Convert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences converts the v1beta2 RefreshPreferences receiver to a v1beta1 RefreshPreferences.
 func Convert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences(in *infrav1exp.RefreshPreferences, out *RefreshPreferences, s apiconversion.Scope) error {
 	// spec.refreshPreferences.disable has been added to v1beta2.
 	return autoConvert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences(in, out, s)
 }
+
+// Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus is a conversion function.
+func Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in *infrav1exp.AWSMachinePoolStatus, out *AWSMachinePoolStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in, out, s)
+}
+
+// Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus is a conversion function.
+func Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(in *infrav1exp.AWSManagedMachinePoolStatus, out *AWSManagedMachinePoolStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(in, out, s)
+}
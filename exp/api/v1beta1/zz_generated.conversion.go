@@ -100,11 +100,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.AWSMachinePoolStatus)(nil), (*AWSMachinePoolStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(a.(*v1beta2.AWSMachinePoolStatus), b.(*AWSMachinePoolStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*AWSManagedMachinePool)(nil), (*v1beta2.AWSManagedMachinePool)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AWSManagedMachinePool_To_v1beta2_AWSManagedMachinePool(a.(*AWSManagedMachinePool), b.(*v1beta2.AWSManagedMachinePool), scope)
 	}); err != nil {
@@ -135,11 +130,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.AWSManagedMachinePoolStatus)(nil), (*AWSManagedMachinePoolStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(a.(*v1beta2.AWSManagedMachinePoolStatus), b.(*AWSManagedMachinePoolStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*BlockDeviceMapping)(nil), (*v1beta2.BlockDeviceMapping)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_BlockDeviceMapping_To_v1beta2_BlockDeviceMapping(a.(*BlockDeviceMapping), b.(*v1beta2.BlockDeviceMapping), scope)
 	}); err != nil {
@@ -225,11 +215,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.MixedInstancesPolicy)(nil), (*MixedInstancesPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_MixedInstancesPolicy_To_v1beta1_MixedInstancesPolicy(a.(*v1beta2.MixedInstancesPolicy), b.(*MixedInstancesPolicy), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*Overrides)(nil), (*v1beta2.Overrides)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Overrides_To_v1beta2_Overrides(a.(*Overrides), b.(*v1beta2.Overrides), scope)
 	}); err != nil {
@@ -300,16 +285,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.AWSMachinePoolStatus)(nil), (*AWSMachinePoolStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(a.(*v1beta2.AWSMachinePoolStatus), b.(*AWSMachinePoolStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.AWSManagedMachinePoolSpec)(nil), (*AWSManagedMachinePoolSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_AWSManagedMachinePoolSpec_To_v1beta1_AWSManagedMachinePoolSpec(a.(*v1beta2.AWSManagedMachinePoolSpec), b.(*AWSManagedMachinePoolSpec), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.AWSManagedMachinePoolStatus)(nil), (*AWSManagedMachinePoolStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(a.(*v1beta2.AWSManagedMachinePoolStatus), b.(*AWSManagedMachinePoolStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.AutoScalingGroup)(nil), (*AutoScalingGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_AutoScalingGroup_To_v1beta1_AutoScalingGroup(a.(*v1beta2.AutoScalingGroup), b.(*AutoScalingGroup), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*apiv1beta2.Instance)(nil), (*apiv1beta1.Instance)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_Instance_To_v1beta1_Instance(a.(*apiv1beta2.Instance), b.(*apiv1beta1.Instance), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta2.MixedInstancesPolicy)(nil), (*MixedInstancesPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_MixedInstancesPolicy_To_v1beta1_MixedInstancesPolicy(a.(*v1beta2.MixedInstancesPolicy), b.(*MixedInstancesPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.RefreshPreferences)(nil), (*RefreshPreferences)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences(a.(*v1beta2.RefreshPreferences), b.(*RefreshPreferences), scope)
 	}); err != nil {
@@ -401,12 +406,26 @@ func autoConvert_v1beta2_AWSLaunchTemplate_To_v1beta1_AWSLaunchTemplate(in *v1be
 	out.ImageLookupOrg = in.ImageLookupOrg
 	out.ImageLookupBaseOS = in.ImageLookupBaseOS
 	out.InstanceType = in.InstanceType
+	// WARNING: in.InstanceRequirements requires manual conversion: does not exist in peer-type
 	out.RootVolume = (*apiv1beta2.Volume)(unsafe.Pointer(in.RootVolume))
+	// WARNING: in.NonRootVolumes requires manual conversion: does not exist in peer-type
 	out.SSHKeyName = (*string)(unsafe.Pointer(in.SSHKeyName))
 	out.VersionNumber = (*int64)(unsafe.Pointer(in.VersionNumber))
 	out.AdditionalSecurityGroups = *(*[]apiv1beta2.AWSResourceReference)(unsafe.Pointer(&in.AdditionalSecurityGroups))
 	out.SpotMarketOptions = (*apiv1beta2.SpotMarketOptions)(unsafe.Pointer(in.SpotMarketOptions))
 	// WARNING: in.InstanceMetadataOptions requires manual conversion: does not exist in peer-type
+	// WARNING: in.Tenancy requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostID requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostAffinity requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostResourceGroupArn requires manual conversion: does not exist in peer-type
+	// WARNING: in.CPUOptions requires manual conversion: does not exist in peer-type
+	// WARNING: in.NitroEnclaveEnabled requires manual conversion: does not exist in peer-type
+	// WARNING: in.HibernationEnabled requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrivateDNSName requires manual conversion: does not exist in peer-type
+	// WARNING: in.ManagedNetworkInterfaces requires manual conversion: does not exist in peer-type
+	// WARNING: in.NetworkInterfaceType requires manual conversion: does not exist in peer-type
+	// WARNING: in.PlacementGroupName requires manual conversion: does not exist in peer-type
+	// WARNING: in.PlacementGroupPartition requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -516,7 +535,15 @@ func autoConvert_v1beta1_AWSMachinePoolSpec_To_v1beta2_AWSMachinePoolSpec(in *AW
 	if err := Convert_v1beta1_AWSLaunchTemplate_To_v1beta2_AWSLaunchTemplate(&in.AWSLaunchTemplate, &out.AWSLaunchTemplate, s); err != nil {
 		return err
 	}
-	out.MixedInstancesPolicy = (*v1beta2.MixedInstancesPolicy)(unsafe.Pointer(in.MixedInstancesPolicy))
+	if in.MixedInstancesPolicy != nil {
+		in, out := &in.MixedInstancesPolicy, &out.MixedInstancesPolicy
+		*out = new(v1beta2.MixedInstancesPolicy)
+		if err := Convert_v1beta1_MixedInstancesPolicy_To_v1beta2_MixedInstancesPolicy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.MixedInstancesPolicy = nil
+	}
 	out.ProviderIDList = *(*[]string)(unsafe.Pointer(&in.ProviderIDList))
 	out.DefaultCoolDown = in.DefaultCoolDown
 	if in.RefreshPreferences != nil {
@@ -543,7 +570,16 @@ func autoConvert_v1beta2_AWSMachinePoolSpec_To_v1beta1_AWSMachinePoolSpec(in *v1
 	if err := Convert_v1beta2_AWSLaunchTemplate_To_v1beta1_AWSLaunchTemplate(&in.AWSLaunchTemplate, &out.AWSLaunchTemplate, s); err != nil {
 		return err
 	}
-	out.MixedInstancesPolicy = (*MixedInstancesPolicy)(unsafe.Pointer(in.MixedInstancesPolicy))
+	// WARNING: in.LaunchTemplateRef requires manual conversion: does not exist in peer-type
+	if in.MixedInstancesPolicy != nil {
+		in, out := &in.MixedInstancesPolicy, &out.MixedInstancesPolicy
+		*out = new(MixedInstancesPolicy)
+		if err := Convert_v1beta2_MixedInstancesPolicy_To_v1beta1_MixedInstancesPolicy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.MixedInstancesPolicy = nil
+	}
 	out.ProviderIDList = *(*[]string)(unsafe.Pointer(&in.ProviderIDList))
 	out.DefaultCoolDown = in.DefaultCoolDown
 	if in.RefreshPreferences != nil {
@@ -557,6 +593,15 @@ func autoConvert_v1beta2_AWSMachinePoolSpec_To_v1beta1_AWSMachinePoolSpec(in *v1
 	}
 	out.CapacityRebalance = in.CapacityRebalance
 	// WARNING: in.SuspendProcesses requires manual conversion: does not exist in peer-type
+	// WARNING: in.WarmPool requires manual conversion: does not exist in peer-type
+	// WARNING: in.LifecycleHooks requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeDrainLifecycleHook requires manual conversion: does not exist in peer-type
+	// WARNING: in.TargetGroupARNs requires manual conversion: does not exist in peer-type
+	// WARNING: in.LoadBalancerNames requires manual conversion: does not exist in peer-type
+	// WARNING: in.HealthCheckType requires manual conversion: does not exist in peer-type
+	// WARNING: in.HealthCheckGracePeriod requires manual conversion: does not exist in peer-type
+	// WARNING: in.ScalingPolicies requires manual conversion: does not exist in peer-type
+	// WARNING: in.ScheduledActions requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -585,17 +630,17 @@ func autoConvert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in
 	out.Instances = *(*[]AWSMachinePoolInstanceStatus)(unsafe.Pointer(&in.Instances))
 	out.LaunchTemplateID = in.LaunchTemplateID
 	out.LaunchTemplateVersion = (*string)(unsafe.Pointer(in.LaunchTemplateVersion))
+	// WARNING: in.ImageID requires manual conversion: does not exist in peer-type
+	// WARNING: in.MatchedInstanceTypes requires manual conversion: does not exist in peer-type
 	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
 	out.ASGStatus = (*ASGStatus)(unsafe.Pointer(in.ASGStatus))
+	// WARNING: in.WarmPoolStatus requires manual conversion: does not exist in peer-type
+	// WARNING: in.InstanceRefresh requires manual conversion: does not exist in peer-type
+	// WARNING: in.InfrastructureMachineKind requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus is an autogenerated conversion function.
-func Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in *v1beta2.AWSMachinePoolStatus, out *AWSMachinePoolStatus, s conversion.Scope) error {
-	return autoConvert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in, out, s)
-}
-
 func autoConvert_v1beta1_AWSManagedMachinePool_To_v1beta2_AWSManagedMachinePool(in *AWSManagedMachinePool, out *v1beta2.AWSManagedMachinePool, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_AWSManagedMachinePoolSpec_To_v1beta2_AWSManagedMachinePoolSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -733,6 +778,7 @@ func autoConvert_v1beta2_AWSManagedMachinePoolSpec_To_v1beta1_AWSManagedMachineP
 	} else {
 		out.AWSLaunchTemplate = nil
 	}
+	// WARNING: in.LaunchTemplateRef requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -757,17 +803,13 @@ func autoConvert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachin
 	out.Replicas = in.Replicas
 	out.LaunchTemplateID = (*string)(unsafe.Pointer(in.LaunchTemplateID))
 	out.LaunchTemplateVersion = (*string)(unsafe.Pointer(in.LaunchTemplateVersion))
+	// WARNING: in.ImageID requires manual conversion: does not exist in peer-type
 	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
 	out.Conditions = *(*clusterapiapiv1beta1.Conditions)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
-// Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus is an autogenerated conversion function.
-func Convert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(in *v1beta2.AWSManagedMachinePoolStatus, out *AWSManagedMachinePoolStatus, s conversion.Scope) error {
-	return autoConvert_v1beta2_AWSManagedMachinePoolStatus_To_v1beta1_AWSManagedMachinePoolStatus(in, out, s)
-}
-
 func autoConvert_v1beta1_AutoScalingGroup_To_v1beta2_AutoScalingGroup(in *AutoScalingGroup, out *v1beta2.AutoScalingGroup, s conversion.Scope) error {
 	out.ID = in.ID
 	out.Tags = *(*apiv1beta2.Tags)(unsafe.Pointer(&in.Tags))
@@ -779,7 +821,15 @@ func autoConvert_v1beta1_AutoScalingGroup_To_v1beta2_AutoScalingGroup(in *AutoSc
 	out.Subnets = *(*[]string)(unsafe.Pointer(&in.Subnets))
 	out.DefaultCoolDown = in.DefaultCoolDown
 	out.CapacityRebalance = in.CapacityRebalance
-	out.MixedInstancesPolicy = (*v1beta2.MixedInstancesPolicy)(unsafe.Pointer(in.MixedInstancesPolicy))
+	if in.MixedInstancesPolicy != nil {
+		in, out := &in.MixedInstancesPolicy, &out.MixedInstancesPolicy
+		*out = new(v1beta2.MixedInstancesPolicy)
+		if err := Convert_v1beta1_MixedInstancesPolicy_To_v1beta2_MixedInstancesPolicy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.MixedInstancesPolicy = nil
+	}
 	out.Status = v1beta2.ASGStatus(in.Status)
 	out.Instances = *(*[]apiv1beta2.Instance)(unsafe.Pointer(&in.Instances))
 	return nil
@@ -796,10 +846,26 @@ func autoConvert_v1beta2_AutoScalingGroup_To_v1beta1_AutoScalingGroup(in *v1beta
 	out.Subnets = *(*[]string)(unsafe.Pointer(&in.Subnets))
 	out.DefaultCoolDown = in.DefaultCoolDown
 	out.CapacityRebalance = in.CapacityRebalance
-	out.MixedInstancesPolicy = (*MixedInstancesPolicy)(unsafe.Pointer(in.MixedInstancesPolicy))
+	if in.MixedInstancesPolicy != nil {
+		in, out := &in.MixedInstancesPolicy, &out.MixedInstancesPolicy
+		*out = new(MixedInstancesPolicy)
+		if err := Convert_v1beta2_MixedInstancesPolicy_To_v1beta1_MixedInstancesPolicy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.MixedInstancesPolicy = nil
+	}
 	out.Status = ASGStatus(in.Status)
 	out.Instances = *(*[]apiv1beta2.Instance)(unsafe.Pointer(&in.Instances))
 	// WARNING: in.CurrentlySuspendProcesses requires manual conversion: does not exist in peer-type
+	// WARNING: in.WarmPool requires manual conversion: does not exist in peer-type
+	// WARNING: in.WarmPoolStatus requires manual conversion: does not exist in peer-type
+	// WARNING: in.LifecycleHooks requires manual conversion: does not exist in peer-type
+	// WARNING: in.TargetGroupARNs requires manual conversion: does not exist in peer-type
+	// WARNING: in.LoadBalancerNames requires manual conversion: does not exist in peer-type
+	// WARNING: in.HealthCheckType requires manual conversion: does not exist in peer-type
+	// WARNING: in.HealthCheckGracePeriod requires manual conversion: does not exist in peer-type
+	// WARNING: in.LaunchTemplateVersion requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1017,14 +1083,10 @@ func Convert_v1beta1_MixedInstancesPolicy_To_v1beta2_MixedInstancesPolicy(in *Mi
 func autoConvert_v1beta2_MixedInstancesPolicy_To_v1beta1_MixedInstancesPolicy(in *v1beta2.MixedInstancesPolicy, out *MixedInstancesPolicy, s conversion.Scope) error {
 	out.InstancesDistribution = (*InstancesDistribution)(unsafe.Pointer(in.InstancesDistribution))
 	out.Overrides = *(*[]Overrides)(unsafe.Pointer(&in.Overrides))
+	// WARNING: in.InstanceRequirements requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_MixedInstancesPolicy_To_v1beta1_MixedInstancesPolicy is an autogenerated conversion function.
-func Convert_v1beta2_MixedInstancesPolicy_To_v1beta1_MixedInstancesPolicy(in *v1beta2.MixedInstancesPolicy, out *MixedInstancesPolicy, s conversion.Scope) error {
-	return autoConvert_v1beta2_MixedInstancesPolicy_To_v1beta1_MixedInstancesPolicy(in, out, s)
-}
-
 func autoConvert_v1beta1_Overrides_To_v1beta2_Overrides(in *Overrides, out *v1beta2.Overrides, s conversion.Scope) error {
 	out.InstanceType = in.InstanceType
 	return nil
@@ -1062,6 +1124,11 @@ func autoConvert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences(in *v1
 	out.Strategy = (*string)(unsafe.Pointer(in.Strategy))
 	out.InstanceWarmup = (*int64)(unsafe.Pointer(in.InstanceWarmup))
 	out.MinHealthyPercentage = (*int64)(unsafe.Pointer(in.MinHealthyPercentage))
+	// WARNING: in.CheckpointPercentages requires manual conversion: does not exist in peer-type
+	// WARNING: in.CheckpointDelay requires manual conversion: does not exist in peer-type
+	// WARNING: in.SkipMatching requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutoRollback requires manual conversion: does not exist in peer-type
+	// WARNING: in.AlarmSpecification requires manual conversion: does not exist in peer-type
 	return nil
 }
 
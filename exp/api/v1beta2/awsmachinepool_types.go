@@ -31,6 +31,18 @@ import (
 const (
 	// LaunchTemplateLatestVersion defines the launching of the latest version of the template.
 	LaunchTemplateLatestVersion = "$Latest"
+
+	// HibernatedCapacityAnnotation records the minimum, maximum and desired capacity of the ASG of an
+	// AWSMachinePool while its cluster is hibernated, so that they are restored when the cluster is resumed.
+	HibernatedCapacityAnnotation = "aws.cluster.x-k8s.io/hibernated-capacity"
+
+	// TerminatingInstanceAnnotation is set on an AWSMachinePool when an instance of its ASG is paused by the node
+	// drain lifecycle hook, so that its node gets drained without waiting for the next resync.
+	TerminatingInstanceAnnotation = "aws.cluster.x-k8s.io/terminating-instance"
+
+	// NodeDrainLifecycleHookName is the name of the terminating lifecycle hook added to the ASG of an
+	// AWSMachinePool with a NodeDrainLifecycleHook.
+	NodeDrainLifecycleHookName = "capa-node-drain"
 )
 
 // AWSMachinePoolSpec defines the desired state of AWSMachinePool.
@@ -67,8 +79,16 @@ type AWSMachinePoolSpec struct {
 	AdditionalTags infrav1.Tags `json:"additionalTags,omitempty"`
 
 	// AWSLaunchTemplate specifies the launch template and version to use when an instance is launched.
-	// +kubebuilder:validation:Required
-	AWSLaunchTemplate AWSLaunchTemplate `json:"awsLaunchTemplate"`
+	// It is required unless LaunchTemplateRef is set.
+	// +optional
+	AWSLaunchTemplate AWSLaunchTemplate `json:"awsLaunchTemplate,omitempty"`
+
+	// LaunchTemplateRef references an existing launch template the ASG launches its instances from, instead
+	// of the launch template CAPA creates from AWSLaunchTemplate. CAPA neither modifies nor prunes the
+	// referenced launch template, and starts an instance refresh when the reference resolves to another version.
+	// Cannot be set together with AWSLaunchTemplate.
+	// +optional
+	LaunchTemplateRef *infrav1.LaunchTemplateReference `json:"launchTemplateRef,omitempty"`
 
 	// MixedInstancesPolicy describes how multiple instance types will be used by the ASG.
 	MixedInstancesPolicy *MixedInstancesPolicy `json:"mixedInstancesPolicy,omitempty"`
@@ -94,6 +114,63 @@ type AWSMachinePoolSpec struct {
 	// SuspendProcesses defines a list of processes to suspend for the given ASG. This is constantly reconciled.
 	// If a process is removed from this list it will automatically be resumed.
 	SuspendProcesses *SuspendProcessesTypes `json:"suspendProcesses,omitempty"`
+
+	// WarmPool describes a pool of pre-initialized instances the ASG draws from when it scales out, and
+	// returns instances to when it scales in. Removing it deletes the warm pool of the ASG.
+	// +optional
+	WarmPool *WarmPool `json:"warmPool,omitempty"`
+
+	// LifecycleHooks are the lifecycle hooks of the ASG, which pause instances while they are launched or
+	// terminated. Lifecycle hooks of the ASG that are not declared here are deleted.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	LifecycleHooks []AWSLifecycleHook `json:"lifecycleHooks,omitempty"`
+
+	// NodeDrainLifecycleHook adds a terminating lifecycle hook to the ASG, which is completed once the node of
+	// the terminating instance has been cordoned and drained.
+	// +optional
+	NodeDrainLifecycleHook *NodeDrainLifecycleHook `json:"nodeDrainLifecycleHook,omitempty"`
+
+	// TargetGroupARNs are the ARNs of the load balancer target groups the instances of the ASG are registered
+	// with. Target groups of the ASG that are not declared here are detached.
+	// +listType=set
+	// +optional
+	TargetGroupARNs []string `json:"targetGroupARNs,omitempty"`
+
+	// LoadBalancerNames are the names of the classic load balancers the instances of the ASG are registered
+	// with. Classic load balancers of the ASG that are not declared here are detached.
+	// +listType=set
+	// +optional
+	LoadBalancerNames []string `json:"loadBalancerNames,omitempty"`
+
+	// HealthCheckType is the type of health check the ASG uses to replace unhealthy instances. ELB also
+	// replaces instances that fail the health checks of the load balancers the ASG is attached to.
+	// Defaults to EC2.
+	// +kubebuilder:validation:Enum:=EC2;ELB
+	// +optional
+	HealthCheckType *ASGHealthCheckType `json:"healthCheckType,omitempty"`
+
+	// HealthCheckGracePeriod is the amount of time the ASG waits after an instance enters service before
+	// checking its health.
+	// +optional
+	HealthCheckGracePeriod *metav1.Duration `json:"healthCheckGracePeriod,omitempty"`
+
+	// ScalingPolicies are the target tracking scaling policies of the ASG. Target tracking scaling policies of
+	// the ASG that are not declared here are deleted. While the AWSMachinePool has scaling policies or
+	// scheduled actions, the desired capacity of the ASG is not updated from the MachinePool replicas, which
+	// follow the ASG instead.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ScalingPolicies []AWSScalingPolicy `json:"scalingPolicies,omitempty"`
+
+	// ScheduledActions are the scheduled actions of the ASG. Scheduled actions of the ASG that are not declared
+	// here are deleted. MinSize and MaxSize are not updated on the ASG while a scheduled action sets them.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ScheduledActions []AWSScheduledAction `json:"scheduledActions,omitempty"`
 }
 
 // SuspendProcessesTypes contains user friendly auto-completable values for suspended process names.
@@ -165,6 +242,43 @@ type RefreshPreferences struct {
 	// during an instance refresh. The default is 90.
 	// +optional
 	MinHealthyPercentage *int64 `json:"minHealthyPercentage,omitempty"`
+
+	// CheckpointPercentages are the percentages of the instances of the ASG replaced by the instance refresh
+	// at which it pauses for CheckpointDelay, in ascending order. Set the last one to 100 to wait after
+	// all instances are replaced before the instance refresh completes.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	CheckpointPercentages []int64 `json:"checkpointPercentages,omitempty"`
+
+	// CheckpointDelay is the number of seconds the instance refresh waits at each checkpoint before
+	// continuing. The default is 3600 (one hour).
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=172800
+	// +optional
+	CheckpointDelay *int64 `json:"checkpointDelay,omitempty"`
+
+	// SkipMatching, if true, skips replacing instances already launched from the launch template version
+	// and instance types the instance refresh rolls out.
+	// +optional
+	SkipMatching *bool `json:"skipMatching,omitempty"`
+
+	// AutoRollback, if true, rolls back the instance refresh when it fails, or when one of the alarms of
+	// AlarmSpecification goes into the ALARM state, replacing the instances already updated with instances
+	// launched from the previous launch template version. The ASG then launches its instances from explicit
+	// versions of the launch template rather than from the latest one, and only moves to a new version once
+	// the instance refresh rolling it out succeeds.
+	// +optional
+	AutoRollback *bool `json:"autoRollback,omitempty"`
+
+	// AlarmSpecification lists the CloudWatch alarms which roll back the instance refresh in progress when
+	// one of them goes into the ALARM state. It requires AutoRollback.
+	// +optional
+	AlarmSpecification *AlarmSpecification `json:"alarmSpecification,omitempty"`
+}
+
+// AutoRollbackEnabled returns whether an instance refresh of the ASG is rolled back when it fails.
+func (r *RefreshPreferences) AutoRollbackEnabled() bool {
+	return r != nil && r.AutoRollback != nil && *r.AutoRollback
 }
 
 // AWSMachinePoolStatus defines the observed state of AWSMachinePool.
@@ -188,10 +302,21 @@ type AWSMachinePoolStatus struct {
 	// The ID of the launch template
 	LaunchTemplateID string `json:"launchTemplateID,omitempty"`
 
-	// The version of the launch template
+	// The version of the launch template. For a referenced launch template, this is the number of the
+	// version the reference resolved to, which the ASG launches its instances from.
 	// +optional
 	LaunchTemplateVersion *string `json:"launchTemplateVersion,omitempty"`
 
+	// ImageID is the ID of the AMI used by the latest version of the launch template, as resolved
+	// from the AMI reference or the image lookup of the launch template.
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
+
+	// MatchedInstanceTypes are the instance types currently matching the instance requirements of the
+	// AWSMachinePool, which the ASG can launch.
+	// +optional
+	MatchedInstanceTypes []string `json:"matchedInstanceTypes,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -231,6 +356,20 @@ type AWSMachinePoolStatus struct {
 	FailureMessage *string `json:"failureMessage,omitempty"`
 
 	ASGStatus *ASGStatus `json:"asgStatus,omitempty"`
+
+	// WarmPoolStatus is the most recently observed state of the warm pool of the ASG.
+	// +optional
+	WarmPoolStatus *WarmPoolStatus `json:"warmPoolStatus,omitempty"`
+
+	// InstanceRefresh is the most recently observed state of the latest instance refresh of the ASG.
+	// +optional
+	InstanceRefresh *InstanceRefreshStatus `json:"instanceRefresh,omitempty"`
+
+	// InfrastructureMachineKind is the kind of the infrastructure resources created for each instance of the
+	// ASG, from which Cluster API creates the Machines of the MachinePool. It is only set when the
+	// MachinePoolMachines feature gate is enabled.
+	// +optional
+	InfrastructureMachineKind string `json:"infrastructureMachineKind,omitempty"`
 }
 
 // AWSMachinePoolInstanceStatus defines the status of the AWSMachinePoolInstance.
@@ -17,9 +17,12 @@ limitations under the License.
 package v1beta2
 
 import (
+	"reflect"
+	"regexp"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
@@ -32,6 +35,8 @@ import (
 
 var log = ctrl.Log.WithName("awsmachinepool-resource")
 
+var alarmARNRegex = regexp.MustCompile(`^arn:[^:]+:cloudwatch:[^:]+:[0-9]{12}:alarm:.+$`)
+
 // SetupWebhookWithManager will setup the webhooks for the AWSMachinePool.
 func (r *AWSMachinePool) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
@@ -109,6 +114,377 @@ func (r *AWSMachinePool) validateAdditionalSecurityGroups() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSMachinePool) validateLaunchTemplatePlacement() field.ErrorList {
+	return validateLaunchTemplatePlacement(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))
+}
+
+// validateLaunchTemplatePlacement checks that the Dedicated Host and placement group settings of a launch template
+// are consistent.
+func validateLaunchTemplatePlacement(lt *AWSLaunchTemplate, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if lt.Tenancy != v1beta2.TenancyHost {
+		if lt.HostID != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("hostID"), *lt.HostID, "can only be set when tenancy is host"))
+		}
+		if lt.HostAffinity != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("hostAffinity"), *lt.HostAffinity, "can only be set when tenancy is host"))
+		}
+		if lt.HostResourceGroupArn != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("hostResourceGroupArn"), *lt.HostResourceGroupArn, "can only be set when tenancy is host"))
+		}
+	}
+
+	if lt.HostID != nil && lt.HostResourceGroupArn != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("hostResourceGroupArn"), "only one of hostID or hostResourceGroupArn may be specified"))
+	}
+
+	if lt.PlacementGroupPartition != 0 && lt.PlacementGroupName == "" {
+		allErrs = append(allErrs, field.Required(path.Child("placementGroupName"), "placementGroupName is required when placementGroupPartition is set"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateLaunchTemplateNetworkInterfaces() field.ErrorList {
+	return validateLaunchTemplateNetworkInterfaces(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))
+}
+
+// validateLaunchTemplateNetworkInterfaces checks that managed network interfaces of a launch template do not
+// set a subnet, as Auto Scaling launches them in the subnet of the instance.
+func validateLaunchTemplateNetworkInterfaces(lt *AWSLaunchTemplate, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, ni := range lt.ManagedNetworkInterfaces {
+		if ni.Subnet != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("managedNetworkInterfaces").Index(i).Child("subnet"), "cannot be set on a launch template"))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateLaunchTemplateVolumes() field.ErrorList {
+	return validateLaunchTemplateVolumes(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))
+}
+
+// validateLaunchTemplateVolumes checks the non root volumes of a launch template, and that only non root
+// volumes are restored from snapshots.
+func validateLaunchTemplateVolumes(lt *AWSLaunchTemplate, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if lt.RootVolume != nil && lt.RootVolume.Snapshot != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("rootVolume", "snapshot"), "snapshot is not supported for the root volume"))
+	}
+
+	for i, volume := range lt.NonRootVolumes {
+		volumePath := path.Child("nonRootVolumes").Index(i)
+
+		if v1beta2.VolumeTypesProvisioned.Has(string(volume.Type)) && volume.IOPS == 0 {
+			allErrs = append(allErrs, field.Required(volumePath.Child("iops"), "iops required if type is 'io1' or 'io2'"))
+		}
+
+		if volume.Throughput != nil {
+			if volume.Type != v1beta2.VolumeTypeGP3 {
+				allErrs = append(allErrs, field.Required(volumePath.Child("throughput"), "throughput is valid only for type 'gp3'"))
+			}
+			if *volume.Throughput < 0 {
+				allErrs = append(allErrs, field.Required(volumePath.Child("throughput"), "throughput must be nonnegative"))
+			}
+		}
+
+		if volume.DeviceName == "" {
+			allErrs = append(allErrs, field.Required(volumePath.Child("deviceName"), "non root volume should have device name"))
+		}
+
+		if volume.Snapshot != nil && (volume.Snapshot.ID == nil) == (len(volume.Snapshot.Filters) == 0) {
+			allErrs = append(allErrs, field.Invalid(volumePath.Child("snapshot"), volume.Snapshot, "exactly one of ID or Filters must be specified"))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSMachinePool) validateLaunchTemplateHibernation() field.ErrorList {
+	return validateLaunchTemplateHibernation(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))
+}
+
+// validateLaunchTemplateHibernation checks that hibernation is only enabled on launch templates with an
+// encrypted root volume and without Nitro Enclaves, as required by EC2.
+func validateLaunchTemplateHibernation(lt *AWSLaunchTemplate, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !lt.HibernationEnabled {
+		return allErrs
+	}
+
+	if lt.RootVolume == nil || lt.RootVolume.Encrypted == nil || !*lt.RootVolume.Encrypted {
+		allErrs = append(allErrs, field.Required(path.Child("rootVolume", "encrypted"), "root volume must be encrypted when hibernation is enabled"))
+	}
+	if lt.NitroEnclaveEnabled {
+		allErrs = append(allErrs, field.Forbidden(path.Child("nitroEnclaveEnabled"), "cannot be enabled together with hibernationEnabled"))
+	}
+
+	return allErrs
+}
+
+// validateInstanceRequirements checks that the instance types of the ASG are either listed or matched against
+// instance requirements, which are set in a single place and only apply to On-Demand and Spot instances launched
+// through a mixed instances policy.
+func (r *AWSMachinePool) validateInstanceRequirements() field.ErrorList {
+	var allErrs field.ErrorList
+
+	ltPath := field.NewPath("spec", "awsLaunchTemplate")
+	if requirements := r.Spec.AWSLaunchTemplate.InstanceRequirements; requirements != nil {
+		if r.Spec.AWSLaunchTemplate.InstanceType != "" {
+			allErrs = append(allErrs, field.Forbidden(ltPath.Child("instanceType"), "cannot be set together with instanceRequirements"))
+		}
+		if r.Spec.AWSLaunchTemplate.SpotMarketOptions != nil {
+			allErrs = append(allErrs, field.Forbidden(ltPath.Child("spotMarketOptions"), "cannot be set together with instanceRequirements, use mixedInstancesPolicy.instancesDistribution instead"))
+		}
+		allErrs = append(allErrs, validateInstanceRequirements(requirements, ltPath.Child("instanceRequirements"))...)
+	}
+
+	if r.Spec.MixedInstancesPolicy == nil || r.Spec.MixedInstancesPolicy.InstanceRequirements == nil {
+		return allErrs
+	}
+
+	policyPath := field.NewPath("spec", "mixedInstancesPolicy")
+	if len(r.Spec.MixedInstancesPolicy.Overrides) > 0 {
+		allErrs = append(allErrs, field.Forbidden(policyPath.Child("overrides"), "cannot be set together with instanceRequirements"))
+	}
+	if r.Spec.AWSLaunchTemplate.InstanceRequirements != nil {
+		allErrs = append(allErrs, field.Forbidden(policyPath.Child("instanceRequirements"), "cannot be set together with awsLaunchTemplate.instanceRequirements"))
+	}
+	allErrs = append(allErrs, validateInstanceRequirements(r.Spec.MixedInstancesPolicy.InstanceRequirements, policyPath.Child("instanceRequirements"))...)
+
+	return allErrs
+}
+
+// validateInstanceRequirements checks that the ranges of instance requirements are not empty, and that instance
+// types are either allowed or excluded, as required by EC2.
+func validateInstanceRequirements(requirements *InstanceRequirements, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validateInstanceRequirementsRange(&requirements.VCPUCount, path.Child("vCPUCount"))...)
+	allErrs = append(allErrs, validateInstanceRequirementsRange(&requirements.MemoryMiB, path.Child("memoryMiB"))...)
+	if requirements.AcceleratorCount != nil {
+		allErrs = append(allErrs, validateInstanceRequirementsRange(requirements.AcceleratorCount, path.Child("acceleratorCount"))...)
+	}
+	if len(requirements.AllowedInstanceTypes) > 0 && len(requirements.ExcludedInstanceTypes) > 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("excludedInstanceTypes"), "cannot be set together with allowedInstanceTypes"))
+	}
+
+	return allErrs
+}
+
+func validateInstanceRequirementsRange(r *InstanceRequirementsRange, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Max != nil && *r.Max < r.Min {
+		allErrs = append(allErrs, field.Invalid(path.Child("max"), *r.Max, "must be greater than or equal to min"))
+	}
+
+	return allErrs
+}
+
+// validateLaunchTemplateRef checks that a referenced launch template is used instead of, not along with, the
+// launch template CAPA creates from awsLaunchTemplate.
+func (r *AWSMachinePool) validateLaunchTemplateRef() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.LaunchTemplateRef == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, r.Spec.LaunchTemplateRef.Validate(field.NewPath("spec", "launchTemplateRef"))...)
+	if !reflect.DeepEqual(r.Spec.AWSLaunchTemplate, AWSLaunchTemplate{}) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "awsLaunchTemplate"), "cannot be set together with launchTemplateRef"))
+	}
+
+	return allErrs
+}
+
+// validateWarmPool checks that the warm pool is consistent, and that the ASG launches instances that can be
+// kept in a warm pool, as EC2 Auto Scaling does not support warm pools with mixed instances policies or Spot instances.
+func (r *AWSMachinePool) validateWarmPool() field.ErrorList {
+	var allErrs field.ErrorList
+
+	warmPool := r.Spec.WarmPool
+	if warmPool == nil {
+		return allErrs
+	}
+
+	path := field.NewPath("spec", "warmPool")
+	if warmPool.MinSize != nil && warmPool.MaxGroupPreparedCapacity != nil && *warmPool.MinSize > *warmPool.MaxGroupPreparedCapacity {
+		allErrs = append(allErrs, field.Invalid(path.Child("minSize"), *warmPool.MinSize, "must not be greater than maxGroupPreparedCapacity"))
+	}
+	if warmPool.PoolState == WarmPoolStateHibernated && r.Spec.LaunchTemplateRef == nil && !r.Spec.AWSLaunchTemplate.HibernationEnabled {
+		allErrs = append(allErrs, field.Invalid(path.Child("poolState"), warmPool.PoolState, "requires awsLaunchTemplate.hibernationEnabled"))
+	}
+	if r.Spec.MixedInstancesPolicy != nil {
+		allErrs = append(allErrs, field.Forbidden(path, "cannot be set together with mixedInstancesPolicy"))
+	}
+	if r.Spec.AWSLaunchTemplate.InstanceRequirements != nil {
+		allErrs = append(allErrs, field.Forbidden(path, "cannot be set together with awsLaunchTemplate.instanceRequirements"))
+	}
+	if r.Spec.AWSLaunchTemplate.SpotMarketOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(path, "cannot be set together with awsLaunchTemplate.spotMarketOptions"))
+	}
+
+	return allErrs
+}
+
+// validateLifecycleHooks checks that the lifecycle hooks have unique names that do not collide with the node
+// drain lifecycle hook, heartbeat timeouts accepted by EC2 Auto Scaling, and a role to publish notifications.
+func (r *AWSMachinePool) validateLifecycleHooks() field.ErrorList {
+	var allErrs field.ErrorList
+
+	path := field.NewPath("spec", "lifecycleHooks")
+	for i, hook := range r.Spec.LifecycleHooks {
+		hookPath := path.Index(i)
+
+		if hook.Name == NodeDrainLifecycleHookName {
+			allErrs = append(allErrs, field.Invalid(hookPath.Child("name"), hook.Name, "is reserved for the node drain lifecycle hook"))
+		}
+		if hook.HeartbeatTimeout != nil {
+			allErrs = append(allErrs, validateHeartbeatTimeout(hook.HeartbeatTimeout, hookPath.Child("heartbeatTimeout"))...)
+		}
+		if hook.NotificationTargetARN != nil && hook.RoleARN == nil {
+			allErrs = append(allErrs, field.Required(hookPath.Child("roleARN"), "roleARN is required when notificationTargetARN is set"))
+		}
+	}
+
+	if r.Spec.NodeDrainLifecycleHook != nil && r.Spec.NodeDrainLifecycleHook.HeartbeatTimeout != nil {
+		allErrs = append(allErrs, validateHeartbeatTimeout(r.Spec.NodeDrainLifecycleHook.HeartbeatTimeout, field.NewPath("spec", "nodeDrainLifecycleHook", "heartbeatTimeout"))...)
+	}
+
+	return allErrs
+}
+
+// validateScalingPolicies checks that the scaling policies track exactly one metric, with a resource label for the
+// ALBRequestCountPerTarget metric only, and a positive target value.
+func (r *AWSMachinePool) validateScalingPolicies() field.ErrorList {
+	var allErrs field.ErrorList
+
+	path := field.NewPath("spec", "scalingPolicies")
+	for i, policy := range r.Spec.ScalingPolicies {
+		policyPath := path.Index(i)
+
+		if policy.TargetValue.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("targetValue"), policy.TargetValue.String(), "must be greater than zero"))
+		}
+		if policy.EstimatedInstanceWarmup != nil && policy.EstimatedInstanceWarmup.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("estimatedInstanceWarmup"), policy.EstimatedInstanceWarmup.Duration.String(), "must not be negative"))
+		}
+
+		switch {
+		case policy.PredefinedMetric == nil && policy.CustomizedMetric == nil:
+			allErrs = append(allErrs, field.Required(policyPath, "one of predefinedMetric and customizedMetric must be set"))
+		case policy.PredefinedMetric != nil && policy.CustomizedMetric != nil:
+			allErrs = append(allErrs, field.Forbidden(policyPath.Child("customizedMetric"), "cannot be set together with predefinedMetric"))
+		case policy.PredefinedMetric != nil:
+			metricPath := policyPath.Child("predefinedMetric", "resourceLabel")
+			if policy.PredefinedMetric.Type == PredefinedMetricTypeALBRequestCountPerTarget && policy.PredefinedMetric.ResourceLabel == nil {
+				allErrs = append(allErrs, field.Required(metricPath, "resourceLabel is required for the ALBRequestCountPerTarget metric"))
+			}
+			if policy.PredefinedMetric.Type != PredefinedMetricTypeALBRequestCountPerTarget && policy.PredefinedMetric.ResourceLabel != nil {
+				allErrs = append(allErrs, field.Forbidden(metricPath, "resourceLabel can only be set for the ALBRequestCountPerTarget metric"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateScheduledActions checks that the scheduled actions run at a given time or recurrence, change the size of
+// the ASG, and stop recurring after they start.
+func (r *AWSMachinePool) validateScheduledActions() field.ErrorList {
+	var allErrs field.ErrorList
+
+	path := field.NewPath("spec", "scheduledActions")
+	for i, action := range r.Spec.ScheduledActions {
+		actionPath := path.Index(i)
+
+		if action.Recurrence == nil && action.StartTime == nil {
+			allErrs = append(allErrs, field.Required(actionPath, "one of recurrence and startTime must be set"))
+		}
+		if action.Recurrence == nil && action.EndTime != nil {
+			allErrs = append(allErrs, field.Forbidden(actionPath.Child("endTime"), "endTime can only be set together with recurrence"))
+		}
+		if action.StartTime != nil && action.EndTime != nil && !action.EndTime.After(action.StartTime.Time) {
+			allErrs = append(allErrs, field.Invalid(actionPath.Child("endTime"), action.EndTime.String(), "must be after startTime"))
+		}
+		if action.MinSize == nil && action.MaxSize == nil && action.DesiredCapacity == nil {
+			allErrs = append(allErrs, field.Required(actionPath, "at least one of minSize, maxSize and desiredCapacity must be set"))
+		}
+		if action.MinSize != nil && action.MaxSize != nil && *action.MinSize > *action.MaxSize {
+			allErrs = append(allErrs, field.Invalid(actionPath.Child("minSize"), *action.MinSize, "must not be greater than maxSize"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateRefreshPreferences checks that the checkpoints of the instance refresh are ascending percentages, and that
+// the instance refresh can be rolled back when alarms are set.
+func (r *AWSMachinePool) validateRefreshPreferences() field.ErrorList {
+	var allErrs field.ErrorList
+
+	prefs := r.Spec.RefreshPreferences
+	if prefs == nil {
+		return allErrs
+	}
+
+	path := field.NewPath("spec", "refreshPreferences")
+	previous := int64(0)
+	for i, percentage := range prefs.CheckpointPercentages {
+		if percentage <= previous || percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(path.Child("checkpointPercentages").Index(i), percentage, "must be greater than the previous checkpoint percentage, and at most 100"))
+		}
+		previous = percentage
+	}
+	if prefs.CheckpointDelay != nil && len(prefs.CheckpointPercentages) == 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("checkpointDelay"), "checkpointDelay can only be set together with checkpointPercentages"))
+	}
+	if prefs.AutoRollbackEnabled() && prefs.Disable {
+		allErrs = append(allErrs, field.Forbidden(path.Child("autoRollback"), "autoRollback cannot be set when instance refresh is disabled"))
+	}
+	if prefs.AlarmSpecification != nil && !prefs.AutoRollbackEnabled() {
+		allErrs = append(allErrs, field.Forbidden(path.Child("alarmSpecification"), "alarmSpecification requires autoRollback"))
+	}
+	if prefs.AlarmSpecification != nil {
+		for i, alarmARN := range prefs.AlarmSpecification.AlarmARNs {
+			if !alarmARNRegex.MatchString(alarmARN) {
+				allErrs = append(allErrs, field.Invalid(path.Child("alarmSpecification", "alarmARNs").Index(i), alarmARN, "must be the ARN of a CloudWatch alarm"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateHealthCheckGracePeriod checks that the health check grace period is not negative.
+func (r *AWSMachinePool) validateHealthCheckGracePeriod() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.HealthCheckGracePeriod != nil && r.Spec.HealthCheckGracePeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "healthCheckGracePeriod"), r.Spec.HealthCheckGracePeriod.Duration.String(), "must not be negative"))
+	}
+
+	return allErrs
+}
+
+func validateHeartbeatTimeout(timeout *metav1.Duration, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if timeout.Duration < 30*time.Second || timeout.Duration > 2*time.Hour {
+		allErrs = append(allErrs, field.Invalid(path, timeout.Duration.String(), "must be between 30s and 2h"))
+	}
+
+	return allErrs
+}
+
 // ValidateCreate will do any extra validation when creating a AWSMachinePool.
 func (r *AWSMachinePool) ValidateCreate() (admission.Warnings, error) {
 	log.Info("AWSMachinePool validate create", "machine-pool", klog.KObj(r))
@@ -120,6 +496,19 @@ func (r *AWSMachinePool) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, r.validateLaunchTemplatePlacement()...)
+	allErrs = append(allErrs, r.validateLaunchTemplateHibernation()...)
+	allErrs = append(allErrs, r.validateLaunchTemplateNetworkInterfaces()...)
+	allErrs = append(allErrs, r.validateLaunchTemplateVolumes()...)
+	allErrs = append(allErrs, r.validateInstanceRequirements()...)
+	allErrs = append(allErrs, r.validateLaunchTemplateRef()...)
+	allErrs = append(allErrs, r.validateWarmPool()...)
+	allErrs = append(allErrs, r.validateLifecycleHooks()...)
+	allErrs = append(allErrs, r.validateHealthCheckGracePeriod()...)
+	allErrs = append(allErrs, r.validateScalingPolicies()...)
+	allErrs = append(allErrs, r.validateScheduledActions()...)
+	allErrs = append(allErrs, r.validateRefreshPreferences()...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.AMI.Validate(field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -136,10 +525,34 @@ func (r *AWSMachinePool) ValidateCreate() (admission.Warnings, error) {
 func (r *AWSMachinePool) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	var allErrs field.ErrorList
 
+	// The launch template owned by CAPA is deleted along with the AWSMachinePool, the ASG cannot move between
+	// it and a referenced launch template. Only the version of a referenced launch template can change.
+	if oldPool, ok := old.(*AWSMachinePool); ok {
+		oldRef, ref := oldPool.Spec.LaunchTemplateRef, r.Spec.LaunchTemplateRef
+		switch {
+		case (oldRef == nil) != (ref == nil):
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "launchTemplateRef"), ref, "cannot be added or removed"))
+		case ref != nil && (!reflect.DeepEqual(oldRef.ID, ref.ID) || !reflect.DeepEqual(oldRef.Name, ref.Name)):
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "launchTemplateRef"), ref, "only the version can be changed"))
+		}
+	}
 	allErrs = append(allErrs, r.validateDefaultCoolDown()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, r.validateLaunchTemplatePlacement()...)
+	allErrs = append(allErrs, r.validateLaunchTemplateHibernation()...)
+	allErrs = append(allErrs, r.validateLaunchTemplateNetworkInterfaces()...)
+	allErrs = append(allErrs, r.validateLaunchTemplateVolumes()...)
+	allErrs = append(allErrs, r.validateInstanceRequirements()...)
+	allErrs = append(allErrs, r.validateLaunchTemplateRef()...)
+	allErrs = append(allErrs, r.validateWarmPool()...)
+	allErrs = append(allErrs, r.validateLifecycleHooks()...)
+	allErrs = append(allErrs, r.validateHealthCheckGracePeriod()...)
+	allErrs = append(allErrs, r.validateScalingPolicies()...)
+	allErrs = append(allErrs, r.validateScheduledActions()...)
+	allErrs = append(allErrs, r.validateRefreshPreferences()...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.AMI.Validate(field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
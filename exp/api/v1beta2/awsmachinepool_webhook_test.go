@@ -19,9 +19,11 @@ package v1beta2
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
@@ -39,6 +41,7 @@ func TestAWSMachinePoolDefault(t *testing.T) {
 
 func TestAWSMachinePoolValidateCreate(t *testing.T) {
 	g := NewWithT(t)
+	elbHealthCheckType := ASGHealthCheckTypeELB
 
 	tests := []struct {
 		name    string
@@ -139,6 +142,574 @@ func TestAWSMachinePoolValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Should pass if host placement is used with host tenancy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						Tenancy:              "host",
+						HostResourceGroupArn: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/hosts"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if hibernation is enabled without an encrypted root volume",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						HibernationEnabled: true,
+						RootVolume: &infrav1.Volume{
+							Size: 100,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if hibernation is enabled with an encrypted root volume",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						HibernationEnabled: true,
+						RootVolume: &infrav1.Volume{
+							Size:      100,
+							Encrypted: aws.Bool(true),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a managed network interface sets a subnet",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						ManagedNetworkInterfaces: []infrav1.ManagedNetworkInterface{
+							{DeviceIndex: 1, Subnet: &infrav1.AWSResourceReference{ID: aws.String("subnet-1")}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with managed network interfaces in the subnet of the instance",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						ManagedNetworkInterfaces: []infrav1.ManagedNetworkInterface{
+							{DeviceIndex: 1, SecondaryPrivateIPAddressCount: aws.Int64(4)},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should pass with a non root volume restored from snapshots matching filters",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NonRootVolumes: []infrav1.Volume{
+							{
+								DeviceName: "/dev/sdb",
+								Size:       100,
+								Snapshot: &infrav1.AWSResourceReference{
+									Filters: []infrav1.Filter{{Name: "tag:dataset", Values: []string{"images"}}},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a non root volume has no device name",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NonRootVolumes: []infrav1.Volume{
+							{
+								Size:     100,
+								Snapshot: &infrav1.AWSResourceReference{ID: aws.String("snap-1")},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if both an AMI ID and an SSM parameter are set",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						AMI: infrav1.AMIReference{
+							ID:           aws.String("ami-1"),
+							SSMParameter: aws.String("/amis/{{.K8sMinorVersion}}"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the root volume is restored from a snapshot",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						RootVolume: &infrav1.Volume{
+							Size:     100,
+							Snapshot: &infrav1.AWSResourceReference{ID: aws.String("snap-1")},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if host placement is used without host tenancy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						HostID: aws.String("h-0123456789abcdef0"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a placement group partition is set without a placement group",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PlacementGroupPartition: 2,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with a warm pool of stopped instances",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					WarmPool: &WarmPool{
+						MinSize:                  aws.Int32(1),
+						MaxGroupPreparedCapacity: aws.Int32(5),
+						PoolState:                WarmPoolStateStopped,
+						ReuseOnScaleIn:           true,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the warm pool min size is greater than its max group prepared capacity",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					WarmPool: &WarmPool{
+						MinSize:                  aws.Int32(5),
+						MaxGroupPreparedCapacity: aws.Int32(1),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the warm pool hibernates instances without hibernation enabled",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					WarmPool: &WarmPool{
+						PoolState: WarmPoolStateHibernated,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with lifecycle hooks and the node drain lifecycle hook",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{
+						{
+							Name:                  "launch",
+							LifecycleTransition:   LifecycleTransitionInstanceLaunch,
+							HeartbeatTimeout:      &metav1.Duration{Duration: 5 * time.Minute},
+							NotificationTargetARN: aws.String("arn:aws:sqs:us-east-1:123456789012:queue"),
+							RoleARN:               aws.String("arn:aws:iam::123456789012:role/role"),
+						},
+					},
+					NodeDrainLifecycleHook: &NodeDrainLifecycleHook{
+						HeartbeatTimeout: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a lifecycle hook uses the name of the node drain lifecycle hook",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{
+						{Name: NodeDrainLifecycleHookName, LifecycleTransition: LifecycleTransitionInstanceTerminate},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a lifecycle hook has a heartbeat timeout over 2 hours",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{
+						{
+							Name:                "terminate",
+							LifecycleTransition: LifecycleTransitionInstanceTerminate,
+							HeartbeatTimeout:    &metav1.Duration{Duration: 3 * time.Hour},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a lifecycle hook has a notification target without a role",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LifecycleHooks: []AWSLifecycleHook{
+						{
+							Name:                  "launch",
+							LifecycleTransition:   LifecycleTransitionInstanceLaunch,
+							NotificationTargetARN: aws.String("arn:aws:sqs:us-east-1:123456789012:queue"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a warm pool is set together with a mixed instances policy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					WarmPool: &WarmPool{},
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides: []Overrides{{InstanceType: "m5.large"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with instance requirements in the mixed instances policy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						InstanceRequirements: &InstanceRequirements{
+							VCPUCount:        InstanceRequirementsRange{Min: 2, Max: aws.Int64(8)},
+							MemoryMiB:        InstanceRequirementsRange{Min: 4096},
+							CPUManufacturers: []CPUManufacturer{CPUManufacturerIntel, CPUManufacturerAMD},
+							BareMetal:        InstanceRequirementExcluded,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if instance requirements are set together with overrides",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					MixedInstancesPolicy: &MixedInstancesPolicy{
+						Overrides:            []Overrides{{InstanceType: "m5.large"}},
+						InstanceRequirements: &InstanceRequirements{VCPUCount: InstanceRequirementsRange{Min: 2}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if launch template instance requirements are set together with an instance type",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceType:         "m5.large",
+						InstanceRequirements: &InstanceRequirements{VCPUCount: InstanceRequirementsRange{Min: 2}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the vCPU count of instance requirements has a max lower than its min",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceRequirements: &InstanceRequirements{VCPUCount: InstanceRequirementsRange{Min: 8, Max: aws.Int64(4)}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if instance requirements both allow and exclude instance types",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						InstanceRequirements: &InstanceRequirements{
+							AllowedInstanceTypes:  []string{"m5.*"},
+							ExcludedInstanceTypes: []string{"m5.metal"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if load balancers are attached with ELB health checks",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TargetGroupARNs:        []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/ingress/0123456789abcdef"},
+					LoadBalancerNames:      []string{"ingress"},
+					HealthCheckType:        &elbHealthCheckType,
+					HealthCheckGracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the health check grace period is negative",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					HealthCheckGracePeriod: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with target tracking scaling policies and scheduled actions",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []AWSScalingPolicy{
+						{
+							Name:             "cpu",
+							TargetValue:      resource.MustParse("50"),
+							PredefinedMetric: &PredefinedMetric{Type: PredefinedMetricTypeASGAverageCPUUtilization},
+						},
+						{
+							Name:        "queue-depth",
+							TargetValue: resource.MustParse("0.5"),
+							CustomizedMetric: &CustomizedMetric{
+								MetricName: "QueueDepthPerInstance",
+								Namespace:  "Workers",
+								Statistic:  MetricStatisticAverage,
+							},
+						},
+					},
+					ScheduledActions: []AWSScheduledAction{
+						{
+							Name:       "business-hours-start",
+							Recurrence: pointer.String("0 8 * * 1-5"),
+							TimeZone:   pointer.String("Europe/Paris"),
+							MinSize:    pointer.Int32(5),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a scaling policy tracks no metric",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []AWSScalingPolicy{
+						{
+							Name:        "cpu",
+							TargetValue: resource.MustParse("50"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a scaling policy tracks the ALB request count without a resource label",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []AWSScalingPolicy{
+						{
+							Name:             "requests",
+							TargetValue:      resource.MustParse("1000"),
+							PredefinedMetric: &PredefinedMetric{Type: PredefinedMetricTypeALBRequestCountPerTarget},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a scaling policy has a target value of zero",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScalingPolicies: []AWSScalingPolicy{
+						{
+							Name:             "cpu",
+							PredefinedMetric: &PredefinedMetric{Type: PredefinedMetricTypeASGAverageCPUUtilization},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a scheduled action does not change the size of the ASG",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScheduledActions: []AWSScheduledAction{
+						{
+							Name:       "business-hours-start",
+							Recurrence: pointer.String("0 8 * * 1-5"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a scheduled action has neither a recurrence nor a start time",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					ScheduledActions: []AWSScheduledAction{
+						{
+							Name:            "scale-up",
+							DesiredCapacity: pointer.Int32(5),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with a referenced launch template",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345"), Version: pointer.String("$Latest")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a referenced launch template is set together with awsLaunchTemplate",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{InstanceType: "m5.large"},
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a launch template is referenced by both ID and name",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345"), Name: pointer.String("base")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with instance refresh checkpoints and alarm-based rollback",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						CheckpointPercentages: []int64{20, 50, 100},
+						CheckpointDelay:       pointer.Int64(600),
+						SkipMatching:          pointer.Bool(true),
+						AutoRollback:          pointer.Bool(true),
+						AlarmSpecification: &AlarmSpecification{
+							AlarmARNs: []string{"arn:aws:cloudwatch:us-east-1:123456789012:alarm:high-error-rate"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the instance refresh checkpoint percentages are not ascending",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						CheckpointPercentages: []int64{50, 20},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if an instance refresh checkpoint percentage is above 100",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						CheckpointPercentages: []int64{50, 120},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the instance refresh checkpoint delay is set without checkpoints",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						CheckpointDelay: pointer.Int64(600),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if auto rollback is set when instance refresh is disabled",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						Disable:      true,
+						AutoRollback: pointer.Bool(true),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if rollback alarms are set without auto rollback",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						AlarmSpecification: &AlarmSpecification{
+							AlarmARNs: []string{"arn:aws:cloudwatch:us-east-1:123456789012:alarm:high-error-rate"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a rollback alarm is not a CloudWatch alarm ARN",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					RefreshPreferences: &RefreshPreferences{
+						AutoRollback: pointer.Bool(true),
+						AlarmSpecification: &AlarmSpecification{
+							AlarmARNs: []string{"high-error-rate"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -252,6 +823,48 @@ func TestAWSMachinePoolValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Should pass update of the version of a referenced launch template",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345"), Version: pointer.String("2")},
+				},
+			},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345"), Version: pointer.String("3")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail update of the referenced launch template",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345")},
+				},
+			},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{Name: pointer.String("base")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail update from awsLaunchTemplate to a referenced launch template",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{InstanceType: "m5.large"},
+				},
+			},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345")},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
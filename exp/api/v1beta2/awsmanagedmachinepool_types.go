@@ -37,6 +37,8 @@ const (
 	Al2x86_64GPU ManagedMachineAMIType = "AL2_x86_64_GPU"
 	// Al2Arm64 is the Arm AMI type.
 	Al2Arm64 ManagedMachineAMIType = "AL2_ARM_64"
+	// Custom is the AMI type of a launch template that specifies the AMI.
+	Custom ManagedMachineAMIType = "CUSTOM"
 )
 
 // ManagedMachinePoolCapacityType specifies the capacity type to be used for the managed MachinePool.
@@ -155,6 +157,13 @@ type AWSManagedMachinePoolSpec struct {
 	// are prohibited (https://docs.aws.amazon.com/eks/latest/userguide/launch-templates.html).
 	// +optional
 	AWSLaunchTemplate *AWSLaunchTemplate `json:"awsLaunchTemplate,omitempty"`
+
+	// LaunchTemplateRef references an existing launch template to create the managed node group with, instead
+	// of the launch template CAPA creates from AWSLaunchTemplate. CAPA neither modifies nor prunes the
+	// referenced launch template, and updates the node group when the reference resolves to another version.
+	// Cannot be set together with AWSLaunchTemplate.
+	// +optional
+	LaunchTemplateRef *infrav1.LaunchTemplateReference `json:"launchTemplateRef,omitempty"`
 }
 
 // ManagedMachinePoolScaling specifies scaling options.
@@ -191,10 +200,16 @@ type AWSManagedMachinePoolStatus struct {
 	// +optional
 	LaunchTemplateID *string `json:"launchTemplateID,omitempty"`
 
-	// The version of the launch template
+	// The version of the launch template. For a referenced launch template, this is the number of the
+	// version the reference resolved to, which the node group is created or updated with.
 	// +optional
 	LaunchTemplateVersion *string `json:"launchTemplateVersion,omitempty"`
 
+	// ImageID is the ID of the AMI used by the latest version of the launch template, as resolved
+	// from the AMI reference or the image lookup of the launch template.
+	// +optional
+	ImageID *string `json:"imageID,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the MachinePool and will contain a succinct value suitable
 	// for machine interpretation.
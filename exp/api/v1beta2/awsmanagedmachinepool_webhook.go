@@ -134,6 +134,37 @@ func (r *AWSManagedMachinePool) validateLaunchTemplate() field.ErrorList {
 	if r.Spec.AWSLaunchTemplate.IamInstanceProfile != "" {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "AWSLaunchTemplate", "IamInstanceProfile"), r.Spec.AWSLaunchTemplate.IamInstanceProfile, "IAM instance profile in launch template is prohibited in EKS managed node group"))
 	}
+	if r.Spec.AWSLaunchTemplate.InstanceRequirements != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "awsLaunchTemplate", "instanceRequirements"), "instance requirements are not supported by EKS managed node groups"))
+	}
+
+	allErrs = append(allErrs, validateLaunchTemplatePlacement(r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, validateLaunchTemplateHibernation(r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, validateLaunchTemplateNetworkInterfaces(r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, validateLaunchTemplateVolumes(r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.AMI.Validate(field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
+
+	return allErrs
+}
+
+// validateLaunchTemplateRef checks that a referenced launch template is used instead of the launch template CAPA
+// creates from awsLaunchTemplate, in which case the same node group settings are prohibited.
+func (r *AWSManagedMachinePool) validateLaunchTemplateRef() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.LaunchTemplateRef == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, r.Spec.LaunchTemplateRef.Validate(field.NewPath("spec", "launchTemplateRef"))...)
+	if r.Spec.AWSLaunchTemplate != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "awsLaunchTemplate"), "cannot be set together with launchTemplateRef"))
+	}
+	if r.Spec.InstanceType != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "InstanceType"), r.Spec.InstanceType, "InstanceType cannot be specified when LaunchTemplateRef is specified"))
+	}
+	if r.Spec.DiskSize != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "DiskSize"), r.Spec.DiskSize, "DiskSize cannot be specified when LaunchTemplateRef is specified"))
+	}
 
 	return allErrs
 }
@@ -159,6 +190,9 @@ func (r *AWSManagedMachinePool) ValidateCreate() (admission.Warnings, error) {
 	if errs := r.validateLaunchTemplate(); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
+	if errs := r.validateLaunchTemplateRef(); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
 
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
@@ -196,6 +230,9 @@ func (r *AWSManagedMachinePool) ValidateUpdate(old runtime.Object) (admission.Wa
 	if errs := r.validateLaunchTemplate(); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
+	if errs := r.validateLaunchTemplateRef(); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -256,6 +293,17 @@ func (r *AWSManagedMachinePool) validateImmutable(old *AWSManagedMachinePool) fi
 	if old.Spec.AWSLaunchTemplate != nil && r.Spec.AWSLaunchTemplate != nil {
 		appendErrorIfMutated(old.Spec.AWSLaunchTemplate.Name, r.Spec.AWSLaunchTemplate.Name, "awsLaunchTemplate.name")
 	}
+	// Only the version of a referenced launch template can change, the node group keeps its launch template.
+	if (old.Spec.LaunchTemplateRef == nil) != (r.Spec.LaunchTemplateRef == nil) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(field.NewPath("spec", "launchTemplateRef"), r.Spec.LaunchTemplateRef, "field is immutable"),
+		)
+	}
+	if old.Spec.LaunchTemplateRef != nil && r.Spec.LaunchTemplateRef != nil {
+		appendErrorIfMutated(old.Spec.LaunchTemplateRef.ID, r.Spec.LaunchTemplateRef.ID, "launchTemplateRef.id")
+		appendErrorIfMutated(old.Spec.LaunchTemplateRef.Name, r.Spec.LaunchTemplateRef.Name, "launchTemplateRef.name")
+	}
 
 	return allErrs
 }
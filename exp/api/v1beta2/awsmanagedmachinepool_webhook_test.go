@@ -150,6 +150,38 @@ func TestAWSManagedMachinePoolValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "referenced launch template is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:  "eks-node-group-3",
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "referenced launch template with an instance type is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:  "eks-node-group-3",
+					InstanceType:      pointer.String("m5.large"),
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "referenced launch template with awsLaunchTemplate is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:  "eks-node-group-3",
+					AWSLaunchTemplate: &AWSLaunchTemplate{Name: "test"},
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345")},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -688,6 +720,38 @@ func TestAWSManagedMachinePoolValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "changing the version of a referenced launch template is accepted",
+			old: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:  "eks-node-group-1",
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345"), Version: pointer.String("2")},
+				},
+			},
+			new: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:  "eks-node-group-1",
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345"), Version: pointer.String("3")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "changing the ID of a referenced launch template is rejected",
+			old: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:  "eks-node-group-1",
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-12345")},
+				},
+			},
+			new: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:  "eks-node-group-1",
+					LaunchTemplateRef: &infrav1.LaunchTemplateReference{ID: pointer.String("lt-67890")},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -27,6 +27,8 @@ const (
 	ASGProvisionFailedReason = "ASGProvisionFailed"
 	// ASGDeletionInProgress ASG is in a deletion in progress state.
 	ASGDeletionInProgress = "ASGDeletionInProgress"
+	// ASGHibernatedReason used when the autoscaling group was scaled to zero because the cluster is hibernated.
+	ASGHibernatedReason = "ASGHibernated"
 
 	// LaunchTemplateReadyCondition represents the status of an AWSMachinePool's associated Launch Template.
 	LaunchTemplateReadyCondition clusterv1.ConditionType = "LaunchTemplateReady"
@@ -52,8 +54,22 @@ const (
 	// InstanceRefreshNotReadyReason used to report instance refresh is not initiated.
 	// If there are instance refreshes that are in progress, then a new instance refresh request will fail.
 	InstanceRefreshNotReadyReason = "InstanceRefreshNotReady"
-	// InstanceRefreshFailedReason used to report when there instance refresh is not initiated.
+	// InstanceRefreshFailedReason used to report when there instance refresh is not initiated, or failed.
 	InstanceRefreshFailedReason = "InstanceRefreshFailed"
+
+	// InstanceRefreshReadyCondition reports on the latest instance refresh of the ASG. It is false while an instance
+	// refresh is in progress, and when the latest one failed, was cancelled or was rolled back.
+	InstanceRefreshReadyCondition clusterv1.ConditionType = "InstanceRefreshReady"
+	// InstanceRefreshInProgressReason used while an instance refresh is pending or in progress.
+	InstanceRefreshInProgressReason = "InstanceRefreshInProgress"
+	// InstanceRefreshCancelledReason used when the latest instance refresh was cancelled.
+	InstanceRefreshCancelledReason = "InstanceRefreshCancelled"
+	// InstanceRefreshRollbackInProgressReason used while the latest instance refresh is rolled back.
+	InstanceRefreshRollbackInProgressReason = "InstanceRefreshRollbackInProgress"
+	// InstanceRefreshRolledBackReason used when the latest instance refresh was rolled back.
+	InstanceRefreshRolledBackReason = "InstanceRefreshRolledBack"
+	// InstanceRefreshRollbackFailedReason used when the rollback of the latest instance refresh failed.
+	InstanceRefreshRollbackFailedReason = "InstanceRefreshRollbackFailed"
 )
 
 const (
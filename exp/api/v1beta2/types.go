@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -92,10 +93,19 @@ type AWSLaunchTemplate struct {
 	// InstanceType is the type of instance to create. Example: m4.xlarge
 	InstanceType string `json:"instanceType,omitempty"`
 
+	// InstanceRequirements are the attributes the instance types of the ASG must have. The instance types
+	// matching them are used instead of InstanceType, which cannot be set together with InstanceRequirements.
+	// +optional
+	InstanceRequirements *InstanceRequirements `json:"instanceRequirements,omitempty"`
+
 	// RootVolume encapsulates the configuration options for the root volume
 	// +optional
 	RootVolume *infrav1.Volume `json:"rootVolume,omitempty"`
 
+	// Configuration options for the non root storage volumes.
+	// +optional
+	NonRootVolumes []infrav1.Volume `json:"nonRootVolumes,omitempty"`
+
 	// SSHKeyName is the name of the ssh key to attach to the instance. Valid values are empty string
 	// (do not use SSH keys), a valid SSH key name, or omitted (use the default SSH key name)
 	// +optional
@@ -120,6 +130,73 @@ type AWSLaunchTemplate struct {
 	// InstanceMetadataOptions defines the behavior for applying metadata to instances.
 	// +optional
 	InstanceMetadataOptions *infrav1.InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
+
+	// Tenancy indicates if instances should run on shared or single-tenant hardware.
+	// +optional
+	// +kubebuilder:validation:Enum:=default;dedicated;host
+	Tenancy string `json:"tenancy,omitempty"`
+
+	// HostID specifies the ID of the Dedicated Host on which instances should be launched.
+	// Only valid when Tenancy is set to host. Cannot be set together with HostResourceGroupArn.
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+
+	// HostAffinity specifies the Dedicated Host affinity setting for instances.
+	// Only valid when Tenancy is set to host.
+	// +optional
+	// +kubebuilder:validation:Enum:=default;host
+	HostAffinity *string `json:"hostAffinity,omitempty"`
+
+	// HostResourceGroupArn is the ARN of the host resource group in which to launch instances.
+	// Only valid when Tenancy is set to host. Cannot be set together with HostID.
+	// +optional
+	HostResourceGroupArn *string `json:"hostResourceGroupArn,omitempty"`
+
+	// CPUOptions defines the number of CPU cores and threads per core of the instances.
+	// When omitted, the defaults of the instance type are used.
+	// +optional
+	CPUOptions *infrav1.CPUOptions `json:"cpuOptions,omitempty"`
+
+	// NitroEnclaveEnabled enables the instances for AWS Nitro Enclaves.
+	// Cannot be set together with HibernationEnabled.
+	// +optional
+	NitroEnclaveEnabled bool `json:"nitroEnclaveEnabled,omitempty"`
+
+	// HibernationEnabled configures the instances for hibernation.
+	// Hibernation requires an encrypted root volume large enough to store the instance memory.
+	// Cannot be set together with NitroEnclaveEnabled.
+	// +optional
+	HibernationEnabled bool `json:"hibernationEnabled,omitempty"`
+
+	// PrivateDNSName is the hostname configuration of the instances.
+	// When omitted, the defaults of the subnet are used.
+	// +optional
+	PrivateDNSName *infrav1.PrivateDNSName `json:"privateDnsName,omitempty"`
+
+	// ManagedNetworkInterfaces is a list of additional ENIs to create for each instance.
+	// They are created when an instance is launched and deleted when it is terminated.
+	// The subnet of the network interfaces cannot be set, Auto Scaling places them in the subnet of the instance.
+	// +optional
+	// +listType=map
+	// +listMapKey=deviceIndex
+	ManagedNetworkInterfaces []infrav1.ManagedNetworkInterface `json:"managedNetworkInterfaces,omitempty"`
+
+	// NetworkInterfaceType is the type of the primary network interface of the instances.
+	// Set it to efa to attach an Elastic Fabric Adapter, which requires an instance type supporting EFA.
+	// +kubebuilder:validation:Enum:=interface;efa
+	// +optional
+	NetworkInterfaceType infrav1.NetworkInterfaceType `json:"networkInterfaceType,omitempty"`
+
+	// PlacementGroupName is the name of the placement group in which to launch the instances.
+	// +optional
+	PlacementGroupName string `json:"placementGroupName,omitempty"`
+
+	// PlacementGroupPartition is the number of the partition in which to launch the instances.
+	// Only valid when PlacementGroupName refers to a placement group with the partition strategy.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=7
+	// +optional
+	PlacementGroupPartition int64 `json:"placementGroupPartition,omitempty"`
 }
 
 // Overrides are used to override the instance type specified by the launch template with multiple
@@ -187,6 +264,145 @@ type InstancesDistribution struct {
 type MixedInstancesPolicy struct {
 	InstancesDistribution *InstancesDistribution `json:"instancesDistribution,omitempty"`
 	Overrides             []Overrides            `json:"overrides,omitempty"`
+
+	// InstanceRequirements are the attributes the instance types of the ASG must have. The instance types
+	// matching them are used instead of the instance types of Overrides, which cannot be set together with
+	// InstanceRequirements.
+	// +optional
+	InstanceRequirements *InstanceRequirements `json:"instanceRequirements,omitempty"`
+}
+
+// CPUManufacturer is a manufacturer of the CPUs of an instance type.
+// +kubebuilder:validation:Enum=intel;amd;amazon-web-services
+type CPUManufacturer string
+
+var (
+	// CPUManufacturerIntel is Intel.
+	CPUManufacturerIntel = CPUManufacturer("intel")
+
+	// CPUManufacturerAMD is AMD.
+	CPUManufacturerAMD = CPUManufacturer("amd")
+
+	// CPUManufacturerAmazonWebServices is Amazon Web Services, whose Graviton CPUs have the arm64 architecture.
+	CPUManufacturerAmazonWebServices = CPUManufacturer("amazon-web-services")
+)
+
+// AcceleratorType is a type of accelerator of an instance type.
+// +kubebuilder:validation:Enum=gpu;fpga;inference
+type AcceleratorType string
+
+var (
+	// AcceleratorTypeGPU is a GPU.
+	AcceleratorTypeGPU = AcceleratorType("gpu")
+
+	// AcceleratorTypeFPGA is an FPGA.
+	AcceleratorTypeFPGA = AcceleratorType("fpga")
+
+	// AcceleratorTypeInference is an inference accelerator.
+	AcceleratorTypeInference = AcceleratorType("inference")
+)
+
+// InstanceGeneration is a generation of instance types.
+// +kubebuilder:validation:Enum=current;previous
+type InstanceGeneration string
+
+var (
+	// InstanceGenerationCurrent is the current generation of instance types.
+	InstanceGenerationCurrent = InstanceGeneration("current")
+
+	// InstanceGenerationPrevious is the previous generation of instance types.
+	InstanceGenerationPrevious = InstanceGeneration("previous")
+)
+
+// InstanceRequirementInclusion defines whether instance types with an attribute are matched.
+type InstanceRequirementInclusion string
+
+var (
+	// InstanceRequirementIncluded matches instance types with and without the attribute.
+	InstanceRequirementIncluded = InstanceRequirementInclusion("included")
+
+	// InstanceRequirementExcluded matches instance types without the attribute.
+	InstanceRequirementExcluded = InstanceRequirementInclusion("excluded")
+
+	// InstanceRequirementRequired matches instance types with the attribute.
+	InstanceRequirementRequired = InstanceRequirementInclusion("required")
+)
+
+// InstanceRequirementsRange is a range of values of an attribute of instance types.
+type InstanceRequirementsRange struct {
+	// Min is the minimum value of the attribute.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	Min int64 `json:"min,omitempty"`
+
+	// Max is the maximum value of the attribute. When omitted, there is no maximum.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	Max *int64 `json:"max,omitempty"`
+}
+
+// InstanceRequirements are the attributes EC2 matches instance types against.
+// See https://docs.aws.amazon.com/autoscaling/ec2/userguide/create-mixed-instances-group-attribute-based-instance-type-selection.html
+type InstanceRequirements struct {
+	// VCPUCount is the range of the number of vCPUs of the instance types.
+	VCPUCount InstanceRequirementsRange `json:"vCPUCount"`
+
+	// MemoryMiB is the range of the memory of the instance types, in MiB.
+	MemoryMiB InstanceRequirementsRange `json:"memoryMiB"`
+
+	// CPUManufacturers are the manufacturers of the CPUs of the instance types. When omitted, CPUs of any
+	// manufacturer are matched.
+	// +optional
+	CPUManufacturers []CPUManufacturer `json:"cpuManufacturers,omitempty"`
+
+	// AcceleratorTypes are the types of accelerators of the instance types. When omitted, instance types with
+	// any accelerator are matched.
+	// +optional
+	AcceleratorTypes []AcceleratorType `json:"acceleratorTypes,omitempty"`
+
+	// AcceleratorCount is the range of the number of accelerators of the instance types. Set its max to 0 to
+	// exclude instance types with accelerators.
+	// +optional
+	AcceleratorCount *InstanceRequirementsRange `json:"acceleratorCount,omitempty"`
+
+	// BurstablePerformance defines whether burstable performance instance types are matched.
+	// When omitted, they are excluded.
+	// +kubebuilder:validation:Enum:=included;excluded;required
+	// +optional
+	BurstablePerformance InstanceRequirementInclusion `json:"burstablePerformance,omitempty"`
+
+	// BareMetal defines whether bare metal instance types are matched. When omitted, they are excluded.
+	// +kubebuilder:validation:Enum:=included;excluded;required
+	// +optional
+	BareMetal InstanceRequirementInclusion `json:"bareMetal,omitempty"`
+
+	// InstanceGenerations are the generations of the instance types. When omitted, instance types of any
+	// generation are matched.
+	// +optional
+	InstanceGenerations []InstanceGeneration `json:"instanceGenerations,omitempty"`
+
+	// OnDemandMaxPricePercentageOverLowestPrice is the price protection threshold of On-Demand Instances, as a
+	// percentage over the price of the cheapest matched instance type. When omitted, it defaults to 20.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	OnDemandMaxPricePercentageOverLowestPrice *int64 `json:"onDemandMaxPricePercentageOverLowestPrice,omitempty"`
+
+	// SpotMaxPricePercentageOverLowestPrice is the price protection threshold of Spot Instances, as a
+	// percentage over the price of the cheapest matched instance type. When omitted, it defaults to 100.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	SpotMaxPricePercentageOverLowestPrice *int64 `json:"spotMaxPricePercentageOverLowestPrice,omitempty"`
+
+	// AllowedInstanceTypes restricts the matched instance types to these ones. Wildcards are accepted,
+	// for example m5.*. Cannot be set together with ExcludedInstanceTypes.
+	// +kubebuilder:validation:MaxItems:=400
+	// +optional
+	AllowedInstanceTypes []string `json:"allowedInstanceTypes,omitempty"`
+
+	// ExcludedInstanceTypes are instance types never matched. Wildcards are accepted, for example r6*.
+	// +kubebuilder:validation:MaxItems:=400
+	// +optional
+	ExcludedInstanceTypes []string `json:"excludedInstanceTypes,omitempty"`
 }
 
 // Tags is a mapping for tags.
@@ -210,6 +426,14 @@ type AutoScalingGroup struct {
 	Status                    ASGStatus
 	Instances                 []infrav1.Instance `json:"instances,omitempty"`
 	CurrentlySuspendProcesses []string           `json:"currentlySuspendProcesses,omitempty"`
+	WarmPool                  *WarmPool          `json:"warmPool,omitempty"`
+	WarmPoolStatus            *WarmPoolStatus    `json:"warmPoolStatus,omitempty"`
+	LifecycleHooks            []AWSLifecycleHook `json:"lifecycleHooks,omitempty"`
+	TargetGroupARNs           []string           `json:"targetGroupARNs,omitempty"`
+	LoadBalancerNames         []string           `json:"loadBalancerNames,omitempty"`
+	HealthCheckType           ASGHealthCheckType `json:"healthCheckType,omitempty"`
+	HealthCheckGracePeriod    *metav1.Duration   `json:"healthCheckGracePeriod,omitempty"`
+	LaunchTemplateVersion     string             `json:"launchTemplateVersion,omitempty"`
 }
 
 // ASGStatus is a status string returned by the autoscaling API.
@@ -218,6 +442,332 @@ type ASGStatus string
 // ASGStatusDeleteInProgress is the string representing an ASG that is currently deleting.
 var ASGStatusDeleteInProgress = ASGStatus("Delete in progress")
 
+// ASGHealthCheckType is the type of health check an ASG uses to determine whether its instances are healthy.
+type ASGHealthCheckType string
+
+const (
+	// ASGHealthCheckTypeEC2 only considers the EC2 status checks of the instances.
+	ASGHealthCheckTypeEC2 = ASGHealthCheckType("EC2")
+
+	// ASGHealthCheckTypeELB considers the health checks of the load balancers the ASG is attached to in
+	// addition to the EC2 status checks.
+	ASGHealthCheckTypeELB = ASGHealthCheckType("ELB")
+)
+
+// WarmPoolState is the state instances of a warm pool are kept in until they enter service.
+type WarmPoolState string
+
+const (
+	// WarmPoolStateStopped keeps the instances of the warm pool stopped, only their volumes are charged.
+	WarmPoolStateStopped = WarmPoolState("Stopped")
+
+	// WarmPoolStateRunning keeps the instances of the warm pool running.
+	WarmPoolStateRunning = WarmPoolState("Running")
+
+	// WarmPoolStateHibernated keeps the instances of the warm pool hibernated, preserving their memory. The
+	// launch template must enable hibernation.
+	WarmPoolStateHibernated = WarmPoolState("Hibernated")
+)
+
+// WarmPool describes the warm pool of an ASG.
+type WarmPool struct {
+	// MinSize is the minimum number of instances to maintain in the warm pool.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinSize *int32 `json:"minSize,omitempty"`
+
+	// MaxGroupPreparedCapacity is the maximum number of instances allowed to be in the warm pool and in service
+	// together. When unset, the warm pool holds the difference between the maximum size of the ASG and its
+	// desired capacity.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxGroupPreparedCapacity *int32 `json:"maxGroupPreparedCapacity,omitempty"`
+
+	// PoolState is the state instances are kept in while they are in the warm pool.
+	// +kubebuilder:validation:Enum=Stopped;Running;Hibernated
+	// +kubebuilder:default=Stopped
+	// +optional
+	PoolState WarmPoolState `json:"poolState,omitempty"`
+
+	// ReuseOnScaleIn returns instances to the warm pool when the ASG scales in, instead of terminating them.
+	// +optional
+	ReuseOnScaleIn bool `json:"reuseOnScaleIn,omitempty"`
+}
+
+// WarmPoolStatus describes the observed state of the warm pool of an ASG.
+type WarmPoolStatus struct {
+	// Size is the number of instances in the warm pool.
+	// +optional
+	Size int32 `json:"size"`
+
+	// Status is set to PendingDelete while the warm pool is being deleted.
+	// +optional
+	Status string `json:"status,omitempty"`
+}
+
+// AlarmSpecification describes the CloudWatch alarms which roll back an instance refresh of an ASG.
+type AlarmSpecification struct {
+	// AlarmARNs are the ARNs of the CloudWatch alarms, which must be in the region of the cluster.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	AlarmARNs []string `json:"alarmARNs"`
+}
+
+// InstanceRefreshStatus describes the observed state of an instance refresh of an ASG.
+type InstanceRefreshStatus struct {
+	// ID is the ID of the instance refresh.
+	ID string `json:"id"`
+
+	// Status is the status of the instance refresh: Pending, InProgress, Successful, Failed, Cancelling,
+	// Cancelled, RollbackInProgress, RollbackFailed or RollbackSuccessful.
+	Status string `json:"status"`
+
+	// StatusReason explains the status of the instance refresh.
+	// +optional
+	StatusReason string `json:"statusReason,omitempty"`
+
+	// PercentageComplete is the percentage of the instances of the ASG the instance refresh replaced.
+	// It goes back down to zero while the instance refresh is rolled back.
+	// +optional
+	PercentageComplete int64 `json:"percentageComplete"`
+
+	// InstancesToUpdate is the number of instances the instance refresh has yet to replace.
+	// +optional
+	InstancesToUpdate int64 `json:"instancesToUpdate"`
+
+	// LaunchTemplateVersion is the version of the launch template the instance refresh rolls out, when
+	// it rolls out an explicit version.
+	// +optional
+	LaunchTemplateVersion string `json:"launchTemplateVersion,omitempty"`
+}
+
+// LifecycleTransition is the state change of an instance paused by a lifecycle hook of an ASG.
+type LifecycleTransition string
+
+const (
+	// LifecycleTransitionInstanceLaunch pauses instances while they are launched.
+	LifecycleTransitionInstanceLaunch = LifecycleTransition("autoscaling:EC2_INSTANCE_LAUNCHING")
+
+	// LifecycleTransitionInstanceTerminate pauses instances while they are terminated.
+	LifecycleTransitionInstanceTerminate = LifecycleTransition("autoscaling:EC2_INSTANCE_TERMINATING")
+)
+
+// LifecycleHookDefaultResult is the action applied to an instance once the heartbeat timeout of the lifecycle hook
+// pausing it expires.
+type LifecycleHookDefaultResult string
+
+const (
+	// LifecycleHookDefaultResultContinue continues the launch or termination of the instance.
+	LifecycleHookDefaultResultContinue = LifecycleHookDefaultResult("CONTINUE")
+
+	// LifecycleHookDefaultResultAbandon terminates a launching instance, and terminates a terminating instance
+	// without running the other terminating lifecycle hooks.
+	LifecycleHookDefaultResultAbandon = LifecycleHookDefaultResult("ABANDON")
+)
+
+// AWSLifecycleHook describes a lifecycle hook of an ASG.
+type AWSLifecycleHook struct {
+	// Name is the name of the lifecycle hook.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	Name string `json:"name"`
+
+	// LifecycleTransition is the state change of the instances the lifecycle hook pauses.
+	// +kubebuilder:validation:Enum="autoscaling:EC2_INSTANCE_LAUNCHING";"autoscaling:EC2_INSTANCE_TERMINATING"
+	LifecycleTransition LifecycleTransition `json:"lifecycleTransition"`
+
+	// HeartbeatTimeout is the maximum time an instance stays paused before the default result is applied,
+	// between 30 seconds and 2 hours. Defaults to 1 hour.
+	// +optional
+	HeartbeatTimeout *metav1.Duration `json:"heartbeatTimeout,omitempty"`
+
+	// DefaultResult is the action applied to the instance once the heartbeat timeout expires. Defaults to ABANDON.
+	// +kubebuilder:validation:Enum=CONTINUE;ABANDON
+	// +optional
+	DefaultResult *LifecycleHookDefaultResult `json:"defaultResult,omitempty"`
+
+	// NotificationTargetARN is the ARN of the SNS topic or SQS queue notified when an instance is paused.
+	// +optional
+	NotificationTargetARN *string `json:"notificationTargetARN,omitempty"`
+
+	// RoleARN is the ARN of the IAM role that allows the ASG to publish to the notification target.
+	// It is required when NotificationTargetARN is set.
+	// +optional
+	RoleARN *string `json:"roleARN,omitempty"`
+
+	// NotificationMetadata is additional information included in the notifications sent to the notification target.
+	// +kubebuilder:validation:MaxLength=1023
+	// +optional
+	NotificationMetadata *string `json:"notificationMetadata,omitempty"`
+}
+
+// NodeDrainLifecycleHook describes the terminating lifecycle hook that pauses instances of an ASG until their node
+// is drained.
+type NodeDrainLifecycleHook struct {
+	// HeartbeatTimeout is the maximum time allowed to drain the node of a terminating instance, between 30 seconds
+	// and 2 hours. The instance is terminated once it expires, whether the node is drained or not. Defaults to
+	// 10 minutes.
+	// +optional
+	HeartbeatTimeout *metav1.Duration `json:"heartbeatTimeout,omitempty"`
+}
+
+// PredefinedMetricType is a metric of an ASG that a target tracking scaling policy can track.
+type PredefinedMetricType string
+
+const (
+	// PredefinedMetricTypeASGAverageCPUUtilization is the average CPU utilization of the instances of the ASG.
+	PredefinedMetricTypeASGAverageCPUUtilization = PredefinedMetricType("ASGAverageCPUUtilization")
+
+	// PredefinedMetricTypeASGAverageNetworkIn is the average number of bytes received by the instances of the ASG.
+	PredefinedMetricTypeASGAverageNetworkIn = PredefinedMetricType("ASGAverageNetworkIn")
+
+	// PredefinedMetricTypeASGAverageNetworkOut is the average number of bytes sent by the instances of the ASG.
+	PredefinedMetricTypeASGAverageNetworkOut = PredefinedMetricType("ASGAverageNetworkOut")
+
+	// PredefinedMetricTypeALBRequestCountPerTarget is the number of requests completed per instance of the ASG
+	// in an application load balancer target group.
+	PredefinedMetricTypeALBRequestCountPerTarget = PredefinedMetricType("ALBRequestCountPerTarget")
+)
+
+// MetricStatistic is the statistic of a CloudWatch metric.
+type MetricStatistic string
+
+const (
+	// MetricStatisticAverage is the average of the values of the metric.
+	MetricStatisticAverage = MetricStatistic("Average")
+
+	// MetricStatisticMinimum is the lowest value of the metric.
+	MetricStatisticMinimum = MetricStatistic("Minimum")
+
+	// MetricStatisticMaximum is the highest value of the metric.
+	MetricStatisticMaximum = MetricStatistic("Maximum")
+
+	// MetricStatisticSampleCount is the number of values of the metric.
+	MetricStatisticSampleCount = MetricStatistic("SampleCount")
+
+	// MetricStatisticSum is the sum of the values of the metric.
+	MetricStatisticSum = MetricStatistic("Sum")
+)
+
+// AWSScalingPolicy describes a target tracking scaling policy of an ASG, which adjusts its desired capacity to
+// keep a metric at a target value.
+type AWSScalingPolicy struct {
+	// Name is the name of the scaling policy.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	Name string `json:"name"`
+
+	// TargetValue is the value of the metric the scaling policy keeps the ASG at.
+	TargetValue resource.Quantity `json:"targetValue"`
+
+	// PredefinedMetric is the predefined metric tracked by the scaling policy. Exactly one of PredefinedMetric
+	// and CustomizedMetric must be set.
+	// +optional
+	PredefinedMetric *PredefinedMetric `json:"predefinedMetric,omitempty"`
+
+	// CustomizedMetric is the CloudWatch metric tracked by the scaling policy. Exactly one of PredefinedMetric
+	// and CustomizedMetric must be set.
+	// +optional
+	CustomizedMetric *CustomizedMetric `json:"customizedMetric,omitempty"`
+
+	// DisableScaleIn prevents the scaling policy from removing instances from the ASG.
+	// +optional
+	DisableScaleIn bool `json:"disableScaleIn,omitempty"`
+
+	// EstimatedInstanceWarmup is the time after which a newly launched instance contributes to the metric.
+	// Defaults to the default cooldown of the ASG.
+	// +optional
+	EstimatedInstanceWarmup *metav1.Duration `json:"estimatedInstanceWarmup,omitempty"`
+}
+
+// PredefinedMetric describes a predefined metric of an ASG.
+type PredefinedMetric struct {
+	// Type is the type of the metric.
+	// +kubebuilder:validation:Enum=ASGAverageCPUUtilization;ASGAverageNetworkIn;ASGAverageNetworkOut;ALBRequestCountPerTarget
+	Type PredefinedMetricType `json:"type"`
+
+	// ResourceLabel identifies the target group of the ALBRequestCountPerTarget metric, in the format
+	// app/<load-balancer-name>/<load-balancer-id>/targetgroup/<target-group-name>/<target-group-id>. It is
+	// required for that metric only.
+	// +kubebuilder:validation:MaxLength=1023
+	// +optional
+	ResourceLabel *string `json:"resourceLabel,omitempty"`
+}
+
+// CustomizedMetric describes a CloudWatch metric tracked by a scaling policy. The metric must change in
+// proportion to the number of instances of the ASG.
+type CustomizedMetric struct {
+	// MetricName is the name of the metric.
+	// +kubebuilder:validation:MinLength=1
+	MetricName string `json:"metricName"`
+
+	// Namespace is the namespace of the metric.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Dimensions are the dimensions of the metric.
+	// +optional
+	Dimensions []MetricDimension `json:"dimensions,omitempty"`
+
+	// Statistic is the statistic of the metric tracked by the scaling policy.
+	// +kubebuilder:validation:Enum=Average;Minimum;Maximum;SampleCount;Sum
+	Statistic MetricStatistic `json:"statistic"`
+
+	// Unit is the unit of the metric, for example Percent or Count.
+	// +optional
+	Unit *string `json:"unit,omitempty"`
+}
+
+// MetricDimension is a dimension of a CloudWatch metric.
+type MetricDimension struct {
+	// Name is the name of the dimension.
+	Name string `json:"name"`
+
+	// Value is the value of the dimension.
+	Value string `json:"value"`
+}
+
+// AWSScheduledAction describes a scheduled action of an ASG, which changes its size at a given time.
+type AWSScheduledAction struct {
+	// Name is the name of the scheduled action.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	Name string `json:"name"`
+
+	// Recurrence is the cron expression of the times the scheduled action recurs at, for example "0 8 * * 1-5".
+	// The times are in UTC unless TimeZone is set. At least one of Recurrence and StartTime must be set.
+	// +optional
+	Recurrence *string `json:"recurrence,omitempty"`
+
+	// StartTime is the time the scheduled action runs at, or starts recurring at when Recurrence is set.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the time a recurring scheduled action stops recurring at.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// TimeZone is the IANA time zone of Recurrence, for example Europe/Paris.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// MinSize is the minimum size the scheduled action sets on the ASG.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinSize *int32 `json:"minSize,omitempty"`
+
+	// MaxSize is the maximum size the scheduled action sets on the ASG.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxSize *int32 `json:"maxSize,omitempty"`
+
+	// DesiredCapacity is the desired capacity the scheduled action sets on the ASG. At least one of MinSize,
+	// MaxSize and DesiredCapacity must be set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DesiredCapacity *int32 `json:"desiredCapacity,omitempty"`
+}
+
 // TaintEffect is the effect for a Kubernetes taint.
 type TaintEffect string
 
@@ -22,6 +22,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apiv1beta2 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
@@ -91,11 +92,23 @@ func (in *AWSFargateProfileList) DeepCopyObject() runtime.Object {
 func (in *AWSLaunchTemplate) DeepCopyInto(out *AWSLaunchTemplate) {
 	*out = *in
 	in.AMI.DeepCopyInto(&out.AMI)
+	if in.InstanceRequirements != nil {
+		in, out := &in.InstanceRequirements, &out.InstanceRequirements
+		*out = new(InstanceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RootVolume != nil {
 		in, out := &in.RootVolume, &out.RootVolume
 		*out = new(apiv1beta2.Volume)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NonRootVolumes != nil {
+		in, out := &in.NonRootVolumes, &out.NonRootVolumes
+		*out = make([]apiv1beta2.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SSHKeyName != nil {
 		in, out := &in.SSHKeyName, &out.SSHKeyName
 		*out = new(string)
@@ -123,6 +136,38 @@ func (in *AWSLaunchTemplate) DeepCopyInto(out *AWSLaunchTemplate) {
 		*out = new(apiv1beta2.InstanceMetadataOptions)
 		**out = **in
 	}
+	if in.HostID != nil {
+		in, out := &in.HostID, &out.HostID
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostAffinity != nil {
+		in, out := &in.HostAffinity, &out.HostAffinity
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostResourceGroupArn != nil {
+		in, out := &in.HostResourceGroupArn, &out.HostResourceGroupArn
+		*out = new(string)
+		**out = **in
+	}
+	if in.CPUOptions != nil {
+		in, out := &in.CPUOptions, &out.CPUOptions
+		*out = new(apiv1beta2.CPUOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateDNSName != nil {
+		in, out := &in.PrivateDNSName, &out.PrivateDNSName
+		*out = new(apiv1beta2.PrivateDNSName)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagedNetworkInterfaces != nil {
+		in, out := &in.ManagedNetworkInterfaces, &out.ManagedNetworkInterfaces
+		*out = make([]apiv1beta2.ManagedNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLaunchTemplate.
@@ -135,6 +180,46 @@ func (in *AWSLaunchTemplate) DeepCopy() *AWSLaunchTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSLifecycleHook) DeepCopyInto(out *AWSLifecycleHook) {
+	*out = *in
+	if in.HeartbeatTimeout != nil {
+		in, out := &in.HeartbeatTimeout, &out.HeartbeatTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DefaultResult != nil {
+		in, out := &in.DefaultResult, &out.DefaultResult
+		*out = new(LifecycleHookDefaultResult)
+		**out = **in
+	}
+	if in.NotificationTargetARN != nil {
+		in, out := &in.NotificationTargetARN, &out.NotificationTargetARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.RoleARN != nil {
+		in, out := &in.RoleARN, &out.RoleARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.NotificationMetadata != nil {
+		in, out := &in.NotificationMetadata, &out.NotificationMetadata
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLifecycleHook.
+func (in *AWSLifecycleHook) DeepCopy() *AWSLifecycleHook {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSLifecycleHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSMachinePool) DeepCopyInto(out *AWSMachinePool) {
 	*out = *in
@@ -242,6 +327,11 @@ func (in *AWSMachinePoolSpec) DeepCopyInto(out *AWSMachinePoolSpec) {
 		}
 	}
 	in.AWSLaunchTemplate.DeepCopyInto(&out.AWSLaunchTemplate)
+	if in.LaunchTemplateRef != nil {
+		in, out := &in.LaunchTemplateRef, &out.LaunchTemplateRef
+		*out = new(apiv1beta2.LaunchTemplateReference)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MixedInstancesPolicy != nil {
 		in, out := &in.MixedInstancesPolicy, &out.MixedInstancesPolicy
 		*out = new(MixedInstancesPolicy)
@@ -263,6 +353,57 @@ func (in *AWSMachinePoolSpec) DeepCopyInto(out *AWSMachinePoolSpec) {
 		*out = new(SuspendProcessesTypes)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WarmPool != nil {
+		in, out := &in.WarmPool, &out.WarmPool
+		*out = new(WarmPool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LifecycleHooks != nil {
+		in, out := &in.LifecycleHooks, &out.LifecycleHooks
+		*out = make([]AWSLifecycleHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeDrainLifecycleHook != nil {
+		in, out := &in.NodeDrainLifecycleHook, &out.NodeDrainLifecycleHook
+		*out = new(NodeDrainLifecycleHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetGroupARNs != nil {
+		in, out := &in.TargetGroupARNs, &out.TargetGroupARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LoadBalancerNames != nil {
+		in, out := &in.LoadBalancerNames, &out.LoadBalancerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthCheckType != nil {
+		in, out := &in.HealthCheckType, &out.HealthCheckType
+		*out = new(ASGHealthCheckType)
+		**out = **in
+	}
+	if in.HealthCheckGracePeriod != nil {
+		in, out := &in.HealthCheckGracePeriod, &out.HealthCheckGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ScalingPolicies != nil {
+		in, out := &in.ScalingPolicies, &out.ScalingPolicies
+		*out = make([]AWSScalingPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScheduledActions != nil {
+		in, out := &in.ScheduledActions, &out.ScheduledActions
+		*out = make([]AWSScheduledAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSMachinePoolSpec.
@@ -297,6 +438,11 @@ func (in *AWSMachinePoolStatus) DeepCopyInto(out *AWSMachinePoolStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MatchedInstanceTypes != nil {
+		in, out := &in.MatchedInstanceTypes, &out.MatchedInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -312,6 +458,16 @@ func (in *AWSMachinePoolStatus) DeepCopyInto(out *AWSMachinePoolStatus) {
 		*out = new(ASGStatus)
 		**out = **in
 	}
+	if in.WarmPoolStatus != nil {
+		in, out := &in.WarmPoolStatus, &out.WarmPoolStatus
+		*out = new(WarmPoolStatus)
+		**out = **in
+	}
+	if in.InstanceRefresh != nil {
+		in, out := &in.InstanceRefresh, &out.InstanceRefresh
+		*out = new(InstanceRefreshStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSMachinePoolStatus.
@@ -475,6 +631,11 @@ func (in *AWSManagedMachinePoolSpec) DeepCopyInto(out *AWSManagedMachinePoolSpec
 		*out = new(AWSLaunchTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LaunchTemplateRef != nil {
+		in, out := &in.LaunchTemplateRef, &out.LaunchTemplateRef
+		*out = new(apiv1beta2.LaunchTemplateReference)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSManagedMachinePoolSpec.
@@ -500,6 +661,11 @@ func (in *AWSManagedMachinePoolStatus) DeepCopyInto(out *AWSManagedMachinePoolSt
 		*out = new(string)
 		**out = **in
 	}
+	if in.ImageID != nil {
+		in, out := &in.ImageID, &out.ImageID
+		*out = new(string)
+		**out = **in
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -529,6 +695,105 @@ func (in *AWSManagedMachinePoolStatus) DeepCopy() *AWSManagedMachinePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSScalingPolicy) DeepCopyInto(out *AWSScalingPolicy) {
+	*out = *in
+	out.TargetValue = in.TargetValue.DeepCopy()
+	if in.PredefinedMetric != nil {
+		in, out := &in.PredefinedMetric, &out.PredefinedMetric
+		*out = new(PredefinedMetric)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomizedMetric != nil {
+		in, out := &in.CustomizedMetric, &out.CustomizedMetric
+		*out = new(CustomizedMetric)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EstimatedInstanceWarmup != nil {
+		in, out := &in.EstimatedInstanceWarmup, &out.EstimatedInstanceWarmup
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSScalingPolicy.
+func (in *AWSScalingPolicy) DeepCopy() *AWSScalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSScalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSScheduledAction) DeepCopyInto(out *AWSScheduledAction) {
+	*out = *in
+	if in.Recurrence != nil {
+		in, out := &in.Recurrence, &out.Recurrence
+		*out = new(string)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DesiredCapacity != nil {
+		in, out := &in.DesiredCapacity, &out.DesiredCapacity
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSScheduledAction.
+func (in *AWSScheduledAction) DeepCopy() *AWSScheduledAction {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSScheduledAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlarmSpecification) DeepCopyInto(out *AlarmSpecification) {
+	*out = *in
+	if in.AlarmARNs != nil {
+		in, out := &in.AlarmARNs, &out.AlarmARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlarmSpecification.
+func (in *AlarmSpecification) DeepCopy() *AlarmSpecification {
+	if in == nil {
+		return nil
+	}
+	out := new(AlarmSpecification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoScalingGroup) DeepCopyInto(out *AutoScalingGroup) {
 	*out = *in
@@ -567,6 +832,38 @@ func (in *AutoScalingGroup) DeepCopyInto(out *AutoScalingGroup) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.WarmPool != nil {
+		in, out := &in.WarmPool, &out.WarmPool
+		*out = new(WarmPool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WarmPoolStatus != nil {
+		in, out := &in.WarmPoolStatus, &out.WarmPoolStatus
+		*out = new(WarmPoolStatus)
+		**out = **in
+	}
+	if in.LifecycleHooks != nil {
+		in, out := &in.LifecycleHooks, &out.LifecycleHooks
+		*out = make([]AWSLifecycleHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TargetGroupARNs != nil {
+		in, out := &in.TargetGroupARNs, &out.TargetGroupARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LoadBalancerNames != nil {
+		in, out := &in.LoadBalancerNames, &out.LoadBalancerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthCheckGracePeriod != nil {
+		in, out := &in.HealthCheckGracePeriod, &out.HealthCheckGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoScalingGroup.
@@ -595,6 +892,31 @@ func (in *BlockDeviceMapping) DeepCopy() *BlockDeviceMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomizedMetric) DeepCopyInto(out *CustomizedMetric) {
+	*out = *in
+	if in.Dimensions != nil {
+		in, out := &in.Dimensions, &out.Dimensions
+		*out = make([]MetricDimension, len(*in))
+		copy(*out, *in)
+	}
+	if in.Unit != nil {
+		in, out := &in.Unit, &out.Unit
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomizedMetric.
+func (in *CustomizedMetric) DeepCopy() *CustomizedMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomizedMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EBS) DeepCopyInto(out *EBS) {
 	*out = *in
@@ -698,6 +1020,98 @@ func (in *FargateSelector) DeepCopy() *FargateSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceRefreshStatus) DeepCopyInto(out *InstanceRefreshStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceRefreshStatus.
+func (in *InstanceRefreshStatus) DeepCopy() *InstanceRefreshStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceRefreshStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceRequirements) DeepCopyInto(out *InstanceRequirements) {
+	*out = *in
+	in.VCPUCount.DeepCopyInto(&out.VCPUCount)
+	in.MemoryMiB.DeepCopyInto(&out.MemoryMiB)
+	if in.CPUManufacturers != nil {
+		in, out := &in.CPUManufacturers, &out.CPUManufacturers
+		*out = make([]CPUManufacturer, len(*in))
+		copy(*out, *in)
+	}
+	if in.AcceleratorTypes != nil {
+		in, out := &in.AcceleratorTypes, &out.AcceleratorTypes
+		*out = make([]AcceleratorType, len(*in))
+		copy(*out, *in)
+	}
+	if in.AcceleratorCount != nil {
+		in, out := &in.AcceleratorCount, &out.AcceleratorCount
+		*out = new(InstanceRequirementsRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstanceGenerations != nil {
+		in, out := &in.InstanceGenerations, &out.InstanceGenerations
+		*out = make([]InstanceGeneration, len(*in))
+		copy(*out, *in)
+	}
+	if in.OnDemandMaxPricePercentageOverLowestPrice != nil {
+		in, out := &in.OnDemandMaxPricePercentageOverLowestPrice, &out.OnDemandMaxPricePercentageOverLowestPrice
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SpotMaxPricePercentageOverLowestPrice != nil {
+		in, out := &in.SpotMaxPricePercentageOverLowestPrice, &out.SpotMaxPricePercentageOverLowestPrice
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AllowedInstanceTypes != nil {
+		in, out := &in.AllowedInstanceTypes, &out.AllowedInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedInstanceTypes != nil {
+		in, out := &in.ExcludedInstanceTypes, &out.ExcludedInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceRequirements.
+func (in *InstanceRequirements) DeepCopy() *InstanceRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceRequirementsRange) DeepCopyInto(out *InstanceRequirementsRange) {
+	*out = *in
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceRequirementsRange.
+func (in *InstanceRequirementsRange) DeepCopy() *InstanceRequirementsRange {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceRequirementsRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstancesDistribution) DeepCopyInto(out *InstancesDistribution) {
 	*out = *in
@@ -773,6 +1187,21 @@ func (in *ManagedRemoteAccess) DeepCopy() *ManagedRemoteAccess {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricDimension) DeepCopyInto(out *MetricDimension) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricDimension.
+func (in *MetricDimension) DeepCopy() *MetricDimension {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricDimension)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MixedInstancesPolicy) DeepCopyInto(out *MixedInstancesPolicy) {
 	*out = *in
@@ -786,6 +1215,11 @@ func (in *MixedInstancesPolicy) DeepCopyInto(out *MixedInstancesPolicy) {
 		*out = make([]Overrides, len(*in))
 		copy(*out, *in)
 	}
+	if in.InstanceRequirements != nil {
+		in, out := &in.InstanceRequirements, &out.InstanceRequirements
+		*out = new(InstanceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MixedInstancesPolicy.
@@ -798,6 +1232,26 @@ func (in *MixedInstancesPolicy) DeepCopy() *MixedInstancesPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainLifecycleHook) DeepCopyInto(out *NodeDrainLifecycleHook) {
+	*out = *in
+	if in.HeartbeatTimeout != nil {
+		in, out := &in.HeartbeatTimeout, &out.HeartbeatTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainLifecycleHook.
+func (in *NodeDrainLifecycleHook) DeepCopy() *NodeDrainLifecycleHook {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainLifecycleHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Overrides) DeepCopyInto(out *Overrides) {
 	*out = *in
@@ -813,6 +1267,26 @@ func (in *Overrides) DeepCopy() *Overrides {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PredefinedMetric) DeepCopyInto(out *PredefinedMetric) {
+	*out = *in
+	if in.ResourceLabel != nil {
+		in, out := &in.ResourceLabel, &out.ResourceLabel
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredefinedMetric.
+func (in *PredefinedMetric) DeepCopy() *PredefinedMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(PredefinedMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Processes) DeepCopyInto(out *Processes) {
 	*out = *in
@@ -988,6 +1462,31 @@ func (in *RefreshPreferences) DeepCopyInto(out *RefreshPreferences) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.CheckpointPercentages != nil {
+		in, out := &in.CheckpointPercentages, &out.CheckpointPercentages
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	if in.CheckpointDelay != nil {
+		in, out := &in.CheckpointDelay, &out.CheckpointDelay
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SkipMatching != nil {
+		in, out := &in.SkipMatching, &out.SkipMatching
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AutoRollback != nil {
+		in, out := &in.AutoRollback, &out.AutoRollback
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AlarmSpecification != nil {
+		in, out := &in.AlarmSpecification, &out.AlarmSpecification
+		*out = new(AlarmSpecification)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefreshPreferences.
@@ -1099,3 +1598,43 @@ func (in *UpdateConfig) DeepCopy() *UpdateConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmPool) DeepCopyInto(out *WarmPool) {
+	*out = *in
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxGroupPreparedCapacity != nil {
+		in, out := &in.MaxGroupPreparedCapacity, &out.MaxGroupPreparedCapacity
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmPool.
+func (in *WarmPool) DeepCopy() *WarmPool {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmPoolStatus) DeepCopyInto(out *WarmPoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmPoolStatus.
+func (in *WarmPoolStatus) DeepCopy() *WarmPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
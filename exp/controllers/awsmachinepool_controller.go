@@ -19,7 +19,10 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
@@ -27,9 +30,11 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -40,11 +45,13 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	asg "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -60,6 +67,7 @@ type AWSMachinePoolReconciler struct {
 	WatchFilterValue             string
 	asgServiceFactory            func(cloud.ClusterScoper) services.ASGInterface
 	ec2ServiceFactory            func(scope.EC2Scope) services.EC2Interface
+	workloadClientsetFactory     func(context.Context, *scope.MachinePoolScope) (kubernetes.Interface, error)
 	TagUnmanagedNetworkResources bool
 }
 
@@ -81,6 +89,8 @@ func (r *AWSMachinePoolReconciler) getEC2Service(scope scope.EC2Scope) services.
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
@@ -166,13 +176,13 @@ func (r *AWSMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			return ctrl.Result{}, r.reconcileDelete(machinePoolScope, infraScope, infraScope)
 		}
 
-		return ctrl.Result{}, r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
+		return r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
 	case *scope.ClusterScope:
 		if !awsMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
 			return ctrl.Result{}, r.reconcileDelete(machinePoolScope, infraScope, infraScope)
 		}
 
-		return ctrl.Result{}, r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
+		return r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
 	default:
 		return ctrl.Result{}, errors.New("infraCluster has unknown type")
 	}
@@ -186,11 +196,21 @@ func (r *AWSMachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctr
 			&expclusterv1.MachinePool{},
 			handler.EnqueueRequestsFromMapFunc(machinePoolToInfrastructureMapFunc(expinfrav1.GroupVersion.WithKind("AWSMachinePool"))),
 		).
+		Watches(
+			&infrav1.AWSCluster{},
+			handler.EnqueueRequestsFromMapFunc(awsClusterToAWSMachinePoolsMapFunc(r.Client, logger.FromContext(ctx))),
+			builder.WithPredicates(hibernationChanged()),
+		).
+		// The AWSMachines of the instances of the ASG are owned by the AWSMachinePool.
+		Watches(
+			&infrav1.AWSMachine{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &expinfrav1.AWSMachinePool{}),
+		).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(logger.FromContext(ctx).GetLogger(), r.WatchFilterValue)).
 		Complete(r)
 }
 
-func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) error {
+func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) (ctrl.Result, error) {
 	clusterScope.Info("Reconciling AWSMachinePool")
 
 	// If the AWSMachine is in an error state, return early.
@@ -199,28 +219,28 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 
 		// TODO: If we are in a failed state, delete the secret regardless of instance state
 
-		return nil
+		return ctrl.Result{}, nil
 	}
 
 	// If the AWSMachinepool doesn't have our finalizer, add it
 	if controllerutil.AddFinalizer(machinePoolScope.AWSMachinePool, expinfrav1.MachinePoolFinalizer) {
 		// Register finalizer immediately to avoid orphaning AWS resources
 		if err := machinePoolScope.PatchObject(); err != nil {
-			return err
+			return ctrl.Result{}, err
 		}
 	}
 
 	if !machinePoolScope.Cluster.Status.InfrastructureReady {
 		machinePoolScope.Info("Cluster infrastructure is not ready yet")
 		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, infrav1.WaitingForClusterInfrastructureReason, clusterv1.ConditionSeverityInfo, "")
-		return nil
+		return ctrl.Result{}, nil
 	}
 
 	// Make sure bootstrap data is available and populated
 	if machinePoolScope.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName == nil {
 		machinePoolScope.Info("Bootstrap data secret reference is not yet available")
 		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, "")
-		return nil
+		return ctrl.Result{}, nil
 	}
 
 	ec2Svc := r.getEC2Service(ec2Scope)
@@ -250,10 +270,16 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		machinePoolScope.Info("starting instance refresh", "number of instances", machinePoolScope.MachinePool.Spec.Replicas)
 		return asgsvc.StartASGInstanceRefresh(machinePoolScope)
 	}
-	if err := ec2Svc.ReconcileLaunchTemplate(machinePoolScope, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
+	if machinePoolScope.AWSMachinePool.Spec.LaunchTemplateRef != nil {
+		if err := r.reconcileLaunchTemplateRef(machinePoolScope, ec2Svc, asgsvc); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
+			machinePoolScope.Error(err, "failed to reconcile launch template reference")
+			return ctrl.Result{}, err
+		}
+	} else if err := ec2Svc.ReconcileLaunchTemplate(machinePoolScope, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
 		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
 		machinePoolScope.Error(err, "failed to reconcile launch template")
-		return err
+		return ctrl.Result{}, err
 	}
 
 	// set the LaunchTemplateReady condition
@@ -263,19 +289,41 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 	asg, err := r.findASG(machinePoolScope, asgsvc)
 	if err != nil {
 		conditions.MarkUnknown(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGNotFoundReason, err.Error())
-		return err
+		return ctrl.Result{}, err
 	}
 
 	if asg == nil {
 		// Create new ASG
 		if err := r.createPool(machinePoolScope, clusterScope); err != nil {
 			conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGProvisionFailedReason, clusterv1.ConditionSeverityError, err.Error())
-			return err
+			return ctrl.Result{}, err
 		}
-		return nil
+		return ctrl.Result{}, nil
+	}
+
+	// Instances of the warm pool are not part of the machine pool until they enter service.
+	instances := make([]infrav1.Instance, 0, len(asg.Instances))
+	for _, instance := range asg.Instances {
+		if !isWarmPoolInstance(instance) {
+			instances = append(instances, instance)
+		}
+	}
+
+	if err := r.reconcileMachinePoolMachines(ctx, machinePoolScope, instances); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile MachinePool machines")
+	}
+
+	// The ASG is scaled to zero and not updated any further while the cluster is hibernated.
+	if done, err := r.reconcileHibernation(machinePoolScope, asgsvc, asg); done || err != nil {
+		return ctrl.Result{}, err
 	}
 
-	if scope.ReplicasExternallyManaged(machinePoolScope.MachinePool) {
+	if err := r.reconcileInstanceRefresh(machinePoolScope, asgsvc, asg); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedInstanceRefreshReconcile", "Failed to reconcile instance refresh: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	if scope.ReplicasExternallyManaged(machinePoolScope.MachinePool) || machinePoolScope.ReplicasManagedByScalingPolicies() {
 		// Set MachinePool replicas to the ASG DesiredCapacity
 		if *machinePoolScope.MachinePool.Spec.Replicas != *asg.DesiredCapacity {
 			machinePoolScope.Info("Setting MachinePool replicas to ASG DesiredCapacity",
@@ -283,38 +331,53 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 				"external", asg.DesiredCapacity)
 			machinePoolScope.MachinePool.Spec.Replicas = asg.DesiredCapacity
 			if err := machinePoolScope.PatchCAPIMachinePoolObject(ctx); err != nil {
-				return err
+				return ctrl.Result{}, err
 			}
 		}
 	}
 
 	if err := r.updatePool(machinePoolScope, clusterScope, asg); err != nil {
 		machinePoolScope.Error(err, "error updating AWSMachinePool")
-		return err
+		return ctrl.Result{}, err
+	}
+
+	if feature.Gates.Enabled(feature.EventBridgeInstanceState) && machinePoolScope.AWSMachinePool.Spec.NodeDrainLifecycleHook != nil {
+		instancestateSvc := instancestate.NewService(ec2Scope)
+		if err := instancestateSvc.AddASGToEventPattern(asg.Name); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to add ASG to Event Bridge lifecycle rule")
+		}
 	}
 
-	launchTemplateID := machinePoolScope.GetLaunchTemplateIDStatus()
 	asgName := machinePoolScope.Name()
 	resourceServiceToUpdate := []scope.ResourceServiceToUpdate{
-		{
-			ResourceID:      &launchTemplateID,
-			ResourceService: ec2Svc,
-		},
 		{
 			ResourceID:      &asgName,
 			ResourceService: asgsvc,
 		},
 	}
+	// A referenced launch template is not owned by CAPA, its tags are left untouched.
+	if machinePoolScope.AWSMachinePool.Spec.LaunchTemplateRef == nil {
+		launchTemplateID := machinePoolScope.GetLaunchTemplateIDStatus()
+		resourceServiceToUpdate = append(resourceServiceToUpdate, scope.ResourceServiceToUpdate{
+			ResourceID:      &launchTemplateID,
+			ResourceService: ec2Svc,
+		})
+	}
 	err = ec2Svc.ReconcileTags(machinePoolScope, resourceServiceToUpdate)
 	if err != nil {
-		return errors.Wrap(err, "error updating tags")
+		return ctrl.Result{}, errors.Wrap(err, "error updating tags")
+	}
+
+	if err := r.reconcileMatchedInstanceTypes(machinePoolScope, ec2Svc); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedMatchInstanceTypes", "Failed to get instance types matching instance requirements: %v", err)
+		return ctrl.Result{}, err
 	}
 
 	// Make sure Spec.ProviderID is always set.
 	machinePoolScope.AWSMachinePool.Spec.ProviderID = asg.ID
-	providerIDList := make([]string, len(asg.Instances))
 
-	for i, ec2 := range asg.Instances {
+	providerIDList := make([]string, len(instances))
+	for i, ec2 := range instances {
 		providerIDList[i] = fmt.Sprintf("aws:///%s/%s", ec2.AvailabilityZone, ec2.ID)
 	}
 
@@ -322,15 +385,17 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 
 	machinePoolScope.AWSMachinePool.Spec.ProviderIDList = providerIDList
 	machinePoolScope.AWSMachinePool.Status.Replicas = int32(len(providerIDList))
+	machinePoolScope.AWSMachinePool.Status.WarmPoolStatus = asg.WarmPoolStatus
 	machinePoolScope.AWSMachinePool.Status.Ready = true
 	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition)
 
-	err = machinePoolScope.UpdateInstanceStatuses(ctx, asg.Instances)
+	err = machinePoolScope.UpdateInstanceStatuses(ctx, instances)
 	if err != nil {
-		machinePoolScope.Error(err, "failed updating instances", "instances", asg.Instances)
+		machinePoolScope.Error(err, "failed updating instances", "instances", instances)
 	}
 
-	return nil
+	// Instances paused by the node drain lifecycle hook are terminated once their node is drained.
+	return r.reconcileTerminatingInstances(ctx, machinePoolScope, asgsvc, asg.Instances)
 }
 
 func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) error {
@@ -348,6 +413,11 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 		machinePoolScope.Debug("Unable to locate ASG")
 		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, expinfrav1.ASGNotFoundReason, "Unable to find matching ASG")
 	} else {
+		if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
+			instancestateSvc := instancestate.NewService(ec2Scope)
+			instancestateSvc.RemoveASGFromEventPattern(asg.Name)
+		}
+
 		machinePoolScope.SetASGStatus(asg.Status)
 		switch asg.Status {
 		case expinfrav1.ASGStatusDeleteInProgress:
@@ -365,6 +435,13 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 		}
 	}
 
+	// A referenced launch template is not owned by CAPA, it is left in place.
+	if machinePoolScope.AWSMachinePool.Spec.LaunchTemplateRef != nil {
+		machinePoolScope.Info("successfully deleted AutoScalingGroup")
+		controllerutil.RemoveFinalizer(machinePoolScope.AWSMachinePool, expinfrav1.MachinePoolFinalizer)
+		return nil
+	}
+
 	launchTemplateID := machinePoolScope.AWSMachinePool.Status.LaunchTemplateID
 	launchTemplate, _, err := ec2Svc.GetLaunchTemplate(machinePoolScope.LaunchTemplateName())
 	if err != nil {
@@ -412,7 +489,11 @@ func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoo
 	if asgDiff != "" {
 		machinePoolScope.Debug("asg diff detected", "diff", subnetDiff)
 	}
-	if asgDiff != "" || subnetDiff != "" {
+	launchTemplateVersionDiff := launchTemplateVersionNeedsUpdate(machinePoolScope, existingASG)
+	if launchTemplateVersionDiff {
+		machinePoolScope.Debug("asg launch template version diff detected", "version", existingASG.LaunchTemplateVersion)
+	}
+	if asgDiff != "" || subnetDiff != "" || launchTemplateVersionDiff {
 		machinePoolScope.Info("updating AutoScalingGroup")
 
 		if err := asgSvc.UpdateASG(machinePoolScope); err != nil {
@@ -473,6 +554,26 @@ func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoo
 			}
 		}
 	}
+
+	if err := asgSvc.ReconcileLoadBalancers(machinePoolScope, existingASG); err != nil {
+		return errors.Wrap(err, "failed to reconcile load balancers")
+	}
+
+	if err := asgSvc.ReconcileWarmPool(machinePoolScope, existingASG); err != nil {
+		return errors.Wrap(err, "failed to reconcile warm pool")
+	}
+
+	if err := asgSvc.ReconcileLifecycleHooks(machinePoolScope); err != nil {
+		return errors.Wrap(err, "failed to reconcile lifecycle hooks")
+	}
+
+	if err := asgSvc.ReconcileScalingPolicies(machinePoolScope); err != nil {
+		return errors.Wrap(err, "failed to reconcile scaling policies")
+	}
+
+	if err := asgSvc.ReconcileScheduledActions(machinePoolScope); err != nil {
+		return errors.Wrap(err, "failed to reconcile scheduled actions")
+	}
 	return nil
 }
 
@@ -489,6 +590,25 @@ func (r *AWSMachinePoolReconciler) createPool(machinePoolScope *scope.MachinePoo
 	return nil
 }
 
+// reconcileMatchedInstanceTypes lists the instance types matching the instance requirements of the ASG in the
+// AWSMachinePool status, so that operators can see which instance types the pool can launch.
+func (r *AWSMachinePoolReconciler) reconcileMatchedInstanceTypes(machinePoolScope *scope.MachinePoolScope, ec2Svc services.EC2Interface) error {
+	requirements := machinePoolScope.GetInstanceRequirements()
+	if requirements == nil {
+		machinePoolScope.AWSMachinePool.Status.MatchedInstanceTypes = nil
+		return nil
+	}
+
+	instanceTypes, err := ec2Svc.GetInstanceTypesFromInstanceRequirements(requirements)
+	if err != nil {
+		return err
+	}
+	sort.Strings(instanceTypes)
+	machinePoolScope.AWSMachinePool.Status.MatchedInstanceTypes = instanceTypes
+
+	return nil
+}
+
 func (r *AWSMachinePoolReconciler) findASG(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) (*expinfrav1.AutoScalingGroup, error) {
 	// Query the instance using tags.
 	asg, err := asgsvc.GetASGByName(machinePoolScope)
@@ -499,11 +619,17 @@ func (r *AWSMachinePoolReconciler) findASG(machinePoolScope *scope.MachinePoolSc
 	return asg, nil
 }
 
+// isWarmPoolInstance returns whether an instance of an ASG is kept in its warm pool, in which case its lifecycle
+// state is prefixed with Warmed.
+func isWarmPoolInstance(instance infrav1.Instance) bool {
+	return strings.HasPrefix(string(instance.State), "Warmed:")
+}
+
 // diffASG compares incoming AWSMachinePool and compares against existing ASG.
 func diffASG(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) string {
 	detectedMachinePoolSpec := machinePoolScope.MachinePool.Spec.DeepCopy()
 
-	if !scope.ReplicasExternallyManaged(machinePoolScope.MachinePool) {
+	if !scope.ReplicasExternallyManaged(machinePoolScope.MachinePool) && !machinePoolScope.ReplicasManagedByScalingPolicies() {
 		detectedMachinePoolSpec.Replicas = existingASG.DesiredCapacity
 	}
 	if diff := cmp.Diff(machinePoolScope.MachinePool.Spec, *detectedMachinePoolSpec); diff != "" {
@@ -511,16 +637,31 @@ func diffASG(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.A
 	}
 
 	detectedAWSMachinePoolSpec := machinePoolScope.AWSMachinePool.Spec.DeepCopy()
-	detectedAWSMachinePoolSpec.MaxSize = existingASG.MaxSize
-	detectedAWSMachinePoolSpec.MinSize = existingASG.MinSize
+	// The sizes set by scheduled actions are not updated from the AWSMachinePool.
+	minSizeScheduled, maxSizeScheduled := machinePoolScope.SizeManagedByScheduledActions()
+	if !maxSizeScheduled {
+		detectedAWSMachinePoolSpec.MaxSize = existingASG.MaxSize
+	}
+	if !minSizeScheduled {
+		detectedAWSMachinePoolSpec.MinSize = existingASG.MinSize
+	}
 	detectedAWSMachinePoolSpec.CapacityRebalance = existingASG.CapacityRebalance
+	// The health check of the ASG is left untouched while it is not set in the AWSMachinePool.
+	if detectedAWSMachinePoolSpec.HealthCheckType != nil {
+		healthCheckType := existingASG.HealthCheckType
+		detectedAWSMachinePoolSpec.HealthCheckType = &healthCheckType
+	}
+	if detectedAWSMachinePoolSpec.HealthCheckGracePeriod != nil {
+		detectedAWSMachinePoolSpec.HealthCheckGracePeriod = existingASG.HealthCheckGracePeriod
+	}
 	{
-		mixedInstancesPolicy := machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy
+		// The instance requirements of the launch template are applied through the mixed instances policy.
+		mixedInstancesPolicy := machinePoolScope.GetMixedInstancesPolicy()
 		// InstancesDistribution is optional, and the default values come from AWS, so
 		// they are not set by the AWSMachinePool defaulting webhook. If InstancesDistribution is
 		// not set, we use the AWS values for the purpose of comparison.
-		if mixedInstancesPolicy != nil && mixedInstancesPolicy.InstancesDistribution == nil {
-			mixedInstancesPolicy = machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy.DeepCopy()
+		if mixedInstancesPolicy != nil && mixedInstancesPolicy.InstancesDistribution == nil && existingASG.MixedInstancesPolicy != nil {
+			mixedInstancesPolicy = mixedInstancesPolicy.DeepCopy()
 			mixedInstancesPolicy.InstancesDistribution = existingASG.MixedInstancesPolicy.InstancesDistribution
 		}
 
@@ -532,6 +673,24 @@ func diffASG(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.A
 	return cmp.Diff(machinePoolScope.AWSMachinePool.Spec, *detectedAWSMachinePoolSpec)
 }
 
+// launchTemplateVersionNeedsUpdate returns whether the ASG needs to be moved to the launch template version it is
+// pinned to with auto rollback, as when only the userdata changed and no instance refresh rolls out the version.
+func launchTemplateVersionNeedsUpdate(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) bool {
+	if !machinePoolScope.AWSMachinePool.Spec.RefreshPreferences.AutoRollbackEnabled() {
+		return false
+	}
+	// The instance refresh in progress moves the ASG once it succeeds.
+	if refresh := machinePoolScope.AWSMachinePool.Status.InstanceRefresh; refresh != nil {
+		switch refresh.Status {
+		case autoscaling.InstanceRefreshStatusPending, autoscaling.InstanceRefreshStatusInProgress,
+			autoscaling.InstanceRefreshStatusCancelling, autoscaling.InstanceRefreshStatusRollbackInProgress:
+			return false
+		}
+	}
+
+	return existingASG.LaunchTemplateVersion != machinePoolScope.GetLaunchTemplateLatestVersionStatus()
+}
+
 // getOwnerMachinePool returns the MachinePool object owning the current resource.
 func getOwnerMachinePool(ctx context.Context, c client.Client, obj metav1.ObjectMeta) (*expclusterv1.MachinePool, error) {
 	for _, ref := range obj.OwnerReferences {
@@ -22,6 +22,7 @@ import (
 	"flag"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
@@ -29,15 +30,21 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/record"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
@@ -199,7 +206,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				buf := new(bytes.Buffer)
 				klog.SetOutput(buf)
 
-				_ = reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(buf).To(ContainSubstring("Error state detected, skipping reconciliation"))
 			})
 			t.Run("should add our finalizer to the machinepool", func(t *testing.T) {
@@ -210,7 +217,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 
 				ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any())
 
-				_ = reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 
 				g.Expect(ms.AWSMachinePool.Finalizers).To(ContainElement(expinfrav1.MachinePoolFinalizer))
 			})
@@ -225,7 +232,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				buf := new(bytes.Buffer)
 				klog.SetOutput(buf)
 
-				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(BeNil())
 				g.Expect(buf.String()).To(ContainSubstring("Cluster infrastructure is not ready yet"))
 				expectConditions(g, ms.AWSMachinePool, []conditionAssertion{{expinfrav1.ASGReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.WaitingForClusterInfrastructureReason}})
@@ -240,7 +247,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				buf := new(bytes.Buffer)
 				klog.SetOutput(buf)
 
-				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 
 				g.Expect(err).To(BeNil())
 				g.Expect(buf.String()).To(ContainSubstring("Bootstrap data secret reference is not yet available"))
@@ -261,7 +268,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 
 				expectedErr := errors.New("no connection available ")
 				ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedErr)
-				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(errors.Cause(err)).To(MatchError(expectedErr))
 			})
 		})
@@ -288,7 +295,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				}, nil)
 				asgSvc.EXPECT().SuspendProcesses("name", []string{"Launch", "Terminate"}).Return(nil).AnyTimes().Times(0)
 
-				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(Succeed())
 			})
 		})
@@ -312,6 +319,12 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				}, nil)
 				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
 				asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
 				asgSvc.EXPECT().SuspendProcesses("name", gomock.InAnyOrder([]string{
 					"ScheduledActions",
 					"Launch",
@@ -324,7 +337,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					"ReplaceUnhealthy",
 				})).Return(nil).AnyTimes().Times(1)
 
-				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(Succeed())
 			})
 		})
@@ -353,10 +366,16 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				}, nil)
 				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
 				asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
 				asgSvc.EXPECT().SuspendProcesses("name", []string{"Terminate"}).Return(nil).AnyTimes().Times(1)
 				asgSvc.EXPECT().ResumeProcesses("name", []string{"process3"}).Return(nil).AnyTimes().Times(1)
 
-				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(Succeed())
 			})
 		})
@@ -373,6 +392,12 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
 			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
 			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
 			ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(nil, "", nil).AnyTimes()
 			ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(nil, nil).AnyTimes()
 			ec2Svc.EXPECT().CreateLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
@@ -386,7 +411,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 
 			g.Expect(testEnv.Create(ctx, ms.MachinePool)).To(Succeed())
 
-			_ = reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 			g.Expect(*ms.MachinePool.Spec.Replicas).To(Equal(int32(1)))
 		})
 		t.Run("No need to update Asg because asgNeedsUpdates is false and no subnets change", func(t *testing.T) {
@@ -416,8 +441,14 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
 			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{"subnet2", "subnet1"}, nil).Times(1)
 			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).Times(0)
-
-			err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 			g.Expect(err).To(Succeed())
 		})
 		t.Run("update Asg due to subnet changes", func(t *testing.T) {
@@ -434,8 +465,14 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
 			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{"subnet1"}, nil).Times(1)
 			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).Times(1)
-
-			err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 			g.Expect(err).To(Succeed())
 		})
 		t.Run("update Asg due to asgNeedsUpdates returns true", func(t *testing.T) {
@@ -452,9 +489,591 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
 			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
 			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).Times(1)
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			g.Expect(err).To(Succeed())
+		})
+		t.Run("warm pool instances are not reported until they enter service", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			ms.AWSMachinePool.Spec.WarmPool = &expinfrav1.WarmPool{
+				MinSize: pointer.Int32(1),
+			}
+
+			asg := expinfrav1.AutoScalingGroup{
+				Name:    "name",
+				MinSize: int32(0),
+				MaxSize: int32(100),
+				Instances: []infrav1.Instance{
+					{ID: "i-inservice", State: "InService", AvailabilityZone: "us-east-1a"},
+					{ID: "i-warmed", State: "Warmed:Stopped", AvailabilityZone: "us-east-1a"},
+				},
+				WarmPool: &expinfrav1.WarmPool{
+					MinSize:   pointer.Int32(1),
+					PoolState: expinfrav1.WarmPoolStateStopped,
+				},
+				WarmPoolStatus: &expinfrav1.WarmPoolStatus{
+					Size: 1,
+				},
+			}
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), &asg).Return(nil).Times(1)
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			g.Expect(err).To(Succeed())
+			g.Expect(ms.AWSMachinePool.Spec.ProviderIDList).To(ConsistOf("aws:///us-east-1a/i-inservice"))
+			g.Expect(ms.AWSMachinePool.Status.Replicas).To(Equal(int32(1)))
+			g.Expect(ms.AWSMachinePool.Status.WarmPoolStatus).To(Equal(&expinfrav1.WarmPoolStatus{Size: 1}))
+		})
+		t.Run("instance types matching the instance requirements are reported", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			requirements := &expinfrav1.InstanceRequirements{
+				VCPUCount: expinfrav1.InstanceRequirementsRange{Min: 2, Max: pointer.Int64(4)},
+				MemoryMiB: expinfrav1.InstanceRequirementsRange{Min: 4096},
+			}
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate.InstanceRequirements = requirements
+
+			asg := expinfrav1.AutoScalingGroup{
+				Name:    "name",
+				MinSize: int32(0),
+				MaxSize: int32(100),
+			}
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+			ec2Svc.EXPECT().GetInstanceTypesFromInstanceRequirements(requirements).Return([]string{"m5.xlarge", "c5.large"}, nil).Times(1)
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			g.Expect(err).To(Succeed())
+			g.Expect(ms.AWSMachinePool.Status.MatchedInstanceTypes).To(Equal([]string{"c5.large", "m5.xlarge"}))
+		})
+		t.Run("terminating instances are completed once their node is drained", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			ms.AWSMachinePool.Spec.NodeDrainLifecycleHook = &expinfrav1.NodeDrainLifecycleHook{}
+
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node-terminating",
+				},
+				Spec: corev1.NodeSpec{
+					ProviderID: "aws:///us-east-1a/i-terminating",
+				},
+			}
+			clientset := fakeclientset.NewSimpleClientset(node)
+			reconciler.workloadClientsetFactory = func(context.Context, *scope.MachinePoolScope) (kubernetes.Interface, error) {
+				return clientset, nil
+			}
+
+			asg := expinfrav1.AutoScalingGroup{
+				Name:    "name",
+				MinSize: int32(0),
+				MaxSize: int32(100),
+				Instances: []infrav1.Instance{
+					{ID: "i-inservice", State: "InService", AvailabilityZone: "us-east-1a"},
+					{ID: "i-terminating", State: "Terminating:Wait", AvailabilityZone: "us-east-1a"},
+				},
+			}
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).Times(1)
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().CompleteLifecycleAction(ms.Name(), expinfrav1.NodeDrainLifecycleHookName, "i-terminating").Return(nil).Times(1)
+
+			result, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			g.Expect(err).To(Succeed())
+			g.Expect(result.RequeueAfter).To(BeZero())
+
+			drainedNode, err := clientset.CoreV1().Nodes().Get(context.Background(), node.Name, metav1.GetOptions{})
+			g.Expect(err).To(Succeed())
+			g.Expect(drainedNode.Spec.Unschedulable).To(BeTrue())
+		})
+		t.Run("an AWSMachine is created for each instance of the ASG when MachinePool machines are enabled", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePoolMachines, true)()
+			reconciler.Client = testEnv.Client
+			reconciler.Recorder = record.NewFakeRecorder(10)
+
+			orphan := &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-i-orphan",
+					Namespace: "default",
+					Labels:    machinePoolMachineLabels(ms),
+				},
+				Spec: infrav1.AWSMachineSpec{
+					InstanceID:   aws.String("i-orphan"),
+					InstanceType: "m6a.32xlarge",
+				},
+			}
+			g.Expect(testEnv.Create(ctx, orphan)).To(Succeed())
+
+			asg := expinfrav1.AutoScalingGroup{
+				Name:    "name",
+				MinSize: int32(0),
+				MaxSize: int32(100),
+				Instances: []infrav1.Instance{
+					{ID: "i-inservice", State: "InService", AvailabilityZone: "us-east-1a", Type: "m6a.32xlarge"},
+					{ID: "i-terminating", State: "Terminating", AvailabilityZone: "us-east-1a", Type: "m6a.32xlarge"},
+				},
+			}
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			g.Expect(err).To(Succeed())
+			g.Expect(ms.AWSMachinePool.Status.InfrastructureMachineKind).To(Equal("AWSMachine"))
+
+			awsMachines := &infrav1.AWSMachineList{}
+			g.Eventually(func() []infrav1.AWSMachine {
+				g.Expect(testEnv.List(ctx, awsMachines, client.InNamespace("default"), client.MatchingLabels(machinePoolMachineLabels(ms)))).To(Succeed())
+				return awsMachines.Items
+			}, 10*time.Second).Should(HaveLen(1))
+
+			awsMachine := awsMachines.Items[0]
+			defer func() {
+				g.Expect(testEnv.Delete(ctx, &awsMachine)).To(Succeed())
+			}()
+			g.Expect(awsMachine.Name).To(Equal("test-i-inservice"))
+			g.Expect(awsMachine.Spec.ProviderID).To(Equal(aws.String("aws:///us-east-1a/i-inservice")))
+			g.Expect(awsMachine.Spec.InstanceID).To(Equal(aws.String("i-inservice")))
+			g.Expect(awsMachine.Spec.InstanceType).To(Equal("m6a.32xlarge"))
+			g.Expect(awsMachine.OwnerReferences).To(HaveLen(1))
+			g.Expect(awsMachine.OwnerReferences[0].Name).To(Equal(awsMachinePool.Name))
+			g.Expect(awsMachine.OwnerReferences[0].Controller).To(BeNil())
+		})
+		t.Run("a referenced launch template is resolved instead of reconciling an owned one", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			ms.AWSMachinePool.Spec.LaunchTemplateRef = &infrav1.LaunchTemplateReference{Name: aws.String("my-template")}
+
+			asg := expinfrav1.AutoScalingGroup{
+				Name:    "name",
+				MinSize: int32(0),
+				MaxSize: int32(100),
+			}
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			ec2Svc.EXPECT().ResolveLaunchTemplate(ms.AWSMachinePool.Spec.LaunchTemplateRef).Return(&infrav1.LaunchTemplateReference{ID: aws.String("lt-12345"), Version: aws.String("3")}, nil)
+			ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Len(1)).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			g.Expect(err).To(Succeed())
+			g.Expect(ms.AWSMachinePool.Status.LaunchTemplateID).To(Equal("lt-12345"))
+			g.Expect(ms.AWSMachinePool.Status.LaunchTemplateVersion).To(Equal(aws.String("3")))
+			g.Expect(conditions.IsTrue(ms.AWSMachinePool, expinfrav1.LaunchTemplateReadyCondition)).To(BeTrue())
+		})
+		t.Run("a new version of the referenced launch template is rolled out with an instance refresh", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			ms.AWSMachinePool.Spec.LaunchTemplateRef = &infrav1.LaunchTemplateReference{Name: aws.String("my-template")}
+			ms.AWSMachinePool.Status.LaunchTemplateVersion = aws.String("2")
+
+			asg := expinfrav1.AutoScalingGroup{
+				Name:    "name",
+				MinSize: int32(0),
+				MaxSize: int32(100),
+			}
+			ec2Svc.EXPECT().ResolveLaunchTemplate(gomock.Any()).Return(&infrav1.LaunchTemplateReference{ID: aws.String("lt-12345"), Version: aws.String("3")}, nil)
+			ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any()).Return(true, nil)
+			asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any()).Return(nil).Times(1)
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).MinTimes(1)
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			g.Expect(err).To(Succeed())
+			g.Expect(ms.AWSMachinePool.Status.LaunchTemplateVersion).To(Equal(aws.String("3")))
+			g.Eventually(recorder.Events).Should(Receive(ContainSubstring("LaunchTemplateVersionChanged")))
+		})
+		t.Run("the progress of the latest instance refresh is reported", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+
+			asg := expinfrav1.AutoScalingGroup{
+				MinSize: int32(0),
+				MaxSize: int32(100),
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					InstancesDistribution: &expinfrav1.InstancesDistribution{
+						OnDemandAllocationStrategy:          expinfrav1.OnDemandAllocationStrategyPrioritized,
+						SpotAllocationStrategy:              expinfrav1.SpotAllocationStrategyCapacityOptimized,
+						OnDemandBaseCapacity:                aws.Int64(0),
+						OnDemandPercentageAboveBaseCapacity: aws.Int64(100),
+					},
+					Overrides: []expinfrav1.Overrides{
+						{
+							InstanceType: "m6a.32xlarge",
+						},
+					},
+				},
+				Subnets:               []string{"subnet1", "subnet2"},
+				LaunchTemplateVersion: "2",
+			}
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{"subnet2", "subnet1"}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).Times(0)
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(&expinfrav1.InstanceRefreshStatus{
+				ID:                 "refresh-1",
+				Status:             "InProgress",
+				PercentageComplete: 40,
+				InstancesToUpdate:  3,
+			}, nil)
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			g.Expect(err).To(Succeed())
+			g.Expect(ms.AWSMachinePool.Status.InstanceRefresh).To(Equal(&expinfrav1.InstanceRefreshStatus{
+				ID:                 "refresh-1",
+				Status:             "InProgress",
+				PercentageComplete: 40,
+				InstancesToUpdate:  3,
+			}))
+			g.Expect(conditions.IsFalse(ms.AWSMachinePool, expinfrav1.InstanceRefreshReadyCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(ms.AWSMachinePool, expinfrav1.InstanceRefreshReadyCondition)).To(Equal(expinfrav1.InstanceRefreshInProgressReason))
+		})
+		t.Run("an instance refresh is rolled back once an alarm goes off", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			alarmARN := "arn:aws:cloudwatch:us-east-1:123456789012:alarm:high-error-rate"
+			ms.AWSMachinePool.Spec.RefreshPreferences = &expinfrav1.RefreshPreferences{
+				AutoRollback:       aws.Bool(true),
+				AlarmSpecification: &expinfrav1.AlarmSpecification{AlarmARNs: []string{alarmARN}},
+			}
+			ms.AWSMachinePool.Status.LaunchTemplateVersion = aws.String("3")
+
+			asg := expinfrav1.AutoScalingGroup{
+				MinSize: int32(0),
+				MaxSize: int32(100),
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					InstancesDistribution: &expinfrav1.InstancesDistribution{
+						OnDemandAllocationStrategy:          expinfrav1.OnDemandAllocationStrategyPrioritized,
+						SpotAllocationStrategy:              expinfrav1.SpotAllocationStrategyCapacityOptimized,
+						OnDemandBaseCapacity:                aws.Int64(0),
+						OnDemandPercentageAboveBaseCapacity: aws.Int64(100),
+					},
+					Overrides: []expinfrav1.Overrides{
+						{
+							InstanceType: "m6a.32xlarge",
+						},
+					},
+				},
+				Subnets:               []string{"subnet1", "subnet2"},
+				LaunchTemplateVersion: "2",
+			}
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{"subnet2", "subnet1"}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).Times(0)
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(&expinfrav1.InstanceRefreshStatus{
+				ID:                    "refresh-1",
+				Status:                "InProgress",
+				LaunchTemplateVersion: "3",
+			}, nil)
+			asgSvc.EXPECT().GetAlarmsInAlarmState([]string{alarmARN}).Return([]string{alarmARN}, nil)
+			asgSvc.EXPECT().RollbackASGInstanceRefresh(gomock.Any()).Return(nil).Times(1)
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			g.Expect(err).To(Succeed())
+			g.Expect(conditions.GetReason(ms.AWSMachinePool, expinfrav1.InstanceRefreshReadyCondition)).To(Equal(expinfrav1.InstanceRefreshRollbackInProgressReason))
+			g.Eventually(recorder.Events).Should(Receive(ContainSubstring("InstanceRefreshRollback")))
+		})
+		t.Run("the launch template version is reverted once the instance refresh is rolled back", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			ms.AWSMachinePool.Spec.RefreshPreferences = &expinfrav1.RefreshPreferences{AutoRollback: aws.Bool(true)}
+			ms.AWSMachinePool.Status.LaunchTemplateVersion = aws.String("3")
+
+			asg := expinfrav1.AutoScalingGroup{
+				MinSize: int32(0),
+				MaxSize: int32(100),
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					InstancesDistribution: &expinfrav1.InstancesDistribution{
+						OnDemandAllocationStrategy:          expinfrav1.OnDemandAllocationStrategyPrioritized,
+						SpotAllocationStrategy:              expinfrav1.SpotAllocationStrategyCapacityOptimized,
+						OnDemandBaseCapacity:                aws.Int64(0),
+						OnDemandPercentageAboveBaseCapacity: aws.Int64(100),
+					},
+					Overrides: []expinfrav1.Overrides{
+						{
+							InstanceType: "m6a.32xlarge",
+						},
+					},
+				},
+				Subnets:               []string{"subnet1", "subnet2"},
+				LaunchTemplateVersion: "2",
+			}
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{"subnet2", "subnet1"}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).Times(0)
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(&expinfrav1.InstanceRefreshStatus{
+				ID:                    "refresh-1",
+				Status:                "RollbackSuccessful",
+				LaunchTemplateVersion: "3",
+			}, nil)
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			g.Expect(err).To(Succeed())
+			g.Expect(ms.AWSMachinePool.Status.LaunchTemplateVersion).To(Equal(aws.String("2")))
+			g.Expect(conditions.IsFalse(ms.AWSMachinePool, expinfrav1.InstanceRefreshReadyCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(ms.AWSMachinePool, expinfrav1.InstanceRefreshReadyCondition)).To(Equal(expinfrav1.InstanceRefreshRolledBackReason))
+		})
+		t.Run("a launch template version which is not rolled out by an instance refresh is applied to a pinned ASG", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			ms.AWSMachinePool.Spec.RefreshPreferences = &expinfrav1.RefreshPreferences{AutoRollback: aws.Bool(true)}
+			ms.AWSMachinePool.Status.LaunchTemplateVersion = aws.String("3")
 
-			err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+			asg := expinfrav1.AutoScalingGroup{
+				MinSize: int32(0),
+				MaxSize: int32(100),
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					InstancesDistribution: &expinfrav1.InstancesDistribution{
+						OnDemandAllocationStrategy:          expinfrav1.OnDemandAllocationStrategyPrioritized,
+						SpotAllocationStrategy:              expinfrav1.SpotAllocationStrategyCapacityOptimized,
+						OnDemandBaseCapacity:                aws.Int64(0),
+						OnDemandPercentageAboveBaseCapacity: aws.Int64(100),
+					},
+					Overrides: []expinfrav1.Overrides{
+						{
+							InstanceType: "m6a.32xlarge",
+						},
+					},
+				},
+				Subnets:               []string{"subnet1", "subnet2"},
+				LaunchTemplateVersion: "2",
+			}
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{"subnet2", "subnet1"}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).Times(1)
+			asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(&expinfrav1.InstanceRefreshStatus{
+				ID:     "refresh-1",
+				Status: "Successful",
+			}, nil)
+			asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+			asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+			_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 			g.Expect(err).To(Succeed())
+			g.Expect(conditions.IsTrue(ms.AWSMachinePool, expinfrav1.InstanceRefreshReadyCondition)).To(BeTrue())
+		})
+		t.Run("hibernated cluster", func(t *testing.T) {
+			t.Run("should record the capacity of the ASG and scale it to zero", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				ms.InfraCluster = cs
+				cs.AWSCluster.Spec.Hibernation = &infrav1.Hibernation{Hibernate: true}
+
+				asg := expinfrav1.AutoScalingGroup{
+					MinSize:         int32(1),
+					MaxSize:         int32(3),
+					DesiredCapacity: pointer.Int32(2),
+				}
+				ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+				gomock.InOrder(
+					asgSvc.EXPECT().SuspendProcesses("test", []string{"ScheduledActions", "AlarmNotification"}).Return(nil),
+					asgSvc.EXPECT().UpdateASGCapacity("test", int32(0), int32(0), int32(0)).Return(nil),
+				)
+				asgSvc.EXPECT().UpdateASG(gomock.Any()).Times(0)
+
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Annotations).To(HaveKeyWithValue(expinfrav1.HibernatedCapacityAnnotation, `{"minSize":1,"maxSize":3,"desiredCapacity":2}`))
+				g.Expect(ms.AWSMachinePool.Status.Ready).To(BeFalse())
+				g.Expect(conditions.GetReason(ms.AWSMachinePool, expinfrav1.ASGReadyCondition)).To(Equal(expinfrav1.ASGHibernatedReason))
+			})
+			t.Run("should only suspend the processes that are not suspended already", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				ms.InfraCluster = cs
+				cs.AWSCluster.Spec.Hibernation = &infrav1.Hibernation{Hibernate: true}
+
+				asg := expinfrav1.AutoScalingGroup{
+					CurrentlySuspendProcesses: []string{"ScheduledActions"},
+				}
+				ms.AWSMachinePool.Annotations = map[string]string{
+					expinfrav1.HibernatedCapacityAnnotation: `{"minSize":1,"maxSize":3,"desiredCapacity":2}`,
+				}
+				ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+				asgSvc.EXPECT().SuspendProcesses("test", []string{"AlarmNotification"}).Return(nil)
+				asgSvc.EXPECT().UpdateASGCapacity(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				g.Expect(err).To(Succeed())
+			})
+			t.Run("should resume the processes suspended for the hibernation, unless suspended in the spec", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				ms.InfraCluster = cs
+
+				asg := expinfrav1.AutoScalingGroup{
+					Name:                      "test",
+					MinSize:                   int32(0),
+					MaxSize:                   int32(0),
+					DesiredCapacity:           pointer.Int32(0),
+					CurrentlySuspendProcesses: []string{"ScheduledActions", "AlarmNotification", "AZRebalance"},
+				}
+				ms.AWSMachinePool.Spec.SuspendProcesses = &expinfrav1.SuspendProcessesTypes{
+					Processes: &expinfrav1.Processes{
+						AZRebalance:       pointer.Bool(true),
+						AlarmNotification: pointer.Bool(true),
+					},
+				}
+				ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+				gomock.InOrder(
+					asgSvc.EXPECT().UpdateASGCapacity("test", int32(1), int32(3), int32(2)).Return(nil),
+					asgSvc.EXPECT().ResumeProcesses("test", []string{"ScheduledActions"}).Return(nil),
+				)
+				asgSvc.EXPECT().SuspendProcesses(gomock.Any(), gomock.Any()).Times(0)
+				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).AnyTimes()
+				asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+				ms.AWSMachinePool.Annotations = map[string]string{
+					expinfrav1.HibernatedCapacityAnnotation: `{"minSize":1,"maxSize":3,"desiredCapacity":2}`,
+				}
+
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				g.Expect(err).To(Succeed())
+				g.Expect(asg.CurrentlySuspendProcesses).To(ConsistOf("AlarmNotification", "AZRebalance"))
+			})
+			t.Run("should restore the capacity of the ASG once the cluster is resumed", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				ms.InfraCluster = cs
+
+				asg := expinfrav1.AutoScalingGroup{
+					MinSize:         int32(0),
+					MaxSize:         int32(0),
+					DesiredCapacity: pointer.Int32(0),
+				}
+				ec2Svc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
+				asgSvc.EXPECT().UpdateASGCapacity("test", int32(1), int32(3), int32(2)).Return(nil)
+				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).AnyTimes()
+				asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileLoadBalancers(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().GetLatestInstanceRefresh(gomock.Any()).Return(nil, nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileWarmPool(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileLifecycleHooks(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileScalingPolicies(gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().ReconcileScheduledActions(gomock.Any()).Return(nil).AnyTimes()
+
+				ms.AWSMachinePool.Annotations = map[string]string{
+					expinfrav1.HibernatedCapacityAnnotation: `{"minSize":1,"maxSize":3,"desiredCapacity":2}`,
+				}
+				ms.MachinePool.Annotations = map[string]string{
+					scope.ReplicasManagedByAnnotation: scope.ExternalAutoscalerReplicasManagedByAnnotationValue,
+				}
+				ms.MachinePool.Spec.Replicas = pointer.Int32(2)
+
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				g.Expect(err).To(Succeed())
+				g.Expect(ms.AWSMachinePool.Annotations).NotTo(HaveKey(expinfrav1.HibernatedCapacityAnnotation))
+				g.Expect(*ms.MachinePool.Spec.Replicas).To(Equal(int32(2)))
+			})
 		})
 	})
 
@@ -497,6 +1116,21 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			g.Expect(ms.AWSMachinePool.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
 			g.Eventually(recorder.Events).Should(Receive(ContainSubstring(expinfrav1.ASGNotFoundReason)))
 		})
+		t.Run("should not delete a referenced launch template", func(t *testing.T) {
+			g := NewWithT(t)
+			setup(t, g)
+			defer teardown(t, g)
+			finalizer(t, g)
+			ms.AWSMachinePool.Spec.LaunchTemplateRef = &infrav1.LaunchTemplateReference{ID: aws.String("lt-12345")}
+
+			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil)
+			ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Times(0)
+			ec2Svc.EXPECT().DeleteLaunchTemplate(gomock.Any()).Times(0)
+
+			err := reconciler.reconcileDelete(ms, cs, cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(ms.AWSMachinePool.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
+		})
 		t.Run("should cause AWSMachinePool to go into NotReady", func(t *testing.T) {
 			g := NewWithT(t)
 			setup(t, g)
@@ -559,6 +1193,7 @@ func setupCluster(clusterName string) (*scope.ClusterScope, error) {
 }
 
 func TestDiffASG(t *testing.T) {
+	elbHealthCheckType := expinfrav1.ASGHealthCheckTypeELB
 	type args struct {
 		machinePoolScope *scope.MachinePoolScope
 		existingASG      *expinfrav1.AutoScalingGroup
@@ -944,6 +1579,140 @@ func TestDiffASG(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "health check type is different",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: pointer.Int32(1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							HealthCheckType:        &elbHealthCheckType,
+							HealthCheckGracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:        pointer.Int32(1),
+					HealthCheckType:        expinfrav1.ASGHealthCheckTypeEC2,
+					HealthCheckGracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "health check is not set",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: pointer.Int32(1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:        pointer.Int32(1),
+					HealthCheckType:        expinfrav1.ASGHealthCheckTypeELB,
+					HealthCheckGracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "scaling policies ignore difference between desiredCapacity and replicas",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: pointer.Int32(1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							ScalingPolicies: []expinfrav1.AWSScalingPolicy{
+								{
+									Name:             "cpu",
+									TargetValue:      resource.MustParse("50"),
+									PredefinedMetric: &expinfrav1.PredefinedMetric{Type: expinfrav1.PredefinedMetricTypeASGAverageCPUUtilization},
+								},
+							},
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: pointer.Int32(4),
+				},
+			},
+			want: false,
+		},
+		{
+			name: "scheduled actions ignore difference between the sizes they set",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: pointer.Int32(1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MinSize: 1,
+							MaxSize: 10,
+							ScheduledActions: []expinfrav1.AWSScheduledAction{
+								{
+									Name:       "business-hours",
+									Recurrence: pointer.String("0 8 * * 1-5"),
+									MinSize:    pointer.Int32(5),
+								},
+							},
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: pointer.Int32(5),
+					MinSize:         5,
+					MaxSize:         10,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "scheduled actions do not ignore difference between the sizes they do not set",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: pointer.Int32(1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							MinSize: 1,
+							MaxSize: 10,
+							ScheduledActions: []expinfrav1.AWSScheduledAction{
+								{
+									Name:       "business-hours",
+									Recurrence: pointer.String("0 8 * * 1-5"),
+									MinSize:    pointer.Int32(5),
+								},
+							},
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity: pointer.Int32(5),
+					MinSize:         5,
+					MaxSize:         20,
+				},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
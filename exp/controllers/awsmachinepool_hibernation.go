@@ -0,0 +1,227 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// hibernatedCapacity is the capacity of an ASG recorded in the HibernatedCapacityAnnotation of its AWSMachinePool.
+type hibernatedCapacity struct {
+	MinSize         int32 `json:"minSize"`
+	MaxSize         int32 `json:"maxSize"`
+	DesiredCapacity int32 `json:"desiredCapacity"`
+}
+
+// hibernationSuspendedProcesses are the processes of the ASG suspended while the cluster is hibernated, so that
+// scheduled actions and scaling policies do not launch instances in the ASG scaled to zero.
+var hibernationSuspendedProcesses = []string{"ScheduledActions", "AlarmNotification"}
+
+// reconcileHibernation scales the ASG of the machine pool to zero while its cluster is hibernated, and back to
+// its previous capacity once the cluster is resumed. It returns done when the rest of the reconciliation must be
+// skipped because the cluster is hibernated.
+func (r *AWSMachinePoolReconciler) reconcileHibernation(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, asg *expinfrav1.AutoScalingGroup) (bool, error) {
+	value, hibernated := machinePoolScope.AWSMachinePool.Annotations[expinfrav1.HibernatedCapacityAnnotation]
+
+	if machinePoolScope.ClusterHibernated() {
+		if !hibernated {
+			capacity, err := json.Marshal(hibernatedCapacity{
+				MinSize:         asg.MinSize,
+				MaxSize:         asg.MaxSize,
+				DesiredCapacity: pointer.Int32Deref(asg.DesiredCapacity, 0),
+			})
+			if err != nil {
+				return true, errors.Wrap(err, "failed to marshal ASG capacity")
+			}
+
+			// Record the capacity before scaling the ASG, it could not be restored otherwise.
+			machinePoolScope.SetAnnotation(expinfrav1.HibernatedCapacityAnnotation, string(capacity))
+			if err := machinePoolScope.PatchObject(); err != nil {
+				return true, err
+			}
+		}
+
+		// Scaled to zero, the ASG would otherwise be scaled up again by its scheduled actions and scaling policies.
+		if toBeSuspended := processesNotIn(hibernationSuspendedProcesses, asg.CurrentlySuspendProcesses); len(toBeSuspended) > 0 {
+			if err := asgsvc.SuspendProcesses(machinePoolScope.Name(), toBeSuspended); err != nil {
+				machinePoolScope.Error(err, "failed to suspend processes of ASG of hibernated cluster")
+				r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedSuspendProcesses", "Failed to suspend processes %v of ASG %q of hibernated cluster: %v", toBeSuspended, machinePoolScope.Name(), err)
+				return true, err
+			}
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulSuspendProcesses", "Suspended processes %v of ASG %q of hibernated cluster", toBeSuspended, machinePoolScope.Name())
+		}
+
+		if asg.MinSize != 0 || asg.MaxSize != 0 || pointer.Int32Deref(asg.DesiredCapacity, 0) != 0 {
+			if err := asgsvc.UpdateASGCapacity(machinePoolScope.Name(), 0, 0, 0); err != nil {
+				machinePoolScope.Error(err, "failed to scale ASG of hibernated cluster to zero")
+				r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedScaleDownASG", "Failed to scale ASG %q of hibernated cluster to zero: %v", machinePoolScope.Name(), err)
+				return true, err
+			}
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulScaleDownASG", "Scaled ASG %q of hibernated cluster to zero", machinePoolScope.Name())
+		}
+
+		machinePoolScope.SetNotReady()
+		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGHibernatedReason, clusterv1.ConditionSeverityInfo, "")
+		return true, nil
+	}
+
+	if !hibernated {
+		return false, nil
+	}
+
+	var capacity hibernatedCapacity
+	if err := json.Unmarshal([]byte(value), &capacity); err != nil {
+		return true, errors.Wrapf(err, "failed to parse annotation %q", expinfrav1.HibernatedCapacityAnnotation)
+	}
+
+	if err := asgsvc.UpdateASGCapacity(machinePoolScope.Name(), capacity.MinSize, capacity.MaxSize, capacity.DesiredCapacity); err != nil {
+		machinePoolScope.Error(err, "failed to scale ASG of resumed cluster")
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedScaleUpASG", "Failed to scale ASG %q of resumed cluster back to %d instances: %v", machinePoolScope.Name(), capacity.DesiredCapacity, err)
+		return true, err
+	}
+	r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulScaleUpASG", "Scaled ASG %q of resumed cluster back to %d instances", machinePoolScope.Name(), capacity.DesiredCapacity)
+
+	// Processes suspended in the spec of the AWSMachinePool are left suspended.
+	currentlySuspended := make(map[string]struct{}, len(asg.CurrentlySuspendProcesses))
+	for _, p := range asg.CurrentlySuspendProcesses {
+		currentlySuspended[p] = struct{}{}
+	}
+	var toBeResumed []string
+	for _, p := range processesNotIn(hibernationSuspendedProcesses, machinePoolScope.AWSMachinePool.Spec.SuspendProcesses.ConvertSetValuesToStringSlice()) {
+		if _, ok := currentlySuspended[p]; ok {
+			toBeResumed = append(toBeResumed, p)
+		}
+	}
+	if len(toBeResumed) > 0 {
+		if err := asgsvc.ResumeProcesses(machinePoolScope.Name(), toBeResumed); err != nil {
+			machinePoolScope.Error(err, "failed to resume processes of ASG of resumed cluster")
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedResumeProcesses", "Failed to resume processes %v of ASG %q of resumed cluster: %v", toBeResumed, machinePoolScope.Name(), err)
+			return true, err
+		}
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulResumeProcesses", "Resumed processes %v of ASG %q of resumed cluster", toBeResumed, machinePoolScope.Name())
+		asg.CurrentlySuspendProcesses = processesNotIn(asg.CurrentlySuspendProcesses, toBeResumed)
+	}
+
+	// Continue the reconciliation with the restored capacity, replicas managed by an external autoscaler would
+	// otherwise be set to zero.
+	asg.MinSize = capacity.MinSize
+	asg.MaxSize = capacity.MaxSize
+	asg.DesiredCapacity = pointer.Int32(capacity.DesiredCapacity)
+	delete(machinePoolScope.AWSMachinePool.Annotations, expinfrav1.HibernatedCapacityAnnotation)
+	return false, nil
+}
+
+// processesNotIn returns the processes that are not part of the excluded ones.
+func processesNotIn(processes, excluded []string) []string {
+	excludedSet := make(map[string]struct{}, len(excluded))
+	for _, p := range excluded {
+		excludedSet[p] = struct{}{}
+	}
+
+	var result []string
+	for _, p := range processes {
+		if _, ok := excludedSet[p]; !ok {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// awsClusterToAWSMachinePoolsMapFunc enqueues the AWSMachinePools of the cluster of an AWSCluster.
+func awsClusterToAWSMachinePoolsMapFunc(c client.Client, log logger.Wrapper) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []ctrl.Request {
+		awsCluster, ok := o.(*infrav1.AWSCluster)
+		if !ok {
+			klog.Errorf("Expected a AWSCluster but got a %T", o)
+		}
+
+		if !awsCluster.ObjectMeta.DeletionTimestamp.IsZero() {
+			return nil
+		}
+
+		clusterKey, err := GetOwnerClusterKey(awsCluster.ObjectMeta)
+		if err != nil {
+			log.Error(err, "couldn't get AWSCluster owner ObjectKey")
+			return nil
+		}
+		if clusterKey == nil {
+			return nil
+		}
+
+		machinePoolList := expinfrav1.AWSMachinePoolList{}
+		if err := c.List(
+			ctx, &machinePoolList, client.InNamespace(clusterKey.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: clusterKey.Name},
+		); err != nil {
+			log.Error(err, "couldn't list machine pools for cluster")
+			return nil
+		}
+
+		var results []ctrl.Request
+		for i := range machinePoolList.Items {
+			mp := machinePoolList.Items[i]
+			results = append(results, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: mp.Namespace,
+					Name:      mp.Name,
+				},
+			})
+		}
+
+		return results
+	}
+}
+
+// hibernationChanged only lets through the updates of an AWSCluster that change its hibernation.
+func hibernationChanged() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCluster, ok := e.ObjectOld.(*infrav1.AWSCluster)
+			if !ok {
+				return false
+			}
+			newCluster, ok := e.ObjectNew.(*infrav1.AWSCluster)
+			if !ok {
+				return false
+			}
+			return !cmp.Equal(oldCluster.Spec.Hibernation, newCluster.Spec.Hibernation)
+		},
+		CreateFunc:  func(e event.CreateEvent) bool { return false },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
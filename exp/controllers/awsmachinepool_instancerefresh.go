@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	corev1 "k8s.io/api/core/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileInstanceRefresh reports the progress of the latest instance refresh of the ASG, and rolls it back once
+// one of the alarms of the alarm specification goes off.
+func (r *AWSMachinePoolReconciler) reconcileInstanceRefresh(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, asg *expinfrav1.AutoScalingGroup) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+
+	refresh, err := asgsvc.GetLatestInstanceRefresh(machinePoolScope)
+	if err != nil {
+		return err
+	}
+	awsMachinePool.Status.InstanceRefresh = refresh
+	if refresh == nil {
+		conditions.MarkTrue(awsMachinePool, expinfrav1.InstanceRefreshReadyCondition)
+		return nil
+	}
+
+	switch refresh.Status {
+	case autoscaling.InstanceRefreshStatusPending, autoscaling.InstanceRefreshStatusInProgress:
+		if rolledBack, err := r.rollbackInstanceRefreshOnAlarm(machinePoolScope, asgsvc); err != nil || rolledBack {
+			return err
+		}
+		conditions.MarkFalse(awsMachinePool, expinfrav1.InstanceRefreshReadyCondition, expinfrav1.InstanceRefreshInProgressReason, clusterv1.ConditionSeverityInfo,
+			"Instance refresh %s is %d%% complete, %d instances to update", refresh.ID, refresh.PercentageComplete, refresh.InstancesToUpdate)
+	case autoscaling.InstanceRefreshStatusCancelling, autoscaling.InstanceRefreshStatusCancelled:
+		conditions.MarkFalse(awsMachinePool, expinfrav1.InstanceRefreshReadyCondition, expinfrav1.InstanceRefreshCancelledReason, clusterv1.ConditionSeverityWarning, refresh.StatusReason)
+	case autoscaling.InstanceRefreshStatusFailed:
+		conditions.MarkFalse(awsMachinePool, expinfrav1.InstanceRefreshReadyCondition, expinfrav1.InstanceRefreshFailedReason, clusterv1.ConditionSeverityError, refresh.StatusReason)
+	case autoscaling.InstanceRefreshStatusRollbackInProgress:
+		conditions.MarkFalse(awsMachinePool, expinfrav1.InstanceRefreshReadyCondition, expinfrav1.InstanceRefreshRollbackInProgressReason, clusterv1.ConditionSeverityWarning, refresh.StatusReason)
+	case autoscaling.InstanceRefreshStatusRollbackSuccessful:
+		revertLaunchTemplateVersion(machinePoolScope, refresh, asg)
+		conditions.MarkFalse(awsMachinePool, expinfrav1.InstanceRefreshReadyCondition, expinfrav1.InstanceRefreshRolledBackReason, clusterv1.ConditionSeverityWarning, refresh.StatusReason)
+	case autoscaling.InstanceRefreshStatusRollbackFailed:
+		revertLaunchTemplateVersion(machinePoolScope, refresh, asg)
+		conditions.MarkFalse(awsMachinePool, expinfrav1.InstanceRefreshReadyCondition, expinfrav1.InstanceRefreshRollbackFailedReason, clusterv1.ConditionSeverityError, refresh.StatusReason)
+	default:
+		conditions.MarkTrue(awsMachinePool, expinfrav1.InstanceRefreshReadyCondition)
+	}
+
+	return nil
+}
+
+// rollbackInstanceRefreshOnAlarm rolls back the instance refresh in progress when one of the alarms of the alarm
+// specification is in the ALARM state.
+func (r *AWSMachinePoolReconciler) rollbackInstanceRefreshOnAlarm(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) (bool, error) {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+
+	prefs := awsMachinePool.Spec.RefreshPreferences
+	if !prefs.AutoRollbackEnabled() || prefs.AlarmSpecification == nil {
+		return false, nil
+	}
+
+	alarms, err := asgsvc.GetAlarmsInAlarmState(prefs.AlarmSpecification.AlarmARNs)
+	if err != nil {
+		return false, err
+	}
+	if len(alarms) == 0 {
+		return false, nil
+	}
+
+	machinePoolScope.Info("Rolling back instance refresh", "alarms", alarms)
+	if err := asgsvc.RollbackASGInstanceRefresh(machinePoolScope); err != nil {
+		return false, err
+	}
+	r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "InstanceRefreshRollback", "Rolling back instance refresh, alarms %v are in the ALARM state", alarms)
+	conditions.MarkFalse(awsMachinePool, expinfrav1.InstanceRefreshReadyCondition, expinfrav1.InstanceRefreshRollbackInProgressReason, clusterv1.ConditionSeverityWarning,
+		"Alarms %v are in the ALARM state", alarms)
+
+	return true, nil
+}
+
+// revertLaunchTemplateVersion moves the launch template version of the AWSMachinePool back to the version the ASG
+// launches its instances from once the instance refresh rolling out the version is rolled back.
+func revertLaunchTemplateVersion(machinePoolScope *scope.MachinePoolScope, refresh *expinfrav1.InstanceRefreshStatus, asg *expinfrav1.AutoScalingGroup) {
+	version := machinePoolScope.GetLaunchTemplateLatestVersionStatus()
+	if asg.LaunchTemplateVersion == "" || asg.LaunchTemplateVersion == version || refresh.LaunchTemplateVersion != version {
+		return
+	}
+
+	machinePoolScope.Info("Reverting launch template version after instance refresh rollback", "version", asg.LaunchTemplateVersion, "rolled-back-version", version)
+	machinePoolScope.SetLaunchTemplateLatestVersionStatus(asg.LaunchTemplateVersion)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+)
+
+// reconcileLaunchTemplateRef resolves the existing launch template the ASG launches its instances from instead of a
+// launch template owned by CAPA. The ASG is pinned to the version the reference resolved to, and only moves to
+// another version along with an instance refresh, as it does for new versions of the launch templates owned by CAPA.
+func (r *AWSMachinePoolReconciler) reconcileLaunchTemplateRef(machinePoolScope *scope.MachinePoolScope, ec2Svc services.EC2Interface, asgsvc services.ASGInterface) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+
+	resolved, err := ec2Svc.ResolveLaunchTemplate(awsMachinePool.Spec.LaunchTemplateRef)
+	if err != nil {
+		return err
+	}
+	awsMachinePool.Status.LaunchTemplateID = aws.StringValue(resolved.ID)
+
+	version := aws.StringValue(resolved.Version)
+	previousVersion := awsMachinePool.Status.LaunchTemplateVersion
+	if previousVersion == nil {
+		// The ASG is created with the resolved version.
+		awsMachinePool.Status.LaunchTemplateVersion = resolved.Version
+		return nil
+	}
+	if version == *previousVersion {
+		return nil
+	}
+	// The ASG stays at its previous version once the instance refresh rolling out the version is rolled back, until
+	// the reference resolves to another version.
+	if refresh := awsMachinePool.Status.InstanceRefresh; refresh != nil && refresh.LaunchTemplateVersion == version &&
+		(refresh.Status == autoscaling.InstanceRefreshStatusRollbackSuccessful || refresh.Status == autoscaling.InstanceRefreshStatusRollbackFailed) {
+		machinePoolScope.Debug("launch template version was rolled back, skipping instance refresh", "version", version)
+		return nil
+	}
+
+	// Only one instance refresh can be in progress, the ASG keeps the version it launches instances from until
+	// the next one can be started.
+	canStartRefresh, err := asgsvc.CanStartASGInstanceRefresh(machinePoolScope)
+	if err != nil {
+		return err
+	}
+	if !canStartRefresh {
+		machinePoolScope.Info("Waiting for the instance refresh in progress to complete before moving to another launch template version", "version", version)
+		return nil
+	}
+
+	machinePoolScope.Info("Moving ASG to another launch template version", "id", resolved.ID, "version", version, "previous-version", *previousVersion)
+	awsMachinePool.Status.LaunchTemplateVersion = resolved.Version
+	// With auto rollback, the instance refresh moves the ASG to the version once it succeeds.
+	if !awsMachinePool.Spec.RefreshPreferences.AutoRollbackEnabled() {
+		if err := asgsvc.UpdateASG(machinePoolScope); err != nil {
+			awsMachinePool.Status.LaunchTemplateVersion = previousVersion
+			return errors.Wrapf(err, "failed to update ASG to launch template version %s", version)
+		}
+	}
+	r.Recorder.Eventf(awsMachinePool, corev1.EventTypeNormal, "LaunchTemplateVersionChanged", "Launch template %s resolves to version %s, previously %s", aws.StringValue(resolved.ID), version, *previousVersion)
+
+	if awsMachinePool.Spec.RefreshPreferences != nil && awsMachinePool.Spec.RefreshPreferences.Disable {
+		machinePoolScope.Debug("instance refresh disabled, skipping instance refresh")
+		return nil
+	}
+
+	machinePoolScope.Info("starting instance refresh", "number of instances", machinePoolScope.MachinePool.Spec.Replicas)
+	if err := asgsvc.StartASGInstanceRefresh(machinePoolScope); err != nil {
+		// The version is moved again, and the instance refresh retried, on the next reconciliation.
+		awsMachinePool.Status.LaunchTemplateVersion = previousVersion
+		return err
+	}
+
+	return nil
+}
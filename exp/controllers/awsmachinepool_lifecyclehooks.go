@@ -0,0 +1,185 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubedrain "k8s.io/kubectl/pkg/drain"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+const (
+	// nodeDrainTimeout is how long a single drain attempt waits for the pods of a node to be evicted before
+	// the AWSMachinePool is requeued.
+	nodeDrainTimeout = 20 * time.Second
+
+	// nodeDrainRequeue is the requeue period of an AWSMachinePool while the node of a terminating instance drains.
+	nodeDrainRequeue = 20 * time.Second
+)
+
+// reconcileTerminatingInstances cordons and drains the nodes of the instances paused by the node drain lifecycle
+// hook of the ASG, and completes their lifecycle action once drained so that EC2 Auto Scaling terminates them.
+func (r *AWSMachinePoolReconciler) reconcileTerminatingInstances(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, instances []infrav1.Instance) (ctrl.Result, error) {
+	if machinePoolScope.AWSMachinePool.Spec.NodeDrainLifecycleHook == nil {
+		delete(machinePoolScope.AWSMachinePool.Annotations, expinfrav1.TerminatingInstanceAnnotation)
+		return ctrl.Result{}, nil
+	}
+
+	var terminating []infrav1.Instance
+	for _, instance := range instances {
+		if string(instance.State) == autoscaling.LifecycleStateTerminatingWait {
+			terminating = append(terminating, instance)
+		}
+	}
+	if len(terminating) == 0 {
+		delete(machinePoolScope.AWSMachinePool.Annotations, expinfrav1.TerminatingInstanceAnnotation)
+		return ctrl.Result{}, nil
+	}
+
+	clientset, err := r.getWorkloadClientset(ctx, machinePoolScope)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to get workload cluster client")
+	}
+
+	result := ctrl.Result{}
+	for _, instance := range terminating {
+		drained, err := r.drainInstanceNode(ctx, machinePoolScope, clientset, instance.ID)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !drained {
+			result.RequeueAfter = nodeDrainRequeue
+			continue
+		}
+
+		if err := asgsvc.CompleteLifecycleAction(machinePoolScope.Name(), expinfrav1.NodeDrainLifecycleHookName, instance.ID); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedCompleteLifecycleAction", "Failed to complete lifecycle action for instance %q: %v", instance.ID, err)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulCompleteLifecycleAction", "Completed lifecycle action for instance %q", instance.ID)
+	}
+
+	return result, nil
+}
+
+// drainInstanceNode cordons and drains the node of an instance. It returns whether the node is drained, an
+// instance without a node has nothing to drain.
+func (r *AWSMachinePoolReconciler) drainInstanceNode(ctx context.Context, machinePoolScope *scope.MachinePoolScope, clientset kubernetes.Interface, instanceID string) (bool, error) {
+	node, err := findNodeByInstanceID(ctx, clientset, instanceID)
+	if err != nil {
+		return false, err
+	}
+	if node == nil {
+		machinePoolScope.Info("No node found for terminating instance, skipping drain", "instance", instanceID)
+		return true, nil
+	}
+
+	drainer := &kubedrain.Helper{
+		Client:              clientset,
+		Ctx:                 ctx,
+		Force:               true,
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  true,
+		GracePeriodSeconds:  -1,
+		Timeout:             nodeDrainTimeout,
+		OnPodDeletedOrEvicted: func(pod *corev1.Pod, usingEviction bool) {
+			verbStr := "Deleted"
+			if usingEviction {
+				verbStr = "Evicted"
+			}
+			machinePoolScope.Info(fmt.Sprintf("%s pod from node", verbStr), "pod", pod.Name, "node", node.Name)
+		},
+		Out:    writer{machinePoolScope.Info},
+		ErrOut: writer{func(msg string, keysAndValues ...interface{}) { machinePoolScope.Error(nil, msg, keysAndValues...) }},
+	}
+
+	if err := kubedrain.RunCordonOrUncordon(drainer, node, true); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDrainNode", "Failed to cordon node %q of instance %q: %v", node.Name, instanceID, err)
+		return false, errors.Wrapf(err, "failed to cordon node %q", node.Name)
+	}
+
+	if err := kubedrain.RunNodeDrain(drainer, node.Name); err != nil {
+		// The eviction of the remaining pods is retried on the next reconcile.
+		machinePoolScope.Info("Node is not drained yet", "node", node.Name, "instance", instanceID, "reason", err.Error())
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDrainNode", "Failed to drain node %q of instance %q: %v", node.Name, instanceID, err)
+		return false, nil
+	}
+
+	r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulDrainNode", "Drained node %q of instance %q", node.Name, instanceID)
+	return true, nil
+}
+
+func (r *AWSMachinePoolReconciler) getWorkloadClientset(ctx context.Context, machinePoolScope *scope.MachinePoolScope) (kubernetes.Interface, error) {
+	if r.workloadClientsetFactory != nil {
+		return r.workloadClientsetFactory(ctx, machinePoolScope)
+	}
+
+	restConfig, err := remote.RESTConfig(ctx, "", r.Client, util.ObjectKey(machinePoolScope.Cluster))
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// findNodeByInstanceID returns the node whose provider ID ends with the ID of an instance, or nil if there is none.
+func findNodeByInstanceID(ctx context.Context, clientset kubernetes.Interface, instanceID string) (*corev1.Node, error) {
+	listOpts := metav1.ListOptions{}
+	for {
+		nodes, err := clientset.CoreV1().Nodes().List(ctx, listOpts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list nodes")
+		}
+
+		for i := range nodes.Items {
+			if strings.HasSuffix(nodes.Items[i].Spec.ProviderID, "/"+instanceID) {
+				return &nodes.Items[i], nil
+			}
+		}
+
+		if nodes.Continue == "" {
+			return nil, nil
+		}
+		listOpts.Continue = nodes.Continue
+	}
+}
+
+// writer implements io.Writer interface as a pass-through for the log functions of a scope.
+type writer struct {
+	logFunc func(msg string, keysAndValues ...interface{})
+}
+
+// Write passes string(p) into writer's logFunc and always returns len(p).
+func (w writer) Write(p []byte) (n int, err error) {
+	w.logFunc(string(p))
+	return len(p), nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/labels/format"
+)
+
+// machinePoolMachineKind is the kind of the infrastructure resources created for the instances of an
+// AWSMachinePool.
+const machinePoolMachineKind = "AWSMachine"
+
+// reconcileMachinePoolMachines creates an AWSMachine for each instance of the ASG, from which Cluster API creates
+// the Machines of the MachinePool, and deletes the Machines of the instances that left the ASG.
+func (r *AWSMachinePoolReconciler) reconcileMachinePoolMachines(ctx context.Context, machinePoolScope *scope.MachinePoolScope, instances []infrav1.Instance) error {
+	if !feature.Gates.Enabled(feature.MachinePoolMachines) {
+		machinePoolScope.AWSMachinePool.Status.InfrastructureMachineKind = ""
+		return nil
+	}
+	machinePoolScope.AWSMachinePool.Status.InfrastructureMachineKind = machinePoolMachineKind
+
+	awsMachines, err := r.getMachinePoolAWSMachines(ctx, machinePoolScope)
+	if err != nil {
+		return err
+	}
+
+	awsMachinesByInstanceID := make(map[string]*infrav1.AWSMachine, len(awsMachines))
+	for i := range awsMachines {
+		awsMachinesByInstanceID[aws.StringValue(awsMachines[i].Spec.InstanceID)] = &awsMachines[i]
+	}
+
+	var errs []error
+	instanceIDs := sets.New[string]()
+	for _, instance := range instances {
+		instanceIDs.Insert(instance.ID)
+		// Instances leaving the ASG do not join the MachinePool, the AWSMachines of the ones that were already
+		// part of it are kept until they are gone.
+		if _, ok := awsMachinesByInstanceID[instance.ID]; ok || isLeavingASG(instance) {
+			continue
+		}
+		if err := r.createMachinePoolAWSMachine(ctx, machinePoolScope, instance); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for instanceID, awsMachine := range awsMachinesByInstanceID {
+		if instanceIDs.Has(instanceID) || !awsMachine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.deleteMachinePoolMachine(ctx, machinePoolScope, awsMachine); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// getMachinePoolAWSMachines lists the AWSMachines of the instances of an AWSMachinePool.
+func (r *AWSMachinePoolReconciler) getMachinePoolAWSMachines(ctx context.Context, machinePoolScope *scope.MachinePoolScope) ([]infrav1.AWSMachine, error) {
+	awsMachines := &infrav1.AWSMachineList{}
+	if err := r.Client.List(ctx, awsMachines, client.InNamespace(machinePoolScope.Namespace()), client.MatchingLabels(machinePoolMachineLabels(machinePoolScope))); err != nil {
+		return nil, errors.Wrapf(err, "failed to list AWSMachines of AWSMachinePool %q", machinePoolScope.Name())
+	}
+
+	return awsMachines.Items, nil
+}
+
+// createMachinePoolAWSMachine creates the AWSMachine of an instance of the ASG. The AWSMachine is named after
+// the instance, so that it is created once even if the cache does not list it yet.
+func (r *AWSMachinePoolReconciler) createMachinePoolAWSMachine(ctx context.Context, machinePoolScope *scope.MachinePoolScope, instance infrav1.Instance) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	awsMachine := &infrav1.AWSMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", awsMachinePool.Name, instance.ID),
+			Namespace: awsMachinePool.Namespace,
+			Labels:    machinePoolMachineLabels(machinePoolScope),
+			// Cluster API sets the Machine it creates for the AWSMachine as its controller.
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: expinfrav1.GroupVersion.String(),
+					Kind:       "AWSMachinePool",
+					Name:       awsMachinePool.Name,
+					UID:        awsMachinePool.UID,
+				},
+			},
+		},
+		Spec: infrav1.AWSMachineSpec{
+			ProviderID:   aws.String(fmt.Sprintf("aws:///%s/%s", instance.AvailabilityZone, instance.ID)),
+			InstanceID:   aws.String(instance.ID),
+			InstanceType: instance.Type,
+		},
+	}
+
+	machinePoolScope.Info("Creating AWSMachine for instance", "instance-id", instance.ID, "awsMachine", klog.KObj(awsMachine))
+	if err := r.Client.Create(ctx, awsMachine); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		r.Recorder.Eventf(awsMachinePool, corev1.EventTypeWarning, "FailedCreateAWSMachine", "Failed to create AWSMachine for instance %q: %v", instance.ID, err)
+		return errors.Wrapf(err, "failed to create AWSMachine for instance %q", instance.ID)
+	}
+	r.Recorder.Eventf(awsMachinePool, corev1.EventTypeNormal, "SuccessfulCreateAWSMachine", "Created AWSMachine %q for instance %q", awsMachine.Name, instance.ID)
+
+	return nil
+}
+
+// deleteMachinePoolMachine deletes the Machine of an instance that left the ASG, which deletes its node and
+// AWSMachine. The AWSMachine is deleted directly while Cluster API has not created a Machine for it yet.
+func (r *AWSMachinePoolReconciler) deleteMachinePoolMachine(ctx context.Context, machinePoolScope *scope.MachinePoolScope, awsMachine *infrav1.AWSMachine) error {
+	machine, err := util.GetOwnerMachine(ctx, r.Client, awsMachine.ObjectMeta)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to get Machine of AWSMachine %q", awsMachine.Name)
+	}
+
+	var obj client.Object = awsMachine
+	if machine != nil {
+		if !machine.DeletionTimestamp.IsZero() {
+			return nil
+		}
+		obj = machine
+	}
+
+	machinePoolScope.Info("Deleting Machine of instance that left the ASG", "instance-id", aws.StringValue(awsMachine.Spec.InstanceID), "object", klog.KObj(obj))
+	if err := r.Client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDeleteMachine", "Failed to delete Machine of instance %q: %v", aws.StringValue(awsMachine.Spec.InstanceID), err)
+		return errors.Wrapf(err, "failed to delete Machine of instance %q", aws.StringValue(awsMachine.Spec.InstanceID))
+	}
+	r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulDeleteMachine", "Deleted Machine of instance %q", aws.StringValue(awsMachine.Spec.InstanceID))
+
+	return nil
+}
+
+// machinePoolMachineLabels returns the labels Cluster API selects the AWSMachines of a MachinePool with.
+func machinePoolMachineLabels(machinePoolScope *scope.MachinePoolScope) map[string]string {
+	return map[string]string{
+		clusterv1.MachinePoolNameLabel: format.MustFormatValue(machinePoolScope.MachinePool.Name),
+		clusterv1.ClusterNameLabel:     machinePoolScope.MachinePool.Spec.ClusterName,
+	}
+}
+
+// isLeavingASG returns whether an instance is being terminated or detached from its ASG.
+func isLeavingASG(instance infrav1.Instance) bool {
+	switch string(instance.State) {
+	case autoscaling.LifecycleStateTerminating, autoscaling.LifecycleStateTerminatingWait,
+		autoscaling.LifecycleStateTerminatingProceed, autoscaling.LifecycleStateTerminated,
+		autoscaling.LifecycleStateDetaching, autoscaling.LifecycleStateDetached:
+		return true
+	}
+	return false
+}
@@ -208,7 +208,22 @@ func (r *AWSManagedMachinePoolReconciler) reconcileNormal(
 	ekssvc := eks.NewNodegroupService(machinePoolScope)
 	ec2svc := r.getEC2Service(ec2Scope)
 
-	if machinePoolScope.ManagedMachinePool.Spec.AWSLaunchTemplate != nil {
+	if ref := machinePoolScope.ManagedMachinePool.Spec.LaunchTemplateRef; ref != nil {
+		// A referenced launch template is not owned by CAPA, it is only resolved. The nodegroup is moved to a
+		// new version of it when reconciling the pool.
+		resolved, err := ec2svc.ResolveLaunchTemplate(ref)
+		if err != nil {
+			r.Recorder.Eventf(machinePoolScope.ManagedMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
+			machinePoolScope.Error(err, "failed to resolve launch template reference")
+			conditions.MarkFalse(machinePoolScope.ManagedMachinePool, expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateReconcileFailedReason, clusterv1.ConditionSeverityError, "")
+			return err
+		}
+		machinePoolScope.ManagedMachinePool.Status.LaunchTemplateID = resolved.ID
+		machinePoolScope.ManagedMachinePool.Status.LaunchTemplateVersion = resolved.Version
+
+		// set the LaunchTemplateReady condition
+		conditions.MarkTrue(machinePoolScope.ManagedMachinePool, expinfrav1.LaunchTemplateReadyCondition)
+	} else if machinePoolScope.ManagedMachinePool.Spec.AWSLaunchTemplate != nil {
 		canUpdateLaunchTemplate := func() (bool, error) {
 			return true, nil
 		}
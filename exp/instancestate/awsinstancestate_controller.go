@@ -36,15 +36,22 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
 
-// Ec2InstanceStateLabelKey defines an ec2 instance state label.
-const Ec2InstanceStateLabelKey = "ec2-instance-state"
+const (
+	// Ec2InstanceStateLabelKey defines an ec2 instance state label.
+	Ec2InstanceStateLabelKey = "ec2-instance-state"
+)
 
 // AwsInstanceStateReconciler reconciles a AwsInstanceState object.
 type AwsInstanceStateReconciler struct {
@@ -58,6 +65,8 @@ type AwsInstanceStateReconciler struct {
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch;update;patch
 
 func (r *AwsInstanceStateReconciler) getSQSService(region string) (sqsiface.SQSAPI, error) {
 	if r.sqsServiceFactory != nil {
@@ -104,7 +113,7 @@ func (r *AwsInstanceStateReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			}
 			return reconcile.Result{}, err
 		}
-		r.queueURLs.Store(awsCluster.Name, queueParams{region: awsCluster.Spec.Region, URL: URL})
+		r.queueURLs.Store(awsCluster.Name, newQueueParams(awsCluster, URL))
 	}
 
 	return ctrl.Result{}, nil
@@ -127,7 +136,7 @@ func (r *AwsInstanceStateReconciler) watchQueuesForInstanceEvents() {
 	if err := r.Client.List(ctx, awsClusterList); err == nil {
 		for i, cluster := range awsClusterList.Items {
 			if URL, err := r.getQueueURL(&awsClusterList.Items[i]); err == nil {
-				r.queueURLs.Store(cluster.Name, queueParams{region: cluster.Spec.Region, URL: URL})
+				r.queueURLs.Store(cluster.Name, newQueueParams(&awsClusterList.Items[i], URL))
 			}
 		}
 	}
@@ -155,7 +164,7 @@ func (r *AwsInstanceStateReconciler) watchQueuesForInstanceEvents() {
 						return
 					}
 					// TODO: handle errors during process message. We currently deletes the message regardless.
-					r.processMessage(ctx, m)
+					r.processMessage(ctx, qp, m)
 
 					_, err = sqsSvs.DeleteMessage(&sqs.DeleteMessageInput{
 						QueueUrl:      aws.String(qp.URL),
@@ -173,45 +182,159 @@ func (r *AwsInstanceStateReconciler) watchQueuesForInstanceEvents() {
 	}
 }
 
-// processMessage triggers a reconcile on an AWSMachine if its EC2 instance state changed.
-func (r *AwsInstanceStateReconciler) processMessage(ctx context.Context, msg message) {
-	if msg.Source != "aws.ec2" || msg.DetailType != instancestate.Ec2StateChangeNotification || msg.MessageDetail == nil {
+// processMessage triggers a reconcile on an AWSMachine if its EC2 instance state changed, marks the owning
+// Machine for remediation if its spot instance is about to be interrupted, and triggers a reconcile on an
+// AWSMachinePool of the cluster of the queue when an instance of its ASG waits for its node to be drained.
+func (r *AwsInstanceStateReconciler) processMessage(ctx context.Context, qp queueParams, msg message) {
+	if msg.MessageDetail == nil {
 		return
 	}
 
-	// Fetch the awsMachine instance by InstanceID
-	awsMachines := &infrav1.AWSMachineList{}
-	err := r.List(ctx, awsMachines, client.MatchingFields{controllers.InstanceIDIndex: msg.MessageDetail.InstanceID})
+	if msg.Source == instancestate.AutoScalingEventSource {
+		if msg.DetailType == instancestate.AutoScalingLifecycleActionTerminate {
+			r.notifyTerminatingInstance(ctx, qp, msg)
+		}
+		return
+	}
+
+	if msg.Source != "aws.ec2" {
+		return
+	}
+
+	switch msg.DetailType {
+	case instancestate.Ec2StateChangeNotification:
+		r.updateInstanceState(ctx, msg)
+	case instancestate.Ec2SpotInstanceInterruptionWarning, instancestate.Ec2InstanceRebalanceRecommendation:
+		r.remediateMachine(ctx, msg)
+	}
+}
+
+// updateInstanceState labels the AWSMachine with the new state of its EC2 instance.
+func (r *AwsInstanceStateReconciler) updateInstanceState(ctx context.Context, msg message) {
+	// The instance changed, so the describe cache of its cluster no longer reflects it.
+	ec2.InvalidateCachedInstance(msg.MessageDetail.InstanceID)
+
+	machine := r.getAWSMachine(ctx, msg.MessageDetail.InstanceID)
+	if machine == nil {
+		return
+	}
+
+	patchHelper, err := patch.NewHelper(machine, r.Client)
+	if err != nil {
+		r.Log.Error(err, "unable to create patch helper")
+		return
+	}
+	// Trigger an update on the machine
+	labels := machine.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+
+	labels[Ec2InstanceStateLabelKey] = string(msg.MessageDetail.State)
+	machine.SetLabels(labels)
+
+	err = patchHelper.Patch(ctx, machine)
+	if err != nil {
+		r.Log.Error(err, "unable to patch AWS machine")
+	}
+}
+
+// remediateMachine marks the Machine owning the AWSMachine of an instance for remediation, so it gets drained
+// and replaced before the instance is interrupted.
+func (r *AwsInstanceStateReconciler) remediateMachine(ctx context.Context, msg message) {
+	awsMachine := r.getAWSMachine(ctx, msg.MessageDetail.InstanceID)
+	if awsMachine == nil {
+		return
+	}
+
+	machine, err := util.GetOwnerMachine(ctx, r.Client, awsMachine.ObjectMeta)
+	if err != nil {
+		r.Log.Error(err, "unable to get owner machine", "awsMachine", klog.KObj(awsMachine))
+		return
+	}
+	if machine == nil || !machine.DeletionTimestamp.IsZero() {
+		return
+	}
+	if conditions.IsFalse(machine, clusterv1.MachineOwnerRemediatedCondition) {
+		return
+	}
+
+	patchHelper, err := patch.NewHelper(machine, r.Client)
+	if err != nil {
+		r.Log.Error(err, "unable to create patch helper")
+		return
+	}
 
+	conditions.MarkFalse(machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning,
+		"%s received for instance %s", msg.DetailType, msg.MessageDetail.InstanceID)
+
+	r.Log.Info("Marking machine for remediation", "machine", klog.KObj(machine), "instanceID", msg.MessageDetail.InstanceID, "event", msg.DetailType)
+	err = patchHelper.Patch(ctx, machine, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{clusterv1.MachineOwnerRemediatedCondition}})
 	if err != nil {
-		r.Log.Error(err, "unable to list machines by instance ID", "instanceID", msg.MessageDetail.InstanceID)
+		r.Log.Error(err, "unable to patch machine")
+	}
+}
+
+// notifyTerminatingInstance annotates the AWSMachinePool of the ASG of an instance paused by the node drain
+// lifecycle hook, so that it drains its node without waiting for the next resync. Only the AWSMachinePools of
+// the cluster of the queue are looked up, as ASGs of other clusters may have the same name.
+func (r *AwsInstanceStateReconciler) notifyTerminatingInstance(ctx context.Context, qp queueParams, msg message) {
+	if msg.MessageDetail.LifecycleHookName != expinfrav1.NodeDrainLifecycleHookName {
+		return
+	}
+
+	listOpts := []client.ListOption{client.InNamespace(qp.namespace)}
+	if qp.clusterName != "" {
+		listOpts = append(listOpts, client.MatchingLabels{clusterv1.ClusterNameLabel: qp.clusterName})
+	}
+	awsMachinePools := &expinfrav1.AWSMachinePoolList{}
+	if err := r.List(ctx, awsMachinePools, listOpts...); err != nil {
+		r.Log.Error(err, "unable to list machine pools", "asg", msg.MessageDetail.AutoScalingGroupName)
+		return
 	}
 
-	if len(awsMachines.Items) > 0 {
-		machine := awsMachines.Items[0]
-		if !machine.ObjectMeta.DeletionTimestamp.IsZero() {
-			return
+	for i := range awsMachinePools.Items {
+		machinePool := &awsMachinePools.Items[i]
+		// The ASG of an AWSMachinePool is named after it.
+		if machinePool.Name != msg.MessageDetail.AutoScalingGroupName || !machinePool.DeletionTimestamp.IsZero() {
+			continue
 		}
-		patchHelper, err := patch.NewHelper(&machine, r.Client)
+
+		patchHelper, err := patch.NewHelper(machinePool, r.Client)
 		if err != nil {
 			r.Log.Error(err, "unable to create patch helper")
-		}
-		// Trigger an update on the machine
-		labels := machine.GetLabels()
-		if labels == nil {
-			labels = make(map[string]string)
+			continue
 		}
 
-		labels[Ec2InstanceStateLabelKey] = string(msg.MessageDetail.State)
-		machine.SetLabels(labels)
+		annotations := machinePool.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[expinfrav1.TerminatingInstanceAnnotation] = msg.MessageDetail.EC2InstanceID
+		machinePool.SetAnnotations(annotations)
 
-		err = patchHelper.Patch(ctx, &machine)
-		if err != nil {
-			r.Log.Error(err, "unable to patch AWS machine")
+		r.Log.Info("Notifying machine pool of terminating instance", "awsMachinePool", klog.KObj(machinePool), "instanceID", msg.MessageDetail.EC2InstanceID)
+		if err := patchHelper.Patch(ctx, machinePool); err != nil {
+			r.Log.Error(err, "unable to patch AWS machine pool")
 		}
 	}
 }
 
+// getAWSMachine returns the AWSMachine of an instance, or nil if there is none or it is being deleted.
+func (r *AwsInstanceStateReconciler) getAWSMachine(ctx context.Context, instanceID string) *infrav1.AWSMachine {
+	awsMachines := &infrav1.AWSMachineList{}
+	err := r.List(ctx, awsMachines, client.MatchingFields{controllers.InstanceIDIndex: instanceID})
+	if err != nil {
+		r.Log.Error(err, "unable to list machines by instance ID", "instanceID", instanceID)
+		return nil
+	}
+
+	if len(awsMachines.Items) == 0 || !awsMachines.Items[0].DeletionTimestamp.IsZero() {
+		return nil
+	}
+	return &awsMachines.Items[0]
+}
+
 // getQueueURL retrieves the SQS queue URL for a given cluster.
 func (r *AwsInstanceStateReconciler) getQueueURL(cluster *infrav1.AWSCluster) (string, error) {
 	sqsSvs, err := r.getSQSService(cluster.Spec.Region)
@@ -238,8 +361,21 @@ func queueNotFoundError(err error) bool {
 }
 
 type queueParams struct {
-	region string
-	URL    string
+	region      string
+	URL         string
+	namespace   string
+	clusterName string
+}
+
+// newQueueParams returns the parameters of the queue of an AWSCluster. The name of its Cluster is the one of the
+// label set by Cluster API, it is empty until the label is set.
+func newQueueParams(awsCluster *infrav1.AWSCluster, URL string) queueParams {
+	return queueParams{
+		region:      awsCluster.Spec.Region,
+		URL:         URL,
+		namespace:   awsCluster.Namespace,
+		clusterName: awsCluster.Labels[clusterv1.ClusterNameLabel],
+	}
 }
 
 type message struct {
@@ -251,4 +387,9 @@ type message struct {
 type messageDetail struct {
 	InstanceID string                `json:"instance-id,omitempty"`
 	State      infrav1.InstanceState `json:"state,omitempty"`
+
+	// Lifecycle actions of EC2 Auto Scaling have their own detail fields.
+	EC2InstanceID        string `json:"EC2InstanceId,omitempty"`
+	AutoScalingGroupName string `json:"AutoScalingGroupName,omitempty"`
+	LifecycleHookName    string `json:"LifecycleHookName,omitempty"`
 }
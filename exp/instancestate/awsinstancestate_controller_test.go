@@ -29,14 +29,20 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_sqsiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestAWSInstanceStateController(t *testing.T) {
@@ -170,6 +176,193 @@ func TestAWSInstanceStateController(t *testing.T) {
 	})
 }
 
+func TestRemediateMachineOnSpotInterruption(t *testing.T) {
+	testCases := []struct {
+		name              string
+		detailType        string
+		instanceID        string
+		conditions        clusterv1.Conditions
+		expectRemediation bool
+		expectMessage     string
+	}{
+		{
+			name:              "marks machine for remediation on spot interruption warning",
+			detailType:        instancestate.Ec2SpotInstanceInterruptionWarning,
+			instanceID:        "i-spot",
+			expectRemediation: true,
+			expectMessage:     instancestate.Ec2SpotInstanceInterruptionWarning + " received for instance i-spot",
+		},
+		{
+			name:              "marks machine for remediation on rebalance recommendation",
+			detailType:        instancestate.Ec2InstanceRebalanceRecommendation,
+			instanceID:        "i-spot",
+			expectRemediation: true,
+			expectMessage:     instancestate.Ec2InstanceRebalanceRecommendation + " received for instance i-spot",
+		},
+		{
+			name:              "does nothing for unknown instances",
+			detailType:        instancestate.Ec2SpotInstanceInterruptionWarning,
+			instanceID:        "i-unknown",
+			expectRemediation: false,
+		},
+		{
+			name:       "does nothing if machine is already marked for remediation",
+			detailType: instancestate.Ec2SpotInstanceInterruptionWarning,
+			instanceID: "i-spot",
+			conditions: clusterv1.Conditions{
+				*conditions.FalseCondition(clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, "unhealthy node"),
+			},
+			expectRemediation: true,
+			expectMessage:     "unhealthy node",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "machine",
+					Namespace: "default",
+				},
+				Spec:   clusterv1.MachineSpec{ClusterName: "cluster"},
+				Status: clusterv1.MachineStatus{Conditions: tc.conditions},
+			}
+			awsMachine := &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "aws-machine",
+					Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: clusterv1.GroupVersion.String(),
+						Kind:       "Machine",
+						Name:       "machine",
+					}},
+				},
+				Spec: infrav1.AWSMachineSpec{
+					InstanceID:   pointer.String("i-spot"),
+					InstanceType: "test",
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(machine, awsMachine).
+				WithStatusSubresource(machine).
+				WithIndex(&infrav1.AWSMachine{}, controllers.InstanceIDIndex, func(o client.Object) []string {
+					m := o.(*infrav1.AWSMachine)
+					if m.Spec.InstanceID != nil {
+						return []string{*m.Spec.InstanceID}
+					}
+					return nil
+				}).Build()
+
+			r := &AwsInstanceStateReconciler{
+				Client: c,
+				Log:    ctrl.Log.WithName("controllers").WithName("AWSInstanceState"),
+			}
+			r.processMessage(context.TODO(), queueParams{namespace: "default", clusterName: "cluster"}, message{
+				Source:        "aws.ec2",
+				DetailType:    tc.detailType,
+				MessageDetail: &messageDetail{InstanceID: tc.instanceID},
+			})
+
+			got := &clusterv1.Machine{}
+			g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(machine), got)).To(Succeed())
+			g.Expect(conditions.IsFalse(got, clusterv1.MachineOwnerRemediatedCondition)).To(Equal(tc.expectRemediation))
+			if tc.expectRemediation {
+				g.Expect(conditions.GetMessage(got, clusterv1.MachineOwnerRemediatedCondition)).To(Equal(tc.expectMessage))
+			}
+		})
+	}
+}
+
+func TestNotifyMachinePoolOfTerminatingInstance(t *testing.T) {
+	testCases := []struct {
+		name              string
+		namespace         string
+		clusterName       string
+		asgName           string
+		lifecycleHookName string
+		expectAnnotation  bool
+	}{
+		{
+			name:              "annotates the machine pool of the ASG",
+			namespace:         "default",
+			clusterName:       "cluster",
+			asgName:           "machine-pool",
+			lifecycleHookName: expinfrav1.NodeDrainLifecycleHookName,
+			expectAnnotation:  true,
+		},
+		{
+			name:              "does nothing for other lifecycle hooks",
+			namespace:         "default",
+			clusterName:       "cluster",
+			asgName:           "machine-pool",
+			lifecycleHookName: "other-hook",
+			expectAnnotation:  false,
+		},
+		{
+			name:              "does nothing for unknown ASGs",
+			namespace:         "default",
+			clusterName:       "cluster",
+			asgName:           "unknown",
+			lifecycleHookName: expinfrav1.NodeDrainLifecycleHookName,
+			expectAnnotation:  false,
+		},
+		{
+			name:              "does nothing for machine pools of clusters in other namespaces",
+			namespace:         "other",
+			clusterName:       "cluster",
+			asgName:           "machine-pool",
+			lifecycleHookName: expinfrav1.NodeDrainLifecycleHookName,
+			expectAnnotation:  false,
+		},
+		{
+			name:              "does nothing for machine pools of other clusters",
+			namespace:         "default",
+			clusterName:       "other-cluster",
+			asgName:           "machine-pool",
+			lifecycleHookName: expinfrav1.NodeDrainLifecycleHookName,
+			expectAnnotation:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machinePool := &expinfrav1.AWSMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "machine-pool",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster"},
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(machinePool).Build()
+
+			r := &AwsInstanceStateReconciler{
+				Client: c,
+				Log:    ctrl.Log.WithName("controllers").WithName("AWSInstanceState"),
+			}
+			r.processMessage(context.TODO(), queueParams{namespace: tc.namespace, clusterName: tc.clusterName}, message{
+				Source:     instancestate.AutoScalingEventSource,
+				DetailType: instancestate.AutoScalingLifecycleActionTerminate,
+				MessageDetail: &messageDetail{
+					EC2InstanceID:        "i-terminating",
+					AutoScalingGroupName: tc.asgName,
+					LifecycleHookName:    tc.lifecycleHookName,
+				},
+			})
+
+			got := &expinfrav1.AWSMachinePool{}
+			g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(machinePool), got)).To(Succeed())
+			if tc.expectAnnotation {
+				g.Expect(got.Annotations).To(HaveKeyWithValue(expinfrav1.TerminatingInstanceAnnotation, "i-terminating"))
+			} else {
+				g.Expect(got.Annotations).NotTo(HaveKey(expinfrav1.TerminatingInstanceAnnotation))
+			}
+		})
+	}
+}
+
 const messageBodyJSON = `{
 	"source": "aws.ec2",
 	"detail-type": "EC2 Instance State-change Notification",
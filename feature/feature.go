@@ -85,6 +85,16 @@ const (
 	// owner: @enxebre
 	// alpha: v2.2
 	ROSA featuregate.Feature = "ROSA"
+
+	// EC2DescribeCache is used to share a per-cluster cache of instances, network interfaces and security groups
+	// between AWSMachine reconciles, listing them periodically instead of describing them one by one.
+	// alpha: v2.3
+	EC2DescribeCache featuregate.Feature = "EC2DescribeCache"
+
+	// MachinePoolMachines is used to create an AWSMachine for each instance of an AWSMachinePool, from which
+	// Cluster API creates the Machines of the MachinePool.
+	// alpha: v2.3
+	MachinePoolMachines featuregate.Feature = "MachinePoolMachines"
 )
 
 func init() {
@@ -107,4 +117,6 @@ var defaultCAPAFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	AlternativeGCStrategy:         {Default: false, PreRelease: featuregate.Alpha},
 	TagUnmanagedNetworkResources:  {Default: true, PreRelease: featuregate.Alpha},
 	ROSA:                          {Default: false, PreRelease: featuregate.Alpha},
+	EC2DescribeCache:              {Default: false, PreRelease: featuregate.Alpha},
+	MachinePoolMachines:           {Default: false, PreRelease: featuregate.Alpha},
 }
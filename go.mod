@@ -38,6 +38,7 @@ require (
 	k8s.io/client-go v0.27.3
 	k8s.io/component-base v0.27.3
 	k8s.io/klog/v2 v2.100.1
+	k8s.io/kubectl v0.27.3
 	k8s.io/utils v0.0.0-20230220204549-a5ecb0141aa5
 	sigs.k8s.io/aws-iam-authenticator v0.6.12
 	sigs.k8s.io/cluster-api v1.5.2
@@ -185,7 +186,6 @@ require (
 	k8s.io/cluster-bootstrap v0.27.2 // indirect
 	k8s.io/component-helpers v0.27.3 // indirect
 	k8s.io/kube-openapi v0.0.0-20230501164219-8b0f38b5fd1f // indirect
-	k8s.io/kubectl v0.27.3 // indirect
 	k8s.io/metrics v0.27.3 // indirect
 	sigs.k8s.io/json v0.0.0-20221116044647-bc3834ca7abd // indirect
 	sigs.k8s.io/kind v0.20.0 // indirect
@@ -103,6 +103,7 @@ var (
 	webhookCertDir           string
 	healthAddr               string
 	serviceEndpoints         string
+	ec2DescribeCachePeriod   time.Duration
 
 	// maxEKSSyncPeriod is the maximum allowed duration for the sync-period flag when using EKS. It is set to 10 minutes
 	// because during resync it will create a new AWS auth token which can a maximum life of 15 minutes and this ensures
@@ -248,6 +249,12 @@ func main() {
 func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServiceEndpoints []scope.ServiceEndpoint,
 	externalResourceGC, alternativeGCStrategy bool,
 ) {
+	var describeCachePeriod time.Duration
+	if feature.Gates.Enabled(feature.EC2DescribeCache) {
+		setupLog.Info("enabling the shared EC2 describe cache", "period", ec2DescribeCachePeriod)
+		describeCachePeriod = ec2DescribeCachePeriod
+	}
+
 	if err := (&controllers.AWSMachineReconciler{
 		Client:                       mgr.GetClient(),
 		Log:                          ctrl.Log.WithName("controllers").WithName("AWSMachine"),
@@ -255,6 +262,7 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		Endpoints:                    awsServiceEndpoints,
 		WatchFilterValue:             watchFilterValue,
 		TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
+		DescribeCachePeriod:          describeCachePeriod,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachineConcurrency, RecoverPanic: pointer.Bool(true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSMachine")
 		os.Exit(1)
@@ -512,6 +520,12 @@ func initFlags(fs *pflag.FlagSet) {
 		fmt.Sprintf("The minimum interval at which watched resources are reconciled. If EKS is enabled the maximum allowed is %s", maxEKSSyncPeriod),
 	)
 
+	fs.DurationVar(&ec2DescribeCachePeriod,
+		"ec2-describe-cache-period",
+		1*time.Minute,
+		"The interval at which the instances, network interfaces and security groups of a cluster are listed into the shared EC2 describe cache, when the EC2DescribeCache feature gate is enabled.",
+	)
+
 	fs.IntVar(&webhookPort,
 		"webhook-port",
 		9443,
@@ -18,6 +18,7 @@ package awserrors
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ssm"
@@ -31,9 +32,13 @@ const (
 	EIPNotFound                       = "InvalidElasticIpID.NotFound"
 	GatewayNotFound                   = "InvalidGatewayID.NotFound"
 	GroupNotFound                     = "InvalidGroup.NotFound"
+	ImageMalformed                    = "InvalidAMIID.Malformed"
+	ImageNotFound                     = "InvalidAMIID.NotFound"
 	InternetGatewayNotFound           = "InvalidInternetGatewayID.NotFound"
 	EgressOnlyInternetGatewayNotFound = "InvalidEgressOnlyInternetGatewayID.NotFound"
 	InUseIPAddress                    = "InvalidIPAddress.InUse"
+	InsufficientCapacity              = "InsufficientCapacity"
+	InsufficientInstanceCapacity      = "InsufficientInstanceCapacity"
 	InvalidAccessKeyID                = "InvalidAccessKeyId"
 	InvalidClientTokenID              = "InvalidClientTokenId"
 	InvalidInstanceID                 = "InvalidInstanceID.NotFound"
@@ -45,12 +50,15 @@ const (
 	NoCredentialProviders                   = "NoCredentialProviders"
 	NoSuchKey                               = "NoSuchKey"
 	PermissionNotFound                      = "InvalidPermission.NotFound"
+	PlacementGroupInUse                     = "InvalidPlacementGroup.InUse"
+	PlacementGroupNotFound                  = "InvalidPlacementGroup.Unknown"
 	ResourceExists                          = "ResourceExistsException"
 	ResourceNotFound                        = "InvalidResourceID.NotFound"
 	RouteTableNotFound                      = "InvalidRouteTableID.NotFound"
 	SubnetNotFound                          = "InvalidSubnetID.NotFound"
 	UnrecognizedClientException             = "UnrecognizedClientException"
 	UnauthorizedOperation                   = "UnauthorizedOperation"
+	ValidationError                         = "ValidationError"
 	VPCNotFound                             = "InvalidVpcID.NotFound"
 	VPCMissingParameter                     = "MissingParameter"
 	ErrCodeRepositoryAlreadyExistsException = "RepositoryAlreadyExistsException"
@@ -175,6 +183,25 @@ func IsInvalidNotFoundError(err error) bool {
 	return false
 }
 
+// IsInsufficientCapacity tests for errors returned when EC2 does not have enough capacity to launch an instance.
+func IsInsufficientCapacity(err error) bool {
+	if code, ok := Code(err); ok {
+		return code == InsufficientInstanceCapacity || code == InsufficientCapacity
+	}
+
+	return false
+}
+
+// IsInstanceNotInASG tests for the error returned by EC2 Auto Scaling for an instance that is not part of any ASG,
+// for instance once it has been detached.
+func IsInstanceNotInASG(err error) bool {
+	if code, ok := Code(err); ok {
+		return code == ValidationError && strings.Contains(Message(err), "No managed instance found")
+	}
+
+	return false
+}
+
 // IsPermissionsError tests for common aws permission errors.
 func IsPermissionsError(err error) bool {
 	if code, ok := Code(err); ok {
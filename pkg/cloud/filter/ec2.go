@@ -166,3 +166,27 @@ func (ec2Filters) IgnoreLocalZones() *ec2.Filter {
 		Values: aws.StringSlice([]string{"opt-in-not-required"}),
 	}
 }
+
+// DedicatedHostGroup returns a filter based on the group a dynamically allocated Dedicated Host is shared by.
+func (ec2Filters) DedicatedHostGroup(group string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String(fmt.Sprintf("tag:%s", infrav1.NameAWSDedicatedHostGroup)),
+		Values: aws.StringSlice([]string{group}),
+	}
+}
+
+// VolumeIDs returns a filter based on the list of volume IDs passed in.
+func (ec2Filters) VolumeIDs(ids ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("volume-id"),
+		Values: aws.StringSlice(ids),
+	}
+}
+
+// SnapshotStates returns a filter based on the list of snapshot states passed in.
+func (ec2Filters) SnapshotStates(states ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("status"),
+		Values: aws.StringSlice(states),
+	}
+}
@@ -41,6 +41,12 @@ const (
 	metricControllerLabel    = "controller"
 	metricStatusCodeLabel    = "status_code"
 	metricErrorCodeLabel     = "error_code"
+
+	metricEC2DescribeCacheRequestsKey = "ec2_describe_cache_requests_total"
+	metricResourceLabel               = "resource"
+	metricResultLabel                 = "result"
+	metricResultHit                   = "hit"
+	metricResultMiss                  = "miss"
 )
 
 var (
@@ -60,12 +66,18 @@ var (
 		Help:      "Number of retries made against an AWS API",
 		Buckets:   []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
 	}, []string{metricControllerLabel, metricServiceLabel, metricRegionLabel, metricOperationLabel})
+	ec2DescribeCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricAWSSubsystem,
+		Name:      metricEC2DescribeCacheRequestsKey,
+		Help:      "Total number of reads of the shared EC2 describe cache, by result",
+	}, []string{metricRegionLabel, metricResourceLabel, metricResultLabel})
 )
 
 func init() {
 	metrics.Registry.MustRegister(awsRequestCount)
 	metrics.Registry.MustRegister(awsRequestDurationSeconds)
 	metrics.Registry.MustRegister(awsCallRetries)
+	metrics.Registry.MustRegister(ec2DescribeCacheRequests)
 }
 
 // CaptureRequestMetrics will monitor and capture request metrics.
@@ -92,6 +104,15 @@ func CaptureRequestMetrics(controller string) func(r *request.Request) {
 	}
 }
 
+// CaptureDescribeCacheRequest counts a read of the shared EC2 describe cache, so that its hit ratio can be monitored.
+func CaptureDescribeCacheRequest(region, resource string, hit bool) {
+	result := metricResultMiss
+	if hit {
+		result = metricResultHit
+	}
+	ec2DescribeCacheRequests.WithLabelValues(region, resource, result).Inc()
+}
+
 func endpointToService(endpoint string) string {
 	endpointURL, err := url.Parse(endpoint)
 	// If possible extract the service name, else return entire endpoint address
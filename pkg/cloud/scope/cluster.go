@@ -244,6 +244,10 @@ func (s *ClusterScope) PatchObject() error {
 		}
 	}
 
+	if len(s.AWSCluster.Spec.PlacementGroups) > 0 {
+		applicableConditions = append(applicableConditions, infrav1.PlacementGroupsReadyCondition)
+	}
+
 	conditions.SetSummary(s.AWSCluster,
 		conditions.WithConditions(applicableConditions...),
 		conditions.WithStepCounterIf(s.AWSCluster.ObjectMeta.DeletionTimestamp.IsZero()),
@@ -263,7 +267,9 @@ func (s *ClusterScope) PatchObject() error {
 			infrav1.RouteTablesReadyCondition,
 			infrav1.ClusterSecurityGroupsReadyCondition,
 			infrav1.BastionHostReadyCondition,
+			infrav1.PlacementGroupsReadyCondition,
 			infrav1.LoadBalancerReadyCondition,
+			infrav1.HibernatedCondition,
 			infrav1.PrincipalUsageAllowedCondition,
 			infrav1.PrincipalCredentialRetrievedCondition,
 		}})
@@ -337,6 +343,25 @@ func (s *ClusterScope) Bastion() *infrav1.Bastion {
 	return &s.AWSCluster.Spec.Bastion
 }
 
+// PlacementGroups returns the placement groups to create with the cluster.
+func (s *ClusterScope) PlacementGroups() []infrav1.PlacementGroupSpec {
+	return s.AWSCluster.Spec.PlacementGroups
+}
+
+// ControlPlaneTerminationProtection returns whether the instances of control plane machines are protected
+// against termination and stop by default.
+func (s *ClusterScope) ControlPlaneTerminationProtection() bool {
+	if s.AWSCluster.Spec.ControlPlaneTerminationProtection == nil {
+		return true
+	}
+	return *s.AWSCluster.Spec.ControlPlaneTerminationProtection
+}
+
+// Hibernation returns the hibernation of the cluster, nil if it was never hibernated.
+func (s *ClusterScope) Hibernation() *infrav1.Hibernation {
+	return s.AWSCluster.Spec.Hibernation
+}
+
 // TagUnmanagedNetworkResources returns if the feature flag tag unmanaged network resources is set.
 func (s *ClusterScope) TagUnmanagedNetworkResources() bool {
 	return s.tagUnmanagedNetworkResources
@@ -43,6 +43,16 @@ type EC2Scope interface {
 	// SetBastionInstance sets the bastion instance in the status of the cluster.
 	SetBastionInstance(instance *infrav1.Instance)
 
+	// PlacementGroups returns the placement groups to create with the cluster.
+	PlacementGroups() []infrav1.PlacementGroupSpec
+
+	// ControlPlaneTerminationProtection returns whether the instances of control plane machines are protected
+	// against termination and stop by default.
+	ControlPlaneTerminationProtection() bool
+
+	// Hibernation returns the hibernation of the cluster, nil if it was never hibernated.
+	Hibernation() *infrav1.Hibernation
+
 	// SSHKeyName returns the SSH key name to use for instances.
 	SSHKeyName() *string
 
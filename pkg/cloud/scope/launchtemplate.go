@@ -36,6 +36,7 @@ type LaunchTemplateScope interface {
 	SetLaunchTemplateIDStatus(id string)
 	GetLaunchTemplateLatestVersionStatus() string
 	SetLaunchTemplateLatestVersionStatus(version string)
+	SetLaunchTemplateImageIDStatus(id string)
 	GetRawBootstrapData() ([]byte, error)
 
 	IsEKSManaged() bool
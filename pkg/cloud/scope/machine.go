@@ -117,6 +117,13 @@ func (m *MachineScope) IsControlPlane() bool {
 	return util.IsControlPlaneMachine(m.Machine)
 }
 
+// IsMachinePoolMachine returns true if the machine is an instance of an AWSMachinePool, which Cluster API
+// created for its MachinePool.
+func (m *MachineScope) IsMachinePoolMachine() bool {
+	_, ok := m.Machine.Labels[clusterv1.MachinePoolNameLabel]
+	return ok
+}
+
 // Role returns the machine role from the labels.
 func (m *MachineScope) Role() string {
 	if util.IsControlPlaneMachine(m.Machine) {
@@ -204,6 +211,29 @@ func (m *MachineScope) UseIgnition(userDataFormat string) bool {
 	return userDataFormat == "ignition" || (m.AWSMachine.Spec.Ignition != nil)
 }
 
+// TerminationProtection returns whether the instance of the machine must be protected against termination and stop.
+func (m *MachineScope) TerminationProtection() bool {
+	if m.AWSMachine.Spec.TerminationProtection != nil {
+		return *m.AWSMachine.Spec.TerminationProtection
+	}
+	// Spot instances cannot be protected.
+	if m.AWSMachine.Spec.SpotMarketOptions != nil {
+		return false
+	}
+	return m.IsControlPlane() && m.InfraCluster.ControlPlaneTerminationProtection()
+}
+
+// ClusterHibernated returns whether the cluster of the machine is hibernated.
+func (m *MachineScope) ClusterHibernated() bool {
+	h := m.InfraCluster.Hibernation()
+	return h != nil && h.Hibernate
+}
+
+// IsWindows returns whether the machine runs the Windows operating system.
+func (m *MachineScope) IsWindows() bool {
+	return m.AWSMachine.Spec.OSType == infrav1.OSTypeWindows
+}
+
 // SecureSecretsBackend returns the chosen secret backend.
 func (m *MachineScope) SecureSecretsBackend() infrav1.SecretBackend {
 	return m.AWSMachine.Spec.CloudInit.SecureSecretsBackend
@@ -316,6 +346,9 @@ func (m *MachineScope) PatchObject() error {
 			infrav1.InstanceReadyCondition,
 			infrav1.SecurityGroupsReadyCondition,
 			infrav1.ELBAttachedCondition,
+			infrav1.VolumesReadyCondition,
+			infrav1.LaunchTemplateUpToDateCondition,
+			infrav1.DriftedCondition,
 		}})
 }
 
@@ -381,3 +414,29 @@ func (m *MachineScope) SetInterruptible() {
 		m.AWSMachine.Status.Interruptible = true
 	}
 }
+
+// DedicatedHostGroup returns the name of the group of machines that share dynamically allocated
+// Dedicated Hosts. Machines of the same MachineDeployment share hosts, other machines get their own.
+func (m *MachineScope) DedicatedHostGroup() string {
+	if name, ok := m.Machine.Labels[clusterv1.MachineDeploymentNameLabel]; ok && name != "" {
+		return name
+	}
+	return m.Name()
+}
+
+// GetDedicatedHostID returns the ID of the Dedicated Host allocated for the AWSMachine, if any.
+func (m *MachineScope) GetDedicatedHostID() *string {
+	if m.AWSMachine.Status.DedicatedHost == nil {
+		return nil
+	}
+	return m.AWSMachine.Status.DedicatedHost.ID
+}
+
+// SetDedicatedHostID sets the ID of the Dedicated Host allocated for the AWSMachine.
+func (m *MachineScope) SetDedicatedHostID(id *string) {
+	if id == nil {
+		m.AWSMachine.Status.DedicatedHost = nil
+		return
+	}
+	m.AWSMachine.Status.DedicatedHost = &infrav1.DedicatedHostStatus{ID: id}
+}
@@ -297,6 +297,31 @@ func TestCompressUserData(t *testing.T) {
 	})
 }
 
+func TestIsMachinePoolMachine(t *testing.T) {
+	t.Run("returns_false_when_machine_is_not_part_of_a_machine_pool", func(t *testing.T) {
+		scope, err := setupMachineScope()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if scope.IsMachinePoolMachine() {
+			t.Fatalf("IsMachinePoolMachine should be false")
+		}
+	})
+
+	t.Run("returns_true_when_machine_has_the_machine_pool_name_label", func(t *testing.T) {
+		scope, err := setupMachineScope()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		scope.Machine.Labels[clusterv1.MachinePoolNameLabel] = "my-machine-pool"
+		if !scope.IsMachinePoolMachine() {
+			t.Fatalf("IsMachinePoolMachine should be true")
+		}
+	})
+}
+
 func TestGetSecretARNDefaultIsNil(t *testing.T) {
 	scope, err := setupMachineScope()
 	if err != nil {
@@ -285,11 +285,44 @@ func (m *MachinePoolScope) SetLaunchTemplateLatestVersionStatus(version string)
 	m.AWSMachinePool.Status.LaunchTemplateVersion = &version
 }
 
+func (m *MachinePoolScope) SetLaunchTemplateImageIDStatus(id string) {
+	m.AWSMachinePool.Status.ImageID = id
+}
+
 // IsEKSManaged checks if the AWSMachinePool is EKS managed.
 func (m *MachinePoolScope) IsEKSManaged() bool {
 	return m.InfraCluster.InfraCluster().GetObjectKind().GroupVersionKind().Kind == ekscontrolplanev1.AWSManagedControlPlaneKind
 }
 
+// ClusterHibernated returns whether the cluster of the AWSMachinePool is hibernated.
+func (m *MachinePoolScope) ClusterHibernated() bool {
+	h := m.InfraCluster.Hibernation()
+	return h != nil && h.Hibernate
+}
+
+// ReplicasManagedByScalingPolicies returns whether the desired capacity of the ASG is driven by the scaling
+// policies or scheduled actions of the AWSMachinePool, in which case it is not updated from the MachinePool
+// replicas.
+func (m *MachinePoolScope) ReplicasManagedByScalingPolicies() bool {
+	return ReplicasManagedByScalingPolicies(m.AWSMachinePool)
+}
+
+// ReplicasManagedByScalingPolicies returns whether the desired capacity of the ASG of an AWSMachinePool is driven
+// by its scaling policies or scheduled actions.
+func ReplicasManagedByScalingPolicies(awsMachinePool *expinfrav1.AWSMachinePool) bool {
+	return len(awsMachinePool.Spec.ScalingPolicies) > 0 || len(awsMachinePool.Spec.ScheduledActions) > 0
+}
+
+// SizeManagedByScheduledActions returns whether the minimum and maximum sizes of the ASG are set by the
+// scheduled actions of the AWSMachinePool, in which case they are not updated from the AWSMachinePool.
+func (m *MachinePoolScope) SizeManagedByScheduledActions() (minSize, maxSize bool) {
+	for _, action := range m.AWSMachinePool.Spec.ScheduledActions {
+		minSize = minSize || action.MinSize != nil
+		maxSize = maxSize || action.MaxSize != nil
+	}
+	return minSize, maxSize
+}
+
 // SubnetIDs returns the machine pool subnet IDs.
 func (m *MachinePoolScope) SubnetIDs(subnetIDs []string) ([]string, error) {
 	strategy, err := newDefaultSubnetPlacementStrategy(&m.Logger)
@@ -393,6 +426,31 @@ func (m *MachinePoolScope) GetLaunchTemplate() *expinfrav1.AWSLaunchTemplate {
 	return &m.AWSMachinePool.Spec.AWSLaunchTemplate
 }
 
+// GetMixedInstancesPolicy returns the mixed instances policy of the ASG. The instance requirements of the launch
+// template are applied through a mixed instances policy, as EC2 Auto Scaling only matches instance types there.
+func (m *MachinePoolScope) GetMixedInstancesPolicy() *expinfrav1.MixedInstancesPolicy {
+	policy := m.AWSMachinePool.Spec.MixedInstancesPolicy
+	requirements := m.AWSMachinePool.Spec.AWSLaunchTemplate.InstanceRequirements
+	if requirements == nil {
+		return policy
+	}
+
+	if policy == nil {
+		return &expinfrav1.MixedInstancesPolicy{InstanceRequirements: requirements}
+	}
+	policy = policy.DeepCopy()
+	policy.InstanceRequirements = requirements
+	return policy
+}
+
+// GetInstanceRequirements returns the instance requirements of the ASG, or nil if it uses explicit instance types.
+func (m *MachinePoolScope) GetInstanceRequirements() *expinfrav1.InstanceRequirements {
+	if policy := m.GetMixedInstancesPolicy(); policy != nil {
+		return policy.InstanceRequirements
+	}
+	return nil
+}
+
 func (m *MachinePoolScope) GetMachinePool() *expclusterv1.MachinePool {
 	return m.MachinePool
 }
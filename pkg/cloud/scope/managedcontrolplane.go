@@ -307,6 +307,21 @@ func (s *ManagedControlPlaneScope) Bastion() *infrav1.Bastion {
 	return &s.ControlPlane.Spec.Bastion
 }
 
+// PlacementGroups returns the placement groups to create with the cluster. They are only supported by AWSCluster.
+func (s *ManagedControlPlaneScope) PlacementGroups() []infrav1.PlacementGroupSpec {
+	return nil
+}
+
+// ControlPlaneTerminationProtection returns false, the control plane of EKS clusters does not run on machines.
+func (s *ManagedControlPlaneScope) ControlPlaneTerminationProtection() bool {
+	return false
+}
+
+// Hibernation returns nil, hibernation is only supported by AWSCluster.
+func (s *ManagedControlPlaneScope) Hibernation() *infrav1.Hibernation {
+	return nil
+}
+
 // TagUnmanagedNetworkResources returns if the feature flag tag unmanaged network resources is set.
 func (s *ManagedControlPlaneScope) TagUnmanagedNetworkResources() bool {
 	return s.tagUnmanagedNetworkResources
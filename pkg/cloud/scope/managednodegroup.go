@@ -383,6 +383,10 @@ func (s *ManagedMachinePoolScope) SetLaunchTemplateLatestVersionStatus(version s
 	s.ManagedMachinePool.Status.LaunchTemplateVersion = &version
 }
 
+func (s *ManagedMachinePoolScope) SetLaunchTemplateImageIDStatus(id string) {
+	s.ManagedMachinePool.Status.ImageID = &id
+}
+
 func (s *ManagedMachinePoolScope) GetLaunchTemplate() *expinfrav1.AWSLaunchTemplate {
 	return s.ManagedMachinePool.Spec.AWSLaunchTemplate
 }
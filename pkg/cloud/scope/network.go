@@ -43,6 +43,9 @@ type NetworkScope interface {
 	// Bastion returns the bastion details for the cluster.
 	Bastion() *infrav1.Bastion
 
+	// Hibernation returns the hibernation of the cluster, nil if it was never hibernated.
+	Hibernation() *infrav1.Hibernation
+
 	// TagUnmanagedNetworkResources returns is tagging unmanaged network resources is set.
 	TagUnmanagedNetworkResources() bool
 
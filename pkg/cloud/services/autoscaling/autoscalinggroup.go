@@ -19,13 +19,16 @@ package asg
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -53,6 +56,24 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 		i.Subnets = strings.Split(*v.VPCZoneIdentifier, ",")
 	}
 
+	if len(v.TargetGroupARNs) > 0 {
+		i.TargetGroupARNs = aws.StringValueSlice(v.TargetGroupARNs)
+	}
+
+	if len(v.LoadBalancerNames) > 0 {
+		i.LoadBalancerNames = aws.StringValueSlice(v.LoadBalancerNames)
+	}
+
+	if v.HealthCheckType != nil {
+		i.HealthCheckType = expinfrav1.ASGHealthCheckType(*v.HealthCheckType)
+	}
+
+	if v.HealthCheckGracePeriod != nil {
+		i.HealthCheckGracePeriod = &metav1.Duration{Duration: time.Duration(*v.HealthCheckGracePeriod) * time.Second}
+	}
+
+	i.LaunchTemplateVersion = sdkLaunchTemplateVersion(v.LaunchTemplate, v.MixedInstancesPolicy)
+
 	if v.MixedInstancesPolicy != nil {
 		i.MixedInstancesPolicy = &expinfrav1.MixedInstancesPolicy{
 			InstancesDistribution: &expinfrav1.InstancesDistribution{
@@ -62,6 +83,10 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 		}
 
 		for _, override := range v.MixedInstancesPolicy.LaunchTemplate.Overrides {
+			if override.InstanceRequirements != nil {
+				i.MixedInstancesPolicy.InstanceRequirements = sdkToInstanceRequirements(override.InstanceRequirements)
+				continue
+			}
 			i.MixedInstancesPolicy.Overrides = append(i.MixedInstancesPolicy.Overrides, expinfrav1.Overrides{InstanceType: aws.StringValue(override.InstanceType)})
 		}
 
@@ -102,6 +127,7 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 		for _, autoscalingInstance := range v.Instances {
 			tmp := &infrav1.Instance{
 				ID:               aws.StringValue(autoscalingInstance.InstanceId),
+				Type:             aws.StringValue(autoscalingInstance.InstanceType),
 				State:            infrav1.InstanceState(*autoscalingInstance.LifecycleState),
 				AvailabilityZone: *autoscalingInstance.AvailabilityZone,
 			}
@@ -117,6 +143,23 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 		i.CurrentlySuspendProcesses = currentlySuspendedProcesses
 	}
 
+	if v.WarmPoolConfiguration != nil {
+		i.WarmPool = &expinfrav1.WarmPool{
+			MinSize:   aws.Int32(int32(aws.Int64Value(v.WarmPoolConfiguration.MinSize))),
+			PoolState: expinfrav1.WarmPoolState(aws.StringValue(v.WarmPoolConfiguration.PoolState)),
+		}
+		if v.WarmPoolConfiguration.MaxGroupPreparedCapacity != nil {
+			i.WarmPool.MaxGroupPreparedCapacity = aws.Int32(int32(*v.WarmPoolConfiguration.MaxGroupPreparedCapacity))
+		}
+		if v.WarmPoolConfiguration.InstanceReusePolicy != nil {
+			i.WarmPool.ReuseOnScaleIn = aws.BoolValue(v.WarmPoolConfiguration.InstanceReusePolicy.ReuseOnScaleIn)
+		}
+		i.WarmPoolStatus = &expinfrav1.WarmPoolStatus{
+			Size:   int32(aws.Int64Value(v.WarmPoolSize)),
+			Status: aws.StringValue(v.WarmPoolConfiguration.Status),
+		}
+	}
+
 	return i, nil
 }
 
@@ -161,23 +204,32 @@ func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfra
 	}
 
 	input := &expinfrav1.AutoScalingGroup{
-		Name:                 machinePoolScope.Name(),
-		MaxSize:              machinePoolScope.AWSMachinePool.Spec.MaxSize,
-		MinSize:              machinePoolScope.AWSMachinePool.Spec.MinSize,
-		Subnets:              subnets,
-		DefaultCoolDown:      machinePoolScope.AWSMachinePool.Spec.DefaultCoolDown,
-		CapacityRebalance:    machinePoolScope.AWSMachinePool.Spec.CapacityRebalance,
-		MixedInstancesPolicy: machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy,
+		Name:                   machinePoolScope.Name(),
+		MaxSize:                machinePoolScope.AWSMachinePool.Spec.MaxSize,
+		MinSize:                machinePoolScope.AWSMachinePool.Spec.MinSize,
+		Subnets:                subnets,
+		DefaultCoolDown:        machinePoolScope.AWSMachinePool.Spec.DefaultCoolDown,
+		CapacityRebalance:      machinePoolScope.AWSMachinePool.Spec.CapacityRebalance,
+		MixedInstancesPolicy:   machinePoolScope.GetMixedInstancesPolicy(),
+		LifecycleHooks:         getLifecycleHooks(machinePoolScope),
+		TargetGroupARNs:        machinePoolScope.AWSMachinePool.Spec.TargetGroupARNs,
+		LoadBalancerNames:      machinePoolScope.AWSMachinePool.Spec.LoadBalancerNames,
+		HealthCheckGracePeriod: machinePoolScope.AWSMachinePool.Spec.HealthCheckGracePeriod,
+	}
+
+	if machinePoolScope.AWSMachinePool.Spec.HealthCheckType != nil {
+		input.HealthCheckType = *machinePoolScope.AWSMachinePool.Spec.HealthCheckType
 	}
 
 	// Default value of MachinePool replicas set by CAPI is 1.
 	mpReplicas := *machinePoolScope.MachinePool.Spec.Replicas
 
 	// Check that MachinePool replicas number is between the minimum and maximum size of the AWSMachinePool.
-	// Ignore the problem for externally managed clusters because MachinePool replicas will be updated to the right value automatically.
+	// Ignore the problem for externally managed clusters and for pools scaled by their scaling policies because MachinePool
+	// replicas will be updated to the right value automatically.
 	if mpReplicas >= machinePoolScope.AWSMachinePool.Spec.MinSize && mpReplicas <= machinePoolScope.AWSMachinePool.Spec.MaxSize {
 		input.DesiredCapacity = &mpReplicas
-	} else if !scope.ReplicasExternallyManaged(machinePoolScope.MachinePool) {
+	} else if !scope.ReplicasExternallyManaged(machinePoolScope.MachinePool) && !machinePoolScope.ReplicasManagedByScalingPolicies() {
 		return nil, fmt.Errorf("incorrect number of replicas %d in MachinePool %v", mpReplicas, machinePoolScope.MachinePool.Name)
 	}
 
@@ -199,7 +251,7 @@ func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfra
 	})
 
 	s.scope.Info("Running instance")
-	if err := s.runPool(input, machinePoolScope.AWSMachinePool.Status.LaunchTemplateID); err != nil {
+	if err := s.runPool(input, launchTemplateSpecification(machinePoolScope)); err != nil {
 		// Only record the failure event if the error is not related to failed dependencies.
 		// This is to avoid spamming failure events since the machine will be requeued by the actuator.
 		// if !awserrors.IsFailedDependency(errors.Cause(err)) {
@@ -213,7 +265,7 @@ func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfra
 	return nil, nil
 }
 
-func (s *Service) runPool(i *expinfrav1.AutoScalingGroup, launchTemplateID string) error {
+func (s *Service) runPool(i *expinfrav1.AutoScalingGroup, launchTemplate *autoscaling.LaunchTemplateSpecification) error {
 	input := &autoscaling.CreateAutoScalingGroupInput{
 		AutoScalingGroupName: aws.String(i.Name),
 		MaxSize:              aws.Int64(int64(i.MaxSize)),
@@ -228,18 +280,37 @@ func (s *Service) runPool(i *expinfrav1.AutoScalingGroup, launchTemplateID strin
 	}
 
 	if i.MixedInstancesPolicy != nil {
-		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(i.Name, i.MixedInstancesPolicy)
+		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(launchTemplate, i.MixedInstancesPolicy)
 	} else {
-		input.LaunchTemplate = &autoscaling.LaunchTemplateSpecification{
-			LaunchTemplateId: aws.String(launchTemplateID),
-			Version:          aws.String(expinfrav1.LaunchTemplateLatestVersion),
-		}
+		input.LaunchTemplate = launchTemplate
 	}
 
 	if i.Tags != nil {
 		input.Tags = BuildTagsFromMap(i.Name, i.Tags)
 	}
 
+	// Load balancers are attached along with the ASG, so that the instances it launches first are registered.
+	if len(i.TargetGroupARNs) > 0 {
+		input.TargetGroupARNs = aws.StringSlice(i.TargetGroupARNs)
+	}
+
+	if len(i.LoadBalancerNames) > 0 {
+		input.LoadBalancerNames = aws.StringSlice(i.LoadBalancerNames)
+	}
+
+	if i.HealthCheckType != "" {
+		input.HealthCheckType = aws.String(string(i.HealthCheckType))
+	}
+
+	if i.HealthCheckGracePeriod != nil {
+		input.HealthCheckGracePeriod = aws.Int64(int64(i.HealthCheckGracePeriod.Duration.Seconds()))
+	}
+
+	// Lifecycle hooks are created along with the ASG, so that they apply to the instances it launches first.
+	for j := range i.LifecycleHooks {
+		input.LifecycleHookSpecificationList = append(input.LifecycleHookSpecificationList, getLifecycleHookSpecification(&i.LifecycleHooks[j]))
+	}
+
 	if _, err := s.ASGClient.CreateAutoScalingGroupWithContext(context.TODO(), input); err != nil {
 		return errors.Wrap(err, "failed to create autoscaling group")
 	}
@@ -292,23 +363,35 @@ func (s *Service) UpdateASG(scope *scope.MachinePoolScope) error {
 
 	input := &autoscaling.UpdateAutoScalingGroupInput{
 		AutoScalingGroupName: aws.String(scope.Name()), //TODO: define dynamically - borrow logic from ec2
-		MaxSize:              aws.Int64(int64(scope.AWSMachinePool.Spec.MaxSize)),
-		MinSize:              aws.Int64(int64(scope.AWSMachinePool.Spec.MinSize)),
 		VPCZoneIdentifier:    aws.String(strings.Join(subnetIDs, ",")),
 		CapacityRebalance:    aws.Bool(scope.AWSMachinePool.Spec.CapacityRebalance),
 	}
 
-	if scope.MachinePool.Spec.Replicas != nil {
+	// The sizes set by scheduled actions are left to them, so that they are not reverted until the next action.
+	minSizeScheduled, maxSizeScheduled := scope.SizeManagedByScheduledActions()
+	if !minSizeScheduled {
+		input.MinSize = aws.Int64(int64(scope.AWSMachinePool.Spec.MinSize))
+	}
+	if !maxSizeScheduled {
+		input.MaxSize = aws.Int64(int64(scope.AWSMachinePool.Spec.MaxSize))
+	}
+
+	if scope.MachinePool.Spec.Replicas != nil && !scope.ReplicasManagedByScalingPolicies() {
 		input.DesiredCapacity = aws.Int64(int64(*scope.MachinePool.Spec.Replicas))
 	}
 
-	if scope.AWSMachinePool.Spec.MixedInstancesPolicy != nil {
-		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(scope.Name(), scope.AWSMachinePool.Spec.MixedInstancesPolicy)
+	if scope.AWSMachinePool.Spec.HealthCheckType != nil {
+		input.HealthCheckType = aws.String(string(*scope.AWSMachinePool.Spec.HealthCheckType))
+	}
+
+	if scope.AWSMachinePool.Spec.HealthCheckGracePeriod != nil {
+		input.HealthCheckGracePeriod = aws.Int64(int64(scope.AWSMachinePool.Spec.HealthCheckGracePeriod.Duration.Seconds()))
+	}
+
+	if mixedInstancesPolicy := scope.GetMixedInstancesPolicy(); mixedInstancesPolicy != nil {
+		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(launchTemplateSpecification(scope), mixedInstancesPolicy)
 	} else {
-		input.LaunchTemplate = &autoscaling.LaunchTemplateSpecification{
-			LaunchTemplateId: aws.String(scope.AWSMachinePool.Status.LaunchTemplateID),
-			Version:          aws.String(expinfrav1.LaunchTemplateLatestVersion),
-		}
+		input.LaunchTemplate = launchTemplateSpecification(scope)
 	}
 
 	if _, err := s.ASGClient.UpdateAutoScalingGroupWithContext(context.TODO(), input); err != nil {
@@ -318,6 +401,38 @@ func (s *Service) UpdateASG(scope *scope.MachinePoolScope) error {
 	return nil
 }
 
+// UpdateASGCapacity updates the minimum, maximum and desired capacity of the ASG, leaving the rest of its
+// configuration untouched.
+func (s *Service) UpdateASGCapacity(name string, minSize, maxSize, desiredCapacity int32) error {
+	input := &autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(name),
+		MinSize:              aws.Int64(int64(minSize)),
+		MaxSize:              aws.Int64(int64(maxSize)),
+		DesiredCapacity:      aws.Int64(int64(desiredCapacity)),
+	}
+
+	if _, err := s.ASGClient.UpdateAutoScalingGroupWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to update capacity of ASG %q", name)
+	}
+
+	return nil
+}
+
+// TerminateASGInstance terminates an instance of an ASG. When decrementCapacity is set, the desired capacity of the
+// ASG is decremented, so that EC2 Auto Scaling does not launch an instance to replace it.
+func (s *Service) TerminateASGInstance(instanceID string, decrementCapacity bool) error {
+	input := &autoscaling.TerminateInstanceInAutoScalingGroupInput{
+		InstanceId:                     aws.String(instanceID),
+		ShouldDecrementDesiredCapacity: aws.Bool(decrementCapacity),
+	}
+
+	if _, err := s.ASGClient.TerminateInstanceInAutoScalingGroupWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to terminate instance %q of ASG", instanceID)
+	}
+
+	return nil
+}
+
 // CanStartASGInstanceRefresh will start an ASG instance with refresh.
 func (s *Service) CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (bool, error) {
 	describeInput := &autoscaling.DescribeInstanceRefreshesInput{AutoScalingGroupName: aws.String(scope.Name())}
@@ -366,6 +481,27 @@ func (s *Service) StartASGInstanceRefresh(scope *scope.MachinePoolScope) error {
 		},
 	}
 
+	if prefs := scope.AWSMachinePool.Spec.RefreshPreferences; prefs != nil {
+		if len(prefs.CheckpointPercentages) > 0 {
+			input.Preferences.CheckpointPercentages = aws.Int64Slice(prefs.CheckpointPercentages)
+			input.Preferences.CheckpointDelay = prefs.CheckpointDelay
+		}
+		input.Preferences.SkipMatching = prefs.SkipMatching
+		input.Preferences.AutoRollback = prefs.AutoRollback
+	}
+
+	// With auto rollback, the ASG launches its instances from an explicit version of the launch template, which
+	// the instance refresh rolls out: the ASG only moves to it once the instance refresh succeeds, and is left
+	// at its previous version when the instance refresh is rolled back.
+	if scope.AWSMachinePool.Spec.RefreshPreferences.AutoRollbackEnabled() {
+		input.DesiredConfiguration = &autoscaling.DesiredConfiguration{}
+		if mixedInstancesPolicy := scope.GetMixedInstancesPolicy(); mixedInstancesPolicy != nil {
+			input.DesiredConfiguration.MixedInstancesPolicy = createSDKMixedInstancesPolicy(launchTemplateSpecification(scope), mixedInstancesPolicy)
+		} else {
+			input.DesiredConfiguration.LaunchTemplate = launchTemplateSpecification(scope)
+		}
+	}
+
 	if _, err := s.ASGClient.StartInstanceRefreshWithContext(context.TODO(), input); err != nil {
 		return errors.Wrapf(err, "failed to start ASG instance refresh %q", scope.Name())
 	}
@@ -373,13 +509,103 @@ func (s *Service) StartASGInstanceRefresh(scope *scope.MachinePoolScope) error {
 	return nil
 }
 
-func createSDKMixedInstancesPolicy(name string, i *expinfrav1.MixedInstancesPolicy) *autoscaling.MixedInstancesPolicy {
+// ReconcileWarmPool creates or updates the warm pool of the ASG to match the AWSMachinePool, and deletes it
+// once it is removed from the AWSMachinePool.
+func (s *Service) ReconcileWarmPool(scope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) error {
+	desired := scope.AWSMachinePool.Spec.WarmPool
+	existing := existingASG.WarmPool
+	deleting := existingASG.WarmPoolStatus != nil && existingASG.WarmPoolStatus.Status == autoscaling.WarmPoolStatusPendingDelete
+
+	if desired == nil {
+		if existing == nil || deleting {
+			return nil
+		}
+
+		s.scope.Info("Deleting warm pool", "asg", existingASG.Name)
+		input := &autoscaling.DeleteWarmPoolInput{
+			AutoScalingGroupName: aws.String(existingASG.Name),
+		}
+		if _, err := s.ASGClient.DeleteWarmPoolWithContext(context.TODO(), input); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedDeleteWarmPool", "Failed to delete warm pool of ASG %q: %v", existingASG.Name, err)
+			return errors.Wrapf(err, "failed to delete warm pool of ASG %q", existingASG.Name)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulDeleteWarmPool", "Deleted warm pool of ASG %q", existingASG.Name)
+		return nil
+	}
+
+	if existing != nil && !deleting && warmPoolEqual(desired, existing) {
+		return nil
+	}
+
+	s.scope.Info("Updating warm pool", "asg", existingASG.Name)
+	input := &autoscaling.PutWarmPoolInput{
+		AutoScalingGroupName: aws.String(existingASG.Name),
+		MinSize:              aws.Int64(int64(pointer.Int32Deref(desired.MinSize, 0))),
+		// A negative value resets a previously set maximum, the warm pool then holds the difference between
+		// the maximum size of the ASG and its desired capacity.
+		MaxGroupPreparedCapacity: aws.Int64(int64(pointer.Int32Deref(desired.MaxGroupPreparedCapacity, -1))),
+		PoolState:                aws.String(string(warmPoolState(desired))),
+		InstanceReusePolicy: &autoscaling.InstanceReusePolicy{
+			ReuseOnScaleIn: aws.Bool(desired.ReuseOnScaleIn),
+		},
+	}
+	if _, err := s.ASGClient.PutWarmPoolWithContext(context.TODO(), input); err != nil {
+		record.Warnf(scope.AWSMachinePool, "FailedPutWarmPool", "Failed to update warm pool of ASG %q: %v", existingASG.Name, err)
+		return errors.Wrapf(err, "failed to update warm pool of ASG %q", existingASG.Name)
+	}
+	record.Eventf(scope.AWSMachinePool, "SuccessfulPutWarmPool", "Updated warm pool of ASG %q", existingASG.Name)
+
+	return nil
+}
+
+// warmPoolEqual compares a warm pool of an AWSMachinePool to the one of an ASG, taking the values applied by
+// EC2 Auto Scaling to unset fields into account.
+func warmPoolEqual(desired, existing *expinfrav1.WarmPool) bool {
+	return pointer.Int32Deref(desired.MinSize, 0) == pointer.Int32Deref(existing.MinSize, 0) &&
+		pointer.Int32Deref(desired.MaxGroupPreparedCapacity, -1) == pointer.Int32Deref(existing.MaxGroupPreparedCapacity, -1) &&
+		warmPoolState(desired) == warmPoolState(existing) &&
+		desired.ReuseOnScaleIn == existing.ReuseOnScaleIn
+}
+
+func warmPoolState(warmPool *expinfrav1.WarmPool) expinfrav1.WarmPoolState {
+	if warmPool.PoolState == "" {
+		return expinfrav1.WarmPoolStateStopped
+	}
+	return warmPool.PoolState
+}
+
+// launchTemplateSpecification returns the launch template the ASG launches its instances from. The launch template
+// owned by CAPA is used at its latest version, a referenced launch template at the version it resolved to, so that
+// the ASG only moves to another version of it along with an instance refresh.
+func launchTemplateSpecification(scope *scope.MachinePoolScope) *autoscaling.LaunchTemplateSpecification {
+	// An instance refresh can only be rolled back when the ASG launches its instances from an explicit version.
+	version := aws.String(expinfrav1.LaunchTemplateLatestVersion)
+	if scope.AWSMachinePool.Spec.LaunchTemplateRef != nil || scope.AWSMachinePool.Spec.RefreshPreferences.AutoRollbackEnabled() {
+		version = scope.AWSMachinePool.Status.LaunchTemplateVersion
+	}
+
+	return &autoscaling.LaunchTemplateSpecification{
+		LaunchTemplateId: aws.String(scope.AWSMachinePool.Status.LaunchTemplateID),
+		Version:          version,
+	}
+}
+
+// sdkLaunchTemplateVersion returns the version of the launch template of an ASG, or of an instance refresh.
+func sdkLaunchTemplateVersion(launchTemplate *autoscaling.LaunchTemplateSpecification, mixedInstancesPolicy *autoscaling.MixedInstancesPolicy) string {
+	if launchTemplate == nil && mixedInstancesPolicy != nil && mixedInstancesPolicy.LaunchTemplate != nil {
+		launchTemplate = mixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification
+	}
+	if launchTemplate == nil {
+		return ""
+	}
+
+	return aws.StringValue(launchTemplate.Version)
+}
+
+func createSDKMixedInstancesPolicy(launchTemplate *autoscaling.LaunchTemplateSpecification, i *expinfrav1.MixedInstancesPolicy) *autoscaling.MixedInstancesPolicy {
 	mixedInstancesPolicy := &autoscaling.MixedInstancesPolicy{
 		LaunchTemplate: &autoscaling.LaunchTemplate{
-			LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
-				LaunchTemplateName: aws.String(name),
-				Version:            aws.String(expinfrav1.LaunchTemplateLatestVersion),
-			},
+			LaunchTemplateSpecification: launchTemplate,
 		},
 	}
 
@@ -398,6 +624,13 @@ func createSDKMixedInstancesPolicy(name string, i *expinfrav1.MixedInstancesPoli
 		})
 	}
 
+	// EC2 Auto Scaling matches instance types against the instance requirements of a single override.
+	if i.InstanceRequirements != nil {
+		mixedInstancesPolicy.LaunchTemplate.Overrides = append(mixedInstancesPolicy.LaunchTemplate.Overrides, &autoscaling.LaunchTemplateOverrides{
+			InstanceRequirements: createSDKInstanceRequirements(i.InstanceRequirements),
+		})
+	}
+
 	return mixedInstancesPolicy
 }
 
@@ -486,6 +719,92 @@ func (s *Service) ResumeProcesses(name string, processes []string) error {
 	return nil
 }
 
+// ReconcileLoadBalancers attaches the target groups and classic load balancers of the AWSMachinePool to the ASG,
+// and detaches the ones that were removed from the AWSMachinePool.
+func (s *Service) ReconcileLoadBalancers(scope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) error {
+	toAttach, toDetach := diffStringSets(scope.AWSMachinePool.Spec.TargetGroupARNs, existingASG.TargetGroupARNs)
+	if len(toAttach) > 0 {
+		s.scope.Info("Attaching target groups", "asg", existingASG.Name, "targetGroups", toAttach)
+		input := &autoscaling.AttachLoadBalancerTargetGroupsInput{
+			AutoScalingGroupName: aws.String(existingASG.Name),
+			TargetGroupARNs:      aws.StringSlice(toAttach),
+		}
+		if _, err := s.ASGClient.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), input); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedAttachTargetGroups", "Failed to attach target groups to ASG %q: %v", existingASG.Name, err)
+			return errors.Wrapf(err, "failed to attach target groups to ASG %q", existingASG.Name)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulAttachTargetGroups", "Attached target groups %v to ASG %q", toAttach, existingASG.Name)
+	}
+	if len(toDetach) > 0 {
+		s.scope.Info("Detaching target groups", "asg", existingASG.Name, "targetGroups", toDetach)
+		input := &autoscaling.DetachLoadBalancerTargetGroupsInput{
+			AutoScalingGroupName: aws.String(existingASG.Name),
+			TargetGroupARNs:      aws.StringSlice(toDetach),
+		}
+		if _, err := s.ASGClient.DetachLoadBalancerTargetGroupsWithContext(context.TODO(), input); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedDetachTargetGroups", "Failed to detach target groups from ASG %q: %v", existingASG.Name, err)
+			return errors.Wrapf(err, "failed to detach target groups from ASG %q", existingASG.Name)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulDetachTargetGroups", "Detached target groups %v from ASG %q", toDetach, existingASG.Name)
+	}
+
+	toAttach, toDetach = diffStringSets(scope.AWSMachinePool.Spec.LoadBalancerNames, existingASG.LoadBalancerNames)
+	if len(toAttach) > 0 {
+		s.scope.Info("Attaching classic load balancers", "asg", existingASG.Name, "loadBalancers", toAttach)
+		input := &autoscaling.AttachLoadBalancersInput{
+			AutoScalingGroupName: aws.String(existingASG.Name),
+			LoadBalancerNames:    aws.StringSlice(toAttach),
+		}
+		if _, err := s.ASGClient.AttachLoadBalancersWithContext(context.TODO(), input); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedAttachLoadBalancers", "Failed to attach classic load balancers to ASG %q: %v", existingASG.Name, err)
+			return errors.Wrapf(err, "failed to attach classic load balancers to ASG %q", existingASG.Name)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulAttachLoadBalancers", "Attached classic load balancers %v to ASG %q", toAttach, existingASG.Name)
+	}
+	if len(toDetach) > 0 {
+		s.scope.Info("Detaching classic load balancers", "asg", existingASG.Name, "loadBalancers", toDetach)
+		input := &autoscaling.DetachLoadBalancersInput{
+			AutoScalingGroupName: aws.String(existingASG.Name),
+			LoadBalancerNames:    aws.StringSlice(toDetach),
+		}
+		if _, err := s.ASGClient.DetachLoadBalancersWithContext(context.TODO(), input); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedDetachLoadBalancers", "Failed to detach classic load balancers from ASG %q: %v", existingASG.Name, err)
+			return errors.Wrapf(err, "failed to detach classic load balancers from ASG %q", existingASG.Name)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulDetachLoadBalancers", "Detached classic load balancers %v from ASG %q", toDetach, existingASG.Name)
+	}
+
+	return nil
+}
+
+// diffStringSets returns the items of desired missing from existing, and the items of existing missing from
+// desired, both sorted.
+func diffStringSets(desired, existing []string) (missing, extra []string) {
+	// Convert the items to a map, so it's easy to create an effective diff from these two slices.
+	desiredSet := make(map[string]struct{}, len(desired))
+	for _, item := range desired {
+		desiredSet[item] = struct{}{}
+	}
+
+	existingSet := make(map[string]struct{}, len(existing))
+	for _, item := range existing {
+		existingSet[item] = struct{}{}
+		if _, ok := desiredSet[item]; !ok {
+			extra = append(extra, item)
+		}
+	}
+
+	for item := range desiredSet {
+		if _, ok := existingSet[item]; !ok {
+			missing = append(missing, item)
+		}
+	}
+
+	sort.Strings(missing)
+	sort.Strings(extra)
+	return missing, extra
+}
+
 func mapToTags(input map[string]string, resourceID *string) []*autoscaling.Tag {
 	tags := make([]*autoscaling.Tag, 0)
 	for k, v := range input {
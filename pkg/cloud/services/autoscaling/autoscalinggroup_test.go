@@ -20,6 +20,7 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
@@ -187,6 +188,54 @@ func TestServiceSDKToAutoScalingGroup(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid input - instance requirements",
+			input: &autoscaling.Group{
+				AutoScalingGroupARN:  aws.String("test-id"),
+				AutoScalingGroupName: aws.String("test-name"),
+				DesiredCapacity:      aws.Int64(1234),
+				MaxSize:              aws.Int64(1234),
+				MinSize:              aws.Int64(1234),
+				MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
+					InstancesDistribution: &autoscaling.InstancesDistribution{
+						OnDemandAllocationStrategy: aws.String("lowest-price"),
+						SpotAllocationStrategy:     aws.String("price-capacity-optimized"),
+					},
+					LaunchTemplate: &autoscaling.LaunchTemplate{
+						Overrides: []*autoscaling.LaunchTemplateOverrides{
+							{
+								InstanceRequirements: &autoscaling.InstanceRequirements{
+									VCpuCount:        &autoscaling.VCpuCountRequest{Min: aws.Int64(2), Max: aws.Int64(8)},
+									MemoryMiB:        &autoscaling.MemoryMiBRequest{Min: aws.Int64(4096)},
+									CpuManufacturers: aws.StringSlice([]string{"intel", "amd"}),
+									BareMetal:        aws.String("excluded"),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				ID:              "test-id",
+				Name:            "test-name",
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					InstancesDistribution: &expinfrav1.InstancesDistribution{
+						OnDemandAllocationStrategy: expinfrav1.OnDemandAllocationStrategyLowestPrice,
+						SpotAllocationStrategy:     expinfrav1.SpotAllocationStrategyPriceCapacityOptimized,
+					},
+					InstanceRequirements: &expinfrav1.InstanceRequirements{
+						VCPUCount:        expinfrav1.InstanceRequirementsRange{Min: 2, Max: aws.Int64(8)},
+						MemoryMiB:        expinfrav1.InstanceRequirementsRange{Min: 4096},
+						CPUManufacturers: []expinfrav1.CPUManufacturer{expinfrav1.CPUManufacturerIntel, expinfrav1.CPUManufacturerAMD},
+						BareMetal:        expinfrav1.InstanceRequirementExcluded,
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid input - suspended processes",
 			input: &autoscaling.Group{
@@ -208,6 +257,38 @@ func TestServiceSDKToAutoScalingGroup(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid input - warm pool",
+			input: &autoscaling.Group{
+				DesiredCapacity: aws.Int64(1234),
+				MaxSize:         aws.Int64(1234),
+				MinSize:         aws.Int64(1234),
+				WarmPoolConfiguration: &autoscaling.WarmPoolConfiguration{
+					MinSize:                  aws.Int64(2),
+					MaxGroupPreparedCapacity: aws.Int64(10),
+					PoolState:                aws.String("Hibernated"),
+					InstanceReusePolicy: &autoscaling.InstanceReusePolicy{
+						ReuseOnScaleIn: aws.Bool(true),
+					},
+				},
+				WarmPoolSize: aws.Int64(3),
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: aws.Int32(1234),
+				MaxSize:         int32(1234),
+				MinSize:         int32(1234),
+				WarmPool: &expinfrav1.WarmPool{
+					MinSize:                  aws.Int32(2),
+					MaxGroupPreparedCapacity: aws.Int32(10),
+					PoolState:                expinfrav1.WarmPoolStateHibernated,
+					ReuseOnScaleIn:           true,
+				},
+				WarmPoolStatus: &expinfrav1.WarmPoolStatus{
+					Size: 3,
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid input - all fields filled",
 			input: &autoscaling.Group{
@@ -360,6 +441,32 @@ func TestServiceSDKToAutoScalingGroup(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid input - load balancers and health check",
+			input: &autoscaling.Group{
+				AutoScalingGroupARN:    aws.String("test-id"),
+				AutoScalingGroupName:   aws.String("test-name"),
+				DesiredCapacity:        aws.Int64(1),
+				MaxSize:                aws.Int64(2),
+				MinSize:                aws.Int64(0),
+				TargetGroupARNs:        aws.StringSlice([]string{"arn:tg-1"}),
+				LoadBalancerNames:      aws.StringSlice([]string{"ingress"}),
+				HealthCheckType:        aws.String("ELB"),
+				HealthCheckGracePeriod: aws.Int64(300),
+			},
+			want: &expinfrav1.AutoScalingGroup{
+				ID:                     "test-id",
+				Name:                   "test-name",
+				DesiredCapacity:        aws.Int32(1),
+				MaxSize:                int32(2),
+				MinSize:                int32(0),
+				TargetGroupARNs:        []string{"arn:tg-1"},
+				LoadBalancerNames:      []string{"ingress"},
+				HealthCheckType:        expinfrav1.ASGHealthCheckTypeELB,
+				HealthCheckGracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -499,8 +606,8 @@ func TestServiceCreateASG(t *testing.T) {
 						},
 						LaunchTemplate: &autoscaling.LaunchTemplate{
 							LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
-								LaunchTemplateName: aws.String("create-asg-success"),
-								Version:            aws.String("$Latest"),
+								LaunchTemplateId: aws.String("launchTemplateID"),
+								Version:          aws.String("$Latest"),
 							},
 							Overrides: []*autoscaling.LaunchTemplateOverrides{
 								{
@@ -606,6 +713,43 @@ func TestServiceCreateASG(t *testing.T) {
 					})
 			},
 		},
+		{
+			name:            "should apply launch template instance requirements through a mixed instances policy",
+			machinePoolName: "create-asg-success",
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.MixedInstancesPolicy = nil
+				mps.AWSMachinePool.Spec.AWSLaunchTemplate.InstanceType = ""
+				mps.AWSMachinePool.Spec.AWSLaunchTemplate.InstanceRequirements = &expinfrav1.InstanceRequirements{
+					VCPUCount:            expinfrav1.InstanceRequirementsRange{Min: 2, Max: aws.Int64(4)},
+					MemoryMiB:            expinfrav1.InstanceRequirementsRange{Min: 2048},
+					AcceleratorCount:     &expinfrav1.InstanceRequirementsRange{Max: aws.Int64(0)},
+					BurstablePerformance: expinfrav1.InstanceRequirementIncluded,
+				}
+			},
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				expected := []*autoscaling.LaunchTemplateOverrides{
+					{
+						InstanceRequirements: &autoscaling.InstanceRequirements{
+							VCpuCount:            &autoscaling.VCpuCountRequest{Min: aws.Int64(2), Max: aws.Int64(4)},
+							MemoryMiB:            &autoscaling.MemoryMiBRequest{Min: aws.Int64(2048)},
+							AcceleratorCount:     &autoscaling.AcceleratorCountRequest{Min: aws.Int64(0), Max: aws.Int64(0)},
+							BurstablePerformance: aws.String("included"),
+						},
+					},
+				}
+				m.CreateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.CreateAutoScalingGroupInput{})).Do(
+					func(ctx context.Context, actual *autoscaling.CreateAutoScalingGroupInput, requestOptions ...request.Option) (*autoscaling.CreateAutoScalingGroupOutput, error) {
+						if actual.LaunchTemplate != nil || actual.MixedInstancesPolicy == nil {
+							t.Fatalf("Actual CreateAutoScalingGroupInput must launch instances through a mixed instances policy: %v", actual)
+						}
+						if !cmp.Equal(expected, actual.MixedInstancesPolicy.LaunchTemplate.Overrides) {
+							t.Fatalf("Actual overrides did not match expected, Actual: %v, Expected: %v", actual.MixedInstancesPolicy.LaunchTemplate.Overrides, expected)
+						}
+						return &autoscaling.CreateAutoScalingGroupOutput{}, nil
+					})
+			},
+		},
 		{
 			name:            "should return error if MachinePool replicas number is less than AWSMachinePool MinSize",
 			machinePoolName: "create-asg-fail",
@@ -723,6 +867,28 @@ func TestServiceUpdateASG(t *testing.T) {
 				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).Return(nil, awserrors.NewFailedDependency("dependency failure"))
 			},
 		},
+		{
+			name:            "should launch instances from the resolved version of a referenced launch template",
+			machinePoolName: "update-asg-launch-template-ref",
+			wantErr:         false,
+			setupMachinePoolScope: func(mps *scope.MachinePoolScope) {
+				mps.AWSMachinePool.Spec.MixedInstancesPolicy = nil
+				mps.AWSMachinePool.Spec.LaunchTemplateRef = &infrav1.LaunchTemplateReference{ID: aws.String("lt-12345"), Version: aws.String("$Latest")}
+				mps.AWSMachinePool.Status.LaunchTemplateID = "lt-12345"
+				mps.AWSMachinePool.Status.LaunchTemplateVersion = aws.String("4")
+			},
+			expect: func(e *mocks.MockEC2APIMockRecorder, m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).
+					DoAndReturn(func(_ context.Context, input *autoscaling.UpdateAutoScalingGroupInput, _ ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+						g := NewWithT(t)
+						g.Expect(input.LaunchTemplate).To(Equal(&autoscaling.LaunchTemplateSpecification{
+							LaunchTemplateId: aws.String("lt-12345"),
+							Version:          aws.String("4"),
+						}))
+						return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
+					})
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -740,6 +906,9 @@ func TestServiceUpdateASG(t *testing.T) {
 			mps, err := getMachinePoolScope(fakeClient, clusterScope)
 			g.Expect(err).ToNot(HaveOccurred())
 			mps.AWSMachinePool.Name = tt.machinePoolName
+			if tt.setupMachinePoolScope != nil {
+				tt.setupMachinePoolScope(mps)
+			}
 
 			err = s.UpdateASG(mps)
 			checkErr(tt.wantErr, err, g)
@@ -836,6 +1005,334 @@ func TestServiceUpdateASGWithSubnetFilters(t *testing.T) {
 	}
 }
 
+func TestServiceUpdateASGCapacity(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:    "should update the capacity of the ASG",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.UpdateAutoScalingGroupInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					MinSize:              aws.Int64(0),
+					MaxSize:              aws.Int64(0),
+					DesiredCapacity:      aws.Int64(0),
+				})).
+					Return(&autoscaling.UpdateAutoScalingGroupOutput{}, nil)
+			},
+		},
+		{
+			name:    "should return an error if the ASG cannot be updated",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.UpdateAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.UpdateAutoScalingGroupInput{})).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.UpdateASGCapacity("asgName", 0, 0, 0)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceTerminateASGInstance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name              string
+		decrementCapacity bool
+		wantErr           bool
+		expect            func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:              "should terminate the instance and decrement the desired capacity of the ASG",
+			decrementCapacity: true,
+			wantErr:           false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.TerminateInstanceInAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+					InstanceId:                     aws.String("instanceID"),
+					ShouldDecrementDesiredCapacity: aws.Bool(true),
+				})).
+					Return(&autoscaling.TerminateInstanceInAutoScalingGroupOutput{}, nil)
+			},
+		},
+		{
+			name:              "should terminate the instance without decrementing the desired capacity of the ASG",
+			decrementCapacity: false,
+			wantErr:           false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.TerminateInstanceInAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+					InstanceId:                     aws.String("instanceID"),
+					ShouldDecrementDesiredCapacity: aws.Bool(false),
+				})).
+					Return(&autoscaling.TerminateInstanceInAutoScalingGroupOutput{}, nil)
+			},
+		},
+		{
+			name:    "should return an error if the instance cannot be terminated",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.TerminateInstanceInAutoScalingGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.TerminateInstanceInAutoScalingGroupInput{})).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.TerminateASGInstance("instanceID", tt.decrementCapacity)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceReconcileWarmPool(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name     string
+		warmPool *expinfrav1.WarmPool
+		asg      *expinfrav1.AutoScalingGroup
+		wantErr  bool
+		expect   func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should create the warm pool of the ASG",
+			warmPool: &expinfrav1.WarmPool{
+				MinSize:        aws.Int32(1),
+				ReuseOnScaleIn: true,
+			},
+			asg: &expinfrav1.AutoScalingGroup{Name: "asgName"},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutWarmPoolWithContext(context.TODO(), gomock.Eq(&autoscaling.PutWarmPoolInput{
+					AutoScalingGroupName:     aws.String("asgName"),
+					MinSize:                  aws.Int64(1),
+					MaxGroupPreparedCapacity: aws.Int64(-1),
+					PoolState:                aws.String("Stopped"),
+					InstanceReusePolicy: &autoscaling.InstanceReusePolicy{
+						ReuseOnScaleIn: aws.Bool(true),
+					},
+				})).Return(&autoscaling.PutWarmPoolOutput{}, nil)
+			},
+		},
+		{
+			name: "should not update a warm pool matching the AWSMachinePool",
+			warmPool: &expinfrav1.WarmPool{
+				MaxGroupPreparedCapacity: aws.Int32(5),
+			},
+			asg: &expinfrav1.AutoScalingGroup{
+				Name: "asgName",
+				WarmPool: &expinfrav1.WarmPool{
+					MinSize:                  aws.Int32(0),
+					MaxGroupPreparedCapacity: aws.Int32(5),
+					PoolState:                expinfrav1.WarmPoolStateStopped,
+				},
+				WarmPoolStatus: &expinfrav1.WarmPoolStatus{Size: 5},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {},
+		},
+		{
+			name: "should update a warm pool in a different state",
+			warmPool: &expinfrav1.WarmPool{
+				PoolState: expinfrav1.WarmPoolStateRunning,
+			},
+			asg: &expinfrav1.AutoScalingGroup{
+				Name: "asgName",
+				WarmPool: &expinfrav1.WarmPool{
+					MinSize:   aws.Int32(0),
+					PoolState: expinfrav1.WarmPoolStateStopped,
+				},
+				WarmPoolStatus: &expinfrav1.WarmPoolStatus{},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutWarmPoolWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.PutWarmPoolInput{})).
+					Return(&autoscaling.PutWarmPoolOutput{}, nil)
+			},
+		},
+		{
+			name:     "should delete the warm pool once it is removed from the AWSMachinePool",
+			warmPool: nil,
+			asg: &expinfrav1.AutoScalingGroup{
+				Name:           "asgName",
+				WarmPool:       &expinfrav1.WarmPool{MinSize: aws.Int32(0)},
+				WarmPoolStatus: &expinfrav1.WarmPoolStatus{},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DeleteWarmPoolWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteWarmPoolInput{
+					AutoScalingGroupName: aws.String("asgName"),
+				})).Return(&autoscaling.DeleteWarmPoolOutput{}, nil)
+			},
+		},
+		{
+			name:     "should not delete a warm pool which is already being deleted",
+			warmPool: nil,
+			asg: &expinfrav1.AutoScalingGroup{
+				Name:           "asgName",
+				WarmPool:       &expinfrav1.WarmPool{MinSize: aws.Int32(0)},
+				WarmPoolStatus: &expinfrav1.WarmPoolStatus{Status: autoscaling.WarmPoolStatusPendingDelete},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {},
+		},
+		{
+			name:     "should return an error if the warm pool cannot be updated",
+			warmPool: &expinfrav1.WarmPool{},
+			asg:      &expinfrav1.AutoScalingGroup{Name: "asgName"},
+			wantErr:  true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.PutWarmPoolWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.PutWarmPoolInput{})).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Spec.WarmPool = tt.warmPool
+
+			err = s.ReconcileWarmPool(mps, tt.asg)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceReconcileLoadBalancers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name              string
+		targetGroupARNs   []string
+		loadBalancerNames []string
+		asg               *expinfrav1.AutoScalingGroup
+		wantErr           bool
+		expect            func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:              "should attach the target groups and classic load balancers missing from the ASG",
+			targetGroupARNs:   []string{"arn:tg-2", "arn:tg-1"},
+			loadBalancerNames: []string{"ingress"},
+			asg:               &expinfrav1.AutoScalingGroup{Name: "asgName"},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Eq(&autoscaling.AttachLoadBalancerTargetGroupsInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					TargetGroupARNs:      aws.StringSlice([]string{"arn:tg-1", "arn:tg-2"}),
+				})).Return(&autoscaling.AttachLoadBalancerTargetGroupsOutput{}, nil)
+				m.AttachLoadBalancersWithContext(context.TODO(), gomock.Eq(&autoscaling.AttachLoadBalancersInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					LoadBalancerNames:    aws.StringSlice([]string{"ingress"}),
+				})).Return(&autoscaling.AttachLoadBalancersOutput{}, nil)
+			},
+		},
+		{
+			name:              "should detach the target groups and classic load balancers removed from the AWSMachinePool",
+			targetGroupARNs:   []string{"arn:tg-1"},
+			loadBalancerNames: nil,
+			asg: &expinfrav1.AutoScalingGroup{
+				Name:              "asgName",
+				TargetGroupARNs:   []string{"arn:tg-1", "arn:tg-2"},
+				LoadBalancerNames: []string{"ingress"},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DetachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Eq(&autoscaling.DetachLoadBalancerTargetGroupsInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					TargetGroupARNs:      aws.StringSlice([]string{"arn:tg-2"}),
+				})).Return(&autoscaling.DetachLoadBalancerTargetGroupsOutput{}, nil)
+				m.DetachLoadBalancersWithContext(context.TODO(), gomock.Eq(&autoscaling.DetachLoadBalancersInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					LoadBalancerNames:    aws.StringSlice([]string{"ingress"}),
+				})).Return(&autoscaling.DetachLoadBalancersOutput{}, nil)
+			},
+		},
+		{
+			name:              "should not update load balancers already attached to the ASG",
+			targetGroupARNs:   []string{"arn:tg-1"},
+			loadBalancerNames: []string{"ingress"},
+			asg: &expinfrav1.AutoScalingGroup{
+				Name:              "asgName",
+				TargetGroupARNs:   []string{"arn:tg-1"},
+				LoadBalancerNames: []string{"ingress"},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {},
+		},
+		{
+			name:            "should return an error if the target groups cannot be attached",
+			targetGroupARNs: []string{"arn:tg-1"},
+			asg:             &expinfrav1.AutoScalingGroup{Name: "asgName"},
+			wantErr:         true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.AttachLoadBalancerTargetGroupsInput{})).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Spec.TargetGroupARNs = tt.targetGroupARNs
+			mps.AWSMachinePool.Spec.LoadBalancerNames = tt.loadBalancerNames
+
+			err = s.ReconcileLoadBalancers(mps, tt.asg)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
 func TestServiceUpdateResourceTags(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1156,9 +1653,10 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	tests := []struct {
-		name    string
-		wantErr bool
-		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		name               string
+		refreshPreferences *expinfrav1.RefreshPreferences
+		wantErr            bool
+		expect             func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
 	}{
 		{
 			name:    "should return error if start instance refresh failed",
@@ -1190,6 +1688,35 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 					Return(&autoscaling.StartInstanceRefreshOutput{}, nil)
 			},
 		},
+		{
+			name: "should start instance refresh with checkpoints and auto rollback to the launch template version",
+			refreshPreferences: &expinfrav1.RefreshPreferences{
+				CheckpointPercentages: []int64{50, 100},
+				CheckpointDelay:       aws.Int64(600),
+				SkipMatching:          aws.Bool(true),
+				AutoRollback:          aws.Bool(true),
+			},
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.StartInstanceRefreshWithContext(context.TODO(), gomock.Eq(&autoscaling.StartInstanceRefreshInput{
+					AutoScalingGroupName: aws.String("mpn"),
+					Strategy:             aws.String("Rolling"),
+					Preferences: &autoscaling.RefreshPreferences{
+						CheckpointPercentages: aws.Int64Slice([]int64{50, 100}),
+						CheckpointDelay:       aws.Int64(600),
+						SkipMatching:          aws.Bool(true),
+						AutoRollback:          aws.Bool(true),
+					},
+					DesiredConfiguration: &autoscaling.DesiredConfiguration{
+						LaunchTemplate: &autoscaling.LaunchTemplateSpecification{
+							LaunchTemplateId: aws.String("launchTemplateID"),
+							Version:          aws.String("2"),
+						},
+					},
+				})).
+					Return(&autoscaling.StartInstanceRefreshOutput{}, nil)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1207,6 +1734,11 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 			mps, err := getMachinePoolScope(fakeClient, clusterScope)
 			g.Expect(err).ToNot(HaveOccurred())
 			mps.AWSMachinePool.Name = "mpn"
+			if tt.refreshPreferences != nil {
+				mps.AWSMachinePool.Spec.RefreshPreferences = tt.refreshPreferences
+				mps.AWSMachinePool.Spec.MixedInstancesPolicy = nil
+				mps.AWSMachinePool.Status.LaunchTemplateVersion = aws.String("2")
+			}
 
 			err = s.StartASGInstanceRefresh(mps)
 			checkErr(tt.wantErr, err, g)
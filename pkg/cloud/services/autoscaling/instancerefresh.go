@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/pkg/errors"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// GetLatestInstanceRefresh returns the state of the latest instance refresh of the ASG, or nil when the ASG
+// was never refreshed.
+func (s *Service) GetLatestInstanceRefresh(scope *scope.MachinePoolScope) (*expinfrav1.InstanceRefreshStatus, error) {
+	input := &autoscaling.DescribeInstanceRefreshesInput{
+		AutoScalingGroupName: aws.String(scope.Name()),
+		// Instance refreshes are listed from the latest one.
+		MaxRecords: aws.Int64(1),
+	}
+
+	out, err := s.ASGClient.DescribeInstanceRefreshesWithContext(context.TODO(), input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instance refreshes of ASG %q", scope.Name())
+	}
+	if len(out.InstanceRefreshes) == 0 {
+		return nil, nil
+	}
+
+	refresh := out.InstanceRefreshes[0]
+	status := &expinfrav1.InstanceRefreshStatus{
+		ID:                 aws.StringValue(refresh.InstanceRefreshId),
+		Status:             aws.StringValue(refresh.Status),
+		StatusReason:       aws.StringValue(refresh.StatusReason),
+		PercentageComplete: aws.Int64Value(refresh.PercentageComplete),
+		InstancesToUpdate:  aws.Int64Value(refresh.InstancesToUpdate),
+	}
+	if refresh.DesiredConfiguration != nil {
+		status.LaunchTemplateVersion = sdkLaunchTemplateVersion(refresh.DesiredConfiguration.LaunchTemplate, refresh.DesiredConfiguration.MixedInstancesPolicy)
+	}
+
+	return status, nil
+}
+
+// RollbackASGInstanceRefresh rolls back the instance refresh in progress of the ASG, replacing the instances it
+// already updated with instances launched from the previous configuration of the ASG.
+func (s *Service) RollbackASGInstanceRefresh(scope *scope.MachinePoolScope) error {
+	input := &autoscaling.RollbackInstanceRefreshInput{
+		AutoScalingGroupName: aws.String(scope.Name()),
+	}
+
+	if _, err := s.ASGClient.RollbackInstanceRefreshWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to roll back instance refresh of ASG %q", scope.Name())
+	}
+
+	return nil
+}
+
+// GetAlarmsInAlarmState returns the ARNs of the CloudWatch alarms which are in the ALARM state.
+func (s *Service) GetAlarmsInAlarmState(alarmARNs []string) ([]string, error) {
+	input := &cloudwatch.DescribeAlarmsInput{
+		AlarmTypes: aws.StringSlice([]string{cloudwatch.AlarmTypeMetricAlarm, cloudwatch.AlarmTypeCompositeAlarm}),
+	}
+	for _, alarmARN := range alarmARNs {
+		parsed, err := arn.Parse(alarmARN)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse alarm ARN %q", alarmARN)
+		}
+		input.AlarmNames = append(input.AlarmNames, aws.String(strings.TrimPrefix(parsed.Resource, "alarm:")))
+	}
+
+	states := map[string]string{}
+	if err := s.CloudWatchClient.DescribeAlarmsPagesWithContext(context.TODO(), input, func(out *cloudwatch.DescribeAlarmsOutput, _ bool) bool {
+		for _, alarm := range out.MetricAlarms {
+			states[aws.StringValue(alarm.AlarmArn)] = aws.StringValue(alarm.StateValue)
+		}
+		for _, alarm := range out.CompositeAlarms {
+			states[aws.StringValue(alarm.AlarmArn)] = aws.StringValue(alarm.StateValue)
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to describe alarms")
+	}
+
+	var inAlarm []string
+	for _, alarmARN := range alarmARNs {
+		state, ok := states[alarmARN]
+		if !ok {
+			return nil, errors.Errorf("alarm %q not found", alarmARN)
+		}
+		if state == cloudwatch.StateValueAlarm {
+			inAlarm = append(inAlarm, alarmARN)
+		}
+	}
+
+	return inAlarm, nil
+}
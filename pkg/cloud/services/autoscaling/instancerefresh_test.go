@@ -0,0 +1,219 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_cloudwatchiface"
+)
+
+func TestServiceGetLatestInstanceRefresh(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name    string
+		want    *expinfrav1.InstanceRefreshStatus
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should return nil if the ASG was never refreshed",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeInstanceRefreshesInput{
+					AutoScalingGroupName: aws.String("mpn"),
+					MaxRecords:           aws.Int64(1),
+				})).Return(&autoscaling.DescribeInstanceRefreshesOutput{}, nil)
+			},
+		},
+		{
+			name: "should return the latest instance refresh",
+			want: &expinfrav1.InstanceRefreshStatus{
+				ID:                    "refresh-1",
+				Status:                autoscaling.InstanceRefreshStatusRollbackSuccessful,
+				StatusReason:          "Rollback was triggered by an alarm",
+				PercentageComplete:    100,
+				InstancesToUpdate:     0,
+				LaunchTemplateVersion: "3",
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeInstanceRefreshesInput{
+					AutoScalingGroupName: aws.String("mpn"),
+					MaxRecords:           aws.Int64(1),
+				})).Return(&autoscaling.DescribeInstanceRefreshesOutput{
+					InstanceRefreshes: []*autoscaling.InstanceRefresh{
+						{
+							InstanceRefreshId:  aws.String("refresh-1"),
+							Status:             aws.String(autoscaling.InstanceRefreshStatusRollbackSuccessful),
+							StatusReason:       aws.String("Rollback was triggered by an alarm"),
+							PercentageComplete: aws.Int64(100),
+							InstancesToUpdate:  aws.Int64(0),
+							DesiredConfiguration: &autoscaling.DesiredConfiguration{
+								MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
+									LaunchTemplate: &autoscaling.LaunchTemplate{
+										LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
+											LaunchTemplateId: aws.String("launchTemplateID"),
+											Version:          aws.String("3"),
+										},
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:    "should return error if describe instance refreshes failed",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeInstanceRefreshesWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserrors.NewNotFound("not found"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = "mpn"
+
+			refresh, err := s.GetLatestInstanceRefresh(mps)
+			checkErr(tt.wantErr, err, g)
+			g.Expect(refresh).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestServiceRollbackASGInstanceRefresh(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	fakeClient := getFakeClient()
+
+	clusterScope, err := getClusterScope(fakeClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	asgMock.EXPECT().RollbackInstanceRefreshWithContext(context.TODO(), gomock.Eq(&autoscaling.RollbackInstanceRefreshInput{
+		AutoScalingGroupName: aws.String("mpn"),
+	})).Return(&autoscaling.RollbackInstanceRefreshOutput{}, nil)
+	s := NewService(clusterScope)
+	s.ASGClient = asgMock
+
+	mps, err := getMachinePoolScope(fakeClient, clusterScope)
+	g.Expect(err).ToNot(HaveOccurred())
+	mps.AWSMachinePool.Name = "mpn"
+
+	g.Expect(s.RollbackASGInstanceRefresh(mps)).To(Succeed())
+}
+
+func TestServiceGetAlarmsInAlarmState(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	const (
+		metricAlarmARN    = "arn:aws:cloudwatch:us-east-1:123456789012:alarm:high-error-rate"
+		compositeAlarmARN = "arn:aws:cloudwatch:us-east-1:123456789012:alarm:unhealthy"
+	)
+
+	tests := []struct {
+		name      string
+		alarmARNs []string
+		want      []string
+		wantErr   bool
+		expect    func(m *mock_cloudwatchiface.MockCloudWatchAPIMockRecorder)
+	}{
+		{
+			name:      "should return the alarms in the ALARM state",
+			alarmARNs: []string{metricAlarmARN, compositeAlarmARN},
+			want:      []string{compositeAlarmARN},
+			expect: func(m *mock_cloudwatchiface.MockCloudWatchAPIMockRecorder) {
+				m.DescribeAlarmsPagesWithContext(context.TODO(), gomock.Eq(&cloudwatch.DescribeAlarmsInput{
+					AlarmNames: aws.StringSlice([]string{"high-error-rate", "unhealthy"}),
+					AlarmTypes: aws.StringSlice([]string{cloudwatch.AlarmTypeMetricAlarm, cloudwatch.AlarmTypeCompositeAlarm}),
+				}), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *cloudwatch.DescribeAlarmsInput, fn func(*cloudwatch.DescribeAlarmsOutput, bool) bool, _ ...interface{}) error {
+						fn(&cloudwatch.DescribeAlarmsOutput{
+							MetricAlarms: []*cloudwatch.MetricAlarm{
+								{AlarmArn: aws.String(metricAlarmARN), StateValue: aws.String(cloudwatch.StateValueOk)},
+							},
+							CompositeAlarms: []*cloudwatch.CompositeAlarm{
+								{AlarmArn: aws.String(compositeAlarmARN), StateValue: aws.String(cloudwatch.StateValueAlarm)},
+							},
+						}, true)
+						return nil
+					})
+			},
+		},
+		{
+			name:      "should return error if an alarm does not exist",
+			alarmARNs: []string{metricAlarmARN},
+			wantErr:   true,
+			expect: func(m *mock_cloudwatchiface.MockCloudWatchAPIMockRecorder) {
+				m.DescribeAlarmsPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name:      "should return error if an alarm ARN is invalid",
+			alarmARNs: []string{"high-error-rate"},
+			wantErr:   true,
+			expect:    func(m *mock_cloudwatchiface.MockCloudWatchAPIMockRecorder) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			cloudWatchMock := mock_cloudwatchiface.NewMockCloudWatchAPI(mockCtrl)
+			tt.expect(cloudWatchMock.EXPECT())
+			s := NewService(clusterScope)
+			s.CloudWatchClient = cloudWatchMock
+
+			alarms, err := s.GetAlarmsInAlarmState(tt.alarmARNs)
+			checkErr(tt.wantErr, err, g)
+			g.Expect(alarms).To(Equal(tt.want))
+		})
+	}
+}
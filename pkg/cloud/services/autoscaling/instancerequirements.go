@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+// createSDKInstanceRequirements translates instance requirements to their EC2 Auto Scaling representation.
+func createSDKInstanceRequirements(i *expinfrav1.InstanceRequirements) *autoscaling.InstanceRequirements {
+	requirements := &autoscaling.InstanceRequirements{
+		VCpuCount: &autoscaling.VCpuCountRequest{
+			Min: aws.Int64(i.VCPUCount.Min),
+			Max: i.VCPUCount.Max,
+		},
+		MemoryMiB: &autoscaling.MemoryMiBRequest{
+			Min: aws.Int64(i.MemoryMiB.Min),
+			Max: i.MemoryMiB.Max,
+		},
+		OnDemandMaxPricePercentageOverLowestPrice: i.OnDemandMaxPricePercentageOverLowestPrice,
+		SpotMaxPricePercentageOverLowestPrice:     i.SpotMaxPricePercentageOverLowestPrice,
+	}
+
+	if i.AcceleratorCount != nil {
+		requirements.AcceleratorCount = &autoscaling.AcceleratorCountRequest{
+			Min: aws.Int64(i.AcceleratorCount.Min),
+			Max: i.AcceleratorCount.Max,
+		}
+	}
+	if i.BurstablePerformance != "" {
+		requirements.BurstablePerformance = aws.String(string(i.BurstablePerformance))
+	}
+	if i.BareMetal != "" {
+		requirements.BareMetal = aws.String(string(i.BareMetal))
+	}
+	for _, manufacturer := range i.CPUManufacturers {
+		requirements.CpuManufacturers = append(requirements.CpuManufacturers, aws.String(string(manufacturer)))
+	}
+	for _, acceleratorType := range i.AcceleratorTypes {
+		requirements.AcceleratorTypes = append(requirements.AcceleratorTypes, aws.String(string(acceleratorType)))
+	}
+	for _, generation := range i.InstanceGenerations {
+		requirements.InstanceGenerations = append(requirements.InstanceGenerations, aws.String(string(generation)))
+	}
+	if len(i.AllowedInstanceTypes) > 0 {
+		requirements.AllowedInstanceTypes = aws.StringSlice(i.AllowedInstanceTypes)
+	}
+	if len(i.ExcludedInstanceTypes) > 0 {
+		requirements.ExcludedInstanceTypes = aws.StringSlice(i.ExcludedInstanceTypes)
+	}
+
+	return requirements
+}
+
+// sdkToInstanceRequirements translates the instance requirements of an ASG to their AWSMachinePool representation.
+func sdkToInstanceRequirements(v *autoscaling.InstanceRequirements) *expinfrav1.InstanceRequirements {
+	requirements := &expinfrav1.InstanceRequirements{
+		OnDemandMaxPricePercentageOverLowestPrice: v.OnDemandMaxPricePercentageOverLowestPrice,
+		SpotMaxPricePercentageOverLowestPrice:     v.SpotMaxPricePercentageOverLowestPrice,
+		BurstablePerformance:                      expinfrav1.InstanceRequirementInclusion(aws.StringValue(v.BurstablePerformance)),
+		BareMetal:                                 expinfrav1.InstanceRequirementInclusion(aws.StringValue(v.BareMetal)),
+	}
+
+	if v.VCpuCount != nil {
+		requirements.VCPUCount = expinfrav1.InstanceRequirementsRange{
+			Min: aws.Int64Value(v.VCpuCount.Min),
+			Max: v.VCpuCount.Max,
+		}
+	}
+	if v.MemoryMiB != nil {
+		requirements.MemoryMiB = expinfrav1.InstanceRequirementsRange{
+			Min: aws.Int64Value(v.MemoryMiB.Min),
+			Max: v.MemoryMiB.Max,
+		}
+	}
+	if v.AcceleratorCount != nil {
+		requirements.AcceleratorCount = &expinfrav1.InstanceRequirementsRange{
+			Min: aws.Int64Value(v.AcceleratorCount.Min),
+			Max: v.AcceleratorCount.Max,
+		}
+	}
+	for _, manufacturer := range v.CpuManufacturers {
+		requirements.CPUManufacturers = append(requirements.CPUManufacturers, expinfrav1.CPUManufacturer(aws.StringValue(manufacturer)))
+	}
+	for _, acceleratorType := range v.AcceleratorTypes {
+		requirements.AcceleratorTypes = append(requirements.AcceleratorTypes, expinfrav1.AcceleratorType(aws.StringValue(acceleratorType)))
+	}
+	for _, generation := range v.InstanceGenerations {
+		requirements.InstanceGenerations = append(requirements.InstanceGenerations, expinfrav1.InstanceGeneration(aws.StringValue(generation)))
+	}
+	if len(v.AllowedInstanceTypes) > 0 {
+		requirements.AllowedInstanceTypes = aws.StringValueSlice(v.AllowedInstanceTypes)
+	}
+	if len(v.ExcludedInstanceTypes) > 0 {
+		requirements.ExcludedInstanceTypes = aws.StringValueSlice(v.ExcludedInstanceTypes)
+	}
+
+	return requirements
+}
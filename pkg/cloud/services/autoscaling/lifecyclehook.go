@@ -0,0 +1,216 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+const (
+	// defaultHeartbeatTimeout is the heartbeat timeout EC2 Auto Scaling applies to lifecycle hooks without one.
+	defaultHeartbeatTimeout = time.Hour
+
+	// defaultNodeDrainHeartbeatTimeout is the heartbeat timeout of the node drain lifecycle hook without one.
+	defaultNodeDrainHeartbeatTimeout = 10 * time.Minute
+)
+
+// getLifecycleHooks returns the lifecycle hooks of the AWSMachinePool, along with its node drain lifecycle hook.
+func getLifecycleHooks(scope *scope.MachinePoolScope) []expinfrav1.AWSLifecycleHook {
+	hooks := append([]expinfrav1.AWSLifecycleHook{}, scope.AWSMachinePool.Spec.LifecycleHooks...)
+
+	if nodeDrainHook := scope.AWSMachinePool.Spec.NodeDrainLifecycleHook; nodeDrainHook != nil {
+		heartbeatTimeout := &metav1.Duration{Duration: defaultNodeDrainHeartbeatTimeout}
+		if nodeDrainHook.HeartbeatTimeout != nil {
+			heartbeatTimeout = nodeDrainHook.HeartbeatTimeout
+		}
+		// The instance is terminated whatever the result, continuing runs the other terminating hooks.
+		defaultResult := expinfrav1.LifecycleHookDefaultResultContinue
+		hooks = append(hooks, expinfrav1.AWSLifecycleHook{
+			Name:                expinfrav1.NodeDrainLifecycleHookName,
+			LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminate,
+			HeartbeatTimeout:    heartbeatTimeout,
+			DefaultResult:       &defaultResult,
+		})
+	}
+
+	return hooks
+}
+
+// DescribeLifecycleHooks returns the lifecycle hooks of an ASG.
+func (s *Service) DescribeLifecycleHooks(asgName string) ([]expinfrav1.AWSLifecycleHook, error) {
+	input := &autoscaling.DescribeLifecycleHooksInput{
+		AutoScalingGroupName: aws.String(asgName),
+	}
+
+	out, err := s.ASGClient.DescribeLifecycleHooksWithContext(context.TODO(), input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe lifecycle hooks of ASG %q", asgName)
+	}
+
+	hooks := make([]expinfrav1.AWSLifecycleHook, 0, len(out.LifecycleHooks))
+	for _, hook := range out.LifecycleHooks {
+		hooks = append(hooks, sdkToLifecycleHook(hook))
+	}
+	return hooks, nil
+}
+
+// ReconcileLifecycleHooks creates or updates the lifecycle hooks of the ASG to match the AWSMachinePool, and
+// deletes the ones it does not declare.
+func (s *Service) ReconcileLifecycleHooks(scope *scope.MachinePoolScope) error {
+	asgName := scope.Name()
+
+	existingHooks, err := s.DescribeLifecycleHooks(asgName)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]expinfrav1.AWSLifecycleHook, len(existingHooks))
+	for _, hook := range existingHooks {
+		existing[hook.Name] = hook
+	}
+
+	hooks := getLifecycleHooks(scope)
+	for i := range hooks {
+		hook := &hooks[i]
+		if existingHook, ok := existing[hook.Name]; ok {
+			delete(existing, hook.Name)
+			if lifecycleHookEqual(hook, &existingHook) {
+				continue
+			}
+		}
+
+		s.scope.Info("Updating lifecycle hook", "asg", asgName, "hook", hook.Name)
+		if _, err := s.ASGClient.PutLifecycleHookWithContext(context.TODO(), getPutLifecycleHookInput(asgName, hook)); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedPutLifecycleHook", "Failed to update lifecycle hook %q of ASG %q: %v", hook.Name, asgName, err)
+			return errors.Wrapf(err, "failed to update lifecycle hook %q of ASG %q", hook.Name, asgName)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulPutLifecycleHook", "Updated lifecycle hook %q of ASG %q", hook.Name, asgName)
+	}
+
+	for name := range existing {
+		s.scope.Info("Deleting lifecycle hook", "asg", asgName, "hook", name)
+		input := &autoscaling.DeleteLifecycleHookInput{
+			AutoScalingGroupName: aws.String(asgName),
+			LifecycleHookName:    aws.String(name),
+		}
+		if _, err := s.ASGClient.DeleteLifecycleHookWithContext(context.TODO(), input); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedDeleteLifecycleHook", "Failed to delete lifecycle hook %q of ASG %q: %v", name, asgName, err)
+			return errors.Wrapf(err, "failed to delete lifecycle hook %q of ASG %q", name, asgName)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulDeleteLifecycleHook", "Deleted lifecycle hook %q of ASG %q", name, asgName)
+	}
+
+	return nil
+}
+
+// CompleteLifecycleAction continues the launch or termination of an instance paused by a lifecycle hook of an ASG.
+func (s *Service) CompleteLifecycleAction(asgName, hookName, instanceID string) error {
+	input := &autoscaling.CompleteLifecycleActionInput{
+		AutoScalingGroupName:  aws.String(asgName),
+		LifecycleHookName:     aws.String(hookName),
+		InstanceId:            aws.String(instanceID),
+		LifecycleActionResult: aws.String(string(expinfrav1.LifecycleHookDefaultResultContinue)),
+	}
+
+	if _, err := s.ASGClient.CompleteLifecycleActionWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to complete lifecycle action of hook %q for instance %q", hookName, instanceID)
+	}
+
+	return nil
+}
+
+func sdkToLifecycleHook(hook *autoscaling.LifecycleHook) expinfrav1.AWSLifecycleHook {
+	result := expinfrav1.AWSLifecycleHook{
+		Name:                  aws.StringValue(hook.LifecycleHookName),
+		LifecycleTransition:   expinfrav1.LifecycleTransition(aws.StringValue(hook.LifecycleTransition)),
+		NotificationTargetARN: hook.NotificationTargetARN,
+		RoleARN:               hook.RoleARN,
+		NotificationMetadata:  hook.NotificationMetadata,
+	}
+	if hook.HeartbeatTimeout != nil {
+		result.HeartbeatTimeout = &metav1.Duration{Duration: time.Duration(*hook.HeartbeatTimeout) * time.Second}
+	}
+	if hook.DefaultResult != nil {
+		defaultResult := expinfrav1.LifecycleHookDefaultResult(*hook.DefaultResult)
+		result.DefaultResult = &defaultResult
+	}
+	return result
+}
+
+func getPutLifecycleHookInput(asgName string, hook *expinfrav1.AWSLifecycleHook) *autoscaling.PutLifecycleHookInput {
+	input := &autoscaling.PutLifecycleHookInput{
+		AutoScalingGroupName:  aws.String(asgName),
+		LifecycleHookName:     aws.String(hook.Name),
+		LifecycleTransition:   aws.String(string(hook.LifecycleTransition)),
+		NotificationTargetARN: hook.NotificationTargetARN,
+		RoleARN:               hook.RoleARN,
+		NotificationMetadata:  hook.NotificationMetadata,
+	}
+	if hook.HeartbeatTimeout != nil {
+		input.HeartbeatTimeout = aws.Int64(int64(hook.HeartbeatTimeout.Duration.Seconds()))
+	}
+	if hook.DefaultResult != nil {
+		input.DefaultResult = aws.String(string(*hook.DefaultResult))
+	}
+	return input
+}
+
+func getLifecycleHookSpecification(hook *expinfrav1.AWSLifecycleHook) *autoscaling.LifecycleHookSpecification {
+	input := getPutLifecycleHookInput("", hook)
+	return &autoscaling.LifecycleHookSpecification{
+		LifecycleHookName:     input.LifecycleHookName,
+		LifecycleTransition:   input.LifecycleTransition,
+		HeartbeatTimeout:      input.HeartbeatTimeout,
+		DefaultResult:         input.DefaultResult,
+		NotificationTargetARN: input.NotificationTargetARN,
+		RoleARN:               input.RoleARN,
+		NotificationMetadata:  input.NotificationMetadata,
+	}
+}
+
+// lifecycleHookEqual compares a lifecycle hook of an AWSMachinePool to the one of an ASG, taking the values applied
+// by EC2 Auto Scaling to unset fields into account.
+func lifecycleHookEqual(desired, existing *expinfrav1.AWSLifecycleHook) bool {
+	heartbeatTimeout := func(hook *expinfrav1.AWSLifecycleHook) time.Duration {
+		if hook.HeartbeatTimeout == nil {
+			return defaultHeartbeatTimeout
+		}
+		return hook.HeartbeatTimeout.Duration
+	}
+	defaultResult := func(hook *expinfrav1.AWSLifecycleHook) expinfrav1.LifecycleHookDefaultResult {
+		if hook.DefaultResult == nil {
+			return expinfrav1.LifecycleHookDefaultResultAbandon
+		}
+		return *hook.DefaultResult
+	}
+
+	return desired.LifecycleTransition == existing.LifecycleTransition &&
+		heartbeatTimeout(desired) == heartbeatTimeout(existing) &&
+		defaultResult(desired) == defaultResult(existing) &&
+		aws.StringValue(desired.NotificationTargetARN) == aws.StringValue(existing.NotificationTargetARN) &&
+		aws.StringValue(desired.RoleARN) == aws.StringValue(existing.RoleARN) &&
+		aws.StringValue(desired.NotificationMetadata) == aws.StringValue(existing.NotificationMetadata)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+)
+
+func TestServiceReconcileLifecycleHooks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name                   string
+		lifecycleHooks         []expinfrav1.AWSLifecycleHook
+		nodeDrainLifecycleHook *expinfrav1.NodeDrainLifecycleHook
+		wantErr                bool
+		expect                 func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should create missing lifecycle hooks along with the node drain lifecycle hook",
+			lifecycleHooks: []expinfrav1.AWSLifecycleHook{
+				{
+					Name:                  "launch",
+					LifecycleTransition:   expinfrav1.LifecycleTransitionInstanceLaunch,
+					NotificationTargetARN: aws.String("target-arn"),
+					RoleARN:               aws.String("role-arn"),
+				},
+			},
+			nodeDrainLifecycleHook: &expinfrav1.NodeDrainLifecycleHook{},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeLifecycleHooksInput{
+					AutoScalingGroupName: aws.String("asg"),
+				})).Return(&autoscaling.DescribeLifecycleHooksOutput{}, nil)
+				m.PutLifecycleHookWithContext(context.TODO(), gomock.Eq(&autoscaling.PutLifecycleHookInput{
+					AutoScalingGroupName:  aws.String("asg"),
+					LifecycleHookName:     aws.String("launch"),
+					LifecycleTransition:   aws.String("autoscaling:EC2_INSTANCE_LAUNCHING"),
+					NotificationTargetARN: aws.String("target-arn"),
+					RoleARN:               aws.String("role-arn"),
+				})).Return(&autoscaling.PutLifecycleHookOutput{}, nil)
+				m.PutLifecycleHookWithContext(context.TODO(), gomock.Eq(&autoscaling.PutLifecycleHookInput{
+					AutoScalingGroupName: aws.String("asg"),
+					LifecycleHookName:    aws.String(expinfrav1.NodeDrainLifecycleHookName),
+					LifecycleTransition:  aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+					HeartbeatTimeout:     aws.Int64(600),
+					DefaultResult:        aws.String("CONTINUE"),
+				})).Return(&autoscaling.PutLifecycleHookOutput{}, nil)
+			},
+		},
+		{
+			name: "should not update lifecycle hooks matching the AWSMachinePool",
+			lifecycleHooks: []expinfrav1.AWSLifecycleHook{
+				{
+					Name:                "terminate",
+					LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminate,
+				},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.DescribeLifecycleHooksInput{})).
+					Return(&autoscaling.DescribeLifecycleHooksOutput{
+						LifecycleHooks: []*autoscaling.LifecycleHook{
+							{
+								AutoScalingGroupName: aws.String("asg"),
+								LifecycleHookName:    aws.String("terminate"),
+								LifecycleTransition:  aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+								HeartbeatTimeout:     aws.Int64(3600),
+								DefaultResult:        aws.String("ABANDON"),
+								GlobalTimeout:        aws.Int64(172800),
+							},
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "should update a lifecycle hook with a different heartbeat timeout and delete undeclared ones",
+			lifecycleHooks: []expinfrav1.AWSLifecycleHook{
+				{
+					Name:                "terminate",
+					LifecycleTransition: expinfrav1.LifecycleTransitionInstanceTerminate,
+					HeartbeatTimeout:    &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.DescribeLifecycleHooksInput{})).
+					Return(&autoscaling.DescribeLifecycleHooksOutput{
+						LifecycleHooks: []*autoscaling.LifecycleHook{
+							{
+								LifecycleHookName:   aws.String("terminate"),
+								LifecycleTransition: aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+								HeartbeatTimeout:    aws.Int64(3600),
+								DefaultResult:       aws.String("ABANDON"),
+							},
+							{
+								LifecycleHookName:   aws.String(expinfrav1.NodeDrainLifecycleHookName),
+								LifecycleTransition: aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+								HeartbeatTimeout:    aws.Int64(600),
+								DefaultResult:       aws.String("CONTINUE"),
+							},
+						},
+					}, nil)
+				m.PutLifecycleHookWithContext(context.TODO(), gomock.Eq(&autoscaling.PutLifecycleHookInput{
+					AutoScalingGroupName: aws.String("asg"),
+					LifecycleHookName:    aws.String("terminate"),
+					LifecycleTransition:  aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+					HeartbeatTimeout:     aws.Int64(300),
+				})).Return(&autoscaling.PutLifecycleHookOutput{}, nil)
+				m.DeleteLifecycleHookWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteLifecycleHookInput{
+					AutoScalingGroupName: aws.String("asg"),
+					LifecycleHookName:    aws.String(expinfrav1.NodeDrainLifecycleHookName),
+				})).Return(&autoscaling.DeleteLifecycleHookOutput{}, nil)
+			},
+		},
+		{
+			name: "should return an error if the lifecycle hooks cannot be described",
+			lifecycleHooks: []expinfrav1.AWSLifecycleHook{
+				{
+					Name:                "launch",
+					LifecycleTransition: expinfrav1.LifecycleTransitionInstanceLaunch,
+				},
+			},
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeLifecycleHooksWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.DescribeLifecycleHooksInput{})).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = "asg"
+			mps.AWSMachinePool.Spec.LifecycleHooks = tt.lifecycleHooks
+			mps.AWSMachinePool.Spec.NodeDrainLifecycleHook = tt.nodeDrainLifecycleHook
+
+			err = s.ReconcileLifecycleHooks(mps)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceCompleteLifecycleAction(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	fakeClient := getFakeClient()
+
+	clusterScope, err := getClusterScope(fakeClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	asgMock.EXPECT().CompleteLifecycleActionWithContext(context.TODO(), gomock.Eq(&autoscaling.CompleteLifecycleActionInput{
+		AutoScalingGroupName:  aws.String("asg"),
+		LifecycleHookName:     aws.String(expinfrav1.NodeDrainLifecycleHookName),
+		InstanceId:            aws.String("i-1"),
+		LifecycleActionResult: aws.String("CONTINUE"),
+	})).Return(&autoscaling.CompleteLifecycleActionOutput{}, nil)
+	s := NewService(clusterScope)
+	s.ASGClient = asgMock
+
+	g.Expect(s.CompleteLifecycleAction("asg", expinfrav1.NodeDrainLifecycleHookName, "i-1")).To(Succeed())
+}
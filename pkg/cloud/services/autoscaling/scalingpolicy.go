@@ -0,0 +1,331 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// targetTrackingScalingPolicyType is the type of the scaling policies managed by the AWSMachinePool.
+const targetTrackingScalingPolicyType = "TargetTrackingScaling"
+
+// DescribeScalingPolicies returns the target tracking scaling policies of an ASG. Scaling policies of other
+// types are not managed by the AWSMachinePool, and are not returned.
+func (s *Service) DescribeScalingPolicies(asgName string) ([]expinfrav1.AWSScalingPolicy, error) {
+	input := &autoscaling.DescribePoliciesInput{
+		AutoScalingGroupName: aws.String(asgName),
+		PolicyTypes:          aws.StringSlice([]string{targetTrackingScalingPolicyType}),
+	}
+
+	policies := []expinfrav1.AWSScalingPolicy{}
+	err := s.ASGClient.DescribePoliciesPagesWithContext(context.TODO(), input, func(out *autoscaling.DescribePoliciesOutput, lastPage bool) bool {
+		for _, policy := range out.ScalingPolicies {
+			if policy.TargetTrackingConfiguration == nil {
+				continue
+			}
+			policies = append(policies, sdkToScalingPolicy(policy))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe scaling policies of ASG %q", asgName)
+	}
+	return policies, nil
+}
+
+// ReconcileScalingPolicies creates or updates the target tracking scaling policies of the ASG to match the
+// AWSMachinePool, and deletes the ones it does not declare.
+func (s *Service) ReconcileScalingPolicies(scope *scope.MachinePoolScope) error {
+	asgName := scope.Name()
+
+	existingPolicies, err := s.DescribeScalingPolicies(asgName)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]expinfrav1.AWSScalingPolicy, len(existingPolicies))
+	for _, policy := range existingPolicies {
+		existing[policy.Name] = policy
+	}
+
+	for i := range scope.AWSMachinePool.Spec.ScalingPolicies {
+		policy := &scope.AWSMachinePool.Spec.ScalingPolicies[i]
+		if existingPolicy, ok := existing[policy.Name]; ok {
+			delete(existing, policy.Name)
+			if scalingPolicyEqual(policy, &existingPolicy) {
+				continue
+			}
+		}
+
+		s.scope.Info("Updating scaling policy", "asg", asgName, "policy", policy.Name)
+		if _, err := s.ASGClient.PutScalingPolicyWithContext(context.TODO(), getPutScalingPolicyInput(asgName, policy)); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedPutScalingPolicy", "Failed to update scaling policy %q of ASG %q: %v", policy.Name, asgName, err)
+			return errors.Wrapf(err, "failed to update scaling policy %q of ASG %q", policy.Name, asgName)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulPutScalingPolicy", "Updated scaling policy %q of ASG %q", policy.Name, asgName)
+	}
+
+	for name := range existing {
+		s.scope.Info("Deleting scaling policy", "asg", asgName, "policy", name)
+		input := &autoscaling.DeletePolicyInput{
+			AutoScalingGroupName: aws.String(asgName),
+			PolicyName:           aws.String(name),
+		}
+		if _, err := s.ASGClient.DeletePolicyWithContext(context.TODO(), input); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedDeleteScalingPolicy", "Failed to delete scaling policy %q of ASG %q: %v", name, asgName, err)
+			return errors.Wrapf(err, "failed to delete scaling policy %q of ASG %q", name, asgName)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulDeleteScalingPolicy", "Deleted scaling policy %q of ASG %q", name, asgName)
+	}
+
+	return nil
+}
+
+// DescribeScheduledActions returns the scheduled actions of an ASG.
+func (s *Service) DescribeScheduledActions(asgName string) ([]expinfrav1.AWSScheduledAction, error) {
+	input := &autoscaling.DescribeScheduledActionsInput{
+		AutoScalingGroupName: aws.String(asgName),
+	}
+
+	actions := []expinfrav1.AWSScheduledAction{}
+	err := s.ASGClient.DescribeScheduledActionsPagesWithContext(context.TODO(), input, func(out *autoscaling.DescribeScheduledActionsOutput, lastPage bool) bool {
+		for _, action := range out.ScheduledUpdateGroupActions {
+			actions = append(actions, sdkToScheduledAction(action))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe scheduled actions of ASG %q", asgName)
+	}
+	return actions, nil
+}
+
+// ReconcileScheduledActions creates or updates the scheduled actions of the ASG to match the AWSMachinePool, and
+// deletes the ones it does not declare.
+func (s *Service) ReconcileScheduledActions(scope *scope.MachinePoolScope) error {
+	asgName := scope.Name()
+
+	existingActions, err := s.DescribeScheduledActions(asgName)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]expinfrav1.AWSScheduledAction, len(existingActions))
+	for _, action := range existingActions {
+		existing[action.Name] = action
+	}
+
+	for i := range scope.AWSMachinePool.Spec.ScheduledActions {
+		action := &scope.AWSMachinePool.Spec.ScheduledActions[i]
+		if existingAction, ok := existing[action.Name]; ok {
+			delete(existing, action.Name)
+			if scheduledActionEqual(action, &existingAction) {
+				continue
+			}
+		}
+
+		s.scope.Info("Updating scheduled action", "asg", asgName, "action", action.Name)
+		if _, err := s.ASGClient.PutScheduledUpdateGroupActionWithContext(context.TODO(), getPutScheduledUpdateGroupActionInput(asgName, action)); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedPutScheduledAction", "Failed to update scheduled action %q of ASG %q: %v", action.Name, asgName, err)
+			return errors.Wrapf(err, "failed to update scheduled action %q of ASG %q", action.Name, asgName)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulPutScheduledAction", "Updated scheduled action %q of ASG %q", action.Name, asgName)
+	}
+
+	for name := range existing {
+		s.scope.Info("Deleting scheduled action", "asg", asgName, "action", name)
+		input := &autoscaling.DeleteScheduledActionInput{
+			AutoScalingGroupName: aws.String(asgName),
+			ScheduledActionName:  aws.String(name),
+		}
+		if _, err := s.ASGClient.DeleteScheduledActionWithContext(context.TODO(), input); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedDeleteScheduledAction", "Failed to delete scheduled action %q of ASG %q: %v", name, asgName, err)
+			return errors.Wrapf(err, "failed to delete scheduled action %q of ASG %q", name, asgName)
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulDeleteScheduledAction", "Deleted scheduled action %q of ASG %q", name, asgName)
+	}
+
+	return nil
+}
+
+func sdkToScalingPolicy(policy *autoscaling.ScalingPolicy) expinfrav1.AWSScalingPolicy {
+	config := policy.TargetTrackingConfiguration
+	result := expinfrav1.AWSScalingPolicy{
+		Name:           aws.StringValue(policy.PolicyName),
+		TargetValue:    *resource.NewMilliQuantity(int64(math.Round(aws.Float64Value(config.TargetValue)*1000)), resource.DecimalSI),
+		DisableScaleIn: aws.BoolValue(config.DisableScaleIn),
+	}
+	if metric := config.PredefinedMetricSpecification; metric != nil {
+		result.PredefinedMetric = &expinfrav1.PredefinedMetric{
+			Type:          expinfrav1.PredefinedMetricType(aws.StringValue(metric.PredefinedMetricType)),
+			ResourceLabel: metric.ResourceLabel,
+		}
+	}
+	if metric := config.CustomizedMetricSpecification; metric != nil {
+		result.CustomizedMetric = &expinfrav1.CustomizedMetric{
+			MetricName: aws.StringValue(metric.MetricName),
+			Namespace:  aws.StringValue(metric.Namespace),
+			Statistic:  expinfrav1.MetricStatistic(aws.StringValue(metric.Statistic)),
+			Unit:       metric.Unit,
+		}
+		for _, dimension := range metric.Dimensions {
+			result.CustomizedMetric.Dimensions = append(result.CustomizedMetric.Dimensions, expinfrav1.MetricDimension{
+				Name:  aws.StringValue(dimension.Name),
+				Value: aws.StringValue(dimension.Value),
+			})
+		}
+	}
+	if policy.EstimatedInstanceWarmup != nil {
+		result.EstimatedInstanceWarmup = &metav1.Duration{Duration: time.Duration(*policy.EstimatedInstanceWarmup) * time.Second}
+	}
+	return result
+}
+
+func getPutScalingPolicyInput(asgName string, policy *expinfrav1.AWSScalingPolicy) *autoscaling.PutScalingPolicyInput {
+	config := &autoscaling.TargetTrackingConfiguration{
+		TargetValue:    aws.Float64(policy.TargetValue.AsApproximateFloat64()),
+		DisableScaleIn: aws.Bool(policy.DisableScaleIn),
+	}
+	if metric := policy.PredefinedMetric; metric != nil {
+		config.PredefinedMetricSpecification = &autoscaling.PredefinedMetricSpecification{
+			PredefinedMetricType: aws.String(string(metric.Type)),
+			ResourceLabel:        metric.ResourceLabel,
+		}
+	}
+	if metric := policy.CustomizedMetric; metric != nil {
+		config.CustomizedMetricSpecification = &autoscaling.CustomizedMetricSpecification{
+			MetricName: aws.String(metric.MetricName),
+			Namespace:  aws.String(metric.Namespace),
+			Statistic:  aws.String(string(metric.Statistic)),
+			Unit:       metric.Unit,
+		}
+		for _, dimension := range metric.Dimensions {
+			config.CustomizedMetricSpecification.Dimensions = append(config.CustomizedMetricSpecification.Dimensions, &autoscaling.MetricDimension{
+				Name:  aws.String(dimension.Name),
+				Value: aws.String(dimension.Value),
+			})
+		}
+	}
+
+	input := &autoscaling.PutScalingPolicyInput{
+		AutoScalingGroupName:        aws.String(asgName),
+		PolicyName:                  aws.String(policy.Name),
+		PolicyType:                  aws.String(targetTrackingScalingPolicyType),
+		TargetTrackingConfiguration: config,
+	}
+	if policy.EstimatedInstanceWarmup != nil {
+		input.EstimatedInstanceWarmup = aws.Int64(int64(policy.EstimatedInstanceWarmup.Duration.Seconds()))
+	}
+	return input
+}
+
+// scalingPolicyEqual compares a scaling policy of an AWSMachinePool to the one of an ASG. Target values are
+// compared with a precision of a thousandth.
+func scalingPolicyEqual(desired, existing *expinfrav1.AWSScalingPolicy) bool {
+	estimatedInstanceWarmup := func(policy *expinfrav1.AWSScalingPolicy) *int64 {
+		if policy.EstimatedInstanceWarmup == nil {
+			return nil
+		}
+		return aws.Int64(int64(policy.EstimatedInstanceWarmup.Duration.Seconds()))
+	}
+
+	return desired.TargetValue.MilliValue() == existing.TargetValue.MilliValue() &&
+		desired.DisableScaleIn == existing.DisableScaleIn &&
+		cmp.Equal(desired.PredefinedMetric, existing.PredefinedMetric) &&
+		cmp.Equal(desired.CustomizedMetric, existing.CustomizedMetric) &&
+		cmp.Equal(estimatedInstanceWarmup(desired), estimatedInstanceWarmup(existing))
+}
+
+func sdkToScheduledAction(action *autoscaling.ScheduledUpdateGroupAction) expinfrav1.AWSScheduledAction {
+	result := expinfrav1.AWSScheduledAction{
+		Name:       aws.StringValue(action.ScheduledActionName),
+		Recurrence: action.Recurrence,
+		TimeZone:   action.TimeZone,
+	}
+	if action.StartTime != nil {
+		result.StartTime = &metav1.Time{Time: *action.StartTime}
+	}
+	if action.EndTime != nil {
+		result.EndTime = &metav1.Time{Time: *action.EndTime}
+	}
+	if action.MinSize != nil {
+		result.MinSize = aws.Int32(int32(*action.MinSize))
+	}
+	if action.MaxSize != nil {
+		result.MaxSize = aws.Int32(int32(*action.MaxSize))
+	}
+	if action.DesiredCapacity != nil {
+		result.DesiredCapacity = aws.Int32(int32(*action.DesiredCapacity))
+	}
+	return result
+}
+
+func getPutScheduledUpdateGroupActionInput(asgName string, action *expinfrav1.AWSScheduledAction) *autoscaling.PutScheduledUpdateGroupActionInput {
+	input := &autoscaling.PutScheduledUpdateGroupActionInput{
+		AutoScalingGroupName: aws.String(asgName),
+		ScheduledActionName:  aws.String(action.Name),
+		Recurrence:           action.Recurrence,
+		TimeZone:             action.TimeZone,
+	}
+	if action.StartTime != nil {
+		input.StartTime = aws.Time(action.StartTime.Time)
+	}
+	if action.EndTime != nil {
+		input.EndTime = aws.Time(action.EndTime.Time)
+	}
+	if action.MinSize != nil {
+		input.MinSize = aws.Int64(int64(*action.MinSize))
+	}
+	if action.MaxSize != nil {
+		input.MaxSize = aws.Int64(int64(*action.MaxSize))
+	}
+	if action.DesiredCapacity != nil {
+		input.DesiredCapacity = aws.Int64(int64(*action.DesiredCapacity))
+	}
+	return input
+}
+
+// scheduledActionEqual compares a scheduled action of an AWSMachinePool to the one of an ASG. EC2 Auto Scaling
+// reports the next run of a recurring scheduled action as its start time, so the start time is only compared
+// for scheduled actions that run once.
+func scheduledActionEqual(desired, existing *expinfrav1.AWSScheduledAction) bool {
+	timeEqual := func(a, b *metav1.Time) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return a.Time.Equal(b.Time)
+	}
+
+	return aws.StringValue(desired.Recurrence) == aws.StringValue(existing.Recurrence) &&
+		(desired.Recurrence != nil || timeEqual(desired.StartTime, existing.StartTime)) &&
+		timeEqual(desired.EndTime, existing.EndTime) &&
+		aws.StringValue(desired.TimeZone) == aws.StringValue(existing.TimeZone) &&
+		cmp.Equal(desired.MinSize, existing.MinSize) &&
+		cmp.Equal(desired.MaxSize, existing.MaxSize) &&
+		cmp.Equal(desired.DesiredCapacity, existing.DesiredCapacity)
+}
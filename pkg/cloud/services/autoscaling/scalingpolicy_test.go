@@ -0,0 +1,355 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+)
+
+func TestServiceReconcileScalingPolicies(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describePolicies := func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, policies ...*autoscaling.ScalingPolicy) {
+		m.DescribePoliciesPagesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribePoliciesInput{
+			AutoScalingGroupName: aws.String("asg"),
+			PolicyTypes:          aws.StringSlice([]string{"TargetTrackingScaling"}),
+		}), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *autoscaling.DescribePoliciesInput, fn func(*autoscaling.DescribePoliciesOutput, bool) bool, _ ...request.Option) error {
+				fn(&autoscaling.DescribePoliciesOutput{ScalingPolicies: policies}, true)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name            string
+		scalingPolicies []expinfrav1.AWSScalingPolicy
+		wantErr         bool
+		expect          func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should create missing scaling policies",
+			scalingPolicies: []expinfrav1.AWSScalingPolicy{
+				{
+					Name:                    "cpu",
+					TargetValue:             resource.MustParse("50"),
+					PredefinedMetric:        &expinfrav1.PredefinedMetric{Type: expinfrav1.PredefinedMetricTypeASGAverageCPUUtilization},
+					EstimatedInstanceWarmup: &metav1.Duration{Duration: 3 * time.Minute},
+				},
+				{
+					Name:        "queue-depth",
+					TargetValue: resource.MustParse("0.5"),
+					CustomizedMetric: &expinfrav1.CustomizedMetric{
+						MetricName: "QueueDepthPerInstance",
+						Namespace:  "Workers",
+						Dimensions: []expinfrav1.MetricDimension{{Name: "Queue", Value: "jobs"}},
+						Statistic:  expinfrav1.MetricStatisticAverage,
+					},
+					DisableScaleIn: true,
+				},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describePolicies(m)
+				m.PutScalingPolicyWithContext(context.TODO(), gomock.Eq(&autoscaling.PutScalingPolicyInput{
+					AutoScalingGroupName: aws.String("asg"),
+					PolicyName:           aws.String("cpu"),
+					PolicyType:           aws.String("TargetTrackingScaling"),
+					TargetTrackingConfiguration: &autoscaling.TargetTrackingConfiguration{
+						TargetValue:    aws.Float64(50),
+						DisableScaleIn: aws.Bool(false),
+						PredefinedMetricSpecification: &autoscaling.PredefinedMetricSpecification{
+							PredefinedMetricType: aws.String("ASGAverageCPUUtilization"),
+						},
+					},
+					EstimatedInstanceWarmup: aws.Int64(180),
+				})).Return(&autoscaling.PutScalingPolicyOutput{}, nil)
+				m.PutScalingPolicyWithContext(context.TODO(), gomock.Eq(&autoscaling.PutScalingPolicyInput{
+					AutoScalingGroupName: aws.String("asg"),
+					PolicyName:           aws.String("queue-depth"),
+					PolicyType:           aws.String("TargetTrackingScaling"),
+					TargetTrackingConfiguration: &autoscaling.TargetTrackingConfiguration{
+						TargetValue:    aws.Float64(0.5),
+						DisableScaleIn: aws.Bool(true),
+						CustomizedMetricSpecification: &autoscaling.CustomizedMetricSpecification{
+							MetricName: aws.String("QueueDepthPerInstance"),
+							Namespace:  aws.String("Workers"),
+							Dimensions: []*autoscaling.MetricDimension{{Name: aws.String("Queue"), Value: aws.String("jobs")}},
+							Statistic:  aws.String("Average"),
+						},
+					},
+				})).Return(&autoscaling.PutScalingPolicyOutput{}, nil)
+			},
+		},
+		{
+			name: "should not update scaling policies matching the AWSMachinePool",
+			scalingPolicies: []expinfrav1.AWSScalingPolicy{
+				{
+					Name:             "cpu",
+					TargetValue:      resource.MustParse("50"),
+					PredefinedMetric: &expinfrav1.PredefinedMetric{Type: expinfrav1.PredefinedMetricTypeASGAverageCPUUtilization},
+				},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describePolicies(m, &autoscaling.ScalingPolicy{
+					AutoScalingGroupName: aws.String("asg"),
+					PolicyName:           aws.String("cpu"),
+					PolicyType:           aws.String("TargetTrackingScaling"),
+					Enabled:              aws.Bool(true),
+					TargetTrackingConfiguration: &autoscaling.TargetTrackingConfiguration{
+						TargetValue:    aws.Float64(50),
+						DisableScaleIn: aws.Bool(false),
+						PredefinedMetricSpecification: &autoscaling.PredefinedMetricSpecification{
+							PredefinedMetricType: aws.String("ASGAverageCPUUtilization"),
+						},
+					},
+				})
+			},
+		},
+		{
+			name: "should update a scaling policy with a different target value and delete undeclared ones",
+			scalingPolicies: []expinfrav1.AWSScalingPolicy{
+				{
+					Name:             "cpu",
+					TargetValue:      resource.MustParse("70"),
+					PredefinedMetric: &expinfrav1.PredefinedMetric{Type: expinfrav1.PredefinedMetricTypeASGAverageCPUUtilization},
+				},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describePolicies(m,
+					&autoscaling.ScalingPolicy{
+						PolicyName: aws.String("cpu"),
+						TargetTrackingConfiguration: &autoscaling.TargetTrackingConfiguration{
+							TargetValue: aws.Float64(50),
+							PredefinedMetricSpecification: &autoscaling.PredefinedMetricSpecification{
+								PredefinedMetricType: aws.String("ASGAverageCPUUtilization"),
+							},
+						},
+					},
+					&autoscaling.ScalingPolicy{
+						PolicyName: aws.String("network-in"),
+						TargetTrackingConfiguration: &autoscaling.TargetTrackingConfiguration{
+							TargetValue: aws.Float64(1000000),
+							PredefinedMetricSpecification: &autoscaling.PredefinedMetricSpecification{
+								PredefinedMetricType: aws.String("ASGAverageNetworkIn"),
+							},
+						},
+					},
+				)
+				m.PutScalingPolicyWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.PutScalingPolicyInput{})).
+					DoAndReturn(func(_ context.Context, input *autoscaling.PutScalingPolicyInput, _ ...request.Option) (*autoscaling.PutScalingPolicyOutput, error) {
+						if aws.StringValue(input.PolicyName) != "cpu" || aws.Float64Value(input.TargetTrackingConfiguration.TargetValue) != 70 {
+							return nil, awserrors.NewFailedDependency("unexpected scaling policy")
+						}
+						return &autoscaling.PutScalingPolicyOutput{}, nil
+					})
+				m.DeletePolicyWithContext(context.TODO(), gomock.Eq(&autoscaling.DeletePolicyInput{
+					AutoScalingGroupName: aws.String("asg"),
+					PolicyName:           aws.String("network-in"),
+				})).Return(&autoscaling.DeletePolicyOutput{}, nil)
+			},
+		},
+		{
+			name: "should return an error if the scaling policies cannot be described",
+			scalingPolicies: []expinfrav1.AWSScalingPolicy{
+				{
+					Name:             "cpu",
+					TargetValue:      resource.MustParse("50"),
+					PredefinedMetric: &expinfrav1.PredefinedMetric{Type: expinfrav1.PredefinedMetricTypeASGAverageCPUUtilization},
+				},
+			},
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribePoliciesPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.DescribePoliciesInput{}), gomock.Any()).
+					Return(awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = "asg"
+			mps.AWSMachinePool.Spec.ScalingPolicies = tt.scalingPolicies
+
+			err = s.ReconcileScalingPolicies(mps)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceReconcileScheduledActions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeScheduledActions := func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder, actions ...*autoscaling.ScheduledUpdateGroupAction) {
+		m.DescribeScheduledActionsPagesWithContext(context.TODO(), gomock.Eq(&autoscaling.DescribeScheduledActionsInput{
+			AutoScalingGroupName: aws.String("asg"),
+		}), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *autoscaling.DescribeScheduledActionsInput, fn func(*autoscaling.DescribeScheduledActionsOutput, bool) bool, _ ...request.Option) error {
+				fn(&autoscaling.DescribeScheduledActionsOutput{ScheduledUpdateGroupActions: actions}, true)
+				return nil
+			})
+	}
+	nextRun := time.Date(2023, time.October, 2, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		scheduledActions []expinfrav1.AWSScheduledAction
+		wantErr          bool
+		expect           func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name: "should create missing scheduled actions",
+			scheduledActions: []expinfrav1.AWSScheduledAction{
+				{
+					Name:       "business-hours-start",
+					Recurrence: aws.String("0 8 * * 1-5"),
+					TimeZone:   aws.String("Europe/Paris"),
+					MinSize:    aws.Int32(5),
+				},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describeScheduledActions(m)
+				m.PutScheduledUpdateGroupActionWithContext(context.TODO(), gomock.Eq(&autoscaling.PutScheduledUpdateGroupActionInput{
+					AutoScalingGroupName: aws.String("asg"),
+					ScheduledActionName:  aws.String("business-hours-start"),
+					Recurrence:           aws.String("0 8 * * 1-5"),
+					TimeZone:             aws.String("Europe/Paris"),
+					MinSize:              aws.Int64(5),
+				})).Return(&autoscaling.PutScheduledUpdateGroupActionOutput{}, nil)
+			},
+		},
+		{
+			name: "should not update recurring scheduled actions matching the AWSMachinePool",
+			scheduledActions: []expinfrav1.AWSScheduledAction{
+				{
+					Name:       "business-hours-start",
+					Recurrence: aws.String("0 8 * * 1-5"),
+					MinSize:    aws.Int32(5),
+				},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describeScheduledActions(m, &autoscaling.ScheduledUpdateGroupAction{
+					AutoScalingGroupName: aws.String("asg"),
+					ScheduledActionName:  aws.String("business-hours-start"),
+					Recurrence:           aws.String("0 8 * * 1-5"),
+					StartTime:            aws.Time(nextRun),
+					MinSize:              aws.Int64(5),
+				})
+			},
+		},
+		{
+			name: "should update a scheduled action with a different size and delete undeclared ones",
+			scheduledActions: []expinfrav1.AWSScheduledAction{
+				{
+					Name:       "business-hours-end",
+					Recurrence: aws.String("0 18 * * 1-5"),
+					MinSize:    aws.Int32(1),
+				},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describeScheduledActions(m,
+					&autoscaling.ScheduledUpdateGroupAction{
+						ScheduledActionName: aws.String("business-hours-end"),
+						Recurrence:          aws.String("0 18 * * 1-5"),
+						StartTime:           aws.Time(nextRun),
+						MinSize:             aws.Int64(2),
+					},
+					&autoscaling.ScheduledUpdateGroupAction{
+						ScheduledActionName: aws.String("business-hours-start"),
+						Recurrence:          aws.String("0 8 * * 1-5"),
+						StartTime:           aws.Time(nextRun),
+						MinSize:             aws.Int64(5),
+					},
+				)
+				m.PutScheduledUpdateGroupActionWithContext(context.TODO(), gomock.Eq(&autoscaling.PutScheduledUpdateGroupActionInput{
+					AutoScalingGroupName: aws.String("asg"),
+					ScheduledActionName:  aws.String("business-hours-end"),
+					Recurrence:           aws.String("0 18 * * 1-5"),
+					MinSize:              aws.Int64(1),
+				})).Return(&autoscaling.PutScheduledUpdateGroupActionOutput{}, nil)
+				m.DeleteScheduledActionWithContext(context.TODO(), gomock.Eq(&autoscaling.DeleteScheduledActionInput{
+					AutoScalingGroupName: aws.String("asg"),
+					ScheduledActionName:  aws.String("business-hours-start"),
+				})).Return(&autoscaling.DeleteScheduledActionOutput{}, nil)
+			},
+		},
+		{
+			name: "should return an error if a scheduled action cannot be updated",
+			scheduledActions: []expinfrav1.AWSScheduledAction{
+				{
+					Name:            "scale-up",
+					StartTime:       &metav1.Time{Time: nextRun},
+					DesiredCapacity: aws.Int32(5),
+				},
+			},
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				describeScheduledActions(m)
+				m.PutScheduledUpdateGroupActionWithContext(context.TODO(), gomock.AssignableToTypeOf(&autoscaling.PutScheduledUpdateGroupActionInput{})).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Name = "asg"
+			mps.AWSMachinePool.Spec.ScheduledActions = tt.scheduledActions
+
+			err = s.ReconcileScheduledActions(mps)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
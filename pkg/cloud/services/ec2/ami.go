@@ -66,6 +66,10 @@ const (
 	// when looking up machine AMIs.
 	defaultMachineAMILookupBaseOS = "ubuntu-18.04"
 
+	// defaultWindowsAMILookupBaseOS is the default base operating system to use
+	// when looking up machine AMIs for Windows instances.
+	defaultWindowsAMILookupBaseOS = "windows-2019"
+
 	// DefaultAmiNameFormat is defined in the build/ directory of this project.
 	// The pattern is:
 	// 1. the string value `capa-ami-`
@@ -85,6 +89,9 @@ const (
 
 	// EKS GPU AMI ID SSM Parameter name.
 	eksGPUAmiSSMParameterFormat = "/aws/service/eks/optimized-ami/%s/amazon-linux-2-gpu/recommended/image_id"
+
+	// EKS Windows AMI ID SSM Parameter name.
+	eksWindowsAmiSSMParameterFormat = "/aws/service/ami-windows-latest/Windows_Server-2019-English-Core-EKS_Optimized-%s/image_id"
 )
 
 // AMILookup contains the parameters used to template AMI names used for lookup.
@@ -112,6 +119,65 @@ func GenerateAmiName(amiNameFormat, baseOS, kubernetesVersion string) (string, e
 	return templateBytes.String(), nil
 }
 
+// SSMParameterLookup contains the parameters used to template the names of SSM parameters holding AMI IDs.
+type SSMParameterLookup struct {
+	// Arch is the architecture in the Go naming scheme, for example amd64.
+	Arch string
+	// Architecture is the architecture in the AWS naming scheme, for example x86_64.
+	Architecture string
+
+	k8sVersion      string
+	k8sMinorVersion string
+}
+
+// K8sVersion returns the Kubernetes version without the v prefix, for example 1.27.3.
+// It returns an error when there is no Kubernetes version, rather than templating an empty string.
+func (p SSMParameterLookup) K8sVersion() (string, error) {
+	if p.k8sVersion == "" {
+		return "", errors.New("no kubernetes version to substitute K8sVersion with")
+	}
+	return p.k8sVersion, nil
+}
+
+// K8sMinorVersion returns the major and minor Kubernetes version, for example 1.27.
+// It returns an error when there is no Kubernetes version, rather than templating an empty string.
+func (p SSMParameterLookup) K8sMinorVersion() (string, error) {
+	if p.k8sMinorVersion == "" {
+		return "", errors.New("no kubernetes version to substitute K8sMinorVersion with")
+	}
+	return p.k8sMinorVersion, nil
+}
+
+// GenerateSSMParameterName will generate the name of an SSM parameter holding an AMI ID.
+// The Kubernetes version may be empty, as long as the format does not reference it.
+func GenerateSSMParameterName(ssmParameterFormat, kubernetesVersion, architecture string) (string, error) {
+	params := SSMParameterLookup{
+		Arch:         architecture,
+		Architecture: architecture,
+	}
+	if architecture == Amd64ArchitectureTag {
+		params.Arch = "amd64"
+	}
+	if kubernetesVersion != "" {
+		parsed, err := semver.ParseTolerant(kubernetesVersion)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse kubernetes version: %q", kubernetesVersion)
+		}
+		params.k8sVersion = strings.TrimPrefix(kubernetesVersion, "v")
+		params.k8sMinorVersion = fmt.Sprintf("%d.%d", parsed.Major, parsed.Minor)
+	}
+
+	var templateBytes bytes.Buffer
+	template, err := template.New("ssmParameter").Parse(ssmParameterFormat)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed create template from string: %q", ssmParameterFormat)
+	}
+	if err := template.Execute(&templateBytes, params); err != nil {
+		return "", errors.Wrapf(err, "failed to substitute string: %q", ssmParameterFormat)
+	}
+	return templateBytes.String(), nil
+}
+
 // Determine architecture based on instance type.
 func (s *Service) pickArchitectureForInstanceType(instanceType string) (string, error) {
 	descInstanceTypeInput := &ec2.DescribeInstanceTypesInput{
@@ -162,6 +228,11 @@ archCheck:
 
 // DefaultAMILookup will do a default AMI lookup.
 func DefaultAMILookup(ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat string) (*ec2.Image, error) {
+	return amiLookup(ec2Client, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat)
+}
+
+// amiLookup looks up the latest AMI matching the name format, restricted further by the additional filters.
+func amiLookup(ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat string, additionalFilters ...*ec2.Filter) (*ec2.Image, error) {
 	if amiNameFormat == "" {
 		amiNameFormat = DefaultAmiNameFormat
 	}
@@ -200,6 +271,7 @@ func DefaultAMILookup(ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVers
 			},
 		},
 	}
+	describeImageInput.Filters = append(describeImageInput.Filters, additionalFilters...)
 
 	out, err := ec2Client.DescribeImagesWithContext(context.TODO(), describeImageInput)
 	if err != nil {
@@ -228,6 +300,25 @@ func (s *Service) defaultAMIIDLookup(amiNameFormat, ownerID, baseOS, architectur
 	return aws.StringValue(latestImage.ImageId), nil
 }
 
+// windowsAMIIDLookup returns the default Windows AMI based on region.
+func (s *Service) windowsAMIIDLookup(amiNameFormat, ownerID, baseOS, architecture, kubernetesVersion string) (string, error) {
+	if baseOS == "" {
+		baseOS = defaultWindowsAMILookupBaseOS
+	}
+
+	latestImage, err := amiLookup(s.EC2Client, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat, &ec2.Filter{
+		Name:   aws.String("platform"),
+		Values: []*string{aws.String("windows")},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeImages", "Failed to find windows ami for OS=%s, Architecture=%s and Kubernetes-version=%s: %v", baseOS, architecture, kubernetesVersion, err)
+		return "", errors.Wrapf(err, "failed to find windows ami")
+	}
+
+	s.scope.Debug("Found and using an existing Windows AMI", "ami-id", aws.StringValue(latestImage.ImageId))
+	return aws.StringValue(latestImage.ImageId), nil
+}
+
 type images []*ec2.Image
 
 // Len is the number of elements in the collection.
@@ -336,6 +427,49 @@ func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, am
 		}
 	}
 
+	id, err := s.getAMIFromSSMParameter(paramName)
+	if err != nil {
+		return "", err
+	}
+	s.scope.Info("found AMI", "id", id, "version", formattedVersion)
+
+	return id, nil
+}
+
+// eksWindowsAMILookup returns the EKS optimized Windows AMI for the Kubernetes version.
+func (s *Service) eksWindowsAMILookup(kubernetesVersion string) (string, error) {
+	formattedVersion, err := formatVersionForEKS(kubernetesVersion)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := s.getAMIFromSSMParameter(fmt.Sprintf(eksWindowsAmiSSMParameterFormat, formattedVersion))
+	if err != nil {
+		return "", err
+	}
+	s.scope.Info("found Windows AMI", "id", id, "version", formattedVersion)
+
+	return id, nil
+}
+
+// ssmParameterAMILookup returns the AMI ID held by the SSM parameter whose name is templated from
+// ssmParameterFormat with the given Kubernetes version and architecture.
+func (s *Service) ssmParameterAMILookup(ssmParameterFormat, kubernetesVersion, architecture string) (string, error) {
+	paramName, err := GenerateSSMParameterName(ssmParameterFormat, kubernetesVersion, architecture)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := s.getAMIFromSSMParameter(paramName)
+	if err != nil {
+		return "", err
+	}
+	s.scope.Info("found AMI", "id", id, "ssm-parameter", paramName)
+
+	return id, nil
+}
+
+func (s *Service) getAMIFromSSMParameter(paramName string) (string, error) {
 	input := &ssm.GetParameterInput{
 		Name: aws.String(paramName),
 	}
@@ -351,10 +485,7 @@ func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, am
 		return "", errors.Errorf("SSM parameter returned with nil value: %q", paramName)
 	}
 
-	id := aws.StringValue(out.Parameter.Value)
-	s.scope.Info("found AMI", "id", id, "version", formattedVersion)
-
-	return id, nil
+	return aws.StringValue(out.Parameter.Value), nil
 }
 
 func formatVersionForEKS(version string) (string, error) {
@@ -365,3 +496,99 @@ func formatVersionForEKS(version string) (string, error) {
 
 	return fmt.Sprintf("%d.%d", parsed.Major, parsed.Minor), nil
 }
+
+// validateImage checks, before launching instances from it, that the image is available, not deprecated and
+// compatible with the architecture and instance type of the instances and with their root volume.
+// An *ImageValidationError is returned if it is not, so that the failure can be reported with an actionable reason.
+func (s *Service) validateImage(imageID, instanceType, architecture string, rootVolume *infrav1.Volume) error {
+	out, err := s.EC2Client.DescribeImagesWithContext(context.TODO(), &ec2.DescribeImagesInput{
+		ImageIds: []*string{aws.String(imageID)},
+	})
+	if err != nil {
+		if code, _ := awserrors.Code(err); code == awserrors.ImageNotFound || code == awserrors.ImageMalformed {
+			return newImageValidationError(infrav1.ImageNotFoundReason, "image %q does not exist, has been deregistered or is not shared with the account: %v", imageID, err)
+		}
+		return errors.Wrapf(err, "failed to describe image %q", imageID)
+	}
+	if len(out.Images) == 0 {
+		return newImageValidationError(infrav1.ImageNotFoundReason, "image %q does not exist, has been deregistered or is not shared with the account", imageID)
+	}
+	image := out.Images[0]
+
+	if state := aws.StringValue(image.State); state != ec2.ImageStateAvailable {
+		return newImageValidationError(infrav1.ImageNotAvailableReason, "image %q is in state %q, it must be %q to launch instances", imageID, state, ec2.ImageStateAvailable)
+	}
+
+	if image.DeprecationTime != nil {
+		deprecationTime, err := time.Parse(time.RFC3339, *image.DeprecationTime)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse deprecation time of image %q", imageID)
+		}
+		if !deprecationTime.After(time.Now()) {
+			return newImageValidationError(infrav1.ImageDeprecatedReason, "image %q was deprecated on %s, a newer image must be used", imageID, *image.DeprecationTime)
+		}
+	}
+
+	if imageArchitecture := aws.StringValue(image.Architecture); imageArchitecture != "" && imageArchitecture != architecture {
+		return newImageValidationError(infrav1.ImageIncompatibleReason, "image %q has the %q architecture, but instance type %q requires %q", imageID, imageArchitecture, instanceType, architecture)
+	}
+
+	if rootVolume != nil {
+		if rootDeviceType := aws.StringValue(image.RootDeviceType); rootDeviceType != "" && rootDeviceType != ec2.DeviceTypeEbs {
+			return newImageValidationError(infrav1.ImageIncompatibleReason, "image %q has an %s root device, a root volume can only be configured for EBS-backed images", imageID, rootDeviceType)
+		}
+		for _, mapping := range image.BlockDeviceMappings {
+			if aws.StringValue(mapping.DeviceName) != aws.StringValue(image.RootDeviceName) || mapping.Ebs == nil || mapping.Ebs.VolumeSize == nil {
+				continue
+			}
+			if rootVolume.Size < *mapping.Ebs.VolumeSize {
+				return newImageValidationError(infrav1.ImageIncompatibleReason, "root volume size (%d) must be greater than or equal to the snapshot size (%d) of image %q", rootVolume.Size, *mapping.Ebs.VolumeSize, imageID)
+			}
+		}
+	}
+
+	if instanceType == "" {
+		return nil
+	}
+	return s.validateImageForInstanceType(image, instanceType)
+}
+
+// validateImageForInstanceType checks that the boot mode and ENA support of the image are supported by the instance type.
+func (s *Service) validateImageForInstanceType(image *ec2.Image, instanceType string) error {
+	out, err := s.EC2Client.DescribeInstanceTypesWithContext(context.TODO(), &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []*string{aws.String(instanceType)},
+	})
+	if err != nil {
+		// The other checks are still worth doing if the controller is not allowed to describe instance types.
+		if awserrors.IsPermissionsError(err) {
+			s.scope.Debug("Insufficient permissions to describe instance types, skipping instance type checks of the image", "instance-type", instanceType)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to describe instance type %q", instanceType)
+	}
+	if len(out.InstanceTypes) == 0 {
+		return nil
+	}
+	info := out.InstanceTypes[0]
+
+	bootMode := aws.StringValue(image.BootMode)
+	if bootMode != "" && bootMode != ec2.BootModeValuesUefiPreferred && len(info.SupportedBootModes) > 0 {
+		supported := false
+		for _, mode := range info.SupportedBootModes {
+			if aws.StringValue(mode) == bootMode {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return newImageValidationError(infrav1.ImageIncompatibleReason, "image %q requires the %q boot mode, which instance type %q does not support (supported: %s)",
+				aws.StringValue(image.ImageId), bootMode, instanceType, strings.Join(aws.StringValueSlice(info.SupportedBootModes), ", "))
+		}
+	}
+
+	if info.NetworkInfo != nil && aws.StringValue(info.NetworkInfo.EnaSupport) == ec2.EnaSupportRequired && !aws.BoolValue(image.EnaSupport) {
+		return newImageValidationError(infrav1.ImageIncompatibleReason, "instance type %q requires ENA, which image %q does not support", instanceType, aws.StringValue(image.ImageId))
+	}
+
+	return nil
+}
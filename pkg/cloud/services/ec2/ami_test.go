@@ -18,9 +18,13 @@ package ec2
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/golang/mock/gomock"
@@ -208,6 +212,46 @@ func TestDefaultAMILookupArm64(t *testing.T) {
 		})
 	}
 }
+func TestWindowsAMIIDLookup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	clusterScope, err := setupClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().DescribeImagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+		DoAndReturn(func(_ context.Context, input *ec2.DescribeImagesInput, _ ...request.Option) (*ec2.DescribeImagesOutput, error) {
+			filters := map[string]string{}
+			for _, f := range input.Filters {
+				filters[aws.StringValue(f.Name)] = aws.StringValue(f.Values[0])
+			}
+			g.Expect(filters).To(HaveKeyWithValue("name", "capa-ami-windows-2019-?1.27.3-*"))
+			g.Expect(filters).To(HaveKeyWithValue("platform", "windows"))
+			return &ec2.DescribeImagesOutput{
+				Images: []*ec2.Image{
+					{
+						ImageId:      aws.String("ami-windows"),
+						CreationDate: aws.String("2023-02-08T17:02:31.000Z"),
+					},
+				},
+			}, nil
+		})
+
+	s := NewService(clusterScope)
+	s.EC2Client = ec2Mock
+
+	id, err := s.windowsAMIIDLookup("", "", "", "x86_64", "v1.27.3")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(id).To(Equal("ami-windows"))
+}
+
 func TestAMIs(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -395,6 +439,74 @@ func TestGenerateAMIName(t *testing.T) {
 	}
 }
 
+func TestGenerateSSMParameterName(t *testing.T) {
+	tests := []struct {
+		name               string
+		ssmParameterFormat string
+		kubernetesVersion  string
+		architecture       string
+		want               string
+		wantErr            bool
+	}{
+		{
+			name:               "Should return the parameter name as is if it is not templated",
+			ssmParameterFormat: "/my/ami",
+			architecture:       Amd64ArchitectureTag,
+			want:               "/my/ami",
+		},
+		{
+			name:               "Should substitute the kubernetes version and amd64 architecture",
+			ssmParameterFormat: "/amis/{{.K8sVersion}}/{{.K8sMinorVersion}}/{{.Arch}}/{{.Architecture}}",
+			kubernetesVersion:  "v1.27.3",
+			architecture:       Amd64ArchitectureTag,
+			want:               "/amis/1.27.3/1.27/amd64/x86_64",
+		},
+		{
+			name:               "Should substitute the arm64 architecture",
+			ssmParameterFormat: "/amis/{{.Arch}}/{{.Architecture}}",
+			architecture:       Arm64ArchitectureTag,
+			want:               "/amis/arm64/arm64",
+		},
+		{
+			name:               "Should return an error on unknown template fields",
+			ssmParameterFormat: "/amis/{{.BaseOS}}",
+			architecture:       Amd64ArchitectureTag,
+			wantErr:            true,
+		},
+		{
+			name:               "Should return an error when the kubernetes version is referenced but not set",
+			ssmParameterFormat: "/amis/{{.K8sVersion}}/{{.Arch}}",
+			architecture:       Amd64ArchitectureTag,
+			wantErr:            true,
+		},
+		{
+			name:               "Should return an error when the kubernetes minor version is referenced but not set",
+			ssmParameterFormat: "/amis/{{.K8sMinorVersion}}/{{.Arch}}",
+			architecture:       Amd64ArchitectureTag,
+			wantErr:            true,
+		},
+		{
+			name:               "Should return an error on invalid kubernetes versions",
+			ssmParameterFormat: "/amis/{{.K8sVersion}}",
+			kubernetesVersion:  "latest",
+			architecture:       Amd64ArchitectureTag,
+			wantErr:            true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := GenerateSSMParameterName(tt.ssmParameterFormat, tt.kubernetesVersion, tt.architecture)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).Should(Equal(tt.want))
+		})
+	}
+}
+
 func TestGetLatestImage(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -579,3 +691,218 @@ func TestEKSAMILookUp(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateImage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeImage := func(image *ec2.Image) func(m *mocks.MockEC2APIMockRecorder) {
+		return func(m *mocks.MockEC2APIMockRecorder) {
+			m.DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
+				ImageIds: []*string{aws.String("ami-1")},
+			})).Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{image}}, nil)
+		}
+	}
+	describeInstanceType := func(info *ec2.InstanceTypeInfo) func(m *mocks.MockEC2APIMockRecorder) {
+		return func(m *mocks.MockEC2APIMockRecorder) {
+			m.DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+				InstanceTypes: []*string{aws.String("m5.large")},
+			})).Return(&ec2.DescribeInstanceTypesOutput{InstanceTypes: []*ec2.InstanceTypeInfo{info}}, nil)
+		}
+	}
+	availableImage := func() *ec2.Image {
+		return &ec2.Image{
+			ImageId:        aws.String("ami-1"),
+			State:          aws.String(ec2.ImageStateAvailable),
+			Architecture:   aws.String(Amd64ArchitectureTag),
+			RootDeviceType: aws.String(ec2.DeviceTypeEbs),
+			RootDeviceName: aws.String("/dev/sda1"),
+			EnaSupport:     aws.Bool(true),
+			BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+				{
+					DeviceName: aws.String("/dev/sda1"),
+					Ebs:        &ec2.EbsBlockDevice{VolumeSize: aws.Int64(20)},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		instanceType string
+		rootVolume   *infrav1.Volume
+		expect       []func(m *mocks.MockEC2APIMockRecorder)
+		wantReason   string
+		wantErr      bool
+	}{
+		{
+			name:         "Should pass for an available image compatible with the instance type and root volume",
+			instanceType: "m5.large",
+			rootVolume:   &infrav1.Volume{Size: 20},
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeImage(availableImage()),
+				describeInstanceType(&ec2.InstanceTypeInfo{
+					SupportedBootModes: aws.StringSlice([]string{ec2.BootModeValuesLegacyBios, ec2.BootModeValuesUefi}),
+					NetworkInfo:        &ec2.NetworkInfo{EnaSupport: aws.String(ec2.EnaSupportRequired)},
+				}),
+			},
+		},
+		{
+			name: "Should fail if the image does not exist or is not shared with the account",
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				func(m *mocks.MockEC2APIMockRecorder) {
+					m.DescribeImagesWithContext(context.TODO(), gomock.Any()).
+						Return(nil, awserr.New(awserrors.ImageNotFound, "The image id '[ami-1]' does not exist", nil))
+				},
+			},
+			wantReason: infrav1.ImageNotFoundReason,
+		},
+		{
+			name: "Should fail if the image has been deregistered",
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				func(m *mocks.MockEC2APIMockRecorder) {
+					m.DescribeImagesWithContext(context.TODO(), gomock.Any()).Return(&ec2.DescribeImagesOutput{}, nil)
+				},
+			},
+			wantReason: infrav1.ImageNotFoundReason,
+		},
+		{
+			name: "Should fail if the image is not available",
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeImage(func() *ec2.Image {
+					image := availableImage()
+					image.State = aws.String(ec2.ImageStatePending)
+					return image
+				}()),
+			},
+			wantReason: infrav1.ImageNotAvailableReason,
+		},
+		{
+			name: "Should fail if the image is deprecated",
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeImage(func() *ec2.Image {
+					image := availableImage()
+					image.DeprecationTime = aws.String(time.Now().Add(-time.Hour).UTC().Format(createDateTimestampFormat))
+					return image
+				}()),
+			},
+			wantReason: infrav1.ImageDeprecatedReason,
+		},
+		{
+			name:         "Should pass if the image will only be deprecated in the future",
+			instanceType: "m5.large",
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeImage(func() *ec2.Image {
+					image := availableImage()
+					image.DeprecationTime = aws.String(time.Now().Add(time.Hour).UTC().Format(createDateTimestampFormat))
+					return image
+				}()),
+				describeInstanceType(&ec2.InstanceTypeInfo{}),
+			},
+		},
+		{
+			name: "Should fail if the image architecture does not match the instance type",
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeImage(func() *ec2.Image {
+					image := availableImage()
+					image.Architecture = aws.String(Arm64ArchitectureTag)
+					return image
+				}()),
+			},
+			wantReason: infrav1.ImageIncompatibleReason,
+		},
+		{
+			name:       "Should fail if the root volume is smaller than the image snapshot",
+			rootVolume: &infrav1.Volume{Size: 10},
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeImage(availableImage()),
+			},
+			wantReason: infrav1.ImageIncompatibleReason,
+		},
+		{
+			name:       "Should fail if a root volume is set for an instance store backed image",
+			rootVolume: &infrav1.Volume{Size: 20},
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeImage(func() *ec2.Image {
+					image := availableImage()
+					image.RootDeviceType = aws.String(ec2.DeviceTypeInstanceStore)
+					return image
+				}()),
+			},
+			wantReason: infrav1.ImageIncompatibleReason,
+		},
+		{
+			name:         "Should fail if the instance type does not support the boot mode of the image",
+			instanceType: "m5.large",
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeImage(func() *ec2.Image {
+					image := availableImage()
+					image.BootMode = aws.String(ec2.BootModeValuesUefi)
+					return image
+				}()),
+				describeInstanceType(&ec2.InstanceTypeInfo{
+					SupportedBootModes: aws.StringSlice([]string{ec2.BootModeValuesLegacyBios}),
+				}),
+			},
+			wantReason: infrav1.ImageIncompatibleReason,
+		},
+		{
+			name:         "Should fail if the instance type requires ENA and the image does not support it",
+			instanceType: "m5.large",
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeImage(func() *ec2.Image {
+					image := availableImage()
+					image.EnaSupport = aws.Bool(false)
+					return image
+				}()),
+				describeInstanceType(&ec2.InstanceTypeInfo{
+					NetworkInfo: &ec2.NetworkInfo{EnaSupport: aws.String(ec2.EnaSupportRequired)},
+				}),
+			},
+			wantReason: infrav1.ImageIncompatibleReason,
+		},
+		{
+			name: "Should return a plain error if the image can't be described",
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				func(m *mocks.MockEC2APIMockRecorder) {
+					m.DescribeImagesWithContext(context.TODO(), gomock.Any()).
+						Return(nil, awserrors.NewFailedDependency("dependency failure"))
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			for _, expect := range tt.expect {
+				expect(ec2Mock.EXPECT())
+			}
+
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			err = s.validateImage("ami-1", tt.instanceType, Amd64ArchitectureTag, tt.rootVolume)
+			switch {
+			case tt.wantReason != "":
+				var imageErr *ImageValidationError
+				g.Expect(errors.As(err, &imageErr)).To(BeTrue())
+				g.Expect(imageErr.Reason).To(Equal(tt.wantReason))
+			case tt.wantErr:
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(ImageValidationReason(err, infrav1.InstanceProvisionFailedReason)).To(Equal(infrav1.InstanceProvisionFailedReason))
+			default:
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
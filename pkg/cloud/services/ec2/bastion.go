@@ -58,6 +58,16 @@ func (s *Service) ReconcileBastion() error {
 		return s.DeleteBastion()
 	}
 
+	// The bastion host is created again once the cluster is resumed.
+	if h := s.scope.Hibernation(); h != nil && h.Hibernate && h.DeleteBastion {
+		s.scope.Debug("Deleting bastion host of hibernated cluster")
+		if err := s.DeleteBastion(); err != nil {
+			return err
+		}
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, infrav1.BastionHostHibernatedReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
 	s.scope.Debug("Reconciling bastion host")
 
 	subnets := s.scope.Subnets()
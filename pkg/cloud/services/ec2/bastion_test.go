@@ -261,6 +261,7 @@ func TestServiceReconcileBastion(t *testing.T) {
 	tests := []struct {
 		name           string
 		bastionEnabled bool
+		hibernation    *infrav1.Hibernation
 		expect         func(m *mocks.MockEC2APIMockRecorder)
 		expectError    bool
 		bastionStatus  *infrav1.Instance
@@ -377,8 +378,35 @@ func TestServiceReconcileBastion(t *testing.T) {
 				Addresses:        []clusterv1.MachineAddress{},
 				AvailabilityZone: "us-east-1",
 				VolumeIDs:        []string{"volume-1"},
+				RootDeviceName:   "device-1",
 			},
 		},
+		{
+			name: "Should delete bastion of hibernated cluster",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstancesWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(foundOutput, nil)
+				m.
+					TerminateInstancesWithContext(context.TODO(),
+						gomock.Eq(&ec2.TerminateInstancesInput{
+							InstanceIds: aws.StringSlice([]string{"id123"}),
+						}),
+					).
+					Return(nil, nil)
+				m.
+					WaitUntilInstanceTerminatedWithContext(context.TODO(),
+						gomock.Eq(&ec2.DescribeInstancesInput{
+							InstanceIds: aws.StringSlice([]string{"id123"}),
+						}),
+					).
+					Return(nil)
+			},
+			bastionEnabled: true,
+			hibernation:    &infrav1.Hibernation{Hibernate: true, DeleteBastion: true},
+			expectError:    false,
+			bastionStatus:  nil,
+		},
 	}
 
 	for _, tc := range tests {
@@ -414,7 +442,8 @@ func TestServiceReconcileBastion(t *testing.T) {
 								},
 							},
 						},
-						Bastion: infrav1.Bastion{Enabled: tc.bastionEnabled},
+						Bastion:     infrav1.Bastion{Enabled: tc.bastionEnabled},
+						Hibernation: tc.hibernation,
 					},
 				}
 
@@ -609,6 +638,7 @@ func TestServiceReconcileBastionUSGOV(t *testing.T) {
 				Addresses:        []clusterv1.MachineAddress{},
 				AvailabilityZone: "us-gov-east-1",
 				VolumeIDs:        []string{"volume-1"},
+				RootDeviceName:   "device-1",
 			},
 		},
 	}
@@ -0,0 +1,250 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// ensureDedicatedHost returns the ID of a Dedicated Host with free capacity for the machine's instance type
+// in the given availability zone. Hosts previously allocated for the machine's group are reused, a new host
+// is allocated when none of them has free capacity.
+func (s *Service) ensureDedicatedHost(scope *scope.MachineScope, instanceType, availabilityZone string) (string, error) {
+	// Reuse the host recorded in status as long as it still has room for this machine.
+	if id := scope.GetDedicatedHostID(); id != nil {
+		host, err := s.describeDedicatedHost(*id)
+		if err != nil {
+			return "", err
+		}
+		if host != nil && aws.StringValue(host.AvailabilityZone) == availabilityZone && dedicatedHostHasCapacity(host, instanceType) {
+			return *id, nil
+		}
+	}
+
+	input := &ec2.DescribeHostsInput{
+		Filter: []*ec2.Filter{
+			filter.EC2.ClusterOwned(s.scope.KubernetesClusterName()),
+			filter.EC2.DedicatedHostGroup(scope.DedicatedHostGroup()),
+			filter.EC2.AvailabilityZone(availabilityZone),
+			filter.EC2.Available(),
+		},
+	}
+
+	var candidates []*ec2.Host
+	if err := s.EC2Client.DescribeHostsPagesWithContext(context.TODO(), input, func(out *ec2.DescribeHostsOutput, lastPage bool) bool {
+		for _, host := range out.Hosts {
+			if dedicatedHostHasCapacity(host, instanceType) {
+				candidates = append(candidates, host)
+			}
+		}
+		return true
+	}); err != nil {
+		return "", errors.Wrap(err, "failed to describe dedicated hosts")
+	}
+
+	if len(candidates) > 0 {
+		// Prefer the most used host, so that the least used ones get empty and can be released.
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return len(candidates[i].Instances) > len(candidates[j].Instances)
+		})
+		s.scope.Debug("Reusing dedicated host", "host-id", aws.StringValue(candidates[0].HostId), "group", scope.DedicatedHostGroup())
+		return aws.StringValue(candidates[0].HostId), nil
+	}
+
+	return s.allocateDedicatedHost(scope, instanceType, availabilityZone)
+}
+
+func (s *Service) allocateDedicatedHost(scope *scope.MachineScope, instanceType, availabilityZone string) (string, error) {
+	s.scope.Debug("Allocating dedicated host", "instance-type", instanceType, "availability-zone", availabilityZone, "group", scope.DedicatedHostGroup())
+
+	additionalTags := infrav1.Tags{}
+	additionalTags.Merge(scope.AdditionalTags())
+	additionalTags.Merge(scope.AWSMachine.Spec.DynamicHostAllocation.Tags)
+	additionalTags[infrav1.NameAWSDedicatedHostGroup] = scope.DedicatedHostGroup()
+
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.KubernetesClusterName(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(scope.DedicatedHostGroup()),
+		Role:        aws.String(scope.Role()),
+		Additional:  additionalTags,
+	})
+
+	input := &ec2.AllocateHostsInput{
+		AvailabilityZone: aws.String(availabilityZone),
+		InstanceType:     aws.String(instanceType),
+		Quantity:         aws.Int64(1),
+		AutoPlacement:    aws.String(ec2.AutoPlacementOff),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeDedicatedHost),
+				Tags:         converters.MapToTags(tags),
+			},
+		},
+	}
+
+	out, err := s.EC2Client.AllocateHostsWithContext(context.TODO(), input)
+	if err != nil {
+		record.Warnf(scope.AWSMachine, "FailedAllocateHost", "Failed to allocate dedicated host: %v", err)
+		return "", errors.Wrap(err, "failed to allocate dedicated host")
+	}
+	if len(out.HostIds) == 0 {
+		return "", errors.New("no dedicated host returned by host allocation")
+	}
+
+	hostID := aws.StringValue(out.HostIds[0])
+	record.Eventf(scope.AWSMachine, "SuccessfulAllocateHost", "Allocated dedicated host %q", hostID)
+	return hostID, nil
+}
+
+// ReleaseDedicatedHostIfEmpty releases a Dedicated Host allocated by CAPA once no instance runs on it anymore.
+// It returns true if the host was released or does not exist anymore. While the only instances left on the host
+// are terminating, it returns terminating so that the release is retried once they are gone: when the machines
+// of a host are deleted together, each of them would otherwise leave the host to the others.
+func (s *Service) ReleaseDedicatedHostIfEmpty(hostID string) (released, terminating bool, err error) {
+	host, err := s.describeDedicatedHost(hostID)
+	if err != nil {
+		return false, false, err
+	}
+
+	if host == nil || aws.StringValue(host.State) == ec2.AllocationStateReleased {
+		return true, false, nil
+	}
+
+	// Only release hosts this cluster allocated.
+	if !infrav1.Tags(converters.TagsToMap(host.Tags)).HasOwned(s.scope.KubernetesClusterName()) {
+		s.scope.Debug("Dedicated host is not owned by the cluster, not releasing it", "host-id", hostID)
+		return true, false, nil
+	}
+
+	if len(host.Instances) > 0 {
+		terminating, err := s.dedicatedHostInstancesTerminating(host)
+		if err != nil {
+			return false, false, err
+		}
+		if terminating {
+			s.scope.Debug("Waiting for the instances of the dedicated host to terminate", "host-id", hostID, "instances", len(host.Instances))
+		} else {
+			s.scope.Debug("Dedicated host still in use, not releasing it", "host-id", hostID, "instances", len(host.Instances))
+		}
+		return false, terminating, nil
+	}
+
+	out, err := s.EC2Client.ReleaseHostsWithContext(context.TODO(), &ec2.ReleaseHostsInput{
+		HostIds: aws.StringSlice([]string{hostID}),
+	})
+	if err != nil {
+		return false, false, errors.Wrapf(err, "failed to release dedicated host %q", hostID)
+	}
+	for _, item := range out.Unsuccessful {
+		if item.Error != nil {
+			return false, false, errors.Errorf("failed to release dedicated host %q: %s", hostID, aws.StringValue(item.Error.Message))
+		}
+	}
+
+	s.scope.Debug("Released dedicated host", "host-id", hostID)
+	return true, false, nil
+}
+
+// dedicatedHostInstancesTerminating returns whether all the instances still placed on the host are shutting down
+// or terminated.
+func (s *Service) dedicatedHostInstancesTerminating(host *ec2.Host) (bool, error) {
+	instanceIDs := make([]*string, 0, len(host.Instances))
+	for _, instance := range host.Instances {
+		instanceIDs = append(instanceIDs, instance.InstanceId)
+	}
+
+	out, err := s.EC2Client.DescribeInstancesWithContext(context.TODO(), &ec2.DescribeInstancesInput{
+		InstanceIds: instanceIDs,
+	})
+	switch {
+	case awserrors.IsNotFound(err):
+		// Instances are only missing once they are gone.
+		return true, nil
+	case err != nil:
+		return false, errors.Wrapf(err, "failed to describe the instances of dedicated host %q", aws.StringValue(host.HostId))
+	}
+
+	for _, reservation := range out.Reservations {
+		for _, instance := range reservation.Instances {
+			switch aws.StringValue(instance.State.Name) {
+			case ec2.InstanceStateNameShuttingDown, ec2.InstanceStateNameTerminated:
+			default:
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func (s *Service) describeDedicatedHost(hostID string) (*ec2.Host, error) {
+	out, err := s.EC2Client.DescribeHostsWithContext(context.TODO(), &ec2.DescribeHostsInput{
+		HostIds: aws.StringSlice([]string{hostID}),
+	})
+	switch {
+	case awserrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to describe dedicated host %q", hostID)
+	}
+
+	if len(out.Hosts) == 0 {
+		return nil, nil
+	}
+	return out.Hosts[0], nil
+}
+
+// subnetAvailabilityZone returns the availability zone of a subnet, looking it up in AWS
+// if the subnet is not part of the cluster network spec.
+func (s *Service) subnetAvailabilityZone(subnetID string) (string, error) {
+	if sn := s.scope.Subnets().FindByID(subnetID); sn != nil && sn.AvailabilityZone != "" {
+		return sn.AvailabilityZone, nil
+	}
+
+	subnets, err := s.getFilteredSubnets(&ec2.Filter{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{subnetID})})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe subnet %q", subnetID)
+	}
+	if len(subnets) == 0 {
+		return "", errors.Errorf("subnet %q not found", subnetID)
+	}
+	return aws.StringValue(subnets[0].AvailabilityZone), nil
+}
+
+func dedicatedHostHasCapacity(host *ec2.Host, instanceType string) bool {
+	if host.AvailableCapacity == nil {
+		return false
+	}
+	for _, c := range host.AvailableCapacity.AvailableInstanceCapacity {
+		if aws.StringValue(c.InstanceType) == instanceType && aws.Int64Value(c.AvailableCapacity) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestEnsureDedicatedHost(t *testing.T) {
+	hostWithCapacity := func(id string, capacity int64, instances int) *ec2.Host {
+		host := &ec2.Host{
+			HostId:           aws.String(id),
+			AvailabilityZone: aws.String("us-east-1a"),
+			AvailableCapacity: &ec2.AvailableCapacity{
+				AvailableInstanceCapacity: []*ec2.InstanceCapacity{
+					{InstanceType: aws.String("m5.large"), AvailableCapacity: aws.Int64(capacity)},
+				},
+			},
+		}
+		for i := 0; i < instances; i++ {
+			host.Instances = append(host.Instances, &ec2.HostInstance{InstanceId: aws.String("i-1")})
+		}
+		return host
+	}
+
+	testCases := []struct {
+		name         string
+		statusHostID *string
+		expect       func(m *mocks.MockEC2APIMockRecorder)
+		check        func(g *WithT, hostID string, err error)
+	}{
+		{
+			name:         "Should reuse the host recorded in status if it has capacity",
+			statusHostID: aws.String("h-status"),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeHostsWithContext(context.TODO(), &ec2.DescribeHostsInput{HostIds: aws.StringSlice([]string{"h-status"})}).
+					Return(&ec2.DescribeHostsOutput{Hosts: []*ec2.Host{hostWithCapacity("h-status", 1, 1)}}, nil)
+			},
+			check: func(g *WithT, hostID string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(hostID).To(Equal("h-status"))
+			},
+		},
+		{
+			name: "Should reuse the most used host of the group with free capacity",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeHostsPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeHostsInput{}), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *ec2.DescribeHostsInput, fn func(*ec2.DescribeHostsOutput, bool) bool, _ ...interface{}) error {
+						fn(&ec2.DescribeHostsOutput{Hosts: []*ec2.Host{
+							hostWithCapacity("h-full", 0, 4),
+							hostWithCapacity("h-least-used", 3, 1),
+							hostWithCapacity("h-most-used", 1, 3),
+						}}, true)
+						return nil
+					})
+			},
+			check: func(g *WithT, hostID string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(hostID).To(Equal("h-most-used"))
+			},
+		},
+		{
+			name: "Should allocate a host if none of the group has free capacity",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeHostsPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeHostsInput{}), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *ec2.DescribeHostsInput, fn func(*ec2.DescribeHostsOutput, bool) bool, _ ...interface{}) error {
+						fn(&ec2.DescribeHostsOutput{Hosts: []*ec2.Host{hostWithCapacity("h-full", 0, 4)}}, true)
+						return nil
+					})
+				m.AllocateHostsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.AllocateHostsInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.AllocateHostsInput, _ ...interface{}) (*ec2.AllocateHostsOutput, error) {
+						if aws.StringValue(input.AvailabilityZone) != "us-east-1a" || aws.StringValue(input.InstanceType) != "m5.large" {
+							return nil, errors.New("unexpected host allocation input")
+						}
+						return &ec2.AllocateHostsOutput{HostIds: aws.StringSlice([]string{"h-new"})}, nil
+					})
+			},
+			check: func(g *WithT, hostID string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(hostID).To(Equal("h-new"))
+			},
+		},
+		{
+			name: "Should return an error if host allocation fails",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeHostsPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeHostsInput{}), gomock.Any()).
+					Return(nil)
+				m.AllocateHostsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.AllocateHostsInput{})).
+					Return(nil, errors.New("InsufficientHostCapacity"))
+			},
+			check: func(g *WithT, hostID string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(hostID).To(BeEmpty())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			cluster := newCluster()
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "machine",
+					Namespace: "default",
+					Labels: map[string]string{
+						clusterv1.MachineDeploymentNameLabel: "md-0",
+					},
+				},
+			}
+			awsMachine := &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-machine", Namespace: "default"},
+				Spec: infrav1.AWSMachineSpec{
+					InstanceType:          "m5.large",
+					Tenancy:               infrav1.TenancyHost,
+					DynamicHostAllocation: &infrav1.DynamicHostAllocationSpec{},
+				},
+			}
+			if tc.statusHostID != nil {
+				awsMachine.Status.DedicatedHost = &infrav1.DedicatedHostStatus{ID: tc.statusHostID}
+			}
+
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, machine).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    cluster,
+				AWSCluster: newAWSCluster(),
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       client,
+				Cluster:      cluster,
+				Machine:      machine,
+				AWSMachine:   awsMachine,
+				InfraCluster: clusterScope,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(machineScope.DedicatedHostGroup()).To(Equal("md-0"))
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			hostID, err := s.ensureDedicatedHost(machineScope, "m5.large", "us-east-1a")
+			tc.check(g, hostID, err)
+		})
+	}
+}
+
+func TestReleaseDedicatedHostIfEmpty(t *testing.T) {
+	ownedTags := []*ec2.Tag{
+		{Key: aws.String(infrav1.ClusterTagKey(newCluster().Name)), Value: aws.String(string(infrav1.ResourceLifecycleOwned))},
+	}
+
+	testCases := []struct {
+		name   string
+		expect func(m *mocks.MockEC2APIMockRecorder)
+		check  func(g *WithT, released, terminating bool, err error)
+	}{
+		{
+			name: "Should release an empty host owned by the cluster",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeHostsWithContext(context.TODO(), &ec2.DescribeHostsInput{HostIds: aws.StringSlice([]string{"h-1"})}).
+					Return(&ec2.DescribeHostsOutput{Hosts: []*ec2.Host{{HostId: aws.String("h-1"), State: aws.String(ec2.AllocationStateAvailable), Tags: ownedTags}}}, nil)
+				m.ReleaseHostsWithContext(context.TODO(), &ec2.ReleaseHostsInput{HostIds: aws.StringSlice([]string{"h-1"})}).
+					Return(&ec2.ReleaseHostsOutput{Successful: aws.StringSlice([]string{"h-1"})}, nil)
+			},
+			check: func(g *WithT, released, terminating bool, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(released).To(BeTrue())
+			},
+		},
+		{
+			name: "Should not release a host with running instances",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeHostsWithContext(context.TODO(), &ec2.DescribeHostsInput{HostIds: aws.StringSlice([]string{"h-1"})}).
+					Return(&ec2.DescribeHostsOutput{Hosts: []*ec2.Host{{
+						HostId:    aws.String("h-1"),
+						State:     aws.String(ec2.AllocationStateAvailable),
+						Tags:      ownedTags,
+						Instances: []*ec2.HostInstance{{InstanceId: aws.String("i-1")}, {InstanceId: aws.String("i-2")}},
+					}}}, nil)
+				m.DescribeInstancesWithContext(context.TODO(), &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1", "i-2"})}).
+					Return(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+						{InstanceId: aws.String("i-1"), State: &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameShuttingDown)}},
+						{InstanceId: aws.String("i-2"), State: &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)}},
+					}}}}, nil)
+			},
+			check: func(g *WithT, released, terminating bool, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(released).To(BeFalse())
+				g.Expect(terminating).To(BeFalse())
+			},
+		},
+		{
+			name: "Should wait for the instances of a host deleted concurrently to terminate",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeHostsWithContext(context.TODO(), &ec2.DescribeHostsInput{HostIds: aws.StringSlice([]string{"h-1"})}).
+					Return(&ec2.DescribeHostsOutput{Hosts: []*ec2.Host{{
+						HostId:    aws.String("h-1"),
+						State:     aws.String(ec2.AllocationStateAvailable),
+						Tags:      ownedTags,
+						Instances: []*ec2.HostInstance{{InstanceId: aws.String("i-1")}, {InstanceId: aws.String("i-2")}},
+					}}}, nil)
+				m.DescribeInstancesWithContext(context.TODO(), &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1", "i-2"})}).
+					Return(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+						{InstanceId: aws.String("i-1"), State: &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameTerminated)}},
+						{InstanceId: aws.String("i-2"), State: &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameShuttingDown)}},
+					}}}}, nil)
+			},
+			check: func(g *WithT, released, terminating bool, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(released).To(BeFalse())
+				g.Expect(terminating).To(BeTrue())
+			},
+		},
+		{
+			name: "Should not release a host that is not owned by the cluster",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeHostsWithContext(context.TODO(), &ec2.DescribeHostsInput{HostIds: aws.StringSlice([]string{"h-1"})}).
+					Return(&ec2.DescribeHostsOutput{Hosts: []*ec2.Host{{HostId: aws.String("h-1"), State: aws.String(ec2.AllocationStateAvailable)}}}, nil)
+			},
+			check: func(g *WithT, released, terminating bool, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(released).To(BeTrue())
+			},
+		},
+		{
+			name: "Should treat an already released host as released",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeHostsWithContext(context.TODO(), &ec2.DescribeHostsInput{HostIds: aws.StringSlice([]string{"h-1"})}).
+					Return(&ec2.DescribeHostsOutput{}, nil)
+			},
+			check: func(g *WithT, released, terminating bool, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(released).To(BeTrue())
+			},
+		},
+		{
+			name: "Should return an error if the host cannot be released",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeHostsWithContext(context.TODO(), &ec2.DescribeHostsInput{HostIds: aws.StringSlice([]string{"h-1"})}).
+					Return(&ec2.DescribeHostsOutput{Hosts: []*ec2.Host{{HostId: aws.String("h-1"), State: aws.String(ec2.AllocationStateAvailable), Tags: ownedTags}}}, nil)
+				m.ReleaseHostsWithContext(context.TODO(), &ec2.ReleaseHostsInput{HostIds: aws.StringSlice([]string{"h-1"})}).
+					Return(&ec2.ReleaseHostsOutput{Unsuccessful: []*ec2.UnsuccessfulItem{{
+						ResourceId: aws.String("h-1"),
+						Error:      &ec2.UnsuccessfulItemError{Message: aws.String("host is in use")},
+					}}}, nil)
+			},
+			check: func(g *WithT, released, terminating bool, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(released).To(BeFalse())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			released, terminating, err := s.ReleaseDedicatedHostIfEmpty("h-1")
+			tc.check(g, released, terminating, err)
+		})
+	}
+}
+
+func TestDedicatedHostsOfClusterWithDifferentKubernetesName(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cluster := newCluster()
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine",
+			Namespace: "default",
+			Labels: map[string]string{
+				clusterv1.MachineDeploymentNameLabel: "md-0",
+			},
+		},
+	}
+	awsMachine := &infrav1.AWSMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-machine", Namespace: "default"},
+		Spec: infrav1.AWSMachineSpec{
+			InstanceType:          "m5.large",
+			Tenancy:               infrav1.TenancyHost,
+			DynamicHostAllocation: &infrav1.DynamicHostAllocationSpec{},
+		},
+	}
+	controlPlane := newAWSManagedControlPlane()
+	controlPlane.Spec.EKSClusterName = "eks-cluster-name"
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, machine).Build()
+	managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		Client:       client,
+		Cluster:      cluster,
+		ControlPlane: controlPlane,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(managedScope.KubernetesClusterName()).NotTo(Equal(managedScope.Name()))
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:       client,
+		Cluster:      cluster,
+		Machine:      machine,
+		AWSMachine:   awsMachine,
+		InfraCluster: managedScope,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ownedTags := []*ec2.Tag{
+		{Key: aws.String(infrav1.ClusterTagKey("eks-cluster-name")), Value: aws.String(string(infrav1.ResourceLifecycleOwned))},
+	}
+	gomock.InOrder(
+		ec2Mock.EXPECT().DescribeHostsPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeHostsInput{}), gomock.Any()).
+			DoAndReturn(func(_ context.Context, input *ec2.DescribeHostsInput, fn func(*ec2.DescribeHostsOutput, bool) bool, _ ...interface{}) error {
+				g.Expect(input.Filter).To(ContainElement(filter.EC2.ClusterOwned("eks-cluster-name")))
+				fn(&ec2.DescribeHostsOutput{Hosts: []*ec2.Host{{
+					HostId:           aws.String("h-1"),
+					AvailabilityZone: aws.String("us-east-1a"),
+					Tags:             ownedTags,
+					AvailableCapacity: &ec2.AvailableCapacity{
+						AvailableInstanceCapacity: []*ec2.InstanceCapacity{
+							{InstanceType: aws.String("m5.large"), AvailableCapacity: aws.Int64(1)},
+						},
+					},
+				}}}, true)
+				return nil
+			}),
+		ec2Mock.EXPECT().DescribeHostsWithContext(context.TODO(), &ec2.DescribeHostsInput{HostIds: aws.StringSlice([]string{"h-1"})}).
+			Return(&ec2.DescribeHostsOutput{Hosts: []*ec2.Host{{HostId: aws.String("h-1"), State: aws.String(ec2.AllocationStateAvailable), Tags: ownedTags}}}, nil),
+		ec2Mock.EXPECT().ReleaseHostsWithContext(context.TODO(), &ec2.ReleaseHostsInput{HostIds: aws.StringSlice([]string{"h-1"})}).
+			Return(&ec2.ReleaseHostsOutput{Successful: aws.StringSlice([]string{"h-1"})}, nil),
+	)
+
+	s := NewService(managedScope)
+	s.EC2Client = ec2Mock
+
+	hostID, err := s.ensureDedicatedHost(machineScope, "m5.large", "us-east-1a")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hostID).To(Equal("h-1"))
+
+	released, _, err := s.ReleaseDedicatedHostIfEmpty("h-1")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(released).To(BeTrue())
+}
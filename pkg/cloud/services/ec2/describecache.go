@@ -0,0 +1,602 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+const (
+	describeCacheResourceInstances         = "instances"
+	describeCacheResourceNetworkInterfaces = "network-interfaces"
+	describeCacheResourceSecurityGroups    = "security-groups"
+
+	// describeCacheIdleTimeout is how long a describe cache is kept after it was last used, so that the caches
+	// of deleted clusters are released.
+	describeCacheIdleTimeout = 30 * time.Minute
+)
+
+// describeCaches holds the describe caches of all clusters, keyed by describeCacheKey.
+var describeCaches sync.Map
+
+type describeCacheKey struct {
+	namespace string
+	name      string
+	region    string
+}
+
+// DescribeCache caches the instances, network interfaces and security groups of the VPC of a cluster, so that
+// reconciling the machines of large clusters does not describe them one by one and get throttled by EC2.
+//
+// Each kind of resource is listed with a single filtered and paginated call at most once per period, when it is
+// read. Lists run without holding the cache lock, readers fall back to describing resources directly meanwhile,
+// and a failed list is retried on the next read. Only cache hits are served from the cache: anything that is not
+// found, or was fetched more than a period ago, falls back to describing the resource directly. Instances are
+// invalidated when they are modified through the EC2 service, or when EventBridge reports a change of their
+// state, and are described directly until they are listed again.
+type DescribeCache struct {
+	clusterName string
+	region      string
+	vpcID       string
+	period      time.Duration
+	now         func() time.Time
+
+	lastUsedMu sync.Mutex
+	lastUsed   time.Time
+
+	mu                        sync.Mutex
+	instances                 map[string]cachedInstance
+	instancesListedAt         time.Time
+	networkInterfaces         map[string]cachedNetworkInterfaces
+	networkInterfacesListedAt time.Time
+	securityGroups            []*ec2.SecurityGroup
+	securityGroupsListedAt    time.Time
+	// listing holds the kinds of resources that are being listed.
+	listing map[string]bool
+	// invalidated holds the time at which instances, and their network interfaces, were last modified.
+	invalidated map[string]time.Time
+}
+
+type cachedInstance struct {
+	instance  *ec2.Instance
+	fetchedAt time.Time
+}
+
+type cachedNetworkInterfaces struct {
+	networkInterfaces []*ec2.NetworkInterface
+	fetchedAt         time.Time
+}
+
+// DescribeCacheFor returns the describe cache shared by the EC2 services of a cluster, which lists resources at
+// most once per period. It returns nil until the VPC of the cluster is known.
+func DescribeCacheFor(clusterScope scope.EC2Scope, period time.Duration) *DescribeCache {
+	vpcID := clusterScope.VPC().ID
+	if vpcID == "" || period <= 0 {
+		return nil
+	}
+
+	key := describeCacheKey{namespace: clusterScope.Namespace(), name: clusterScope.Name(), region: clusterScope.Region()}
+	if v, ok := describeCaches.Load(key); ok {
+		if c := v.(*DescribeCache); c.vpcID == vpcID && c.period == period {
+			c.touch()
+			return c
+		}
+	}
+
+	c := newDescribeCache(clusterScope.Name(), clusterScope.Region(), vpcID, period)
+	describeCaches.Store(key, c)
+	releaseIdleDescribeCaches()
+	return c
+}
+
+// InvalidateCachedInstance drops an instance, and its network interfaces, from the describe caches of all
+// clusters, for instance when EventBridge reports a change of its state.
+func InvalidateCachedInstance(instanceID string) {
+	describeCaches.Range(func(_, v interface{}) bool {
+		v.(*DescribeCache).invalidateInstance(instanceID)
+		return true
+	})
+}
+
+func newDescribeCache(clusterName, region, vpcID string, period time.Duration) *DescribeCache {
+	c := &DescribeCache{
+		clusterName: clusterName,
+		region:      region,
+		vpcID:       vpcID,
+		period:      period,
+		now:         time.Now,
+		invalidated: map[string]time.Time{},
+		listing:     map[string]bool{},
+	}
+	c.touch()
+	return c
+}
+
+func releaseIdleDescribeCaches() {
+	describeCaches.Range(func(key, v interface{}) bool {
+		c := v.(*DescribeCache)
+		c.lastUsedMu.Lock()
+		idle := c.now().Sub(c.lastUsed) > describeCacheIdleTimeout+c.period
+		c.lastUsedMu.Unlock()
+		if idle {
+			describeCaches.Delete(key)
+		}
+		return true
+	})
+}
+
+func (c *DescribeCache) touch() {
+	c.lastUsedMu.Lock()
+	defer c.lastUsedMu.Unlock()
+	c.lastUsed = c.now()
+}
+
+func (c *DescribeCache) record(resource string, hit bool) {
+	metrics.CaptureDescribeCacheRequest(c.region, resource, hit)
+}
+
+// validLocked reports whether a resource of the given instance fetched at the given time was fetched during the
+// last period and not modified since.
+func (c *DescribeCache) validLocked(instanceID string, fetchedAt time.Time) bool {
+	if !c.freshLocked(fetchedAt) {
+		return false
+	}
+	invalidatedAt, ok := c.invalidated[instanceID]
+	return !ok || fetchedAt.After(invalidatedAt)
+}
+
+// freshLocked reports whether a resource fetched at the given time was fetched during the last period.
+func (c *DescribeCache) freshLocked(fetchedAt time.Time) bool {
+	return c.now().Sub(fetchedAt) < c.period
+}
+
+// startList reports whether a kind of resource is due to be listed again, and marks it as being listed if so.
+// Callers that get true must call finishListLocked once the list is done.
+func (c *DescribeCache) startList(resource string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var listedAt time.Time
+	switch resource {
+	case describeCacheResourceInstances:
+		listedAt = c.instancesListedAt
+	case describeCacheResourceNetworkInterfaces:
+		listedAt = c.networkInterfacesListedAt
+	case describeCacheResourceSecurityGroups:
+		listedAt = c.securityGroupsListedAt
+	}
+	if c.listing[resource] || c.freshLocked(listedAt) {
+		return false
+	}
+	c.listing[resource] = true
+	return true
+}
+
+// finishListLocked marks a kind of resource as no longer being listed.
+func (c *DescribeCache) finishListLocked(resource string) {
+	delete(c.listing, resource)
+}
+
+// instance returns the instance with the given ID, or nil if it is not cached.
+func (c *DescribeCache) instance(client ec2iface.EC2API, instanceID string) *ec2.Instance {
+	if c == nil {
+		return nil
+	}
+
+	c.listInstances(client)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.instances[instanceID]
+	hit := ok && c.validLocked(instanceID, entry.fetchedAt)
+	c.record(describeCacheResourceInstances, hit)
+	if !hit {
+		return nil
+	}
+	return entry.instance
+}
+
+// runningInstanceByName returns a pending or running instance of the cluster with the given name, or nil if none
+// is cached.
+func (c *DescribeCache) runningInstanceByName(client ec2iface.EC2API, name string) *ec2.Instance {
+	if c == nil {
+		return nil
+	}
+
+	c.listInstances(client)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, entry := range c.instances {
+		if !c.validLocked(id, entry.fetchedAt) || aws.StringValue(entry.instance.VpcId) != c.vpcID {
+			continue
+		}
+		if state := aws.StringValue(entry.instance.State.Name); state != ec2.InstanceStateNamePending && state != ec2.InstanceStateNameRunning {
+			continue
+		}
+		if !hasTag(entry.instance.Tags, "Name", name) ||
+			!hasTag(entry.instance.Tags, infrav1.ClusterTagKey(c.clusterName), string(infrav1.ResourceLifecycleOwned)) {
+			continue
+		}
+		c.record(describeCacheResourceInstances, true)
+		return entry.instance
+	}
+	c.record(describeCacheResourceInstances, false)
+	return nil
+}
+
+// storeInstance caches an instance that was described directly, before the given time.
+func (c *DescribeCache) storeInstance(instance *ec2.Instance, fetchedAt time.Time) {
+	if c == nil || instance == nil || instance.InstanceId == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The instances were listed again while the instance was described, and may be more recent.
+	if fetchedAt.Before(c.instancesListedAt) {
+		return
+	}
+	if c.instances == nil {
+		c.instances = map[string]cachedInstance{}
+	}
+	c.instances[*instance.InstanceId] = cachedInstance{instance: instance, fetchedAt: fetchedAt}
+}
+
+// instanceNetworkInterfaces returns the network interfaces attached to an instance, if they are cached.
+func (c *DescribeCache) instanceNetworkInterfaces(client ec2iface.EC2API, instanceID string) ([]*ec2.NetworkInterface, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.listNetworkInterfaces(client)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.networkInterfaces[instanceID]
+	hit := ok && len(entry.networkInterfaces) > 0 && c.validLocked(instanceID, entry.fetchedAt)
+	c.record(describeCacheResourceNetworkInterfaces, hit)
+	if !hit {
+		return nil, false
+	}
+	return entry.networkInterfaces, true
+}
+
+// storeInstanceNetworkInterfaces caches the network interfaces of an instance that were described directly,
+// before the given time.
+func (c *DescribeCache) storeInstanceNetworkInterfaces(instanceID string, networkInterfaces []*ec2.NetworkInterface, fetchedAt time.Time) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if fetchedAt.Before(c.networkInterfacesListedAt) {
+		return
+	}
+	if c.networkInterfaces == nil {
+		c.networkInterfaces = map[string]cachedNetworkInterfaces{}
+	}
+	c.networkInterfaces[instanceID] = cachedNetworkInterfaces{networkInterfaces: networkInterfaces, fetchedAt: fetchedAt}
+}
+
+// securityGroupIDs returns the IDs of the security groups of the VPC that match the given filters, if the filters
+// can be evaluated without EC2 and every security group ID or name they request is cached. Security groups created
+// since the last list are not cached, so filters that do not request IDs or names are always left to EC2.
+func (c *DescribeCache) securityGroupIDs(client ec2iface.EC2API, filters []*ec2.Filter) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	if !securityGroupFiltersSupported(filters) {
+		c.record(describeCacheResourceSecurityGroups, false)
+		return nil, false
+	}
+
+	c.listSecurityGroups(client)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var ids []string
+	var matched []*ec2.SecurityGroup
+	if c.freshLocked(c.securityGroupsListedAt) {
+		for _, sg := range c.securityGroups {
+			if securityGroupMatches(sg, filters) {
+				ids = append(ids, aws.StringValue(sg.GroupId))
+				matched = append(matched, sg)
+			}
+		}
+	}
+	hit := len(ids) > 0 && securityGroupsRequested(matched, filters)
+	c.record(describeCacheResourceSecurityGroups, hit)
+	return ids, hit
+}
+
+// invalidateInstance drops an instance and its network interfaces from the cache after they were modified.
+func (c *DescribeCache) invalidateInstance(instanceID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.invalidated[instanceID] = c.now()
+}
+
+// invalidateNetworkInterface drops the instance a network interface is attached to from the cache after the
+// network interface was modified.
+func (c *DescribeCache) invalidateNetworkInterface(networkInterfaceID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for instanceID, entry := range c.networkInterfaces {
+		for _, eni := range entry.networkInterfaces {
+			if aws.StringValue(eni.NetworkInterfaceId) == networkInterfaceID {
+				c.invalidated[instanceID] = c.now()
+			}
+		}
+	}
+}
+
+// listInstances lists the instances of the cluster when they were not listed during the last period.
+func (c *DescribeCache) listInstances(client ec2iface.EC2API) {
+	if !c.startList(describeCacheResourceInstances) {
+		return
+	}
+
+	listedAt := c.now()
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(c.vpcID),
+			filter.EC2.ClusterOwned(c.clusterName),
+			filter.EC2.InstanceStates(
+				ec2.InstanceStateNamePending,
+				ec2.InstanceStateNameRunning,
+				ec2.InstanceStateNameStopping,
+				ec2.InstanceStateNameStopped,
+				ec2.InstanceStateNameShuttingDown,
+			),
+		},
+	}
+
+	instances := map[string]cachedInstance{}
+	err := client.DescribeInstancesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, res := range out.Reservations {
+			for _, instance := range res.Instances {
+				instances[aws.StringValue(instance.InstanceId)] = cachedInstance{instance: instance, fetchedAt: listedAt}
+			}
+		}
+		return true
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.finishListLocked(describeCacheResourceInstances)
+	if err != nil {
+		return
+	}
+	// Keep the instances that were described directly while the list was running.
+	for id, entry := range c.instances {
+		if entry.fetchedAt.After(listedAt) {
+			instances[id] = entry
+		}
+	}
+	c.instances = instances
+	c.instancesListedAt = listedAt
+	c.releaseInvalidatedLocked()
+}
+
+// listNetworkInterfaces lists the network interfaces of the VPC when they were not listed during the last period.
+func (c *DescribeCache) listNetworkInterfaces(client ec2iface.EC2API) {
+	if !c.startList(describeCacheResourceNetworkInterfaces) {
+		return
+	}
+
+	listedAt := c.now()
+	input := &ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{filter.EC2.VPC(c.vpcID)},
+	}
+
+	networkInterfaces := map[string]cachedNetworkInterfaces{}
+	err := client.DescribeNetworkInterfacesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeNetworkInterfacesOutput, lastPage bool) bool {
+		for _, eni := range out.NetworkInterfaces {
+			if eni.Attachment == nil || eni.Attachment.InstanceId == nil {
+				continue
+			}
+			entry := networkInterfaces[*eni.Attachment.InstanceId]
+			entry.networkInterfaces = append(entry.networkInterfaces, eni)
+			entry.fetchedAt = listedAt
+			networkInterfaces[*eni.Attachment.InstanceId] = entry
+		}
+		return true
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.finishListLocked(describeCacheResourceNetworkInterfaces)
+	if err != nil {
+		return
+	}
+	// Keep the network interfaces that were described directly while the list was running.
+	for id, entry := range c.networkInterfaces {
+		if entry.fetchedAt.After(listedAt) {
+			networkInterfaces[id] = entry
+		}
+	}
+	c.networkInterfaces = networkInterfaces
+	c.networkInterfacesListedAt = listedAt
+	c.releaseInvalidatedLocked()
+}
+
+// listSecurityGroups lists the security groups of the VPC when they were not listed during the last period.
+func (c *DescribeCache) listSecurityGroups(client ec2iface.EC2API) {
+	if !c.startList(describeCacheResourceSecurityGroups) {
+		return
+	}
+
+	listedAt := c.now()
+	input := &ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{filter.EC2.VPC(c.vpcID)},
+	}
+
+	var securityGroups []*ec2.SecurityGroup
+	err := client.DescribeSecurityGroupsPagesWithContext(context.TODO(), input, func(out *ec2.DescribeSecurityGroupsOutput, lastPage bool) bool {
+		securityGroups = append(securityGroups, out.SecurityGroups...)
+		return true
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.finishListLocked(describeCacheResourceSecurityGroups)
+	if err != nil {
+		return
+	}
+	c.securityGroups = securityGroups
+	c.securityGroupsListedAt = listedAt
+}
+
+// releaseInvalidatedLocked forgets the invalidations that predate the last list of both instances and network
+// interfaces, as no cached entry is older than them anymore.
+func (c *DescribeCache) releaseInvalidatedLocked() {
+	oldest := c.instancesListedAt
+	if c.networkInterfacesListedAt.Before(oldest) {
+		oldest = c.networkInterfacesListedAt
+	}
+	for instanceID, invalidatedAt := range c.invalidated {
+		if invalidatedAt.Before(oldest) {
+			delete(c.invalidated, instanceID)
+		}
+	}
+}
+
+// securityGroupFiltersSupported reports whether the filters can be evaluated against cached security groups.
+// Wildcards and filters on other attributes are left to EC2.
+func securityGroupFiltersSupported(filters []*ec2.Filter) bool {
+	for _, f := range filters {
+		name := aws.StringValue(f.Name)
+		switch {
+		case name == "vpc-id", name == "group-id", name == "group-name", name == "tag-key", strings.HasPrefix(name, "tag:"):
+		default:
+			return false
+		}
+		for _, value := range f.Values {
+			if strings.ContainsAny(aws.StringValue(value), "*?") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// securityGroupsRequested reports whether every security group ID and name requested by the filters is among the
+// given security groups.
+func securityGroupsRequested(securityGroups []*ec2.SecurityGroup, filters []*ec2.Filter) bool {
+	requested := false
+	for _, f := range filters {
+		name := aws.StringValue(f.Name)
+		if name != "group-id" && name != "group-name" {
+			continue
+		}
+		requested = true
+		for _, value := range aws.StringValueSlice(f.Values) {
+			found := false
+			for _, sg := range securityGroups {
+				if (name == "group-id" && aws.StringValue(sg.GroupId) == value) || (name == "group-name" && aws.StringValue(sg.GroupName) == value) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return requested
+}
+
+// securityGroupMatches reports whether a security group matches all the filters, and any value of each filter.
+func securityGroupMatches(sg *ec2.SecurityGroup, filters []*ec2.Filter) bool {
+	for _, f := range filters {
+		name := aws.StringValue(f.Name)
+		matched := false
+		for _, value := range aws.StringValueSlice(f.Values) {
+			switch {
+			case name == "vpc-id":
+				matched = aws.StringValue(sg.VpcId) == value
+			case name == "group-id":
+				matched = aws.StringValue(sg.GroupId) == value
+			case name == "group-name":
+				matched = aws.StringValue(sg.GroupName) == value
+			case name == "tag-key":
+				matched = hasTagKey(sg.Tags, value)
+			default:
+				matched = hasTag(sg.Tags, strings.TrimPrefix(name, "tag:"), value)
+			}
+			if matched {
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func hasTagKey(tags []*ec2.Tag, key string) bool {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTag(tags []*ec2.Tag, key, value string) bool {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key && aws.StringValue(tag.Value) == value {
+			return true
+		}
+	}
+	return false
+}
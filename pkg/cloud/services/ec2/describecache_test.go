@@ -0,0 +1,367 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestDescribeCacheFor(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := setupClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(DescribeCacheFor(clusterScope, time.Minute)).To(BeNil(), "no cache is expected before the VPC is known")
+
+	clusterScope.AWSCluster.Spec.NetworkSpec.VPC.ID = "vpc-describe-cache"
+	c := DescribeCacheFor(clusterScope, time.Minute)
+	g.Expect(c).NotTo(BeNil())
+	g.Expect(DescribeCacheFor(clusterScope, time.Minute)).To(BeIdenticalTo(c))
+	g.Expect(DescribeCacheFor(clusterScope, 0)).To(BeNil(), "no cache is expected when it is disabled")
+
+	clusterScope.AWSCluster.Spec.NetworkSpec.VPC.ID = "vpc-describe-cache-2"
+	g.Expect(DescribeCacheFor(clusterScope, time.Minute)).NotTo(BeIdenticalTo(c))
+}
+
+func TestDescribeCacheInstances(t *testing.T) {
+	cachedInstance := &ec2.Instance{
+		InstanceId: aws.String("i-cached"),
+		VpcId:      aws.String("vpc-1"),
+		State:      &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+		Placement:  &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")},
+		Tags: []*ec2.Tag{
+			{Key: aws.String("Name"), Value: aws.String("machine-1")},
+			{Key: aws.String(infrav1.ClusterTagKey("test-cluster")), Value: aws.String(string(infrav1.ResourceLifecycleOwned))},
+		},
+	}
+	uncachedInstance := &ec2.Instance{
+		InstanceId: aws.String("i-uncached"),
+		State:      &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+		Placement:  &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")},
+	}
+	expectList := func(m *mocks.MockEC2APIMockRecorder) {
+		m.DescribeInstancesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...interface{}) error {
+				fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{cachedInstance}}}}, true)
+				return nil
+			})
+	}
+
+	testCases := []struct {
+		name   string
+		expect func(m *mocks.MockEC2APIMockRecorder)
+		run    func(g *WithT, s *Service)
+	}{
+		{
+			name:   "Should serve instances from a single list call",
+			expect: expectList,
+			run: func(g *WithT, s *Service) {
+				for i := 0; i < 3; i++ {
+					instance, err := s.InstanceIfExists(aws.String("i-cached"))
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(instance.ID).To(Equal("i-cached"))
+				}
+			},
+		},
+		{
+			name: "Should describe instances that are not cached directly",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectList(m)
+				m.DescribeInstancesWithContext(context.TODO(), &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-uncached"})}).
+					Return(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{uncachedInstance}}}}, nil)
+			},
+			run: func(g *WithT, s *Service) {
+				for i := 0; i < 2; i++ {
+					instance, err := s.InstanceIfExists(aws.String("i-uncached"))
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(instance.ID).To(Equal("i-uncached"))
+				}
+			},
+		},
+		{
+			name: "Should describe instances directly after they were modified",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectList(m)
+				m.CreateTagsWithContext(context.TODO(), gomock.Any()).Return(&ec2.CreateTagsOutput{}, nil)
+				m.DescribeInstancesWithContext(context.TODO(), &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-cached"})}).
+					Return(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{cachedInstance}}}}, nil)
+			},
+			run: func(g *WithT, s *Service) {
+				_, err := s.InstanceIfExists(aws.String("i-cached"))
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(s.UpdateResourceTags(aws.String("i-cached"), map[string]string{"foo": "bar"}, nil)).To(Succeed())
+				_, err = s.InstanceIfExists(aws.String("i-cached"))
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
+		{
+			name: "Should describe instances directly after EventBridge reported a change of their state",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectList(m)
+				m.DescribeInstancesWithContext(context.TODO(), &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-cached"})}).
+					Return(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{cachedInstance}}}}, nil)
+			},
+			run: func(g *WithT, s *Service) {
+				_, err := s.InstanceIfExists(aws.String("i-cached"))
+				g.Expect(err).NotTo(HaveOccurred())
+				InvalidateCachedInstance("i-cached")
+				_, err = s.InstanceIfExists(aws.String("i-cached"))
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
+		{
+			name: "Should list instances again after the period",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectList(m)
+				expectList(m)
+			},
+			run: func(g *WithT, s *Service) {
+				_, err := s.InstanceIfExists(aws.String("i-cached"))
+				g.Expect(err).NotTo(HaveOccurred())
+				now := time.Now().Add(2 * time.Minute)
+				s.DescribeCache.now = func() time.Time { return now }
+				_, err = s.InstanceIfExists(aws.String("i-cached"))
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
+		{
+			name: "Should list instances again on the next read after a failed list",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstancesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+					Return(errors.New("throttled"))
+				m.DescribeInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{cachedInstance}}}}, nil)
+				expectList(m)
+			},
+			run: func(g *WithT, s *Service) {
+				_, err := s.InstanceIfExists(aws.String("i-cached"))
+				g.Expect(err).NotTo(HaveOccurred())
+				_, err = s.InstanceIfExists(aws.String("i-cached"))
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
+		{
+			name:   "Should serve running instances by name from the cache",
+			expect: expectList,
+			run: func(g *WithT, s *Service) {
+				instance, err := s.GetRunningInstanceByTags(&scope.MachineScope{AWSMachine: &infrav1.AWSMachine{ObjectMeta: metav1.ObjectMeta{Name: "machine-1"}}})
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(instance.ID).To(Equal("i-cached"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+			s.DescribeCache = newDescribeCache("test-cluster", "us-east-1", "vpc-1", time.Minute)
+			describeCaches.Store(describeCacheKey{name: t.Name()}, s.DescribeCache)
+			defer describeCaches.Delete(describeCacheKey{name: t.Name()})
+
+			tc.run(g, s)
+		})
+	}
+}
+
+func TestDescribeCacheNetworkInterfaces(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := setupClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ec2Mock.EXPECT().DescribeNetworkInterfacesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *ec2.DescribeNetworkInterfacesInput, fn func(*ec2.DescribeNetworkInterfacesOutput, bool) bool, _ ...interface{}) error {
+			fn(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []*ec2.NetworkInterface{
+				{
+					NetworkInterfaceId: aws.String("eni-1"),
+					Attachment:         &ec2.NetworkInterfaceAttachment{InstanceId: aws.String("i-1")},
+					Groups:             []*ec2.GroupIdentifier{{GroupId: aws.String("sg-1")}},
+				},
+				{
+					NetworkInterfaceId: aws.String("eni-unattached"),
+				},
+			}}, true)
+			return nil
+		})
+	ec2Mock.EXPECT().ModifyNetworkInterfaceAttributeWithContext(context.TODO(), gomock.Any()).
+		Return(&ec2.ModifyNetworkInterfaceAttributeOutput{}, nil)
+	ec2Mock.EXPECT().DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []*ec2.NetworkInterface{
+			{
+				NetworkInterfaceId: aws.String("eni-1"),
+				Groups:             []*ec2.GroupIdentifier{{GroupId: aws.String("sg-2")}},
+			},
+		}}, nil)
+
+	s := NewService(clusterScope)
+	s.EC2Client = ec2Mock
+	s.DescribeCache = newDescribeCache("test-cluster", "us-east-1", "vpc-1", time.Minute)
+
+	groups, err := s.GetInstanceSecurityGroups("i-1")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(groups).To(Equal(map[string][]string{"eni-1": {"sg-1"}}))
+
+	// The security groups of the network interface are modified, so it is described directly afterwards.
+	g.Expect(s.UpdateInstanceSecurityGroups("i-1", []string{"sg-2"})).To(Succeed())
+	groups, err = s.GetInstanceSecurityGroups("i-1")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(groups).To(Equal(map[string][]string{"eni-1": {"sg-2"}}))
+}
+
+func TestDescribeCacheSecurityGroups(t *testing.T) {
+	securityGroups := []*ec2.SecurityGroup{
+		{
+			GroupId:   aws.String("sg-1"),
+			GroupName: aws.String("workers"),
+			VpcId:     aws.String("vpc-1"),
+			Tags:      []*ec2.Tag{{Key: aws.String("role"), Value: aws.String("worker")}},
+		},
+		{
+			GroupId:   aws.String("sg-2"),
+			GroupName: aws.String("bastion"),
+			VpcId:     aws.String("vpc-1"),
+			Tags:      []*ec2.Tag{{Key: aws.String("role"), Value: aws.String("bastion")}},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		filters  []infrav1.Filter
+		expect   func(m *mocks.MockEC2APIMockRecorder)
+		expected []string
+	}{
+		{
+			name:     "Should match IDs against cached security groups",
+			filters:  []infrav1.Filter{{Name: "group-id", Values: []string{"sg-1", "sg-2"}}},
+			expected: []string{"sg-1", "sg-2"},
+		},
+		{
+			name:    "Should describe security groups directly when a requested ID is not cached",
+			filters: []infrav1.Filter{{Name: "group-id", Values: []string{"sg-1", "sg-new"}}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{securityGroups[0], {GroupId: aws.String("sg-new")}}}, nil)
+			},
+			expected: []string{"sg-1", "sg-new"},
+		},
+		{
+			name:    "Should describe security groups directly for filters that do not request IDs or names",
+			filters: []infrav1.Filter{{Name: "tag:role", Values: []string{"worker", "other"}}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: securityGroups[:1]}, nil)
+			},
+			expected: []string{"sg-1"},
+		},
+		{
+			name: "Should match several filters against cached security groups",
+			filters: []infrav1.Filter{
+				{Name: "tag-key", Values: []string{"role"}},
+				{Name: "group-name", Values: []string{"bastion"}},
+			},
+			expected: []string{"sg-2"},
+		},
+		{
+			name:    "Should describe security groups directly for wildcard filters",
+			filters: []infrav1.Filter{{Name: "group-name", Values: []string{"work*"}}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: securityGroups[:1]}, nil)
+			},
+			expected: []string{"sg-1"},
+		},
+		{
+			name:    "Should describe security groups directly when none is cached",
+			filters: []infrav1.Filter{{Name: "tag:role", Values: []string{"control-plane"}}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock.EXPECT().DescribeSecurityGroupsPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, input *ec2.DescribeSecurityGroupsInput, fn func(*ec2.DescribeSecurityGroupsOutput, bool) bool, _ ...interface{}) error {
+					fn(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: securityGroups}, true)
+					return nil
+				}).AnyTimes()
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+			s.DescribeCache = newDescribeCache("test-cluster", "us-east-1", "vpc-1", time.Minute)
+
+			ids, err := s.GetAdditionalSecurityGroupsIDs([]infrav1.AWSResourceReference{{Filters: tc.filters}})
+			g.Expect(err).NotTo(HaveOccurred())
+			if len(tc.expected) == 0 {
+				g.Expect(ids).To(BeEmpty())
+			} else {
+				g.Expect(ids).To(Equal(tc.expected))
+			}
+		})
+	}
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package ec2
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrInstanceNotFoundByID defines an error for when the instance with the provided provider ID is missing.
@@ -25,3 +28,31 @@ var (
 	// ErrDescribeInstance defines an error for when AWS SDK returns error when describing instances.
 	ErrDescribeInstance = errors.New("failed to describe instance by id")
 )
+
+// ImageValidationError is returned when an AMI cannot be used to launch an instance. Reason is one of the
+// image reasons of the InstanceReady condition.
+type ImageValidationError struct {
+	Reason  string
+	Message string
+}
+
+// Error implements the Error interface.
+func (e *ImageValidationError) Error() string {
+	return e.Message
+}
+
+func newImageValidationError(reason, format string, args ...interface{}) error {
+	return &ImageValidationError{
+		Reason:  reason,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// ImageValidationReason returns the reason of err if it is an *ImageValidationError, or defaultReason otherwise.
+func ImageValidationReason(err error, defaultReason string) string {
+	var imageErr *ImageValidationError
+	if errors.As(err, &imageErr) {
+		return imageErr.Reason
+	}
+	return defaultReason
+}
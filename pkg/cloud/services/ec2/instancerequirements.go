@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+// GetInstanceTypesFromInstanceRequirements returns the instance types of the region matching the given instance requirements.
+func (s *Service) GetInstanceTypesFromInstanceRequirements(requirements *expinfrav1.InstanceRequirements) ([]string, error) {
+	input := &ec2.GetInstanceTypesFromInstanceRequirementsInput{
+		ArchitectureTypes:    aws.StringSlice([]string{instanceRequirementsArchitecture(requirements)}),
+		VirtualizationTypes:  aws.StringSlice([]string{ec2.VirtualizationTypeHvm}),
+		InstanceRequirements: createSDKInstanceRequirementsRequest(requirements),
+	}
+
+	instanceTypes := []string{}
+	if err := s.EC2Client.GetInstanceTypesFromInstanceRequirementsPagesWithContext(context.TODO(), input, func(out *ec2.GetInstanceTypesFromInstanceRequirementsOutput, lastPage bool) bool {
+		for _, instanceType := range out.InstanceTypes {
+			instanceTypes = append(instanceTypes, aws.StringValue(instanceType.InstanceType))
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to get instance types from instance requirements")
+	}
+
+	return instanceTypes, nil
+}
+
+// instanceRequirementsArchitecture returns the architecture of the instance types matching instance requirements.
+// Only the AWS Graviton processors are arm64, every other CPU manufacturer is x86_64.
+func instanceRequirementsArchitecture(requirements *expinfrav1.InstanceRequirements) string {
+	if len(requirements.CPUManufacturers) == 0 {
+		return Amd64ArchitectureTag
+	}
+	for _, manufacturer := range requirements.CPUManufacturers {
+		if manufacturer != expinfrav1.CPUManufacturerAmazonWebServices {
+			return Amd64ArchitectureTag
+		}
+	}
+	return Arm64ArchitectureTag
+}
+
+func createSDKInstanceRequirementsRequest(i *expinfrav1.InstanceRequirements) *ec2.InstanceRequirementsRequest {
+	requirements := &ec2.InstanceRequirementsRequest{
+		VCpuCount: &ec2.VCpuCountRangeRequest{
+			Min: aws.Int64(i.VCPUCount.Min),
+			Max: i.VCPUCount.Max,
+		},
+		MemoryMiB: &ec2.MemoryMiBRequest{
+			Min: aws.Int64(i.MemoryMiB.Min),
+			Max: i.MemoryMiB.Max,
+		},
+		OnDemandMaxPricePercentageOverLowestPrice: i.OnDemandMaxPricePercentageOverLowestPrice,
+		SpotMaxPricePercentageOverLowestPrice:     i.SpotMaxPricePercentageOverLowestPrice,
+	}
+
+	if i.AcceleratorCount != nil {
+		requirements.AcceleratorCount = &ec2.AcceleratorCountRequest{
+			Min: aws.Int64(i.AcceleratorCount.Min),
+			Max: i.AcceleratorCount.Max,
+		}
+	}
+	if i.BurstablePerformance != "" {
+		requirements.BurstablePerformance = aws.String(string(i.BurstablePerformance))
+	}
+	if i.BareMetal != "" {
+		requirements.BareMetal = aws.String(string(i.BareMetal))
+	}
+	for _, manufacturer := range i.CPUManufacturers {
+		requirements.CpuManufacturers = append(requirements.CpuManufacturers, aws.String(string(manufacturer)))
+	}
+	for _, acceleratorType := range i.AcceleratorTypes {
+		requirements.AcceleratorTypes = append(requirements.AcceleratorTypes, aws.String(string(acceleratorType)))
+	}
+	for _, generation := range i.InstanceGenerations {
+		requirements.InstanceGenerations = append(requirements.InstanceGenerations, aws.String(string(generation)))
+	}
+	if len(i.AllowedInstanceTypes) > 0 {
+		requirements.AllowedInstanceTypes = aws.StringSlice(i.AllowedInstanceTypes)
+	}
+	if len(i.ExcludedInstanceTypes) > 0 {
+		requirements.ExcludedInstanceTypes = aws.StringSlice(i.ExcludedInstanceTypes)
+	}
+
+	return requirements
+}
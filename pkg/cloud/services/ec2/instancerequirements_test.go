@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestGetInstanceTypesFromInstanceRequirements(t *testing.T) {
+	testCases := []struct {
+		name         string
+		requirements *expinfrav1.InstanceRequirements
+		expect       func(m *mocks.MockEC2APIMockRecorder)
+		check        func(g *WithT, instanceTypes []string, err error)
+	}{
+		{
+			name: "Should return the instance types of every page matching the instance requirements",
+			requirements: &expinfrav1.InstanceRequirements{
+				VCPUCount:             expinfrav1.InstanceRequirementsRange{Min: 2, Max: aws.Int64(4)},
+				MemoryMiB:             expinfrav1.InstanceRequirementsRange{Min: 4096},
+				InstanceGenerations:   []expinfrav1.InstanceGeneration{expinfrav1.InstanceGenerationCurrent},
+				ExcludedInstanceTypes: []string{"t2.*"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.GetInstanceTypesFromInstanceRequirementsPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.GetInstanceTypesFromInstanceRequirementsInput{}), gomock.Any()).
+					DoAndReturn(func(_ context.Context, input *ec2.GetInstanceTypesFromInstanceRequirementsInput, fn func(*ec2.GetInstanceTypesFromInstanceRequirementsOutput, bool) bool, _ ...interface{}) error {
+						if aws.StringValue(input.ArchitectureTypes[0]) != Amd64ArchitectureTag ||
+							aws.Int64Value(input.InstanceRequirements.VCpuCount.Max) != 4 ||
+							aws.StringValue(input.InstanceRequirements.InstanceGenerations[0]) != "current" ||
+							aws.StringValue(input.InstanceRequirements.ExcludedInstanceTypes[0]) != "t2.*" {
+							return errors.New("unexpected instance requirements input")
+						}
+						fn(&ec2.GetInstanceTypesFromInstanceRequirementsOutput{InstanceTypes: []*ec2.InstanceTypeInfoFromInstanceRequirements{
+							{InstanceType: aws.String("m5.large")},
+						}}, false)
+						fn(&ec2.GetInstanceTypesFromInstanceRequirementsOutput{InstanceTypes: []*ec2.InstanceTypeInfoFromInstanceRequirements{
+							{InstanceType: aws.String("c5.xlarge")},
+						}}, true)
+						return nil
+					})
+			},
+			check: func(g *WithT, instanceTypes []string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(instanceTypes).To(Equal([]string{"m5.large", "c5.xlarge"}))
+			},
+		},
+		{
+			name: "Should match arm64 instance types if only AWS Graviton CPUs are required",
+			requirements: &expinfrav1.InstanceRequirements{
+				CPUManufacturers: []expinfrav1.CPUManufacturer{expinfrav1.CPUManufacturerAmazonWebServices},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.GetInstanceTypesFromInstanceRequirementsPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.GetInstanceTypesFromInstanceRequirementsInput{}), gomock.Any()).
+					DoAndReturn(func(_ context.Context, input *ec2.GetInstanceTypesFromInstanceRequirementsInput, fn func(*ec2.GetInstanceTypesFromInstanceRequirementsOutput, bool) bool, _ ...interface{}) error {
+						if aws.StringValue(input.ArchitectureTypes[0]) != Arm64ArchitectureTag {
+							return errors.New("unexpected architecture")
+						}
+						fn(&ec2.GetInstanceTypesFromInstanceRequirementsOutput{InstanceTypes: []*ec2.InstanceTypeInfoFromInstanceRequirements{
+							{InstanceType: aws.String("m6g.large")},
+						}}, true)
+						return nil
+					})
+			},
+			check: func(g *WithT, instanceTypes []string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(instanceTypes).To(Equal([]string{"m6g.large"}))
+			},
+		},
+		{
+			name:         "Should return an error if instance types cannot be matched",
+			requirements: &expinfrav1.InstanceRequirements{},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.GetInstanceTypesFromInstanceRequirementsPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.GetInstanceTypesFromInstanceRequirementsInput{}), gomock.Any()).
+					Return(errors.New("UnauthorizedOperation"))
+			},
+			check: func(g *WithT, instanceTypes []string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(instanceTypes).To(BeNil())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    newCluster(),
+				AWSCluster: newAWSCluster(),
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			instanceTypes, err := s.GetInstanceTypesFromInstanceRequirements(tc.requirements)
+			tc.check(g, instanceTypes, err)
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -37,12 +38,17 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 // GetRunningInstanceByTags returns the existing instance or nothing if it doesn't exist.
 func (s *Service) GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.Instance, error) {
 	s.scope.Debug("Looking for existing machine instance by tags")
 
+	if instance := s.DescribeCache.runningInstanceByName(s.EC2Client, scope.Name()); instance != nil {
+		return s.SDKToInstance(instance)
+	}
+
 	input := &ec2.DescribeInstancesInput{
 		Filters: []*ec2.Filter{
 			filter.EC2.VPC(s.scope.VPC().ID),
@@ -83,10 +89,15 @@ func (s *Service) InstanceIfExists(id *string) (*infrav1.Instance, error) {
 
 	s.scope.Debug("Looking for instance by id", "instance-id", *id)
 
+	if instance := s.DescribeCache.instance(s.EC2Client, *id); instance != nil {
+		return s.SDKToInstance(instance)
+	}
+
 	input := &ec2.DescribeInstancesInput{
 		InstanceIds: []*string{id},
 	}
 
+	fetchedAt := time.Now()
 	out, err := s.EC2Client.DescribeInstancesWithContext(context.TODO(), input)
 	switch {
 	case awserrors.IsNotFound(err):
@@ -98,6 +109,7 @@ func (s *Service) InstanceIfExists(id *string) (*infrav1.Instance, error) {
 	}
 
 	if len(out.Reservations) > 0 && len(out.Reservations[0].Instances) > 0 {
+		s.DescribeCache.storeInstance(out.Reservations[0].Instances[0], fetchedAt)
 		return s.SDKToInstance(out.Reservations[0].Instances[0])
 	} else {
 		// Failed to find instance with provider id.
@@ -118,6 +130,8 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 		RootVolume:        scope.AWSMachine.Spec.RootVolume.DeepCopy(),
 		NonRootVolumes:    scope.AWSMachine.Spec.NonRootVolumes,
 		NetworkInterfaces: scope.AWSMachine.Spec.NetworkInterfaces,
+
+		ManagedNetworkInterfaces: scope.AWSMachine.Spec.ManagedNetworkInterfaces,
 	}
 
 	// Make sure to use the MachineScope here to get the merger of AWSCluster and AWSMachine tags
@@ -132,15 +146,26 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 
 	var err error
 
-	imageArchitecture, err := s.pickArchitectureForInstanceType(input.Type)
-	if err != nil {
-		return nil, err
+	// Instances launched from a launch template only override the settings of the template the machine sets explicitly.
+	fromLaunchTemplate := scope.AWSMachine.Spec.LaunchTemplate != nil
+
+	var imageArchitecture string
+	if input.Type != "" {
+		imageArchitecture, err = s.pickArchitectureForInstanceType(input.Type)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Pick image from the machine configuration, or use a default one.
 	if scope.AWSMachine.Spec.AMI.ID != nil { //nolint:nestif
 		input.ImageID = *scope.AWSMachine.Spec.AMI.ID
-	} else {
+	} else if scope.AWSMachine.Spec.AMI.SSMParameter != nil {
+		input.ImageID, err = s.ssmParameterAMILookup(*scope.AWSMachine.Spec.AMI.SSMParameter, aws.StringValue(scope.Machine.Spec.Version), imageArchitecture)
+		if err != nil {
+			return nil, err
+		}
+	} else if !fromLaunchTemplate {
 		if scope.Machine.Spec.Version == nil {
 			err := errors.New("Either AWSMachine's spec.ami.id or Machine's spec.version must be defined")
 			scope.SetFailureReason(capierrors.CreateMachineError)
@@ -163,12 +188,24 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 			imageLookupBaseOS = scope.InfraCluster.ImageLookupBaseOS()
 		}
 
-		if scope.IsEKSManaged() && imageLookupFormat == "" && imageLookupOrg == "" && imageLookupBaseOS == "" {
+		eksLookup := scope.IsEKSManaged() && imageLookupFormat == "" && imageLookupOrg == "" && imageLookupBaseOS == ""
+		switch {
+		case eksLookup && scope.IsWindows():
+			input.ImageID, err = s.eksWindowsAMILookup(*scope.Machine.Spec.Version)
+			if err != nil {
+				return nil, err
+			}
+		case eksLookup:
 			input.ImageID, err = s.eksAMILookup(*scope.Machine.Spec.Version, imageArchitecture, scope.AWSMachine.Spec.AMI.EKSOptimizedLookupType)
 			if err != nil {
 				return nil, err
 			}
-		} else {
+		case scope.IsWindows():
+			input.ImageID, err = s.windowsAMIIDLookup(imageLookupFormat, imageLookupOrg, imageLookupBaseOS, imageArchitecture, *scope.Machine.Spec.Version)
+			if err != nil {
+				return nil, err
+			}
+		default:
 			input.ImageID, err = s.defaultAMIIDLookup(imageLookupFormat, imageLookupOrg, imageLookupBaseOS, imageArchitecture, *scope.Machine.Spec.Version)
 			if err != nil {
 				return nil, err
@@ -182,6 +219,22 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	}
 	input.SubnetID = subnetID
 
+	// Keep launching with the instance type and subnet picked for the first instance of the machine.
+	if scope.AWSMachine.Status.InstanceType != "" {
+		input.Type = scope.AWSMachine.Status.InstanceType
+	}
+	if scope.AWSMachine.Status.SubnetID != "" {
+		input.SubnetID = scope.AWSMachine.Status.SubnetID
+	}
+
+	// The image and instance type of a launch template are not known until the instance is launched.
+	if input.ImageID != "" && input.Type != "" {
+		if err := s.validateImage(input.ImageID, input.Type, imageArchitecture, input.RootVolume); err != nil {
+			record.Warnf(scope.AWSMachine, "InvalidImage", "Cannot launch instance from image %q: %v", input.ImageID, err)
+			return nil, err
+		}
+	}
+
 	if !scope.IsExternallyManaged() && !scope.IsEKSManaged() && s.scope.Network().APIServerELB.DNSName == "" {
 		record.Eventf(s.scope.InfraCluster(), "FailedCreateInstance", "Failed to run controlplane, APIServer ELB not available")
 
@@ -216,6 +269,8 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	case scope.AWSMachine.Spec.SSHKeyName != nil:
 		// prefer AWSMachine.Spec.SSHKeyName if it is defined
 		prioritizedSSHKeyName = *scope.AWSMachine.Spec.SSHKeyName
+	case fromLaunchTemplate:
+		// The key pair of the launch template is used.
 	case scope.InfraCluster.SSHKeyName() != nil:
 		// fallback to AWSCluster.Spec.SSHKeyName if it is defined
 		prioritizedSSHKeyName = *scope.InfraCluster.SSHKeyName()
@@ -237,10 +292,50 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	input.Tenancy = scope.AWSMachine.Spec.Tenancy
 
 	input.PlacementGroupName = scope.AWSMachine.Spec.PlacementGroupName
+	input.PlacementGroupPartition = scope.AWSMachine.Spec.PlacementGroupPartition
+
+	input.NetworkInterfaceType = scope.AWSMachine.Spec.NetworkInterfaceType
+
+	input.HostID = scope.AWSMachine.Spec.HostID
+	input.HostAffinity = scope.AWSMachine.Spec.HostAffinity
+	input.HostResourceGroupArn = scope.AWSMachine.Spec.HostResourceGroupArn
+
+	input.CPUOptions = scope.AWSMachine.Spec.CPUOptions
+	input.NitroEnclaveEnabled = scope.AWSMachine.Spec.NitroEnclaveEnabled
+	input.HibernationEnabled = scope.AWSMachine.Spec.HibernationEnabled
+	input.TerminationProtection = scope.TerminationProtection()
+
+	input.PrivateDNSName = scope.AWSMachine.Spec.PrivateDNSName
+
+	if scope.AWSMachine.Spec.LaunchTemplate != nil {
+		// Keep launching from the version resolved for the first instance of the machine.
+		version := scope.AWSMachine.Status.LaunchTemplateVersion
+		if version == "" {
+			version, err = s.ResolveLaunchTemplateVersion(scope.AWSMachine.Spec.LaunchTemplate)
+			if err != nil {
+				return nil, err
+			}
+		}
+		input.LaunchTemplate = scope.AWSMachine.Spec.LaunchTemplate.DeepCopy()
+		input.LaunchTemplate.Version = aws.String(version)
+	}
+
+	if scope.AWSMachine.Spec.DynamicHostAllocation != nil {
+		availabilityZone, err := s.subnetAvailabilityZone(input.SubnetID)
+		if err != nil {
+			return nil, err
+		}
+		hostID, err := s.ensureDedicatedHost(scope, input.Type, availabilityZone)
+		if err != nil {
+			return nil, err
+		}
+		scope.SetDedicatedHostID(aws.String(hostID))
+		input.HostID = aws.String(hostID)
+	}
 
 	s.scope.Debug("Running instance", "machine-role", scope.Role())
 	s.scope.Debug("Running instance with instance metadata options", "metadata options", input.InstanceMetadataOptions)
-	out, err := s.runInstance(scope.Role(), input)
+	out, err := s.runInstanceWithFallback(scope, input, imageArchitecture)
 	if err != nil {
 		// Only record the failure event if the error is not related to failed dependencies.
 		// This is to avoid spamming failure events since the machine will be requeued by the actuator.
@@ -284,6 +379,113 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	return out, nil
 }
 
+// runInstanceWithFallback runs the instance with the first instance type and subnet EC2 has capacity for.
+// All the instance types are tried in a subnet before moving on to the next fallback subnet. Fallback instance
+// types the image cannot be launched with are skipped and reported in the FallbackInstanceTypesCompatible
+// condition, fallback subnets outside of the failure domain of the machine are skipped. Once an instance has
+// been launched, its instance type and subnet are recorded in the machine status and no fallback is tried anymore.
+func (s *Service) runInstanceWithFallback(scope *scope.MachineScope, input *infrav1.Instance, imageArchitecture string) (*infrav1.Instance, error) {
+	instanceTypes := []string{input.Type}
+	var fallbackSubnets []infrav1.AWSResourceReference
+	if scope.AWSMachine.Status.InstanceType == "" && input.HostID == nil {
+		instanceTypes = append(instanceTypes, scope.AWSMachine.Spec.FallbackInstanceTypes...)
+		fallbackSubnets = scope.AWSMachine.Spec.FallbackSubnets
+	}
+
+	// The instance type has been validated against the image already, fallback instance types are validated on first use.
+	compatible := map[string]bool{input.Type: true}
+	var incompatible []string
+	var lastErr error
+	for i := 0; i <= len(fallbackSubnets); i++ {
+		if i > 0 {
+			subnetID, err := s.getSubnetIDFromReference(&fallbackSubnets[i-1])
+			if err != nil {
+				return nil, err
+			}
+			if failureDomain := scope.Machine.Spec.FailureDomain; failureDomain != nil {
+				availabilityZone, err := s.subnetAvailabilityZone(subnetID)
+				if err != nil {
+					return nil, err
+				}
+				if availabilityZone != *failureDomain {
+					s.scope.Debug("Skipping fallback subnet outside of the failure domain of the machine", "subnet-id", subnetID, "availability-zone", availabilityZone, "failure-domain", *failureDomain)
+					continue
+				}
+			}
+			input.SubnetID = subnetID
+		}
+
+		for _, instanceType := range instanceTypes {
+			if _, ok := compatible[instanceType]; !ok {
+				ok, err := s.fallbackInstanceTypeCompatible(scope, input, instanceType, imageArchitecture)
+				if err != nil {
+					return nil, err
+				}
+				compatible[instanceType] = ok
+				if !ok {
+					incompatible = append(incompatible, instanceType)
+					conditions.MarkFalse(scope.AWSMachine, infrav1.FallbackInstanceTypesCompatibleCondition, infrav1.FallbackInstanceTypesIncompatibleReason, clusterv1.ConditionSeverityWarning,
+						"Skipped fallback instance types the image %q cannot be launched with: %s", input.ImageID, strings.Join(incompatible, ", "))
+				}
+			}
+			if !compatible[instanceType] {
+				continue
+			}
+
+			input.Type = instanceType
+			out, err := s.runInstance(scope.Role(), input)
+			if err == nil {
+				scope.AWSMachine.Status.InstanceType = instanceType
+				scope.AWSMachine.Status.SubnetID = input.SubnetID
+				scope.AWSMachine.Status.ImageID = input.ImageID
+				// The instance type and image were picked by the launch template.
+				if instanceType == "" {
+					scope.AWSMachine.Status.InstanceType = out.Type
+				}
+				if input.ImageID == "" {
+					scope.AWSMachine.Status.ImageID = out.ImageID
+				}
+				if input.LaunchTemplate != nil {
+					scope.AWSMachine.Status.LaunchTemplateVersion = aws.StringValue(input.LaunchTemplate.Version)
+				}
+				scope.AWSMachine.Status.TerminationProtection = input.TerminationProtection
+				return out, nil
+			}
+			if !awserrors.IsInsufficientCapacity(errors.Cause(err)) {
+				return nil, err
+			}
+
+			record.Warnf(scope.AWSMachine, "InsufficientCapacity", "Insufficient capacity for instance type %q in subnet %q", instanceType, input.SubnetID)
+			lastErr = err
+		}
+	}
+
+	return nil, lastErr
+}
+
+// fallbackInstanceTypeCompatible checks whether the image of the instance can be launched with a fallback instance type.
+func (s *Service) fallbackInstanceTypeCompatible(scope *scope.MachineScope, input *infrav1.Instance, instanceType, imageArchitecture string) (bool, error) {
+	architecture, err := s.pickArchitectureForInstanceType(instanceType)
+	if err != nil {
+		return false, err
+	}
+	if architecture != imageArchitecture {
+		record.Warnf(scope.AWSMachine, "IncompatibleFallbackInstanceType", "Skipping fallback instance type %q: it has the %q architecture, but image %q has %q", instanceType, architecture, input.ImageID, imageArchitecture)
+		return false, nil
+	}
+
+	if err := s.validateImage(input.ImageID, instanceType, imageArchitecture, input.RootVolume); err != nil {
+		var imageErr *ImageValidationError
+		if !errors.As(err, &imageErr) {
+			return false, err
+		}
+		record.Warnf(scope.AWSMachine, "IncompatibleFallbackInstanceType", "Skipping fallback instance type %q: %v", instanceType, err)
+		return false, nil
+	}
+
+	return true, nil
+}
+
 // findSubnet attempts to retrieve a subnet ID in the following order:
 // - subnetID specified in machine configuration,
 // - subnet based on filters in machine configuration
@@ -472,6 +674,7 @@ func (s *Service) GetCoreNodeSecurityGroups(scope scope.LaunchTemplateScope) ([]
 // Returns nil on success, error in all other cases.
 func (s *Service) TerminateInstance(instanceID string) error {
 	s.scope.Debug("Attempting to terminate instance", "instance-id", instanceID)
+	defer s.DescribeCache.invalidateInstance(instanceID)
 
 	input := &ec2.TerminateInstancesInput{
 		InstanceIds: aws.StringSlice([]string{instanceID}),
@@ -485,6 +688,44 @@ func (s *Service) TerminateInstance(instanceID string) error {
 	return nil
 }
 
+// StopInstance stops an EC2 instance without waiting for it to be stopped. The instance is hibernated instead,
+// keeping the content of its memory, when hibernate is true.
+func (s *Service) StopInstance(instanceID string, hibernate bool) error {
+	s.scope.Debug("Attempting to stop instance", "instance-id", instanceID, "hibernate", hibernate)
+	defer s.DescribeCache.invalidateInstance(instanceID)
+
+	input := &ec2.StopInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	}
+	if hibernate {
+		input.Hibernate = aws.Bool(true)
+	}
+
+	if _, err := s.EC2Client.StopInstancesWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to stop instance with id %q", instanceID)
+	}
+
+	s.scope.Debug("Stopped instance", "instance-id", instanceID)
+	return nil
+}
+
+// StartInstance starts a stopped EC2 instance without waiting for it to be running.
+func (s *Service) StartInstance(instanceID string) error {
+	s.scope.Debug("Attempting to start instance", "instance-id", instanceID)
+	defer s.DescribeCache.invalidateInstance(instanceID)
+
+	input := &ec2.StartInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	}
+
+	if _, err := s.EC2Client.StartInstancesWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to start instance with id %q", instanceID)
+	}
+
+	s.scope.Debug("Started instance", "instance-id", instanceID)
+	return nil
+}
+
 // TerminateInstanceAndWait terminates and waits
 // for an EC2 instance to terminate.
 func (s *Service) TerminateInstanceAndWait(instanceID string) error {
@@ -507,8 +748,6 @@ func (s *Service) TerminateInstanceAndWait(instanceID string) error {
 
 func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instance, error) {
 	input := &ec2.RunInstancesInput{
-		InstanceType: aws.String(i.Type),
-		ImageId:      aws.String(i.ImageID),
 		KeyName:      i.SSHKeyName,
 		EbsOptimized: i.EBSOptimized,
 		MaxCount:     aws.Int64(1),
@@ -516,9 +755,18 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		UserData:     i.UserData,
 	}
 
+	// The instance type and image are left to the launch template when they are not set.
+	if i.Type != "" {
+		input.InstanceType = aws.String(i.Type)
+	}
+	if i.ImageID != "" {
+		input.ImageId = aws.String(i.ImageID)
+	}
+
 	s.scope.Debug("userData size", "bytes", len(*i.UserData), "role", role)
 
-	if len(i.NetworkInterfaces) > 0 {
+	switch {
+	case len(i.NetworkInterfaces) > 0:
 		netInterfaces := make([]*ec2.InstanceNetworkInterfaceSpecification, 0, len(i.NetworkInterfaces))
 
 		for index, id := range i.NetworkInterfaces {
@@ -529,7 +777,21 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 
 		input.NetworkInterfaces = netInterfaces
-	} else {
+	case len(i.ManagedNetworkInterfaces) > 0 || i.NetworkInterfaceType != "":
+		// The subnet and security groups of the instance cannot be set alongside network interfaces,
+		// so the primary network interface has to be described as well.
+		primary := &ec2.InstanceNetworkInterfaceSpecification{
+			DeviceIndex: aws.Int64(0),
+			SubnetId:    aws.String(i.SubnetID),
+		}
+		if len(i.SecurityGroupIDs) > 0 {
+			primary.Groups = aws.StringSlice(i.SecurityGroupIDs)
+		}
+		if i.NetworkInterfaceType != "" {
+			primary.InterfaceType = aws.String(string(i.NetworkInterfaceType))
+		}
+		input.NetworkInterfaces = []*ec2.InstanceNetworkInterfaceSpecification{primary}
+	default:
 		input.SubnetId = aws.String(i.SubnetID)
 
 		if len(i.SecurityGroupIDs) > 0 {
@@ -537,6 +799,14 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 	}
 
+	if len(i.ManagedNetworkInterfaces) > 0 {
+		managed, err := s.getManagedNetworkInterfaceSpecifications(i.ManagedNetworkInterfaces, i.SubnetID, i.SecurityGroupIDs)
+		if err != nil {
+			return nil, err
+		}
+		input.NetworkInterfaces = append(input.NetworkInterfaces, managed...)
+	}
+
 	if i.IAMProfile != "" {
 		input.IamInstanceProfile = &ec2.IamInstanceProfileSpecification{
 			Name: aws.String(i.IAMProfile),
@@ -564,6 +834,13 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 
 		blockDeviceMapping := volumeToBlockDeviceMapping(&nonRootVolume)
+		if nonRootVolume.Snapshot != nil {
+			snapshotID, err := s.checkVolumeSnapshot(&nonRootVolume)
+			if err != nil {
+				return nil, err
+			}
+			blockDeviceMapping.Ebs.SnapshotId = snapshotID
+		}
 		blockdeviceMappings = append(blockdeviceMappings, blockDeviceMapping)
 	}
 
@@ -603,6 +880,59 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 			input.Placement = &ec2.Placement{}
 		}
 		input.Placement.GroupName = &i.PlacementGroupName
+		if i.PlacementGroupPartition != 0 {
+			input.Placement.PartitionNumber = aws.Int64(i.PlacementGroupPartition)
+		}
+	}
+
+	if i.HostID != nil || i.HostAffinity != nil || i.HostResourceGroupArn != nil {
+		if input.Placement == nil {
+			input.Placement = &ec2.Placement{}
+		}
+		input.Placement.HostId = i.HostID
+		input.Placement.Affinity = i.HostAffinity
+		input.Placement.HostResourceGroupArn = i.HostResourceGroupArn
+	}
+
+	if i.CPUOptions != nil {
+		input.CpuOptions = &ec2.CpuOptionsRequest{
+			CoreCount:      i.CPUOptions.CoreCount,
+			ThreadsPerCore: i.CPUOptions.ThreadsPerCore,
+		}
+	}
+
+	if i.NitroEnclaveEnabled {
+		input.EnclaveOptions = &ec2.EnclaveOptionsRequest{
+			Enabled: aws.Bool(true),
+		}
+	}
+
+	if i.HibernationEnabled {
+		input.HibernationOptions = &ec2.HibernationOptionsRequest{
+			Configured: aws.Bool(true),
+		}
+	}
+
+	if i.TerminationProtection {
+		input.DisableApiTermination = aws.Bool(true)
+		input.DisableApiStop = aws.Bool(true)
+	}
+
+	// Only the fields set on the machine are set above, so that they override the ones of the launch template.
+	if i.LaunchTemplate != nil {
+		input.LaunchTemplate = &ec2.LaunchTemplateSpecification{
+			LaunchTemplateId:   i.LaunchTemplate.ID,
+			LaunchTemplateName: i.LaunchTemplate.Name,
+			Version:            i.LaunchTemplate.Version,
+		}
+	}
+
+	if i.PrivateDNSName != nil {
+		input.PrivateDnsNameOptions = &ec2.PrivateDnsNameOptionsRequest{
+			EnableResourceNameDnsAAAARecord: i.PrivateDNSName.EnableResourceNameDNSAAAARecord,
+			EnableResourceNameDnsARecord:    i.PrivateDNSName.EnableResourceNameDNSARecord,
+			HostnameType:                    i.PrivateDNSName.HostnameType,
+		}
 	}
 
 	out, err := s.EC2Client.RunInstancesWithContext(context.TODO(), input)
@@ -670,6 +1000,7 @@ func (s *Service) GetInstanceSecurityGroups(instanceID string) (map[string][]str
 // EC2 instance.
 func (s *Service) UpdateInstanceSecurityGroups(instanceID string, ids []string) error {
 	s.scope.Debug("Attempting to update security groups on instance", "instance-id", instanceID)
+	defer s.DescribeCache.invalidateInstance(instanceID)
 
 	enis, err := s.getInstanceENIs(instanceID)
 	if err != nil {
@@ -693,6 +1024,7 @@ func (s *Service) UpdateInstanceSecurityGroups(instanceID string, ids []string)
 // receiving to avoid calling AWS if we don't need to.
 func (s *Service) UpdateResourceTags(resourceID *string, create, remove map[string]string) error {
 	s.scope.Debug("Attempting to update tags on resource", "resource-id", *resourceID)
+	defer s.DescribeCache.invalidateInstance(*resourceID)
 
 	// If we have anything to create or update
 	if len(create) > 0 {
@@ -736,6 +1068,10 @@ func (s *Service) UpdateResourceTags(resourceID *string, create, remove map[stri
 }
 
 func (s *Service) getInstanceENIs(instanceID string) ([]*ec2.NetworkInterface, error) {
+	if enis, ok := s.DescribeCache.instanceNetworkInterfaces(s.EC2Client, instanceID); ok {
+		return enis, nil
+	}
+
 	input := &ec2.DescribeNetworkInterfacesInput{
 		Filters: []*ec2.Filter{
 			{
@@ -745,11 +1081,13 @@ func (s *Service) getInstanceENIs(instanceID string) ([]*ec2.NetworkInterface, e
 		},
 	}
 
+	fetchedAt := time.Now()
 	output, err := s.EC2Client.DescribeNetworkInterfacesWithContext(context.TODO(), input)
 	if err != nil {
 		return nil, err
 	}
 
+	s.DescribeCache.storeInstanceNetworkInterfaces(instanceID, output.NetworkInterfaces, fetchedAt)
 	return output.NetworkInterfaces, nil
 }
 
@@ -805,16 +1143,17 @@ func (s *Service) getImageSnapshotSize(imageID string) (*int64, error) {
 // additional call to EC2 is required to get this value.
 func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 	i := &infrav1.Instance{
-		ID:           aws.StringValue(v.InstanceId),
-		State:        infrav1.InstanceState(*v.State.Name),
-		Type:         aws.StringValue(v.InstanceType),
-		SubnetID:     aws.StringValue(v.SubnetId),
-		ImageID:      aws.StringValue(v.ImageId),
-		SSHKeyName:   v.KeyName,
-		PrivateIP:    v.PrivateIpAddress,
-		PublicIP:     v.PublicIpAddress,
-		ENASupport:   v.EnaSupport,
-		EBSOptimized: v.EbsOptimized,
+		ID:             aws.StringValue(v.InstanceId),
+		State:          infrav1.InstanceState(*v.State.Name),
+		Type:           aws.StringValue(v.InstanceType),
+		SubnetID:       aws.StringValue(v.SubnetId),
+		ImageID:        aws.StringValue(v.ImageId),
+		SSHKeyName:     v.KeyName,
+		PrivateIP:      v.PrivateIpAddress,
+		PublicIP:       v.PublicIpAddress,
+		ENASupport:     v.EnaSupport,
+		EBSOptimized:   v.EbsOptimized,
+		RootDeviceName: aws.StringValue(v.RootDeviceName),
 	}
 
 	// Extract IAM Instance Profile name from ARN
@@ -838,6 +1177,35 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 	i.Addresses = s.getInstanceAddresses(v)
 
 	i.AvailabilityZone = aws.StringValue(v.Placement.AvailabilityZone)
+	i.HostID = v.Placement.HostId
+	i.HostAffinity = v.Placement.Affinity
+	i.HostResourceGroupArn = v.Placement.HostResourceGroupArn
+	i.PlacementGroupName = aws.StringValue(v.Placement.GroupName)
+	i.PlacementGroupPartition = aws.Int64Value(v.Placement.PartitionNumber)
+
+	if v.CpuOptions != nil {
+		i.CPUOptions = &infrav1.CPUOptions{
+			CoreCount:      v.CpuOptions.CoreCount,
+			ThreadsPerCore: v.CpuOptions.ThreadsPerCore,
+		}
+	}
+	if v.EnclaveOptions != nil {
+		i.NitroEnclaveEnabled = aws.BoolValue(v.EnclaveOptions.Enabled)
+	}
+	if v.HibernationOptions != nil {
+		i.HibernationEnabled = aws.BoolValue(v.HibernationOptions.Configured)
+	}
+	if v.PrivateDnsNameOptions != nil {
+		i.PrivateDNSName = &infrav1.PrivateDNSName{
+			EnableResourceNameDNSAAAARecord: v.PrivateDnsNameOptions.EnableResourceNameDnsAAAARecord,
+			EnableResourceNameDNSARecord:    v.PrivateDnsNameOptions.EnableResourceNameDnsARecord,
+			HostnameType:                    v.PrivateDnsNameOptions.HostnameType,
+		}
+	}
+
+	if len(v.NetworkInterfaces) > 0 {
+		i.AttachedNetworkInterfaces = sdkToNetworkInterfaceStatuses(v.NetworkInterfaces)
+	}
 
 	for _, volume := range v.BlockDeviceMappings {
 		i.VolumeIDs = append(i.VolumeIDs, *volume.Ebs.VolumeId)
@@ -890,6 +1258,26 @@ func (s *Service) getInstanceAddresses(instance *ec2.Instance) []clusterv1.Machi
 			addresses = append(addresses, publicDNSAddress, publicIPAddress)
 		}
 	}
+
+	// With resource-name hostnames, the instance's private DNS name is based on the instance ID and differs
+	// from the IP based DNS names of its network interfaces. The kubelet registers the node with the instance's
+	// private DNS name, so make sure it is part of the machine addresses.
+	if privateDNSName := aws.StringValue(instance.PrivateDnsName); privateDNSName != "" {
+		found := false
+		for _, address := range addresses {
+			if address.Type == clusterv1.MachineInternalDNS && address.Address == privateDNSName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			addresses = append(addresses, clusterv1.MachineAddress{
+				Type:    clusterv1.MachineInternalDNS,
+				Address: privateDNSName,
+			})
+		}
+	}
+
 	return addresses
 }
 
@@ -914,6 +1302,7 @@ func (s *Service) getNetworkInterfaceSecurityGroups(interfaceID string) ([]strin
 
 func (s *Service) attachSecurityGroupsToNetworkInterface(groups []string, interfaceID string) error {
 	s.scope.Info("Updating security groups", "groups", groups)
+	defer s.DescribeCache.invalidateNetworkInterface(interfaceID)
 
 	input := &ec2.ModifyNetworkInterfaceAttributeInput{
 		NetworkInterfaceId: aws.String(interfaceID),
@@ -929,6 +1318,8 @@ func (s *Service) attachSecurityGroupsToNetworkInterface(groups []string, interf
 // DetachSecurityGroupsFromNetworkInterface looks up an ENI by interfaceID and
 // detaches a list of Security Groups from that ENI.
 func (s *Service) DetachSecurityGroupsFromNetworkInterface(groups []string, interfaceID string) error {
+	defer s.DescribeCache.invalidateNetworkInterface(interfaceID)
+
 	existingGroups, err := s.getNetworkInterfaceSecurityGroups(interfaceID)
 	if err != nil {
 		return errors.Wrapf(err, "failed to look up network interface security groups")
@@ -972,6 +1363,8 @@ func (s *Service) checkRootVolume(rootVolume *infrav1.Volume, imageID string) (*
 
 // ModifyInstanceMetadataOptions modifies the metadata options of the given EC2 instance.
 func (s *Service) ModifyInstanceMetadataOptions(instanceID string, options *infrav1.InstanceMetadataOptions) error {
+	defer s.DescribeCache.invalidateInstance(instanceID)
+
 	input := &ec2.ModifyInstanceMetadataOptionsInput{
 		HttpEndpoint:            aws.String(string(options.HTTPEndpoint)),
 		HttpPutResponseHopLimit: aws.Int64(options.HTTPPutResponseHopLimit),
@@ -988,6 +1381,90 @@ func (s *Service) ModifyInstanceMetadataOptions(instanceID string, options *infr
 	return nil
 }
 
+// ModifyInstanceProtection enables or disables the termination and stop protection of the given EC2 instance.
+func (s *Service) ModifyInstanceProtection(instanceID string, enabled bool) error {
+	s.scope.Debug("Updating instance protection", "instance-id", instanceID, "enabled", enabled)
+	defer s.DescribeCache.invalidateInstance(instanceID)
+
+	// DisableApiTermination and DisableApiStop can only be modified one at a time.
+	if _, err := s.EC2Client.ModifyInstanceAttributeWithContext(context.TODO(), &ec2.ModifyInstanceAttributeInput{
+		InstanceId:            aws.String(instanceID),
+		DisableApiTermination: &ec2.AttributeBooleanValue{Value: aws.Bool(enabled)},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to modify termination protection of instance %q", instanceID)
+	}
+
+	if _, err := s.EC2Client.ModifyInstanceAttributeWithContext(context.TODO(), &ec2.ModifyInstanceAttributeInput{
+		InstanceId:     aws.String(instanceID),
+		DisableApiStop: &ec2.AttributeBooleanValue{Value: aws.Bool(enabled)},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to modify stop protection of instance %q", instanceID)
+	}
+
+	return nil
+}
+
+// ModifyInstanceStopProtection enables or disables the stop protection of the given EC2 instance, leaving its
+// termination protection as is.
+func (s *Service) ModifyInstanceStopProtection(instanceID string, enabled bool) error {
+	s.scope.Debug("Updating instance stop protection", "instance-id", instanceID, "enabled", enabled)
+	defer s.DescribeCache.invalidateInstance(instanceID)
+
+	if _, err := s.EC2Client.ModifyInstanceAttributeWithContext(context.TODO(), &ec2.ModifyInstanceAttributeInput{
+		InstanceId:     aws.String(instanceID),
+		DisableApiStop: &ec2.AttributeBooleanValue{Value: aws.Bool(enabled)},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to modify stop protection of instance %q", instanceID)
+	}
+
+	return nil
+}
+
+// ModifyInstanceIAMProfile associates the given IAM instance profile with the EC2 instance, replacing the
+// profile currently associated with it.
+func (s *Service) ModifyInstanceIAMProfile(instanceID string, profile string) error {
+	defer s.DescribeCache.invalidateInstance(instanceID)
+
+	out, err := s.EC2Client.DescribeIamInstanceProfileAssociationsWithContext(context.TODO(), &ec2.DescribeIamInstanceProfileAssociationsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("instance-id"),
+				Values: aws.StringSlice([]string{instanceID}),
+			},
+			{
+				Name:   aws.String("state"),
+				Values: aws.StringSlice([]string{ec2.IamInstanceProfileAssociationStateAssociated}),
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe IAM instance profile associations of instance %q", instanceID)
+	}
+
+	var associationID *string
+	if len(out.IamInstanceProfileAssociations) > 0 {
+		associationID = out.IamInstanceProfileAssociations[0].AssociationId
+	}
+
+	s.scope.Info("Updating IAM instance profile", "instance-id", instanceID, "profile", profile)
+	if associationID == nil {
+		_, err = s.EC2Client.AssociateIamInstanceProfileWithContext(context.TODO(), &ec2.AssociateIamInstanceProfileInput{
+			InstanceId:         aws.String(instanceID),
+			IamInstanceProfile: &ec2.IamInstanceProfileSpecification{Name: aws.String(profile)},
+		})
+	} else {
+		_, err = s.EC2Client.ReplaceIamInstanceProfileAssociationWithContext(context.TODO(), &ec2.ReplaceIamInstanceProfileAssociationInput{
+			AssociationId:      associationID,
+			IamInstanceProfile: &ec2.IamInstanceProfileSpecification{Name: aws.String(profile)},
+		})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to update IAM instance profile of instance %q", instanceID)
+	}
+
+	return nil
+}
+
 // filterGroups filters a list for a string.
 func filterGroups(list []string, strToFilter string) (newList []string) {
 	for _, item := range list {
@@ -42,6 +42,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestInstanceIfExists(t *testing.T) {
@@ -273,6 +274,332 @@ func TestTerminateInstance(t *testing.T) {
 	}
 }
 
+func TestModifyInstanceProtection(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name        string
+		enabled     bool
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name:    "should enable termination and stop protection",
+			enabled: true,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				gomock.InOrder(
+					m.ModifyInstanceAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifyInstanceAttributeInput{
+						InstanceId:            aws.String("i-exist"),
+						DisableApiTermination: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
+					})).Return(&ec2.ModifyInstanceAttributeOutput{}, nil),
+					m.ModifyInstanceAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifyInstanceAttributeInput{
+						InstanceId:     aws.String("i-exist"),
+						DisableApiStop: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
+					})).Return(&ec2.ModifyInstanceAttributeOutput{}, nil),
+				)
+			},
+		},
+		{
+			name:    "should disable termination and stop protection",
+			enabled: false,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				gomock.InOrder(
+					m.ModifyInstanceAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifyInstanceAttributeInput{
+						InstanceId:            aws.String("i-exist"),
+						DisableApiTermination: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+					})).Return(&ec2.ModifyInstanceAttributeOutput{}, nil),
+					m.ModifyInstanceAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifyInstanceAttributeInput{
+						InstanceId:     aws.String("i-exist"),
+						DisableApiStop: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+					})).Return(&ec2.ModifyInstanceAttributeOutput{}, nil),
+				)
+			},
+		},
+		{
+			name:    "should return an error when termination protection cannot be modified",
+			enabled: true,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.ModifyInstanceAttributeWithContext(context.TODO(), gomock.Any()).
+					Return(nil, errors.New("unauthorized"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.ModifyInstanceProtection("i-exist", tc.enabled)
+			if tc.expectError && err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestModifyInstanceStopProtection(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name        string
+		enabled     bool
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name:    "should only disable stop protection",
+			enabled: false,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.ModifyInstanceAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifyInstanceAttributeInput{
+					InstanceId:     aws.String("i-exist"),
+					DisableApiStop: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+				})).Return(&ec2.ModifyInstanceAttributeOutput{}, nil)
+			},
+		},
+		{
+			name:    "should return an error when stop protection cannot be modified",
+			enabled: true,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.ModifyInstanceAttributeWithContext(context.TODO(), gomock.Any()).
+					Return(nil, errors.New("unauthorized"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.ModifyInstanceStopProtection("i-exist", tc.enabled)
+			if tc.expectError && err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestModifyInstanceIAMProfile(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeInput := &ec2.DescribeIamInstanceProfileAssociationsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("instance-id"),
+				Values: aws.StringSlice([]string{"i-exist"}),
+			},
+			{
+				Name:   aws.String("state"),
+				Values: aws.StringSlice([]string{ec2.IamInstanceProfileAssociationStateAssociated}),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "should replace the associated IAM instance profile",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeIamInstanceProfileAssociationsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeIamInstanceProfileAssociationsOutput{
+						IamInstanceProfileAssociations: []*ec2.IamInstanceProfileAssociation{{AssociationId: aws.String("iip-assoc-1")}},
+					}, nil)
+				m.ReplaceIamInstanceProfileAssociationWithContext(context.TODO(), gomock.Eq(&ec2.ReplaceIamInstanceProfileAssociationInput{
+					AssociationId:      aws.String("iip-assoc-1"),
+					IamInstanceProfile: &ec2.IamInstanceProfileSpecification{Name: aws.String("nodes")},
+				})).Return(&ec2.ReplaceIamInstanceProfileAssociationOutput{}, nil)
+			},
+		},
+		{
+			name: "should associate the IAM instance profile when none is associated",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeIamInstanceProfileAssociationsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribeIamInstanceProfileAssociationsOutput{}, nil)
+				m.AssociateIamInstanceProfileWithContext(context.TODO(), gomock.Eq(&ec2.AssociateIamInstanceProfileInput{
+					InstanceId:         aws.String("i-exist"),
+					IamInstanceProfile: &ec2.IamInstanceProfileSpecification{Name: aws.String("nodes")},
+				})).Return(&ec2.AssociateIamInstanceProfileOutput{}, nil)
+			},
+		},
+		{
+			name: "should return an error when the associations cannot be described",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeIamInstanceProfileAssociationsWithContext(context.TODO(), gomock.Any()).
+					Return(nil, errors.New("unauthorized"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.ModifyInstanceIAMProfile("i-exist", "nodes")
+			if tc.expectError && err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestStopStartInstance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name        string
+		call        func(s *Service) error
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "should stop the instance",
+			call: func(s *Service) error { return s.StopInstance("i-exist", false) },
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.StopInstancesWithContext(context.TODO(), gomock.Eq(&ec2.StopInstancesInput{
+					InstanceIds: aws.StringSlice([]string{"i-exist"}),
+				})).Return(&ec2.StopInstancesOutput{}, nil)
+			},
+		},
+		{
+			name: "should hibernate the instance",
+			call: func(s *Service) error { return s.StopInstance("i-exist", true) },
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.StopInstancesWithContext(context.TODO(), gomock.Eq(&ec2.StopInstancesInput{
+					InstanceIds: aws.StringSlice([]string{"i-exist"}),
+					Hibernate:   aws.Bool(true),
+				})).Return(&ec2.StopInstancesOutput{}, nil)
+			},
+		},
+		{
+			name: "should return an error when the instance cannot be stopped",
+			call: func(s *Service) error { return s.StopInstance("i-exist", false) },
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.StopInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(nil, errors.New("OperationNotPermitted"))
+			},
+			expectError: true,
+		},
+		{
+			name: "should start the instance",
+			call: func(s *Service) error { return s.StartInstance("i-exist") },
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.StartInstancesWithContext(context.TODO(), gomock.Eq(&ec2.StartInstancesInput{
+					InstanceIds: aws.StringSlice([]string{"i-exist"}),
+				})).Return(&ec2.StartInstancesOutput{}, nil)
+			},
+		},
+		{
+			name: "should return an error when the instance cannot be started",
+			call: func(s *Service) error { return s.StartInstance("i-exist") },
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.StartInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(nil, errors.New("InsufficientInstanceCapacity"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = tc.call(s)
+			if tc.expectError && err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
 func TestCreateInstance(t *testing.T) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -3591,6 +3918,255 @@ func TestCreateInstance(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "with a launch template only sets the overrides of the machine",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.String("bootstrap-data"),
+					},
+					Version: pointer.String("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				LaunchTemplate: &infrav1.LaunchTemplateReference{
+					ID:      aws.String("lt-0123456789abcdef0"),
+					Version: aws.String("$Default"),
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+						LaunchTemplateId: aws.String("lt-0123456789abcdef0"),
+						Versions:         aws.StringSlice([]string{"$Default"}),
+					})).
+					Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+						LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+							{
+								LaunchTemplateId: aws.String("lt-0123456789abcdef0"),
+								VersionNumber:    aws.Int64(3),
+							},
+						},
+					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, input *ec2.RunInstancesInput, requestOptions ...request.Option) (*ec2.Reservation, error) {
+						expected := &ec2.RunInstancesInput{
+							LaunchTemplate: &ec2.LaunchTemplateSpecification{
+								LaunchTemplateId: aws.String("lt-0123456789abcdef0"),
+								Version:          aws.String("3"),
+							},
+							MaxCount:         aws.Int64(1),
+							MinCount:         aws.Int64(1),
+							SecurityGroupIds: aws.StringSlice([]string{"2", "3"}),
+							SubnetId:         aws.String("subnet-1"),
+							UserData:         aws.String(base64.StdEncoding.EncodeToString(data)),
+							TagSpecifications: []*ec2.TagSpecification{
+								{
+									ResourceType: aws.String(ec2.ResourceTypeInstance),
+									Tags: []*ec2.Tag{
+										{Key: aws.String("MachineName"), Value: aws.String("/")},
+										{Key: aws.String("Name"), Value: aws.String("aws-test1")},
+										{Key: aws.String("kubernetes.io/cluster/test1"), Value: aws.String("owned")},
+										{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"), Value: aws.String("owned")},
+										{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/role"), Value: aws.String("node")},
+									},
+								},
+							},
+						}
+						if !cmp.Equal(input, expected) {
+							t.Fatalf("unexpected RunInstances input: %s", cmp.Diff(expected, input))
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-1"),
+									ImageId:      aws.String("ami-golden"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with termination protection launches the instance protected",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.String("bootstrap-data"),
+					},
+					Version: pointer.String("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				LaunchTemplate: &infrav1.LaunchTemplateReference{
+					ID:      aws.String("lt-0123456789abcdef0"),
+					Version: aws.String("$Default"),
+				},
+				TerminationProtection: aws.Bool(true),
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+						LaunchTemplateId: aws.String("lt-0123456789abcdef0"),
+						Versions:         aws.StringSlice([]string{"$Default"}),
+					})).
+					Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+						LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+							{
+								LaunchTemplateId: aws.String("lt-0123456789abcdef0"),
+								VersionNumber:    aws.Int64(3),
+							},
+						},
+					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, input *ec2.RunInstancesInput, requestOptions ...request.Option) (*ec2.Reservation, error) {
+						expected := &ec2.RunInstancesInput{
+							LaunchTemplate: &ec2.LaunchTemplateSpecification{
+								LaunchTemplateId: aws.String("lt-0123456789abcdef0"),
+								Version:          aws.String("3"),
+							},
+							DisableApiStop:        aws.Bool(true),
+							DisableApiTermination: aws.Bool(true),
+							MaxCount:              aws.Int64(1),
+							MinCount:              aws.Int64(1),
+							SecurityGroupIds:      aws.StringSlice([]string{"2", "3"}),
+							SubnetId:              aws.String("subnet-1"),
+							UserData:              aws.String(base64.StdEncoding.EncodeToString(data)),
+							TagSpecifications: []*ec2.TagSpecification{
+								{
+									ResourceType: aws.String(ec2.ResourceTypeInstance),
+									Tags: []*ec2.Tag{
+										{Key: aws.String("MachineName"), Value: aws.String("/")},
+										{Key: aws.String("Name"), Value: aws.String("aws-test1")},
+										{Key: aws.String("kubernetes.io/cluster/test1"), Value: aws.String("owned")},
+										{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"), Value: aws.String("owned")},
+										{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/role"), Value: aws.String("node")},
+									},
+								},
+							},
+						}
+						if !cmp.Equal(input, expected) {
+							t.Fatalf("unexpected RunInstances input: %s", cmp.Diff(expected, input))
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-1"),
+									ImageId:      aws.String("ami-golden"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -3657,6 +4233,11 @@ func TestCreateInstance(t *testing.T) {
 			}
 			machineScope.AWSMachine.Spec = *tc.machineConfig
 			tc.expect(ec2Mock.EXPECT())
+			// The image is validated before launching the instance, which is covered by TestValidateImage.
+			ec2Mock.EXPECT().DescribeImagesWithContext(context.TODO(), gomock.Any()).
+				Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{State: aws.String(ec2.ImageStateAvailable)}}}, nil).AnyTimes()
+			ec2Mock.EXPECT().DescribeInstanceTypesWithContext(context.TODO(), gomock.Any()).
+				Return(&ec2.DescribeInstanceTypesOutput{}, nil).AnyTimes()
 
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
@@ -3908,3 +4489,322 @@ func TestGetFilteredSecurityGroupID(t *testing.T) {
 		})
 	}
 }
+
+func TestGetInstanceAddresses(t *testing.T) {
+	testCases := []struct {
+		name     string
+		instance *ec2.Instance
+		want     []clusterv1.MachineAddress
+	}{
+		{
+			name: "private and public addresses of the network interfaces",
+			instance: &ec2.Instance{
+				PrivateDnsName: aws.String("ip-10-0-0-1.ec2.internal"),
+				NetworkInterfaces: []*ec2.InstanceNetworkInterface{
+					{
+						PrivateDnsName:   aws.String("ip-10-0-0-1.ec2.internal"),
+						PrivateIpAddress: aws.String("10.0.0.1"),
+						Association: &ec2.InstanceNetworkInterfaceAssociation{
+							PublicDnsName: aws.String("ec2-1-2-3-4.compute-1.amazonaws.com"),
+							PublicIp:      aws.String("1.2.3.4"),
+						},
+					},
+				},
+			},
+			want: []clusterv1.MachineAddress{
+				{Type: clusterv1.MachineInternalDNS, Address: "ip-10-0-0-1.ec2.internal"},
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+				{Type: clusterv1.MachineExternalDNS, Address: "ec2-1-2-3-4.compute-1.amazonaws.com"},
+				{Type: clusterv1.MachineExternalIP, Address: "1.2.3.4"},
+			},
+		},
+		{
+			name: "resource name private DNS name of the instance is appended",
+			instance: &ec2.Instance{
+				PrivateDnsName: aws.String("i-1234567890abcdef0.ec2.internal"),
+				NetworkInterfaces: []*ec2.InstanceNetworkInterface{
+					{
+						PrivateDnsName:   aws.String("ip-10-0-0-1.ec2.internal"),
+						PrivateIpAddress: aws.String("10.0.0.1"),
+					},
+				},
+			},
+			want: []clusterv1.MachineAddress{
+				{Type: clusterv1.MachineInternalDNS, Address: "ip-10-0-0-1.ec2.internal"},
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+				{Type: clusterv1.MachineInternalDNS, Address: "i-1234567890abcdef0.ec2.internal"},
+			},
+		},
+		{
+			name: "empty private DNS name of the instance is not appended",
+			instance: &ec2.Instance{
+				NetworkInterfaces: []*ec2.InstanceNetworkInterface{
+					{
+						PrivateDnsName:   aws.String("ip-10-0-0-1.ec2.internal"),
+						PrivateIpAddress: aws.String("10.0.0.1"),
+					},
+				},
+			},
+			want: []clusterv1.MachineAddress{
+				{Type: clusterv1.MachineInternalDNS, Address: "ip-10-0-0-1.ec2.internal"},
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Service{}
+			addresses := s.getInstanceAddresses(tc.instance)
+			if !cmp.Equal(tc.want, addresses) {
+				t.Fatalf("addresses mismatch: %s", cmp.Diff(tc.want, addresses))
+			}
+		})
+	}
+}
+
+func TestRunInstanceWithFallback(t *testing.T) {
+	capacityErr := awserr.New(awserrors.InsufficientInstanceCapacity, "We currently do not have sufficient capacity", nil)
+	describeArchitecture := func(m *mocks.MockEC2APIMockRecorder, instanceType, architecture string) {
+		m.DescribeInstanceTypesWithContext(context.TODO(), &ec2.DescribeInstanceTypesInput{InstanceTypes: aws.StringSlice([]string{instanceType})}).
+			Return(&ec2.DescribeInstanceTypesOutput{
+				InstanceTypes: []*ec2.InstanceTypeInfo{
+					{ProcessorInfo: &ec2.ProcessorInfo{SupportedArchitectures: aws.StringSlice([]string{architecture})}},
+				},
+			}, nil)
+	}
+	validateImage := func(m *mocks.MockEC2APIMockRecorder, instanceType string, enaSupport bool) {
+		m.DescribeImagesWithContext(context.TODO(), &ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"ami-1"})}).
+			Return(&ec2.DescribeImagesOutput{
+				Images: []*ec2.Image{
+					{ImageId: aws.String("ami-1"), State: aws.String(ec2.ImageStateAvailable), Architecture: aws.String("x86_64"), EnaSupport: aws.Bool(enaSupport)},
+				},
+			}, nil)
+		m.DescribeInstanceTypesWithContext(context.TODO(), &ec2.DescribeInstanceTypesInput{InstanceTypes: aws.StringSlice([]string{instanceType})}).
+			Return(&ec2.DescribeInstanceTypesOutput{
+				InstanceTypes: []*ec2.InstanceTypeInfo{
+					{NetworkInfo: &ec2.NetworkInfo{EnaSupport: aws.String(ec2.EnaSupportRequired)}},
+				},
+			}, nil)
+	}
+	runInstance := func(m *mocks.MockEC2APIMockRecorder, instanceType, subnetID string, err error) {
+		call := m.RunInstancesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+			DoAndReturn(func(_ context.Context, input *ec2.RunInstancesInput, _ ...request.Option) (*ec2.Reservation, error) {
+				if aws.StringValue(input.InstanceType) != instanceType || aws.StringValue(input.SubnetId) != subnetID {
+					return nil, errors.Errorf("unexpected launch of %q in %q", aws.StringValue(input.InstanceType), aws.StringValue(input.SubnetId))
+				}
+				if err != nil {
+					return nil, err
+				}
+				return &ec2.Reservation{
+					Instances: []*ec2.Instance{
+						{
+							InstanceId:   aws.String("i-1"),
+							InstanceType: input.InstanceType,
+							SubnetId:     input.SubnetId,
+							State:        &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNamePending)},
+							Placement:    &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")},
+						},
+					},
+				}, nil
+			})
+		call.Times(1)
+	}
+
+	testCases := []struct {
+		name          string
+		spec          infrav1.AWSMachineSpec
+		status        infrav1.AWSMachineStatus
+		failureDomain *string
+		expect        func(m *mocks.MockEC2APIMockRecorder)
+		wantErr       bool
+		wantStatus    infrav1.AWSMachineStatus
+		wantSkipped   string
+	}{
+		{
+			name: "Should record the instance type and subnet when there is capacity",
+			spec: infrav1.AWSMachineSpec{
+				InstanceType:          "m5.large",
+				FallbackInstanceTypes: []string{"m5a.large"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				runInstance(m, "m5.large", "subnet-1", nil)
+			},
+			wantStatus: infrav1.AWSMachineStatus{InstanceType: "m5.large", SubnetID: "subnet-1", ImageID: "ami-1"},
+		},
+		{
+			name: "Should try the next instance type on insufficient capacity",
+			spec: infrav1.AWSMachineSpec{
+				InstanceType:          "m5.large",
+				FallbackInstanceTypes: []string{"m6g.large", "m5a.large"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				runInstance(m, "m5.large", "subnet-1", capacityErr)
+				describeArchitecture(m, "m6g.large", "arm64")
+				describeArchitecture(m, "m5a.large", "x86_64")
+				validateImage(m, "m5a.large", true)
+				runInstance(m, "m5a.large", "subnet-1", nil)
+			},
+			wantStatus:  infrav1.AWSMachineStatus{InstanceType: "m5a.large", SubnetID: "subnet-1", ImageID: "ami-1"},
+			wantSkipped: "m6g.large",
+		},
+		{
+			name: "Should skip fallback instance types the image cannot be launched with",
+			spec: infrav1.AWSMachineSpec{
+				InstanceType:          "m5.large",
+				FallbackInstanceTypes: []string{"m6i.large", "m5a.large"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				runInstance(m, "m5.large", "subnet-1", capacityErr)
+				describeArchitecture(m, "m6i.large", "x86_64")
+				validateImage(m, "m6i.large", false)
+				describeArchitecture(m, "m5a.large", "x86_64")
+				validateImage(m, "m5a.large", true)
+				runInstance(m, "m5a.large", "subnet-1", nil)
+			},
+			wantStatus:  infrav1.AWSMachineStatus{InstanceType: "m5a.large", SubnetID: "subnet-1", ImageID: "ami-1"},
+			wantSkipped: "m6i.large",
+		},
+		{
+			name: "Should try the fallback subnets once all instance types are out of capacity",
+			spec: infrav1.AWSMachineSpec{
+				InstanceType:          "m5.large",
+				FallbackInstanceTypes: []string{"m5a.large"},
+				FallbackSubnets:       []infrav1.AWSResourceReference{{ID: aws.String("subnet-2")}},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				runInstance(m, "m5.large", "subnet-1", capacityErr)
+				describeArchitecture(m, "m5a.large", "x86_64")
+				validateImage(m, "m5a.large", true)
+				runInstance(m, "m5a.large", "subnet-1", capacityErr)
+				runInstance(m, "m5.large", "subnet-2", nil)
+			},
+			wantStatus: infrav1.AWSMachineStatus{InstanceType: "m5.large", SubnetID: "subnet-2", ImageID: "ami-1"},
+		},
+		{
+			name: "Should skip the fallback subnets outside of the failure domain of the machine",
+			spec: infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+				FallbackSubnets: []infrav1.AWSResourceReference{
+					{ID: aws.String("subnet-2")},
+					{ID: aws.String("subnet-3")},
+				},
+			},
+			failureDomain: aws.String("us-east-1a"),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				runInstance(m, "m5.large", "subnet-1", capacityErr)
+				m.DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+					Filters: []*ec2.Filter{{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"subnet-2"})}},
+				}).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-2"), AvailabilityZone: aws.String("us-east-1b")}},
+				}, nil)
+				m.DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+					Filters: []*ec2.Filter{{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"subnet-3"})}},
+				}).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-3"), AvailabilityZone: aws.String("us-east-1a")}},
+				}, nil)
+				runInstance(m, "m5.large", "subnet-3", nil)
+			},
+			wantStatus: infrav1.AWSMachineStatus{InstanceType: "m5.large", SubnetID: "subnet-3", ImageID: "ami-1"},
+		},
+		{
+			name: "Should not fall back on other errors",
+			spec: infrav1.AWSMachineSpec{
+				InstanceType:          "m5.large",
+				FallbackInstanceTypes: []string{"m5a.large"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				runInstance(m, "m5.large", "subnet-1", awserr.New(awserrors.UnauthorizedOperation, "", nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not fall back once an instance type has been recorded",
+			spec: infrav1.AWSMachineSpec{
+				InstanceType:          "m5.large",
+				FallbackInstanceTypes: []string{"m5a.large"},
+			},
+			status: infrav1.AWSMachineStatus{InstanceType: "m5a.large", SubnetID: "subnet-1"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				runInstance(m, "m5a.large", "subnet-1", capacityErr)
+			},
+			wantErr:    true,
+			wantStatus: infrav1.AWSMachineStatus{InstanceType: "m5a.large", SubnetID: "subnet-1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			if err != nil {
+				t.Fatalf("failed to create scheme: %v", err)
+			}
+			cluster := newCluster()
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: "default"},
+				Spec:       clusterv1.MachineSpec{FailureDomain: tc.failureDomain},
+			}
+			awsMachine := &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-machine", Namespace: "default"},
+				Spec:       tc.spec,
+				Status:     tc.status,
+			}
+
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, machine).Build()
+			clusterScope, err := setupClusterScope(client)
+			if err != nil {
+				t.Fatalf("failed to create test context: %v", err)
+			}
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       client,
+				Cluster:      cluster,
+				Machine:      machine,
+				AWSMachine:   awsMachine,
+				InfraCluster: clusterScope,
+			})
+			if err != nil {
+				t.Fatalf("failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			input := &infrav1.Instance{
+				Type:     tc.spec.InstanceType,
+				ImageID:  "ami-1",
+				SubnetID: "subnet-1",
+				UserData: aws.String(""),
+			}
+			if tc.status.InstanceType != "" {
+				input.Type = tc.status.InstanceType
+			}
+
+			_, err = s.runInstanceWithFallback(machineScope, input, "x86_64")
+			if tc.wantErr && err == nil {
+				t.Fatal("expected error but got no error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+			if tc.wantSkipped != "" {
+				condition := conditions.Get(awsMachine, infrav1.FallbackInstanceTypesCompatibleCondition)
+				if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != infrav1.FallbackInstanceTypesIncompatibleReason {
+					t.Fatalf("expected the %s condition to be false, got %v", infrav1.FallbackInstanceTypesCompatibleCondition, condition)
+				}
+				if !strings.HasSuffix(condition.Message, tc.wantSkipped) {
+					t.Fatalf("expected the %s condition to report %q, got %q", infrav1.FallbackInstanceTypesCompatibleCondition, tc.wantSkipped, condition.Message)
+				}
+			} else if conditions.Has(awsMachine, infrav1.FallbackInstanceTypesCompatibleCondition) {
+				t.Fatalf("unexpected %s condition", infrav1.FallbackInstanceTypesCompatibleCondition)
+			}
+			awsMachine.Status.Conditions = nil
+			if !cmp.Equal(awsMachine.Status, tc.wantStatus) {
+				t.Fatalf("status mismatch: %s", cmp.Diff(tc.wantStatus, awsMachine.Status))
+			}
+		})
+	}
+}
@@ -49,6 +49,9 @@ const (
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	TagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws-last-applied-tags"
+
+	// launchTemplateDefaultVersion is the version of a launch template marked as the default one.
+	launchTemplateDefaultVersion = "$Default"
 )
 
 func (s *Service) ReconcileLaunchTemplate(
@@ -79,6 +82,10 @@ func (s *Service) ReconcileLaunchTemplate(
 
 	if launchTemplate == nil {
 		scope.Info("no existing launch template found, creating")
+		if err := ec2svc.validateLaunchTemplateImage(scope, *imageID); err != nil {
+			conditions.MarkFalse(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, ImageValidationReason(err, expinfrav1.LaunchTemplateCreateFailedReason), clusterv1.ConditionSeverityError, err.Error())
+			return err
+		}
 		launchTemplateID, err := ec2svc.CreateLaunchTemplate(scope, imageID, bootstrapData)
 		if err != nil {
 			conditions.MarkFalse(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateCreateFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -86,6 +93,7 @@ func (s *Service) ReconcileLaunchTemplate(
 		}
 
 		scope.SetLaunchTemplateIDStatus(launchTemplateID)
+		scope.SetLaunchTemplateImageIDStatus(*imageID)
 		return scope.PatchObject()
 	}
 
@@ -139,6 +147,10 @@ func (s *Service) ReconcileLaunchTemplate(
 	// userdata, OR we've discovered a new AMI ID.
 	if needsUpdate || tagsChanged || *imageID != *launchTemplate.AMI.ID || launchTemplateUserDataHash != bootstrapDataHash {
 		scope.Info("creating new version for launch template", "existing", launchTemplate, "incoming", scope.GetLaunchTemplate())
+		if err := ec2svc.validateLaunchTemplateImage(scope, *imageID); err != nil {
+			conditions.MarkFalse(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, ImageValidationReason(err, expinfrav1.LaunchTemplateCreateFailedReason), clusterv1.ConditionSeverityError, err.Error())
+			return err
+		}
 		// There is a limit to the number of Launch Template Versions.
 		// We ensure that the number of versions does not grow without bound by following a simple rule: Before we create a new version, we delete one old version, if there is at least one old version that is not in use.
 		if err := ec2svc.PruneLaunchTemplateVersions(scope.GetLaunchTemplateIDStatus()); err != nil {
@@ -157,6 +169,7 @@ func (s *Service) ReconcileLaunchTemplate(
 			return err
 		}
 	}
+	scope.SetLaunchTemplateImageIDStatus(*imageID)
 
 	if needsUpdate || tagsChanged || *imageID != *launchTemplate.AMI.ID {
 		if err := runPostLaunchTemplateUpdateOperation(); err != nil {
@@ -493,11 +506,49 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 	}
 	data.SecurityGroupIds = append(data.SecurityGroupIds, aws.StringSlice(securityGroupIDs)...)
 
+	if len(lt.ManagedNetworkInterfaces) > 0 || lt.NetworkInterfaceType != "" {
+		data.NetworkInterfaces, err = s.getLaunchTemplateNetworkInterfaceRequests(lt.NetworkInterfaceType, lt.ManagedNetworkInterfaces, aws.StringValueSlice(data.SecurityGroupIds))
+		if err != nil {
+			return nil, err
+		}
+		// Security groups cannot be set on both the launch template and its network interfaces.
+		data.SecurityGroupIds = nil
+	}
+
 	// set the AMI ID
 	data.ImageId = imageID
 
 	data.InstanceMarketOptions = getLaunchTemplateInstanceMarketOptionsRequest(scope.GetLaunchTemplate().SpotMarketOptions)
 
+	data.Placement = getLaunchTemplatePlacementRequest(lt)
+
+	if lt.CPUOptions != nil {
+		data.CpuOptions = &ec2.LaunchTemplateCpuOptionsRequest{
+			CoreCount:      lt.CPUOptions.CoreCount,
+			ThreadsPerCore: lt.CPUOptions.ThreadsPerCore,
+		}
+	}
+
+	if lt.NitroEnclaveEnabled {
+		data.EnclaveOptions = &ec2.LaunchTemplateEnclaveOptionsRequest{
+			Enabled: aws.Bool(true),
+		}
+	}
+
+	if lt.HibernationEnabled {
+		data.HibernationOptions = &ec2.LaunchTemplateHibernationOptionsRequest{
+			Configured: aws.Bool(true),
+		}
+	}
+
+	if lt.PrivateDNSName != nil {
+		data.PrivateDnsNameOptions = &ec2.LaunchTemplatePrivateDnsNameOptionsRequest{
+			EnableResourceNameDnsAAAARecord: lt.PrivateDNSName.EnableResourceNameDNSAAAARecord,
+			EnableResourceNameDnsARecord:    lt.PrivateDNSName.EnableResourceNameDNSARecord,
+			HostnameType:                    lt.PrivateDNSName.HostnameType,
+		}
+	}
+
 	// Set up root volume
 	if lt.RootVolume != nil {
 		rootDeviceName, err := s.checkRootVolume(lt.RootVolume, *data.ImageId)
@@ -513,6 +564,24 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 		}
 	}
 
+	for i := range lt.NonRootVolumes {
+		nonRootVolume := lt.NonRootVolumes[i]
+
+		if nonRootVolume.DeviceName == "" {
+			return nil, errors.Errorf("non root volume should have device name specified")
+		}
+
+		req := volumeToLaunchTemplateBlockDeviceMappingRequest(&nonRootVolume)
+		if nonRootVolume.Snapshot != nil {
+			snapshotID, err := s.checkVolumeSnapshot(&nonRootVolume)
+			if err != nil {
+				return nil, err
+			}
+			req.Ebs.SnapshotId = snapshotID
+		}
+		data.BlockDeviceMappings = append(data.BlockDeviceMappings, req)
+	}
+
 	data.TagSpecifications = s.buildLaunchTemplateTagSpecificationRequest(scope)
 
 	return data, nil
@@ -618,6 +687,54 @@ func (s *Service) GetLaunchTemplateLatestVersion(id string) (string, error) {
 	return strconv.Itoa(int(*out.LaunchTemplateVersions[0].VersionNumber)), nil
 }
 
+// ResolveLaunchTemplateVersion returns the number of the version a reference to an existing launch template resolves to.
+func (s *Service) ResolveLaunchTemplateVersion(ref *infrav1.LaunchTemplateReference) (string, error) {
+	resolved, err := s.ResolveLaunchTemplate(ref)
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(resolved.Version), nil
+}
+
+// ResolveLaunchTemplate returns the ID of the launch template a reference to an existing launch template points
+// to, along with the number of the version it resolves to.
+func (s *Service) ResolveLaunchTemplate(ref *infrav1.LaunchTemplateReference) (*infrav1.LaunchTemplateReference, error) {
+	version := launchTemplateDefaultVersion
+	if ref.Version != nil {
+		version = *ref.Version
+	}
+
+	input := &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId:   ref.ID,
+		LaunchTemplateName: ref.Name,
+		Versions:           aws.StringSlice([]string{version}),
+	}
+
+	out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(context.TODO(), input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe version %q of launch template %q", version, launchTemplateReferenceName(ref))
+	}
+
+	if out == nil || len(out.LaunchTemplateVersions) == 0 {
+		return nil, errors.Errorf("version %q of launch template %q not found", version, launchTemplateReferenceName(ref))
+	}
+
+	launchTemplateVersion := out.LaunchTemplateVersions[0]
+	return &infrav1.LaunchTemplateReference{
+		ID:      launchTemplateVersion.LaunchTemplateId,
+		Version: aws.String(strconv.FormatInt(aws.Int64Value(launchTemplateVersion.VersionNumber), 10)),
+	}, nil
+}
+
+// launchTemplateReferenceName returns the ID or name of a referenced launch template.
+func launchTemplateReferenceName(ref *infrav1.LaunchTemplateReference) string {
+	if ref.ID != nil {
+		return *ref.ID
+	}
+	return aws.StringValue(ref.Name)
+}
+
 func (s *Service) deleteLaunchTemplateVersion(id string, version *int64) error {
 	s.scope.Debug("Deleting launch template version", "id", id)
 
@@ -668,10 +785,60 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		}
 	}
 
+	if v.Placement != nil {
+		i.Tenancy = aws.StringValue(v.Placement.Tenancy)
+		i.HostID = v.Placement.HostId
+		i.HostAffinity = v.Placement.Affinity
+		i.HostResourceGroupArn = v.Placement.HostResourceGroupArn
+		i.PlacementGroupName = aws.StringValue(v.Placement.GroupName)
+		i.PlacementGroupPartition = aws.Int64Value(v.Placement.PartitionNumber)
+	}
+
+	if v.CpuOptions != nil {
+		i.CPUOptions = &infrav1.CPUOptions{
+			CoreCount:      v.CpuOptions.CoreCount,
+			ThreadsPerCore: v.CpuOptions.ThreadsPerCore,
+		}
+	}
+	if v.EnclaveOptions != nil {
+		i.NitroEnclaveEnabled = aws.BoolValue(v.EnclaveOptions.Enabled)
+	}
+	if v.HibernationOptions != nil {
+		i.HibernationEnabled = aws.BoolValue(v.HibernationOptions.Configured)
+	}
+	if v.PrivateDnsNameOptions != nil {
+		i.PrivateDNSName = &infrav1.PrivateDNSName{
+			EnableResourceNameDNSAAAARecord: v.PrivateDnsNameOptions.EnableResourceNameDnsAAAARecord,
+			EnableResourceNameDNSARecord:    v.PrivateDnsNameOptions.EnableResourceNameDnsARecord,
+			HostnameType:                    v.PrivateDnsNameOptions.HostnameType,
+		}
+	}
+
 	if v.IamInstanceProfile != nil {
 		i.IamInstanceProfile = aws.StringValue(v.IamInstanceProfile.Name)
 	}
 
+	// The root volume cannot be told apart from the other volumes without describing the image,
+	// so the volumes of all the block device mappings are listed.
+	for _, mapping := range v.BlockDeviceMappings {
+		if mapping.Ebs == nil {
+			continue
+		}
+		volume := infrav1.Volume{
+			DeviceName:    aws.StringValue(mapping.DeviceName),
+			Size:          aws.Int64Value(mapping.Ebs.VolumeSize),
+			Type:          infrav1.VolumeType(aws.StringValue(mapping.Ebs.VolumeType)),
+			IOPS:          aws.Int64Value(mapping.Ebs.Iops),
+			Throughput:    mapping.Ebs.Throughput,
+			Encrypted:     mapping.Ebs.Encrypted,
+			EncryptionKey: aws.StringValue(mapping.Ebs.KmsKeyId),
+		}
+		if mapping.Ebs.SnapshotId != nil {
+			volume.Snapshot = &infrav1.AWSResourceReference{ID: mapping.Ebs.SnapshotId}
+		}
+		i.NonRootVolumes = append(i.NonRootVolumes, volume)
+	}
+
 	// Extract IAM Instance Profile name from ARN
 	if v.IamInstanceProfile != nil && v.IamInstanceProfile.Arn != nil {
 		split := strings.Split(aws.StringValue(v.IamInstanceProfile.Arn), "instance-profile/")
@@ -680,12 +847,23 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		}
 	}
 
-	for _, id := range v.SecurityGroupIds {
+	securityGroupIDs := v.SecurityGroupIds
+	for _, ni := range v.NetworkInterfaces {
+		// With managed network interfaces, the security groups of the instances are set on the primary one.
+		if aws.Int64Value(ni.DeviceIndex) == 0 && len(securityGroupIDs) == 0 {
+			securityGroupIDs = ni.Groups
+		}
+		if aws.Int64Value(ni.DeviceIndex) == 0 {
+			i.NetworkInterfaceType = infrav1.NetworkInterfaceType(aws.StringValue(ni.InterfaceType))
+		}
+	}
+	for _, id := range securityGroupIDs {
 		// FIXME(dlipovetsky): This will include the core security groups as well, making the
 		// "Additional" a bit dishonest. However, including the core groups drastically simplifies
 		// comparison with the incoming security groups.
 		i.AdditionalSecurityGroups = append(i.AdditionalSecurityGroups, infrav1.AWSResourceReference{ID: id})
 	}
+	i.ManagedNetworkInterfaces = sdkToManagedNetworkInterfaces(v.NetworkInterfaces)
 
 	if v.UserData == nil {
 		return i, userdata.ComputeHash(nil), nil
@@ -714,6 +892,37 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, inc
 		return true, nil
 	}
 
+	if incoming.Tenancy != existing.Tenancy ||
+		aws.StringValue(incoming.HostID) != aws.StringValue(existing.HostID) ||
+		aws.StringValue(incoming.HostAffinity) != aws.StringValue(existing.HostAffinity) ||
+		aws.StringValue(incoming.HostResourceGroupArn) != aws.StringValue(existing.HostResourceGroupArn) {
+		return true, nil
+	}
+
+	if incoming.PlacementGroupName != existing.PlacementGroupName || incoming.PlacementGroupPartition != existing.PlacementGroupPartition {
+		return true, nil
+	}
+
+	if !cmp.Equal(incoming.CPUOptions, existing.CPUOptions) {
+		return true, nil
+	}
+
+	if incoming.NitroEnclaveEnabled != existing.NitroEnclaveEnabled || incoming.HibernationEnabled != existing.HibernationEnabled {
+		return true, nil
+	}
+
+	if !privateDNSNameEqual(incoming.PrivateDNSName, existing.PrivateDNSName) {
+		return true, nil
+	}
+
+	volumesChanged, err := s.nonRootVolumesChanged(incoming, existing)
+	if err != nil {
+		return false, err
+	}
+	if volumesChanged {
+		return true, nil
+	}
+
 	incomingIDs, err := s.GetAdditionalSecurityGroupsIDs(incoming.AdditionalSecurityGroups)
 	if err != nil {
 		return false, err
@@ -736,6 +945,25 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, inc
 		return true, nil
 	}
 
+	if incoming.NetworkInterfaceType != existing.NetworkInterfaceType {
+		return true, nil
+	}
+
+	if len(incoming.ManagedNetworkInterfaces) > 0 || len(existing.ManagedNetworkInterfaces) > 0 {
+		// Compare the network interfaces once defaulted and resolved, as the existing ones only reference IDs.
+		incomingInterfaces, err := s.getLaunchTemplateNetworkInterfaceRequests(incoming.NetworkInterfaceType, incoming.ManagedNetworkInterfaces, incomingIDs)
+		if err != nil {
+			return false, err
+		}
+		existingInterfaces, err := s.getLaunchTemplateNetworkInterfaceRequests(existing.NetworkInterfaceType, existing.ManagedNetworkInterfaces, existingIDs)
+		if err != nil {
+			return false, err
+		}
+		if !cmp.Equal(incomingInterfaces, existingInterfaces) {
+			return true, nil
+		}
+	}
+
 	return false, nil
 }
 
@@ -748,14 +976,13 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 	}
 
 	templateVersion := scope.GetMachinePool().Spec.Template.Spec.Version
-	if templateVersion == nil {
+	if templateVersion == nil && lt.AMI.SSMParameter == nil {
 		err := errors.New("Either AWSMachinePool's spec.awslaunchtemplate.ami.id or MachinePool's spec.template.spec.version must be defined")
 		s.scope.Error(err, "")
 		return nil, err
 	}
 
 	var lookupAMI string
-	var err error
 
 	imageLookupFormat := lt.ImageLookupFormat
 	if imageLookupFormat == "" {
@@ -772,21 +999,17 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 		imageLookupBaseOS = scope.GetEC2Scope().ImageLookupBaseOS()
 	}
 
-	instanceType := lt.InstanceType
-
-	// If instance type is not specified on a launch template, we can safely assume the instance type will be a `t3.medium`.
-	// As specified in the AWS docs https://docs.aws.amazon.com/eks/latest/userguide/launch-templates.html.
-	// We will set the default architecture to `x86_64` as a result.
-	imageArchitecture := Amd64ArchitectureTag
+	imageArchitecture, err := s.launchTemplateArchitecture(lt)
+	if err != nil {
+		return nil, err
+	}
 
-	if instanceType != "" {
-		imageArchitecture, err = s.pickArchitectureForInstanceType(instanceType)
+	if lt.AMI.SSMParameter != nil {
+		lookupAMI, err = s.ssmParameterAMILookup(*lt.AMI.SSMParameter, aws.StringValue(templateVersion), imageArchitecture)
 		if err != nil {
 			return nil, err
 		}
-	}
-
-	if scope.IsEKSManaged() && imageLookupFormat == "" && imageLookupOrg == "" && imageLookupBaseOS == "" {
+	} else if scope.IsEKSManaged() && imageLookupFormat == "" && imageLookupOrg == "" && imageLookupBaseOS == "" {
 		lookupAMI, err = s.eksAMILookup(
 			*templateVersion,
 			imageArchitecture,
@@ -811,6 +1034,34 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 	return aws.String(lookupAMI), nil
 }
 
+// launchTemplateArchitecture returns the architecture of the images of a launch template.
+func (s *Service) launchTemplateArchitecture(lt *expinfrav1.AWSLaunchTemplate) (string, error) {
+	if lt.InstanceRequirements != nil {
+		return instanceRequirementsArchitecture(lt.InstanceRequirements), nil
+	}
+
+	// If instance type is not specified on a launch template, we can safely assume the instance type will be a `t3.medium`.
+	// As specified in the AWS docs https://docs.aws.amazon.com/eks/latest/userguide/launch-templates.html.
+	// We will set the default architecture to `x86_64` as a result.
+	if lt.InstanceType == "" {
+		return Amd64ArchitectureTag, nil
+	}
+
+	return s.pickArchitectureForInstanceType(lt.InstanceType)
+}
+
+// validateLaunchTemplateImage checks that the image can be used with the instance type and root volume of the launch template.
+func (s *Service) validateLaunchTemplateImage(scope scope.LaunchTemplateScope, imageID string) error {
+	lt := scope.GetLaunchTemplate()
+
+	architecture, err := s.launchTemplateArchitecture(lt)
+	if err != nil {
+		return err
+	}
+
+	return s.validateImage(imageID, lt.InstanceType, architecture, lt.RootVolume)
+}
+
 func (s *Service) GetAdditionalSecurityGroupsIDs(securityGroups []infrav1.AWSResourceReference) ([]string, error) {
 	var additionalSecurityGroupsIDs []string
 
@@ -879,6 +1130,10 @@ func (s *Service) getFilteredSecurityGroupIDs(securityGroup infrav1.AWSResourceR
 		filters = append(filters, &ec2.Filter{Name: aws.String(f.Name), Values: aws.StringSlice(f.Values)})
 	}
 
+	if ids, ok := s.DescribeCache.securityGroupIDs(s.EC2Client, filters); ok {
+		return ids, nil
+	}
+
 	sgs, err := s.EC2Client.DescribeSecurityGroupsWithContext(context.TODO(), &ec2.DescribeSecurityGroupsInput{Filters: filters})
 	if err != nil {
 		return nil, err
@@ -891,6 +1146,90 @@ func (s *Service) getFilteredSecurityGroupIDs(securityGroup infrav1.AWSResourceR
 	return ids, nil
 }
 
+// nonRootVolumesChanged checks whether the non-root volumes of the existing launch template differ from the incoming
+// ones, once their snapshots are resolved. The existing launch template lists its root volume as well, if any.
+func (s *Service) nonRootVolumesChanged(incoming, existing *expinfrav1.AWSLaunchTemplate) (bool, error) {
+	existingVolumes := make(map[string]infrav1.Volume, len(existing.NonRootVolumes))
+	for _, volume := range existing.NonRootVolumes {
+		existingVolumes[volume.DeviceName] = volume
+	}
+
+	for i := range incoming.NonRootVolumes {
+		volume := incoming.NonRootVolumes[i]
+		existingVolume, ok := existingVolumes[volume.DeviceName]
+		if !ok {
+			return true, nil
+		}
+		delete(existingVolumes, volume.DeviceName)
+
+		var snapshotID string
+		if volume.Snapshot != nil {
+			snapshot, err := s.getVolumeSnapshot(volume.Snapshot)
+			if err != nil {
+				return false, errors.Wrapf(err, "failed to get snapshot of volume %q", volume.DeviceName)
+			}
+			snapshotID = aws.StringValue(snapshot.SnapshotId)
+		}
+		var existingSnapshotID string
+		if existingVolume.Snapshot != nil {
+			existingSnapshotID = aws.StringValue(existingVolume.Snapshot.ID)
+		}
+
+		if volume.Size != existingVolume.Size ||
+			(volume.Type != "" && volume.Type != existingVolume.Type) ||
+			volume.IOPS != existingVolume.IOPS ||
+			aws.Int64Value(volume.Throughput) != aws.Int64Value(existingVolume.Throughput) ||
+			(aws.BoolValue(volume.Encrypted) || volume.EncryptionKey != "") != aws.BoolValue(existingVolume.Encrypted) ||
+			volume.EncryptionKey != existingVolume.EncryptionKey ||
+			snapshotID != existingSnapshotID {
+			return true, nil
+		}
+	}
+
+	// Besides the non-root volumes, only the root volume may be left.
+	remaining := 0
+	if incoming.RootVolume != nil {
+		remaining = 1
+	}
+	return len(existingVolumes) > remaining, nil
+}
+
+// privateDNSNameEqual compares hostname options, treating unset record options as disabled
+// since that is how EC2 reports them back.
+func privateDNSNameEqual(a, b *infrav1.PrivateDNSName) bool {
+	if a == nil {
+		a = &infrav1.PrivateDNSName{}
+	}
+	if b == nil {
+		b = &infrav1.PrivateDNSName{}
+	}
+	return aws.BoolValue(a.EnableResourceNameDNSAAAARecord) == aws.BoolValue(b.EnableResourceNameDNSAAAARecord) &&
+		aws.BoolValue(a.EnableResourceNameDNSARecord) == aws.BoolValue(b.EnableResourceNameDNSARecord) &&
+		aws.StringValue(a.HostnameType) == aws.StringValue(b.HostnameType)
+}
+
+func getLaunchTemplatePlacementRequest(lt *expinfrav1.AWSLaunchTemplate) *ec2.LaunchTemplatePlacementRequest {
+	if lt.Tenancy == "" && lt.HostID == nil && lt.HostAffinity == nil && lt.HostResourceGroupArn == nil && lt.PlacementGroupName == "" {
+		return nil
+	}
+
+	placement := &ec2.LaunchTemplatePlacementRequest{
+		HostId:               lt.HostID,
+		Affinity:             lt.HostAffinity,
+		HostResourceGroupArn: lt.HostResourceGroupArn,
+	}
+	if lt.Tenancy != "" {
+		placement.Tenancy = aws.String(lt.Tenancy)
+	}
+	if lt.PlacementGroupName != "" {
+		placement.GroupName = aws.String(lt.PlacementGroupName)
+		if lt.PlacementGroupPartition != 0 {
+			placement.PartitionNumber = aws.Int64(lt.PlacementGroupPartition)
+		}
+	}
+	return placement
+}
+
 func getLaunchTemplateInstanceMarketOptionsRequest(spotMarketOptions *infrav1.SpotMarketOptions) *ec2.LaunchTemplateInstanceMarketOptionsRequest {
 	if spotMarketOptions == nil {
 		// Instance is not a Spot instance
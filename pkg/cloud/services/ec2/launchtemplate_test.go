@@ -195,8 +195,11 @@ func TestGetLaunchTemplate(t *testing.T) {
 					AMI: infrav1.AMIReference{
 						ID: aws.String("foo-image"),
 					},
-					IamInstanceProfile:       "foo-profile",
-					SSHKeyName:               aws.String("foo-keyname"),
+					IamInstanceProfile: "foo-profile",
+					SSHKeyName:         aws.String("foo-keyname"),
+					NonRootVolumes: []infrav1.Volume{
+						{DeviceName: "foo-device", Size: 16, Type: "cool", Encrypted: aws.Bool(true)},
+					},
 					VersionNumber:            aws.Int64(1),
 					AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-id")}},
 				}
@@ -253,8 +256,11 @@ func TestGetLaunchTemplate(t *testing.T) {
 					AMI: infrav1.AMIReference{
 						ID: aws.String("foo-image"),
 					},
-					IamInstanceProfile:       "foo-profile",
-					SSHKeyName:               aws.String("foo-keyname"),
+					IamInstanceProfile: "foo-profile",
+					SSHKeyName:         aws.String("foo-keyname"),
+					NonRootVolumes: []infrav1.Volume{
+						{DeviceName: "foo-device", Size: 16, Type: "cool", Encrypted: aws.Bool(true)},
+					},
 					VersionNumber:            aws.Int64(1),
 					AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-id")}},
 				}
@@ -336,10 +342,141 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 				},
 				IamInstanceProfile: "foo-profile",
 				SSHKeyName:         aws.String("foo-keyname"),
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "foo-device", Size: 16, Type: "cool", Encrypted: aws.Bool(true)},
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantHash: testUserDataHash,
+		},
+		{
+			name: "cpu, enclave and hibernation options",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId: aws.String("foo-image"),
+					CpuOptions: &ec2.LaunchTemplateCpuOptions{
+						CoreCount:      aws.Int64(4),
+						ThreadsPerCore: aws.Int64(1),
+					},
+					EnclaveOptions: &ec2.LaunchTemplateEnclaveOptions{
+						Enabled: aws.Bool(false),
+					},
+					HibernationOptions: &ec2.LaunchTemplateHibernationOptions{
+						Configured: aws.Bool(true),
+					},
+					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				CPUOptions: &infrav1.CPUOptions{
+					CoreCount:      aws.Int64(4),
+					ThreadsPerCore: aws.Int64(1),
+				},
+				HibernationEnabled: true,
 				VersionNumber:      aws.Int64(1),
 			},
 			wantHash: testUserDataHash,
 		},
+		{
+			name: "managed network interfaces",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId: aws.String("foo-image"),
+					NetworkInterfaces: []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecification{
+						{
+							DeviceIndex: aws.Int64(0),
+							Groups:      aws.StringSlice([]string{"sg-1", "sg-2"}),
+						},
+						{
+							DeviceIndex:         aws.Int64(1),
+							Groups:              aws.StringSlice([]string{"sg-3"}),
+							Ipv4PrefixCount:     aws.Int64(2),
+							DeleteOnTermination: aws.Bool(true),
+						},
+					},
+					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-1")},
+					{ID: aws.String("sg-2")},
+				},
+				ManagedNetworkInterfaces: []infrav1.ManagedNetworkInterface{
+					{
+						DeviceIndex:     1,
+						IPv4PrefixCount: aws.Int64(2),
+						SecurityGroups:  []infrav1.AWSResourceReference{{ID: aws.String("sg-3")}},
+					},
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantHash: testUserDataHash,
+		},
+		{
+			name: "efa network interfaces in a partition placement group",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId: aws.String("foo-image"),
+					NetworkInterfaces: []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecification{
+						{
+							DeviceIndex:   aws.Int64(0),
+							Groups:        aws.StringSlice([]string{"sg-1"}),
+							InterfaceType: aws.String("efa"),
+						},
+						{
+							DeviceIndex:         aws.Int64(1),
+							Groups:              aws.StringSlice([]string{"sg-1"}),
+							InterfaceType:       aws.String("efa"),
+							DeleteOnTermination: aws.Bool(true),
+						},
+					},
+					Placement: &ec2.LaunchTemplatePlacement{
+						GroupName:       aws.String("hpc"),
+						PartitionNumber: aws.Int64(2),
+					},
+					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-1")},
+				},
+				NetworkInterfaceType: infrav1.NetworkInterfaceTypeEFAWithENAInterface,
+				ManagedNetworkInterfaces: []infrav1.ManagedNetworkInterface{
+					{
+						DeviceIndex:    1,
+						InterfaceType:  infrav1.NetworkInterfaceTypeEFAWithENAInterface,
+						SecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-1")}},
+					},
+				},
+				PlacementGroupName:      "hpc",
+				PlacementGroupPartition: 2,
+				VersionNumber:           aws.Int64(1),
+			},
+			wantHash: testUserDataHash,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -486,6 +623,222 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 			want:    true,
 			wantErr: false,
 		},
+		{
+			name: "Should return true if CPU options changed",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				CPUOptions: &infrav1.CPUOptions{ThreadsPerCore: aws.Int64(1)},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "Should return true if Nitro Enclaves got enabled",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NitroEnclaveEnabled: true,
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name:     "Should return true if hibernation got disabled",
+			incoming: &expinfrav1.AWSLaunchTemplate{},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				HibernationEnabled: true,
+			},
+			want: true,
+		},
+		{
+			name: "Should return false if CPU options are unchanged",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				CPUOptions: &infrav1.CPUOptions{CoreCount: aws.Int64(2), ThreadsPerCore: aws.Int64(1)},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				CPUOptions: &infrav1.CPUOptions{CoreCount: aws.Int64(2), ThreadsPerCore: aws.Int64(1)},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "managed network interfaces defaulted to the security groups of the instances",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-999")},
+				},
+				ManagedNetworkInterfaces: []infrav1.ManagedNetworkInterface{
+					{DeviceIndex: 1, IPv4PrefixCount: aws.Int64(2)},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+					{ID: aws.String("sg-999")},
+				},
+				ManagedNetworkInterfaces: []infrav1.ManagedNetworkInterface{
+					{
+						DeviceIndex:     1,
+						IPv4PrefixCount: aws.Int64(2),
+						SecurityGroups: []infrav1.AWSResourceReference{
+							{ID: aws.String("sg-999")},
+							{ID: aws.String("sg-111")},
+							{ID: aws.String("sg-222")},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "managed network interface prefix count changed",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				ManagedNetworkInterfaces: []infrav1.ManagedNetworkInterface{
+					{DeviceIndex: 1, IPv4PrefixCount: aws.Int64(4), SecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-333")}}},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+				ManagedNetworkInterfaces: []infrav1.ManagedNetworkInterface{
+					{DeviceIndex: 1, IPv4PrefixCount: aws.Int64(2), SecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-333")}}},
+				},
+			},
+			want: true,
+		},
+		{
+			name:     "Should return false if private DNS name options are unset and existing options are disabled",
+			incoming: &expinfrav1.AWSLaunchTemplate{},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				PrivateDNSName: &infrav1.PrivateDNSName{
+					EnableResourceNameDNSAAAARecord: aws.Bool(false),
+					EnableResourceNameDNSARecord:    aws.Bool(false),
+				},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Should return false if private DNS name options are unchanged",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				PrivateDNSName: &infrav1.PrivateDNSName{
+					EnableResourceNameDNSARecord: aws.Bool(true),
+					HostnameType:                 aws.String("resource-name"),
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				PrivateDNSName: &infrav1.PrivateDNSName{
+					EnableResourceNameDNSAAAARecord: aws.Bool(false),
+					EnableResourceNameDNSARecord:    aws.Bool(true),
+					HostnameType:                    aws.String("resource-name"),
+				},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Should return true if private DNS name hostname type changed",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				PrivateDNSName: &infrav1.PrivateDNSName{
+					HostnameType: aws.String("resource-name"),
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				PrivateDNSName: &infrav1.PrivateDNSName{
+					HostnameType: aws.String("ip-name"),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Should return false if non root volumes are unchanged besides the root volume",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 100, Type: infrav1.VolumeTypeGP3, Snapshot: &infrav1.AWSResourceReference{
+						Filters: []infrav1.Filter{{Name: "tag:dataset", Values: []string{"images"}}},
+					}},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/xvda", Size: 16},
+					{DeviceName: "/dev/sdb", Size: 100, Type: infrav1.VolumeTypeGP3, Snapshot: &infrav1.AWSResourceReference{ID: aws.String("snap-1")}},
+				},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSnapshotsPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSnapshotsInput{}), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *ec2.DescribeSnapshotsInput, fn func(*ec2.DescribeSnapshotsOutput, bool) bool, _ ...request.Option) error {
+						fn(&ec2.DescribeSnapshotsOutput{Snapshots: []*ec2.Snapshot{{SnapshotId: aws.String("snap-1"), VolumeSize: aws.Int64(100)}}}, true)
+						return nil
+					})
+			},
+			want: false,
+		},
+		{
+			name: "Should return true if the snapshot of a non root volume resolves to a newer snapshot",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 100, Snapshot: &infrav1.AWSResourceReference{
+						Filters: []infrav1.Filter{{Name: "tag:dataset", Values: []string{"images"}}},
+					}},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/sdb", Size: 100, Snapshot: &infrav1.AWSResourceReference{ID: aws.String("snap-1")}},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSnapshotsPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSnapshotsInput{}), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *ec2.DescribeSnapshotsInput, fn func(*ec2.DescribeSnapshotsOutput, bool) bool, _ ...request.Option) error {
+						fn(&ec2.DescribeSnapshotsOutput{Snapshots: []*ec2.Snapshot{{SnapshotId: aws.String("snap-2"), VolumeSize: aws.Int64(100)}}}, true)
+						return nil
+					})
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if the size of a non root volume changed",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{{DeviceName: "/dev/sdb", Size: 200}},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{{DeviceName: "/dev/sdb", Size: 100}},
+			},
+			want: true,
+		},
+		{
+			name:     "Should return true if a non root volume was removed",
+			incoming: &expinfrav1.AWSLaunchTemplate{},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{{DeviceName: "/dev/sdb", Size: 100}},
+			},
+			want: true,
+		},
+		{
+			name: "Should return true if private DNS name A record is enabled",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				PrivateDNSName: &infrav1.PrivateDNSName{
+					EnableResourceNameDNSARecord: aws.Bool(true),
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -669,6 +1022,164 @@ func TestGetLaunchTemplateID(t *testing.T) {
 	}
 }
 
+func TestResolveLaunchTemplateVersion(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name   string
+		ref    *infrav1.LaunchTemplateReference
+		expect func(m *mocks.MockEC2APIMockRecorder)
+		check  func(g *WithT, version string, err error)
+	}{
+		{
+			name: "Should resolve the default version when no version is set",
+			ref:  &infrav1.LaunchTemplateReference{ID: aws.String("lt-12345")},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateId: aws.String("lt-12345"),
+					Versions:         []*string{aws.String("$Default")},
+				})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+					LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+						{LaunchTemplateId: aws.String("lt-12345"), VersionNumber: aws.Int64(3)},
+					},
+				}, nil)
+			},
+			check: func(g *WithT, version string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(version).To(Equal("3"))
+			},
+		},
+		{
+			name: "Should resolve the requested version of a template referenced by name",
+			ref:  &infrav1.LaunchTemplateReference{Name: aws.String("foo"), Version: aws.String("$Latest")},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateName: aws.String("foo"),
+					Versions:           []*string{aws.String("$Latest")},
+				})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+					LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+						{LaunchTemplateName: aws.String("foo"), VersionNumber: aws.Int64(7)},
+					},
+				}, nil)
+			},
+			check: func(g *WithT, version string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(version).To(Equal("7"))
+			},
+		},
+		{
+			name: "Should return an error if the template does not exist",
+			ref:  &infrav1.LaunchTemplateReference{Name: aws.String("foo")},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(awserrors.LaunchTemplateNameNotFound, "The specified launch template, with template name foo, does not exist.", nil))
+			},
+			check: func(g *WithT, version string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(version).To(BeEmpty())
+			},
+		},
+		{
+			name: "Should return an error if the version does not exist",
+			ref:  &infrav1.LaunchTemplateReference{ID: aws.String("lt-12345"), Version: aws.String("9")},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeLaunchTemplateVersionsOutput{}, nil)
+			},
+			check: func(g *WithT, version string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(version).To(BeEmpty())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			mockEC2Client := mocks.NewMockEC2API(mockCtrl)
+
+			s := NewService(cs)
+			s.EC2Client = mockEC2Client
+
+			tc.expect(mockEC2Client.EXPECT())
+			version, err := s.ResolveLaunchTemplateVersion(tc.ref)
+			tc.check(g, version, err)
+		})
+	}
+}
+
+func TestResolveLaunchTemplate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name   string
+		ref    *infrav1.LaunchTemplateReference
+		expect func(m *mocks.MockEC2APIMockRecorder)
+		check  func(g *WithT, resolved *infrav1.LaunchTemplateReference, err error)
+	}{
+		{
+			name: "Should resolve the ID and version of a template referenced by name",
+			ref:  &infrav1.LaunchTemplateReference{Name: aws.String("foo"), Version: aws.String("$Latest")},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateName: aws.String("foo"),
+					Versions:           []*string{aws.String("$Latest")},
+				})).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+					LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+						{LaunchTemplateId: aws.String("lt-12345"), LaunchTemplateName: aws.String("foo"), VersionNumber: aws.Int64(7)},
+					},
+				}, nil)
+			},
+			check: func(g *WithT, resolved *infrav1.LaunchTemplateReference, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(resolved).To(Equal(&infrav1.LaunchTemplateReference{ID: aws.String("lt-12345"), Version: aws.String("7")}))
+			},
+		},
+		{
+			name: "Should return an error if the template does not exist",
+			ref:  &infrav1.LaunchTemplateReference{ID: aws.String("lt-12345")},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersionsWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(awserrors.LaunchTemplateNameNotFound, "The specified launch template, with template ID lt-12345, does not exist.", nil))
+			},
+			check: func(g *WithT, resolved *infrav1.LaunchTemplateReference, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(resolved).To(BeNil())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			mockEC2Client := mocks.NewMockEC2API(mockCtrl)
+
+			s := NewService(cs)
+			s.EC2Client = mockEC2Client
+
+			tc.expect(mockEC2Client.EXPECT())
+			resolved, err := s.ResolveLaunchTemplate(tc.ref)
+			tc.check(g, resolved, err)
+		})
+	}
+}
+
 func TestDeleteLaunchTemplate(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1511,6 +2022,129 @@ func TestDiscoverLaunchTemplateAMIForEKS(t *testing.T) {
 	}
 }
 
+func TestDiscoverLaunchTemplateAMIFromSSMParameter(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	describeInstanceTypes := func(architecture string) func(m *mocks.MockEC2APIMockRecorder) {
+		return func(m *mocks.MockEC2APIMockRecorder) {
+			m.DescribeInstanceTypesWithContext(context.TODO(), gomock.Any()).
+				Return(&ec2.DescribeInstanceTypesOutput{
+					InstanceTypes: []*ec2.InstanceTypeInfo{
+						{
+							ProcessorInfo: &ec2.ProcessorInfo{
+								SupportedArchitectures: []*string{aws.String(architecture)},
+							},
+						},
+					},
+				}, nil)
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		awsLaunchTemplate expinfrav1.AWSLaunchTemplate
+		machineTemplate   clusterv1.MachineTemplateSpec
+		expectEC2         func(m *mocks.MockEC2APIMockRecorder)
+		expectSSM         func(m *mock_ssmiface.MockSSMAPIMockRecorder)
+		check             func(*WithT, *string, error)
+	}{
+		{
+			name: "Should return AMI from the SSM parameter templated with the version and architecture",
+			awsLaunchTemplate: expinfrav1.AWSLaunchTemplate{
+				Name:         "aws-launch-tmpl",
+				InstanceType: "t4g.large",
+				AMI: infrav1.AMIReference{
+					SSMParameter: aws.String("/amis/{{.K8sMinorVersion}}/{{.Arch}}/{{.K8sVersion}}"),
+				},
+			},
+			machineTemplate: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Version: aws.String("v1.27.3"),
+				},
+			},
+			expectEC2: describeInstanceTypes("arm64"),
+			expectSSM: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(&ssm.GetParameterInput{Name: aws.String("/amis/1.27/arm64/1.27.3")}).
+					Return(&ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: aws.String("ami-arm64")}}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(res).Should(Equal(aws.String("ami-arm64")))
+			},
+		},
+		{
+			name: "Should not require a machine version if the SSM parameter does not reference it",
+			awsLaunchTemplate: expinfrav1.AWSLaunchTemplate{
+				Name:         "aws-launch-tmpl",
+				InstanceType: "m5.large",
+				AMI: infrav1.AMIReference{
+					SSMParameter: aws.String("/aws/service/canonical/ubuntu/server/22.04/stable/current/{{.Arch}}/hvm/ebs-gp2/ami-id"),
+				},
+			},
+			expectEC2: describeInstanceTypes("x86_64"),
+			expectSSM: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(&ssm.GetParameterInput{Name: aws.String("/aws/service/canonical/ubuntu/server/22.04/stable/current/amd64/hvm/ebs-gp2/ami-id")}).
+					Return(&ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: aws.String("ami-ubuntu")}}, nil)
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(res).Should(Equal(aws.String("ami-ubuntu")))
+			},
+		},
+		{
+			name: "Should return an error if the SSM parameter can't be read",
+			awsLaunchTemplate: expinfrav1.AWSLaunchTemplate{
+				Name:         "aws-launch-tmpl",
+				InstanceType: "m5.large",
+				AMI: infrav1.AMIReference{
+					SSMParameter: aws.String("/amis/missing"),
+				},
+			},
+			expectEC2: describeInstanceTypes("x86_64"),
+			expectSSM: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(&ssm.GetParameterInput{Name: aws.String("/amis/missing")}).
+					Return(nil, awserrors.NewNotFound("ParameterNotFound"))
+			},
+			check: func(g *WithT, res *string, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(res).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate = tc.awsLaunchTemplate
+			ms.MachinePool.Spec.Template = tc.machineTemplate
+
+			tc.expectEC2(ec2Mock.EXPECT())
+			tc.expectSSM(ssmMock.EXPECT())
+
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+			s.SSMClient = ssmMock
+
+			id, err := s.DiscoverLaunchTemplateAMI(ms)
+			tc.check(g, id, err)
+		})
+	}
+}
+
 func TestDeleteLaunchTemplateVersion(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
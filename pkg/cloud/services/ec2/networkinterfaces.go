@@ -0,0 +1,217 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+)
+
+// getManagedNetworkInterfaceSpecifications returns the network interfaces to create when running an instance.
+// Network interfaces without subnet or security groups use the ones of the instance. They are all deleted
+// when the instance is terminated.
+func (s *Service) getManagedNetworkInterfaceSpecifications(interfaces []infrav1.ManagedNetworkInterface, subnetID string, securityGroupIDs []string) ([]*ec2.InstanceNetworkInterfaceSpecification, error) {
+	specs := make([]*ec2.InstanceNetworkInterfaceSpecification, 0, len(interfaces))
+	for _, ni := range interfaces {
+		niSubnetID := subnetID
+		if ni.Subnet != nil {
+			id, err := s.getSubnetIDFromReference(ni.Subnet)
+			if err != nil {
+				return nil, err
+			}
+			niSubnetID = id
+		}
+
+		groups := securityGroupIDs
+		if len(ni.SecurityGroups) > 0 {
+			ids, err := s.GetAdditionalSecurityGroupsIDs(ni.SecurityGroups)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get security groups of network interface %d", ni.DeviceIndex)
+			}
+			groups = ids
+		}
+
+		spec := &ec2.InstanceNetworkInterfaceSpecification{
+			DeviceIndex:                    aws.Int64(ni.DeviceIndex),
+			SubnetId:                       aws.String(niSubnetID),
+			SecondaryPrivateIpAddressCount: ni.SecondaryPrivateIPAddressCount,
+			Ipv4PrefixCount:                ni.IPv4PrefixCount,
+			Ipv6PrefixCount:                ni.IPv6PrefixCount,
+			DeleteOnTermination:            aws.Bool(true),
+		}
+		if ni.Description != "" {
+			spec.Description = aws.String(ni.Description)
+		}
+		if len(groups) > 0 {
+			spec.Groups = aws.StringSlice(groups)
+		}
+		if ni.InterfaceType != "" {
+			spec.InterfaceType = aws.String(string(ni.InterfaceType))
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// getLaunchTemplateNetworkInterfaceRequests returns the network interfaces of a launch template with managed
+// network interfaces or a primary network interface type. The primary network interface carries the security groups
+// of the instances, as they cannot be set on the launch template itself once network interfaces are specified.
+func (s *Service) getLaunchTemplateNetworkInterfaceRequests(primaryType infrav1.NetworkInterfaceType, interfaces []infrav1.ManagedNetworkInterface, securityGroupIDs []string) ([]*ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest, error) {
+	primary := &ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+		DeviceIndex: aws.Int64(0),
+		Groups:      aws.StringSlice(sortedCopy(securityGroupIDs)),
+	}
+	if primaryType != "" {
+		primary.InterfaceType = aws.String(string(primaryType))
+	}
+	requests := []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{primary}
+
+	for _, ni := range interfaces {
+		groups := securityGroupIDs
+		if len(ni.SecurityGroups) > 0 {
+			ids, err := s.GetAdditionalSecurityGroupsIDs(ni.SecurityGroups)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get security groups of network interface %d", ni.DeviceIndex)
+			}
+			groups = ids
+		}
+
+		req := &ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+			DeviceIndex:                    aws.Int64(ni.DeviceIndex),
+			Groups:                         aws.StringSlice(sortedCopy(groups)),
+			SecondaryPrivateIpAddressCount: ni.SecondaryPrivateIPAddressCount,
+			Ipv4PrefixCount:                ni.IPv4PrefixCount,
+			Ipv6PrefixCount:                ni.IPv6PrefixCount,
+			DeleteOnTermination:            aws.Bool(true),
+		}
+		if ni.Description != "" {
+			req.Description = aws.String(ni.Description)
+		}
+		if ni.InterfaceType != "" {
+			req.InterfaceType = aws.String(string(ni.InterfaceType))
+		}
+		requests = append(requests, req)
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		return aws.Int64Value(requests[i].DeviceIndex) < aws.Int64Value(requests[j].DeviceIndex)
+	})
+	return requests, nil
+}
+
+// getSubnetIDFromReference resolves a subnet reference of the cluster VPC to a subnet ID.
+func (s *Service) getSubnetIDFromReference(ref *infrav1.AWSResourceReference) (string, error) {
+	if ref.ID != nil {
+		return *ref.ID, nil
+	}
+
+	criteria := []*ec2.Filter{
+		filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+		filter.EC2.VPC(s.scope.VPC().ID),
+	}
+	for _, f := range ref.Filters {
+		criteria = append(criteria, &ec2.Filter{Name: aws.String(f.Name), Values: aws.StringSlice(f.Values)})
+	}
+
+	subnets, err := s.getFilteredSubnets(criteria...)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to filter subnets for criteria %q", criteria)
+	}
+	if len(subnets) == 0 {
+		return "", awserrors.NewFailedDependency(fmt.Sprintf("no subnets available matching criteria %q", criteria))
+	}
+	return aws.StringValue(subnets[0].SubnetId), nil
+}
+
+// sdkToNetworkInterfaceStatuses converts the network interfaces of an instance, ordered by device index.
+func sdkToNetworkInterfaceStatuses(interfaces []*ec2.InstanceNetworkInterface) []infrav1.NetworkInterfaceStatus {
+	statuses := make([]infrav1.NetworkInterfaceStatus, 0, len(interfaces))
+	for _, ni := range interfaces {
+		status := infrav1.NetworkInterfaceStatus{
+			ID:       aws.StringValue(ni.NetworkInterfaceId),
+			SubnetID: aws.StringValue(ni.SubnetId),
+		}
+		if ni.Attachment != nil {
+			status.DeviceIndex = aws.Int64Value(ni.Attachment.DeviceIndex)
+			status.DeleteOnTermination = aws.BoolValue(ni.Attachment.DeleteOnTermination)
+		}
+		for _, ip := range ni.PrivateIpAddresses {
+			status.PrivateIPAddresses = append(status.PrivateIPAddresses, aws.StringValue(ip.PrivateIpAddress))
+		}
+		for _, prefix := range ni.Ipv4Prefixes {
+			status.IPv4Prefixes = append(status.IPv4Prefixes, aws.StringValue(prefix.Ipv4Prefix))
+		}
+		for _, prefix := range ni.Ipv6Prefixes {
+			status.IPv6Prefixes = append(status.IPv6Prefixes, aws.StringValue(prefix.Ipv6Prefix))
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].DeviceIndex < statuses[j].DeviceIndex
+	})
+	return statuses
+}
+
+// sdkToManagedNetworkInterfaces converts the secondary network interfaces of a launch template.
+// Launch templates with managed network interfaces always describe the primary one, network interfaces
+// of other launch templates are ignored.
+func sdkToManagedNetworkInterfaces(interfaces []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecification) []infrav1.ManagedNetworkInterface {
+	hasPrimary := false
+	for _, ni := range interfaces {
+		if aws.Int64Value(ni.DeviceIndex) == 0 {
+			hasPrimary = true
+		}
+	}
+	if !hasPrimary {
+		return nil
+	}
+
+	var out []infrav1.ManagedNetworkInterface
+	for _, ni := range interfaces {
+		if aws.Int64Value(ni.DeviceIndex) == 0 {
+			continue
+		}
+		managed := infrav1.ManagedNetworkInterface{
+			DeviceIndex:                    aws.Int64Value(ni.DeviceIndex),
+			Description:                    aws.StringValue(ni.Description),
+			SecondaryPrivateIPAddressCount: ni.SecondaryPrivateIpAddressCount,
+			IPv4PrefixCount:                ni.Ipv4PrefixCount,
+			IPv6PrefixCount:                ni.Ipv6PrefixCount,
+			InterfaceType:                  infrav1.NetworkInterfaceType(aws.StringValue(ni.InterfaceType)),
+		}
+		for _, id := range ni.Groups {
+			managed.SecurityGroups = append(managed.SecurityGroups, infrav1.AWSResourceReference{ID: id})
+		}
+		out = append(out, managed)
+	}
+	return out
+}
+
+func sortedCopy(in []string) []string {
+	out := append([]string(nil), in...)
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestGetManagedNetworkInterfaceSpecifications(t *testing.T) {
+	testCases := []struct {
+		name       string
+		interfaces []infrav1.ManagedNetworkInterface
+		expect     func(m *mocks.MockEC2APIMockRecorder)
+		want       []*ec2.InstanceNetworkInterfaceSpecification
+		wantErr    bool
+	}{
+		{
+			name: "Should default to the subnet and security groups of the instance",
+			interfaces: []infrav1.ManagedNetworkInterface{
+				{DeviceIndex: 1, SecondaryPrivateIPAddressCount: aws.Int64(3)},
+				{DeviceIndex: 2, IPv4PrefixCount: aws.Int64(1), IPv6PrefixCount: aws.Int64(1), Description: "pods"},
+			},
+			want: []*ec2.InstanceNetworkInterfaceSpecification{
+				{
+					DeviceIndex:                    aws.Int64(1),
+					SubnetId:                       aws.String("subnet-instance"),
+					Groups:                         aws.StringSlice([]string{"sg-instance"}),
+					SecondaryPrivateIpAddressCount: aws.Int64(3),
+					DeleteOnTermination:            aws.Bool(true),
+				},
+				{
+					DeviceIndex:         aws.Int64(2),
+					SubnetId:            aws.String("subnet-instance"),
+					Groups:              aws.StringSlice([]string{"sg-instance"}),
+					Description:         aws.String("pods"),
+					Ipv4PrefixCount:     aws.Int64(1),
+					Ipv6PrefixCount:     aws.Int64(1),
+					DeleteOnTermination: aws.Bool(true),
+				},
+			},
+		},
+		{
+			name: "Should resolve the subnet and security groups of the network interface",
+			interfaces: []infrav1.ManagedNetworkInterface{
+				{
+					DeviceIndex: 1,
+					Subnet: &infrav1.AWSResourceReference{
+						Filters: []infrav1.Filter{{Name: "tag:Name", Values: []string{"pods"}}},
+					},
+					SecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-pods")}},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-pods")}}}, nil)
+			},
+			want: []*ec2.InstanceNetworkInterfaceSpecification{
+				{
+					DeviceIndex:         aws.Int64(1),
+					SubnetId:            aws.String("subnet-pods"),
+					Groups:              aws.StringSlice([]string{"sg-pods"}),
+					DeleteOnTermination: aws.Bool(true),
+				},
+			},
+		},
+		{
+			name: "Should set the type of EFA network interfaces",
+			interfaces: []infrav1.ManagedNetworkInterface{
+				{DeviceIndex: 1, InterfaceType: infrav1.NetworkInterfaceTypeEFAWithENAInterface},
+			},
+			want: []*ec2.InstanceNetworkInterfaceSpecification{
+				{
+					DeviceIndex:         aws.Int64(1),
+					SubnetId:            aws.String("subnet-instance"),
+					Groups:              aws.StringSlice([]string{"sg-instance"}),
+					InterfaceType:       aws.String("efa"),
+					DeleteOnTermination: aws.Bool(true),
+				},
+			},
+		},
+		{
+			name: "Should fail if no subnet matches the filters",
+			interfaces: []infrav1.ManagedNetworkInterface{
+				{
+					DeviceIndex: 1,
+					Subnet: &infrav1.AWSResourceReference{
+						Filters: []infrav1.Filter{{Name: "tag:Name", Values: []string{"pods"}}},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{}, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			got, err := s.getManagedNetworkInterfaceSpecifications(tc.interfaces, "subnet-instance", []string{"sg-instance"})
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestSDKToNetworkInterfaceStatuses(t *testing.T) {
+	g := NewWithT(t)
+
+	got := sdkToNetworkInterfaceStatuses([]*ec2.InstanceNetworkInterface{
+		{
+			NetworkInterfaceId: aws.String("eni-secondary"),
+			SubnetId:           aws.String("subnet-1"),
+			Attachment:         &ec2.InstanceNetworkInterfaceAttachment{DeviceIndex: aws.Int64(1), DeleteOnTermination: aws.Bool(true)},
+			PrivateIpAddresses: []*ec2.InstancePrivateIpAddress{{PrivateIpAddress: aws.String("10.0.0.20")}},
+			Ipv4Prefixes:       []*ec2.InstanceIpv4Prefix{{Ipv4Prefix: aws.String("10.0.1.0/28")}},
+		},
+		{
+			NetworkInterfaceId: aws.String("eni-primary"),
+			SubnetId:           aws.String("subnet-1"),
+			Attachment:         &ec2.InstanceNetworkInterfaceAttachment{DeviceIndex: aws.Int64(0), DeleteOnTermination: aws.Bool(true)},
+			PrivateIpAddresses: []*ec2.InstancePrivateIpAddress{{PrivateIpAddress: aws.String("10.0.0.10")}},
+		},
+	})
+
+	g.Expect(got).To(Equal([]infrav1.NetworkInterfaceStatus{
+		{
+			ID:                  "eni-primary",
+			SubnetID:            "subnet-1",
+			PrivateIPAddresses:  []string{"10.0.0.10"},
+			DeleteOnTermination: true,
+		},
+		{
+			ID:                  "eni-secondary",
+			DeviceIndex:         1,
+			SubnetID:            "subnet-1",
+			PrivateIPAddresses:  []string{"10.0.0.20"},
+			IPv4Prefixes:        []string{"10.0.1.0/28"},
+			DeleteOnTermination: true,
+		},
+	}))
+}
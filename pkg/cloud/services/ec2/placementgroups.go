@@ -0,0 +1,192 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// ReconcilePlacementGroups ensures the placement groups declared in the cluster spec exist, and deletes the
+// placement groups owned by the cluster that have been removed from it.
+func (s *Service) ReconcilePlacementGroups() error {
+	if !s.hasPlacementGroups() {
+		s.scope.Trace("Skipping placement groups reconcile")
+		return nil
+	}
+
+	s.scope.Debug("Reconciling placement groups")
+
+	existing, err := s.describeClusterOwnedPlacementGroups()
+	if err != nil {
+		return err
+	}
+
+	desired := make(map[string]struct{}, len(s.scope.PlacementGroups()))
+	for _, pg := range s.scope.PlacementGroups() {
+		desired[pg.Name] = struct{}{}
+		if _, ok := existing[pg.Name]; ok {
+			continue
+		}
+		if err := s.createPlacementGroup(pg); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	var inUse []string
+	for name, pg := range existing {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		if err := s.deletePlacementGroup(pg); err != nil {
+			// Placement groups can only be deleted once no instance runs in them anymore, which is not worth
+			// failing the reconciliation of the cluster for.
+			if code, ok := awserrors.Code(errors.Cause(err)); ok && code == awserrors.PlacementGroupInUse {
+				inUse = append(inUse, name)
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return kerrors.NewAggregate(errs)
+	}
+
+	if len(inUse) > 0 {
+		sort.Strings(inUse)
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.PlacementGroupsReadyCondition, infrav1.PlacementGroupsInUseReason, clusterv1.ConditionSeverityInfo,
+			"Waiting for instances to leave placement groups %s before deleting them", strings.Join(inUse, ", "))
+		return nil
+	}
+
+	if len(s.scope.PlacementGroups()) == 0 {
+		// All the placement groups have been removed from the spec and deleted.
+		conditions.Delete(s.scope.InfraCluster(), infrav1.PlacementGroupsReadyCondition)
+		return nil
+	}
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.PlacementGroupsReadyCondition)
+	return nil
+}
+
+// DeletePlacementGroups deletes all the placement groups owned by the cluster.
+func (s *Service) DeletePlacementGroups() error {
+	if !s.hasPlacementGroups() {
+		return nil
+	}
+
+	existing, err := s.describeClusterOwnedPlacementGroups()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, pg := range existing {
+		if err := s.deletePlacementGroup(pg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// hasPlacementGroups returns true if the cluster declares placement groups or has declared some in the past,
+// which avoids describing placement groups for clusters that never used them.
+func (s *Service) hasPlacementGroups() bool {
+	return len(s.scope.PlacementGroups()) > 0 || conditions.Has(s.scope.InfraCluster(), infrav1.PlacementGroupsReadyCondition)
+}
+
+func (s *Service) describeClusterOwnedPlacementGroups() (map[string]*ec2.PlacementGroup, error) {
+	out, err := s.EC2Client.DescribePlacementGroupsWithContext(context.TODO(), &ec2.DescribePlacementGroupsInput{
+		Filters: []*ec2.Filter{filter.EC2.ClusterOwned(s.scope.Name())},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe placement groups")
+	}
+
+	groups := make(map[string]*ec2.PlacementGroup, len(out.PlacementGroups))
+	for _, pg := range out.PlacementGroups {
+		if aws.StringValue(pg.State) == ec2.PlacementGroupStateDeleted {
+			continue
+		}
+		groups[aws.StringValue(pg.GroupName)] = pg
+	}
+	return groups, nil
+}
+
+func (s *Service) createPlacementGroup(spec infrav1.PlacementGroupSpec) error {
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(spec.Name),
+		Role:        aws.String(infrav1.CommonRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	})
+
+	input := &ec2.CreatePlacementGroupInput{
+		GroupName:      aws.String(spec.Name),
+		Strategy:       aws.String(string(spec.Strategy)),
+		PartitionCount: spec.PartitionCount,
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypePlacementGroup),
+				Tags:         converters.MapToTags(tags),
+			},
+		},
+	}
+
+	if _, err := s.EC2Client.CreatePlacementGroupWithContext(context.TODO(), input); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreatePlacementGroup", "Failed to create placement group %q: %v", spec.Name, err)
+		return errors.Wrapf(err, "failed to create placement group %q", spec.Name)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreatePlacementGroup", "Created placement group %q", spec.Name)
+	s.scope.Debug("Created placement group", "name", spec.Name, "strategy", spec.Strategy)
+	return nil
+}
+
+func (s *Service) deletePlacementGroup(pg *ec2.PlacementGroup) error {
+	name := aws.StringValue(pg.GroupName)
+	_, err := s.EC2Client.DeletePlacementGroupWithContext(context.TODO(), &ec2.DeletePlacementGroupInput{
+		GroupName: pg.GroupName,
+	})
+	if code, ok := awserrors.Code(err); ok && code == awserrors.PlacementGroupNotFound {
+		return nil
+	}
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeletePlacementGroup", "Failed to delete placement group %q: %v", name, err)
+		return errors.Wrapf(err, "failed to delete placement group %q", name)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeletePlacementGroup", "Deleted placement group %q", name)
+	s.scope.Debug("Deleted placement group", "name", name)
+	return nil
+}
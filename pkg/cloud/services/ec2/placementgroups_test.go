@@ -0,0 +1,267 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcilePlacementGroups(t *testing.T) {
+	describeInput := &ec2.DescribePlacementGroupsInput{
+		Filters: []*ec2.Filter{filter.EC2.ClusterOwned("test-cluster")},
+	}
+
+	tests := []struct {
+		name            string
+		placementGroups []infrav1.PlacementGroupSpec
+		hasCondition    bool
+		expect          func(m *mocks.MockEC2APIMockRecorder)
+		expectError     bool
+		expectCondition bool
+		expectReason    string
+	}{
+		{
+			name:   "should not describe placement groups when the cluster never declared any",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name: "should create missing placement groups",
+			placementGroups: []infrav1.PlacementGroupSpec{
+				{Name: "hpc", Strategy: infrav1.PlacementGroupStrategyCluster},
+				{Name: "storage", Strategy: infrav1.PlacementGroupStrategyPartition, PartitionCount: aws.Int64(3)},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{GroupName: aws.String("hpc"), Strategy: aws.String(ec2.PlacementStrategyCluster), State: aws.String(ec2.PlacementGroupStateAvailable)},
+						},
+					}, nil)
+				m.CreatePlacementGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreatePlacementGroupInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreatePlacementGroupInput, _ ...interface{}) (*ec2.CreatePlacementGroupOutput, error) {
+						if aws.StringValue(input.GroupName) != "storage" || aws.StringValue(input.Strategy) != ec2.PlacementStrategyPartition || aws.Int64Value(input.PartitionCount) != 3 {
+							return nil, errors.New("unexpected placement group creation input")
+						}
+						if len(input.TagSpecifications) != 1 || aws.StringValue(input.TagSpecifications[0].ResourceType) != ec2.ResourceTypePlacementGroup {
+							return nil, errors.New("unexpected placement group tag specifications")
+						}
+						tags := converters.TagsToMap(input.TagSpecifications[0].Tags)
+						if !tags.HasOwned("test-cluster") || tags["Name"] != "storage" {
+							return nil, errors.New("unexpected placement group tags")
+						}
+						return &ec2.CreatePlacementGroupOutput{}, nil
+					})
+			},
+			expectCondition: true,
+		},
+		{
+			name:            "should delete owned placement groups removed from the spec",
+			placementGroups: []infrav1.PlacementGroupSpec{{Name: "hpc", Strategy: infrav1.PlacementGroupStrategyCluster}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{GroupName: aws.String("hpc"), State: aws.String(ec2.PlacementGroupStateAvailable)},
+							{GroupName: aws.String("old"), State: aws.String(ec2.PlacementGroupStateAvailable)},
+							{GroupName: aws.String("gone"), State: aws.String(ec2.PlacementGroupStateDeleted)},
+						},
+					}, nil)
+				m.DeletePlacementGroupWithContext(context.TODO(), gomock.Eq(&ec2.DeletePlacementGroupInput{
+					GroupName: aws.String("old"),
+				})).Return(&ec2.DeletePlacementGroupOutput{}, nil)
+			},
+			expectCondition: true,
+		},
+		{
+			name:         "should delete the remaining placement groups once all are removed from the spec",
+			hasCondition: true,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{GroupName: aws.String("old"), State: aws.String(ec2.PlacementGroupStateAvailable)},
+						},
+					}, nil)
+				m.DeletePlacementGroupWithContext(context.TODO(), gomock.Eq(&ec2.DeletePlacementGroupInput{
+					GroupName: aws.String("old"),
+				})).Return(&ec2.DeletePlacementGroupOutput{}, nil)
+			},
+		},
+		{
+			name:            "should wait for instances to leave placement groups removed from the spec",
+			placementGroups: []infrav1.PlacementGroupSpec{{Name: "hpc", Strategy: infrav1.PlacementGroupStrategyCluster}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{GroupName: aws.String("hpc"), State: aws.String(ec2.PlacementGroupStateAvailable)},
+							{GroupName: aws.String("old"), State: aws.String(ec2.PlacementGroupStateAvailable)},
+						},
+					}, nil)
+				m.DeletePlacementGroupWithContext(context.TODO(), gomock.Eq(&ec2.DeletePlacementGroupInput{
+					GroupName: aws.String("old"),
+				})).Return(nil, awserr.New(awserrors.PlacementGroupInUse, "in use", nil))
+			},
+			expectReason: infrav1.PlacementGroupsInUseReason,
+		},
+		{
+			name:            "should return an error when a placement group cannot be created",
+			placementGroups: []infrav1.PlacementGroupSpec{{Name: "hpc", Strategy: infrav1.PlacementGroupStrategySpread}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{}, nil)
+				m.CreatePlacementGroupWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New("InvalidPlacementGroup.Duplicate", "duplicate", nil))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			clusterScope := newPlacementGroupsClusterScope(g, tc.placementGroups)
+			if tc.hasCondition {
+				conditions.MarkTrue(clusterScope.AWSCluster, infrav1.PlacementGroupsReadyCondition)
+			}
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			err := s.ReconcilePlacementGroups()
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(conditions.IsTrue(clusterScope.AWSCluster, infrav1.PlacementGroupsReadyCondition)).To(Equal(tc.expectCondition))
+			if tc.expectReason != "" {
+				g.Expect(conditions.GetReason(clusterScope.AWSCluster, infrav1.PlacementGroupsReadyCondition)).To(Equal(tc.expectReason))
+			}
+		})
+	}
+}
+
+func TestDeletePlacementGroups(t *testing.T) {
+	describeOutput := &ec2.DescribePlacementGroupsOutput{
+		PlacementGroups: []*ec2.PlacementGroup{
+			{GroupName: aws.String("hpc"), State: aws.String(ec2.PlacementGroupStateAvailable)},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "should delete owned placement groups",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Any()).Return(describeOutput, nil)
+				m.DeletePlacementGroupWithContext(context.TODO(), gomock.Eq(&ec2.DeletePlacementGroupInput{
+					GroupName: aws.String("hpc"),
+				})).Return(&ec2.DeletePlacementGroupOutput{}, nil)
+			},
+		},
+		{
+			name: "should ignore placement groups that do not exist anymore",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Any()).Return(describeOutput, nil)
+				m.DeletePlacementGroupWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(awserrors.PlacementGroupNotFound, "not found", nil))
+			},
+		},
+		{
+			name: "should return an error while instances still run in a placement group",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroupsWithContext(context.TODO(), gomock.Any()).Return(describeOutput, nil)
+				m.DeletePlacementGroupWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(awserrors.PlacementGroupInUse, "in use", nil))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			clusterScope := newPlacementGroupsClusterScope(g, []infrav1.PlacementGroupSpec{
+				{Name: "hpc", Strategy: infrav1.PlacementGroupStrategyCluster},
+			})
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			err := s.DeletePlacementGroups()
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func newPlacementGroupsClusterScope(g *WithT, placementGroups []infrav1.PlacementGroupSpec) *scope.ClusterScope {
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: infrav1.AWSClusterSpec{
+			PlacementGroups: placementGroups,
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).WithStatusSubresource(awsCluster).Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: awsCluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	return clusterScope
+}
@@ -32,6 +32,10 @@ type Service struct {
 
 	// SSMClient is used to look up the official EKS AMI ID
 	SSMClient ssmiface.SSMAPI
+
+	// DescribeCache, when set, serves instances, network interfaces and security groups from the describe cache
+	// shared by the services of the cluster, instead of describing them one by one.
+	DescribeCache *DescribeCache
 }
 
 // NewService returns a new service given the ec2 api client.
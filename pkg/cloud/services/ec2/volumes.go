@@ -0,0 +1,277 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+)
+
+// volumeModificationCooldown is the time AWS requires between two modifications of the same volume.
+const volumeModificationCooldown = 6 * time.Hour
+
+// ModifyInstanceVolumes modifies the volumes attached to an instance so their size, type, IOPS and throughput
+// match the given root and non-root volumes. It returns whether volumes are being modified, and how long to
+// wait before volumes modified less than six hours ago can be modified again.
+func (s *Service) ModifyInstanceVolumes(instance *infrav1.Instance, rootVolume *infrav1.Volume, nonRootVolumes []infrav1.Volume) (bool, time.Duration, error) {
+	desired := desiredInstanceVolumes(instance, rootVolume, nonRootVolumes)
+	if len(desired) == 0 || len(instance.VolumeIDs) == 0 {
+		return false, 0, nil
+	}
+
+	out, err := s.EC2Client.DescribeVolumesWithContext(context.TODO(), &ec2.DescribeVolumesInput{
+		VolumeIds: aws.StringSlice(instance.VolumeIDs),
+	})
+	if err != nil {
+		return false, 0, errors.Wrapf(err, "failed to describe volumes of instance %q", instance.ID)
+	}
+
+	attached := attachedVolumes(out.Volumes, instance.ID)
+	var inputs []*ec2.ModifyVolumeInput
+	for deviceName, spec := range desired {
+		volume, ok := attached[deviceName]
+		if !ok {
+			continue
+		}
+		if input := volumeModificationInput(volume, spec); input != nil {
+			inputs = append(inputs, input)
+		}
+	}
+	sort.Slice(inputs, func(i, j int) bool {
+		return aws.StringValue(inputs[i].VolumeId) < aws.StringValue(inputs[j].VolumeId)
+	})
+	if len(inputs) == 0 {
+		return false, 0, nil
+	}
+
+	ids := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		ids = append(ids, aws.StringValue(input.VolumeId))
+	}
+	modifications, err := s.latestVolumeModifications(ids)
+	if err != nil {
+		return false, 0, err
+	}
+
+	inProgress := false
+	var cooldown time.Duration
+	for _, input := range inputs {
+		volumeID := aws.StringValue(input.VolumeId)
+		if modification, ok := modifications[volumeID]; ok {
+			state := aws.StringValue(modification.ModificationState)
+			if state == ec2.VolumeModificationStateModifying || state == ec2.VolumeModificationStateOptimizing {
+				inProgress = true
+				continue
+			}
+			if state != ec2.VolumeModificationStateFailed {
+				if remaining := volumeModificationCooldown - time.Since(volumeModificationEndTime(modification)); remaining > 0 {
+					s.scope.Debug("Volume was modified recently, waiting before modifying it again", "volume-id", volumeID, "remaining", remaining)
+					if cooldown == 0 || remaining < cooldown {
+						cooldown = remaining
+					}
+					continue
+				}
+			}
+		}
+
+		s.scope.Info("Modifying volume", "instance-id", instance.ID, "volume-id", volumeID)
+		if _, err := s.EC2Client.ModifyVolumeWithContext(context.TODO(), input); err != nil {
+			return inProgress, cooldown, errors.Wrapf(err, "failed to modify volume %q", volumeID)
+		}
+		inProgress = true
+	}
+
+	return inProgress, cooldown, nil
+}
+
+// DriftedVolumes returns the device names of the volumes of an instance whose size, type, IOPS or throughput
+// differ from the given root and non-root volumes, or that are not attached to the instance anymore.
+func (s *Service) DriftedVolumes(instance *infrav1.Instance, rootVolume *infrav1.Volume, nonRootVolumes []infrav1.Volume) ([]string, error) {
+	desired := desiredInstanceVolumes(instance, rootVolume, nonRootVolumes)
+	if len(desired) == 0 || len(instance.VolumeIDs) == 0 {
+		return nil, nil
+	}
+
+	out, err := s.EC2Client.DescribeVolumesWithContext(context.TODO(), &ec2.DescribeVolumesInput{
+		VolumeIds: aws.StringSlice(instance.VolumeIDs),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe volumes of instance %q", instance.ID)
+	}
+
+	attached := attachedVolumes(out.Volumes, instance.ID)
+	var drifted []string
+	for deviceName, spec := range desired {
+		volume, ok := attached[deviceName]
+		if !ok || volumeModificationInput(volume, spec) != nil {
+			drifted = append(drifted, deviceName)
+		}
+	}
+	sort.Strings(drifted)
+	return drifted, nil
+}
+
+// attachedVolumes indexes the given volumes by the device name they are attached to the instance with.
+func attachedVolumes(volumes []*ec2.Volume, instanceID string) map[string]*ec2.Volume {
+	attached := make(map[string]*ec2.Volume, len(volumes))
+	for _, volume := range volumes {
+		for _, attachment := range volume.Attachments {
+			if aws.StringValue(attachment.InstanceId) == instanceID {
+				attached[aws.StringValue(attachment.Device)] = volume
+			}
+		}
+	}
+	return attached
+}
+
+// desiredInstanceVolumes indexes the given root and non-root volumes by device name.
+func desiredInstanceVolumes(instance *infrav1.Instance, rootVolume *infrav1.Volume, nonRootVolumes []infrav1.Volume) map[string]*infrav1.Volume {
+	desired := make(map[string]*infrav1.Volume, len(nonRootVolumes)+1)
+	if rootVolume != nil && instance.RootDeviceName != "" {
+		desired[instance.RootDeviceName] = rootVolume
+	}
+	for i := range nonRootVolumes {
+		desired[nonRootVolumes[i].DeviceName] = &nonRootVolumes[i]
+	}
+	return desired
+}
+
+// latestVolumeModifications returns the most recent modification of each of the given volumes.
+func (s *Service) latestVolumeModifications(volumeIDs []string) (map[string]*ec2.VolumeModification, error) {
+	modifications := make(map[string]*ec2.VolumeModification, len(volumeIDs))
+	input := &ec2.DescribeVolumesModificationsInput{
+		Filters: []*ec2.Filter{filter.EC2.VolumeIDs(volumeIDs...)},
+	}
+	if err := s.EC2Client.DescribeVolumesModificationsPagesWithContext(context.TODO(), input, func(out *ec2.DescribeVolumesModificationsOutput, lastPage bool) bool {
+		for _, modification := range out.VolumesModifications {
+			id := aws.StringValue(modification.VolumeId)
+			if latest, ok := modifications[id]; !ok || aws.TimeValue(modification.StartTime).After(aws.TimeValue(latest.StartTime)) {
+				modifications[id] = modification
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to describe volume modifications")
+	}
+	return modifications, nil
+}
+
+// volumeModificationEndTime returns when a volume modification completed, which is when the cooldown before
+// the next modification of the volume starts. Modifications without an end time are still in progress, so
+// their start time is used.
+func volumeModificationEndTime(modification *ec2.VolumeModification) time.Time {
+	if modification.EndTime != nil {
+		return aws.TimeValue(modification.EndTime)
+	}
+	return aws.TimeValue(modification.StartTime)
+}
+
+// volumeModificationInput returns the modification needed for a volume to match the spec, or nil if it
+// already does. Volumes are never shrunk, and unset IOPS and throughput are left to the volume type's default.
+func volumeModificationInput(volume *ec2.Volume, spec *infrav1.Volume) *ec2.ModifyVolumeInput {
+	input := &ec2.ModifyVolumeInput{VolumeId: volume.VolumeId}
+	modified := false
+
+	if spec.Size > aws.Int64Value(volume.Size) {
+		input.Size = aws.Int64(spec.Size)
+		modified = true
+	}
+	if spec.Type != "" && string(spec.Type) != aws.StringValue(volume.VolumeType) {
+		input.VolumeType = aws.String(string(spec.Type))
+		modified = true
+	}
+	if spec.IOPS != 0 && spec.IOPS != aws.Int64Value(volume.Iops) {
+		input.Iops = aws.Int64(spec.IOPS)
+		modified = true
+	}
+	if spec.Throughput != nil && *spec.Throughput != aws.Int64Value(volume.Throughput) {
+		input.Throughput = spec.Throughput
+		modified = true
+	}
+
+	if !modified {
+		return nil
+	}
+	return input
+}
+
+// checkVolumeSnapshot returns the ID of the snapshot a volume is restored from, after checking the volume
+// is at least as large as the snapshot.
+func (s *Service) checkVolumeSnapshot(volume *infrav1.Volume) (*string, error) {
+	snapshot, err := s.getVolumeSnapshot(volume.Snapshot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get snapshot of volume %q", volume.DeviceName)
+	}
+
+	if volume.Size < aws.Int64Value(snapshot.VolumeSize) {
+		return nil, errors.Errorf("volume %q size (%d) must be greater than or equal to snapshot size (%d)", volume.DeviceName, volume.Size, aws.Int64Value(snapshot.VolumeSize))
+	}
+
+	return snapshot.SnapshotId, nil
+}
+
+// getVolumeSnapshot returns the snapshot with the given ID, or the newest completed snapshot matching the
+// given filters. Snapshots are looked up among the ones owned by the account, unless the filters specify
+// their owner.
+func (s *Service) getVolumeSnapshot(ref *infrav1.AWSResourceReference) (*ec2.Snapshot, error) {
+	input := &ec2.DescribeSnapshotsInput{}
+	if ref.ID != nil {
+		input.SnapshotIds = []*string{ref.ID}
+	} else {
+		input.Filters = []*ec2.Filter{filter.EC2.SnapshotStates(ec2.SnapshotStateCompleted)}
+		hasOwnerFilter := false
+		for _, f := range ref.Filters {
+			input.Filters = append(input.Filters, &ec2.Filter{Name: aws.String(f.Name), Values: aws.StringSlice(f.Values)})
+			if f.Name == "owner-id" || f.Name == "owner-alias" {
+				hasOwnerFilter = true
+			}
+		}
+		if !hasOwnerFilter {
+			input.OwnerIds = aws.StringSlice([]string{"self"})
+		}
+	}
+
+	var newest *ec2.Snapshot
+	if err := s.EC2Client.DescribeSnapshotsPagesWithContext(context.TODO(), input, func(out *ec2.DescribeSnapshotsOutput, lastPage bool) bool {
+		for _, snapshot := range out.Snapshots {
+			if newest == nil || aws.TimeValue(snapshot.StartTime).After(aws.TimeValue(newest.StartTime)) {
+				newest = snapshot
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to describe snapshots")
+	}
+
+	if newest == nil {
+		if ref.ID != nil {
+			return nil, awserrors.NewNotFound(fmt.Sprintf("snapshot %q not found", *ref.ID))
+		}
+		return nil, awserrors.NewFailedDependency(fmt.Sprintf("no snapshots available matching criteria %q", input.Filters))
+	}
+	return newest, nil
+}
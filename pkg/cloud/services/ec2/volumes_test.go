@@ -0,0 +1,443 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestModifyInstanceVolumes(t *testing.T) {
+	instance := &infrav1.Instance{
+		ID:             "i-1",
+		RootDeviceName: "/dev/xvda",
+		VolumeIDs:      []string{"vol-root", "vol-data"},
+	}
+	describeVolumes := func(m *mocks.MockEC2APIMockRecorder) {
+		m.DescribeVolumesWithContext(context.TODO(), &ec2.DescribeVolumesInput{VolumeIds: aws.StringSlice([]string{"vol-root", "vol-data"})}).
+			Return(&ec2.DescribeVolumesOutput{Volumes: []*ec2.Volume{
+				{
+					VolumeId:    aws.String("vol-root"),
+					Size:        aws.Int64(8),
+					VolumeType:  aws.String("gp2"),
+					Iops:        aws.Int64(100),
+					Attachments: []*ec2.VolumeAttachment{{Device: aws.String("/dev/xvda"), InstanceId: aws.String("i-1")}},
+				},
+				{
+					VolumeId:   aws.String("vol-data"),
+					Size:       aws.Int64(20),
+					VolumeType: aws.String("gp3"),
+					Iops:       aws.Int64(3000),
+					Throughput: aws.Int64(125),
+					Attachments: []*ec2.VolumeAttachment{
+						{Device: aws.String("/dev/sdc"), InstanceId: aws.String("i-0")},
+						{Device: aws.String("/dev/sdb"), InstanceId: aws.String("i-1")},
+					},
+				},
+			}}, nil)
+	}
+	describeModifications := func(modifications ...*ec2.VolumeModification) func(m *mocks.MockEC2APIMockRecorder) {
+		return func(m *mocks.MockEC2APIMockRecorder) {
+			m.DescribeVolumesModificationsPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVolumesModificationsInput{}), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ *ec2.DescribeVolumesModificationsInput, fn func(*ec2.DescribeVolumesModificationsOutput, bool) bool, _ ...interface{}) error {
+					fn(&ec2.DescribeVolumesModificationsOutput{VolumesModifications: modifications}, true)
+					return nil
+				})
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		rootVolume     *infrav1.Volume
+		nonRootVolumes []infrav1.Volume
+		expect         []func(m *mocks.MockEC2APIMockRecorder)
+		check          func(g *WithT, inProgress bool, cooldown time.Duration, err error)
+	}{
+		{
+			name:           "Should do nothing if volumes match the spec",
+			rootVolume:     &infrav1.Volume{Size: 8, Type: infrav1.VolumeTypeGP2},
+			nonRootVolumes: []infrav1.Volume{{DeviceName: "/dev/sdb", Size: 20, Type: infrav1.VolumeTypeGP3}},
+			expect:         []func(m *mocks.MockEC2APIMockRecorder){describeVolumes},
+			check: func(g *WithT, inProgress bool, cooldown time.Duration, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(inProgress).To(BeFalse())
+				g.Expect(cooldown).To(BeZero())
+			},
+		},
+		{
+			name:       "Should not shrink volumes",
+			rootVolume: &infrav1.Volume{Size: 4},
+			expect:     []func(m *mocks.MockEC2APIMockRecorder){describeVolumes},
+			check: func(g *WithT, inProgress bool, cooldown time.Duration, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(inProgress).To(BeFalse())
+			},
+		},
+		{
+			name:           "Should modify volumes that differ from the spec",
+			rootVolume:     &infrav1.Volume{Size: 16, Type: infrav1.VolumeTypeGP3},
+			nonRootVolumes: []infrav1.Volume{{DeviceName: "/dev/sdb", Size: 20, Type: infrav1.VolumeTypeGP3, IOPS: 4000, Throughput: aws.Int64(250)}},
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeVolumes,
+				describeModifications(),
+				func(m *mocks.MockEC2APIMockRecorder) {
+					m.ModifyVolumeWithContext(context.TODO(), &ec2.ModifyVolumeInput{
+						VolumeId:   aws.String("vol-root"),
+						Size:       aws.Int64(16),
+						VolumeType: aws.String("gp3"),
+					}).Return(&ec2.ModifyVolumeOutput{}, nil)
+					m.ModifyVolumeWithContext(context.TODO(), &ec2.ModifyVolumeInput{
+						VolumeId:   aws.String("vol-data"),
+						Iops:       aws.Int64(4000),
+						Throughput: aws.Int64(250),
+					}).Return(&ec2.ModifyVolumeOutput{}, nil)
+				},
+			},
+			check: func(g *WithT, inProgress bool, cooldown time.Duration, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(inProgress).To(BeTrue())
+				g.Expect(cooldown).To(BeZero())
+			},
+		},
+		{
+			name:       "Should wait for modifications in progress",
+			rootVolume: &infrav1.Volume{Size: 16},
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeVolumes,
+				describeModifications(&ec2.VolumeModification{
+					VolumeId:          aws.String("vol-root"),
+					ModificationState: aws.String(ec2.VolumeModificationStateModifying),
+					StartTime:         aws.Time(time.Now().Add(-time.Minute)),
+				}),
+			},
+			check: func(g *WithT, inProgress bool, cooldown time.Duration, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(inProgress).To(BeTrue())
+				g.Expect(cooldown).To(BeZero())
+			},
+		},
+		{
+			name:       "Should wait for the cooldown of recently modified volumes",
+			rootVolume: &infrav1.Volume{Size: 32},
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeVolumes,
+				describeModifications(
+					&ec2.VolumeModification{
+						VolumeId:          aws.String("vol-root"),
+						ModificationState: aws.String(ec2.VolumeModificationStateCompleted),
+						StartTime:         aws.Time(time.Now().Add(-7 * time.Hour)),
+					},
+					&ec2.VolumeModification{
+						VolumeId:          aws.String("vol-root"),
+						ModificationState: aws.String(ec2.VolumeModificationStateCompleted),
+						StartTime:         aws.Time(time.Now().Add(-3 * time.Hour)),
+						EndTime:           aws.Time(time.Now().Add(-2 * time.Hour)),
+					},
+				),
+			},
+			check: func(g *WithT, inProgress bool, cooldown time.Duration, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(inProgress).To(BeFalse())
+				g.Expect(cooldown).To(BeNumerically("~", 4*time.Hour, time.Minute))
+			},
+		},
+		{
+			name:       "Should retry failed modifications without waiting for the cooldown",
+			rootVolume: &infrav1.Volume{Size: 16},
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeVolumes,
+				describeModifications(&ec2.VolumeModification{
+					VolumeId:          aws.String("vol-root"),
+					ModificationState: aws.String(ec2.VolumeModificationStateFailed),
+					StartTime:         aws.Time(time.Now().Add(-time.Hour)),
+				}),
+				func(m *mocks.MockEC2APIMockRecorder) {
+					m.ModifyVolumeWithContext(context.TODO(), &ec2.ModifyVolumeInput{
+						VolumeId: aws.String("vol-root"),
+						Size:     aws.Int64(16),
+					}).Return(&ec2.ModifyVolumeOutput{}, nil)
+				},
+			},
+			check: func(g *WithT, inProgress bool, cooldown time.Duration, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(inProgress).To(BeTrue())
+			},
+		},
+		{
+			name:       "Should return an error if a volume cannot be modified",
+			rootVolume: &infrav1.Volume{Size: 16},
+			expect: []func(m *mocks.MockEC2APIMockRecorder){
+				describeVolumes,
+				describeModifications(),
+				func(m *mocks.MockEC2APIMockRecorder) {
+					m.ModifyVolumeWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.ModifyVolumeInput{})).
+						Return(nil, errors.New("IncorrectModificationState"))
+				},
+			},
+			check: func(g *WithT, inProgress bool, cooldown time.Duration, err error) {
+				g.Expect(err).To(HaveOccurred())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			for _, expect := range tc.expect {
+				expect(ec2Mock.EXPECT())
+			}
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			inProgress, cooldown, err := s.ModifyInstanceVolumes(instance, tc.rootVolume, tc.nonRootVolumes)
+			tc.check(g, inProgress, cooldown, err)
+		})
+	}
+}
+
+func TestDriftedVolumes(t *testing.T) {
+	instance := &infrav1.Instance{
+		ID:             "i-1",
+		RootDeviceName: "/dev/xvda",
+		VolumeIDs:      []string{"vol-root", "vol-data"},
+	}
+	describeOutput := &ec2.DescribeVolumesOutput{Volumes: []*ec2.Volume{
+		{
+			VolumeId:    aws.String("vol-root"),
+			Size:        aws.Int64(8),
+			VolumeType:  aws.String("gp2"),
+			Attachments: []*ec2.VolumeAttachment{{Device: aws.String("/dev/xvda"), InstanceId: aws.String("i-1")}},
+		},
+		{
+			VolumeId:    aws.String("vol-data"),
+			Size:        aws.Int64(20),
+			VolumeType:  aws.String("gp3"),
+			Attachments: []*ec2.VolumeAttachment{{Device: aws.String("/dev/sdb"), InstanceId: aws.String("i-1")}},
+		},
+	}}
+
+	testCases := []struct {
+		name           string
+		rootVolume     *infrav1.Volume
+		nonRootVolumes []infrav1.Volume
+		expected       []string
+	}{
+		{
+			name:           "Should not report volumes that match the spec",
+			rootVolume:     &infrav1.Volume{Size: 8, Type: infrav1.VolumeTypeGP2},
+			nonRootVolumes: []infrav1.Volume{{DeviceName: "/dev/sdb", Size: 20, Type: infrav1.VolumeTypeGP3}},
+		},
+		{
+			name:           "Should report volumes that were modified",
+			rootVolume:     &infrav1.Volume{Size: 16, Type: infrav1.VolumeTypeGP2},
+			nonRootVolumes: []infrav1.Volume{{DeviceName: "/dev/sdb", Size: 20, Type: infrav1.VolumeTypeGP3}},
+			expected:       []string{"/dev/xvda"},
+		},
+		{
+			name: "Should report volumes that were detached",
+			nonRootVolumes: []infrav1.Volume{
+				{DeviceName: "/dev/sdb", Size: 20, Type: infrav1.VolumeTypeGP3},
+				{DeviceName: "/dev/sdc", Size: 50, Type: infrav1.VolumeTypeGP3},
+			},
+			expected: []string{"/dev/sdc"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock.EXPECT().DescribeVolumesWithContext(context.TODO(), &ec2.DescribeVolumesInput{VolumeIds: aws.StringSlice([]string{"vol-root", "vol-data"})}).
+				Return(describeOutput, nil)
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			drifted, err := s.DriftedVolumes(instance, tc.rootVolume, tc.nonRootVolumes)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drifted).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestCheckVolumeSnapshot(t *testing.T) {
+	now := time.Now()
+	describeSnapshots := func(input *ec2.DescribeSnapshotsInput, snapshots ...*ec2.Snapshot) func(m *mocks.MockEC2APIMockRecorder) {
+		return func(m *mocks.MockEC2APIMockRecorder) {
+			m.DescribeSnapshotsPagesWithContext(context.TODO(), input, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ *ec2.DescribeSnapshotsInput, fn func(*ec2.DescribeSnapshotsOutput, bool) bool, _ ...interface{}) error {
+					fn(&ec2.DescribeSnapshotsOutput{Snapshots: snapshots}, true)
+					return nil
+				})
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		volume *infrav1.Volume
+		expect func(m *mocks.MockEC2APIMockRecorder)
+		check  func(g *WithT, snapshotID *string, err error)
+	}{
+		{
+			name: "Should return the snapshot with the given ID",
+			volume: &infrav1.Volume{
+				DeviceName: "/dev/sdb",
+				Size:       100,
+				Snapshot:   &infrav1.AWSResourceReference{ID: aws.String("snap-1")},
+			},
+			expect: describeSnapshots(
+				&ec2.DescribeSnapshotsInput{SnapshotIds: aws.StringSlice([]string{"snap-1"})},
+				&ec2.Snapshot{SnapshotId: aws.String("snap-1"), VolumeSize: aws.Int64(50)},
+			),
+			check: func(g *WithT, snapshotID *string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(snapshotID).To(Equal(aws.String("snap-1")))
+			},
+		},
+		{
+			name: "Should return the newest completed snapshot owned by the account matching the filters",
+			volume: &infrav1.Volume{
+				DeviceName: "/dev/sdb",
+				Size:       100,
+				Snapshot: &infrav1.AWSResourceReference{
+					Filters: []infrav1.Filter{{Name: "tag:dataset", Values: []string{"images"}}},
+				},
+			},
+			expect: describeSnapshots(
+				&ec2.DescribeSnapshotsInput{
+					Filters: []*ec2.Filter{
+						{Name: aws.String("status"), Values: aws.StringSlice([]string{ec2.SnapshotStateCompleted})},
+						{Name: aws.String("tag:dataset"), Values: aws.StringSlice([]string{"images"})},
+					},
+					OwnerIds: aws.StringSlice([]string{"self"}),
+				},
+				&ec2.Snapshot{SnapshotId: aws.String("snap-old"), VolumeSize: aws.Int64(50), StartTime: aws.Time(now.Add(-time.Hour))},
+				&ec2.Snapshot{SnapshotId: aws.String("snap-new"), VolumeSize: aws.Int64(50), StartTime: aws.Time(now)},
+			),
+			check: func(g *WithT, snapshotID *string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(snapshotID).To(Equal(aws.String("snap-new")))
+			},
+		},
+		{
+			name: "Should look up snapshots of other accounts when filtering by owner",
+			volume: &infrav1.Volume{
+				DeviceName: "/dev/sdb",
+				Size:       100,
+				Snapshot: &infrav1.AWSResourceReference{
+					Filters: []infrav1.Filter{{Name: "owner-id", Values: []string{"123456789012"}}},
+				},
+			},
+			expect: describeSnapshots(
+				&ec2.DescribeSnapshotsInput{
+					Filters: []*ec2.Filter{
+						{Name: aws.String("status"), Values: aws.StringSlice([]string{ec2.SnapshotStateCompleted})},
+						{Name: aws.String("owner-id"), Values: aws.StringSlice([]string{"123456789012"})},
+					},
+				},
+				&ec2.Snapshot{SnapshotId: aws.String("snap-shared"), VolumeSize: aws.Int64(50)},
+			),
+			check: func(g *WithT, snapshotID *string, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(snapshotID).To(Equal(aws.String("snap-shared")))
+			},
+		},
+		{
+			name: "Should fail if no snapshot matches the filters",
+			volume: &infrav1.Volume{
+				DeviceName: "/dev/sdb",
+				Size:       100,
+				Snapshot: &infrav1.AWSResourceReference{
+					Filters: []infrav1.Filter{{Name: "tag:dataset", Values: []string{"images"}}},
+				},
+			},
+			expect: describeSnapshots(&ec2.DescribeSnapshotsInput{
+				Filters: []*ec2.Filter{
+					{Name: aws.String("status"), Values: aws.StringSlice([]string{ec2.SnapshotStateCompleted})},
+					{Name: aws.String("tag:dataset"), Values: aws.StringSlice([]string{"images"})},
+				},
+				OwnerIds: aws.StringSlice([]string{"self"}),
+			}),
+			check: func(g *WithT, snapshotID *string, err error) {
+				g.Expect(err).To(HaveOccurred())
+			},
+		},
+		{
+			name: "Should fail if the volume is smaller than the snapshot",
+			volume: &infrav1.Volume{
+				DeviceName: "/dev/sdb",
+				Size:       20,
+				Snapshot:   &infrav1.AWSResourceReference{ID: aws.String("snap-1")},
+			},
+			expect: describeSnapshots(
+				&ec2.DescribeSnapshotsInput{SnapshotIds: aws.StringSlice([]string{"snap-1"})},
+				&ec2.Snapshot{SnapshotId: aws.String("snap-1"), VolumeSize: aws.Int64(50)},
+			),
+			check: func(g *WithT, snapshotID *string, err error) {
+				g.Expect(err).To(MatchError(ContainSubstring("must be greater than or equal to snapshot size (50)")))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			snapshotID, err := s.checkVolumeSnapshot(tc.volume)
+			tc.check(g, snapshotID, err)
+		})
+	}
+}
@@ -218,7 +218,12 @@ func (s *NodegroupService) createNodegroup() (*eks.Nodegroup, error) {
 		RemoteAccess:  remoteAccess,
 		UpdateConfig:  s.updateConfig(),
 	}
-	if managedPool.AMIType != nil && (managedPool.AWSLaunchTemplate == nil || managedPool.AWSLaunchTemplate.AMI.ID == nil) {
+	switch {
+	case managedPool.AMIType == nil:
+	case managedPool.AWSLaunchTemplate != nil && managedPool.AWSLaunchTemplate.AMI.ID != nil:
+	// The AMI of a referenced launch template is unknown, amiType CUSTOM declares the template specifies one.
+	case managedPool.LaunchTemplateRef != nil && *managedPool.AMIType == expinfrav1.Custom:
+	default:
 		input.AmiType = aws.String(string(*managedPool.AMIType))
 	}
 	if managedPool.DiskSize != nil {
@@ -242,7 +247,7 @@ func (s *NodegroupService) createNodegroup() (*eks.Nodegroup, error) {
 		}
 		input.CapacityType = aws.String(capacityType)
 	}
-	if managedPool.AWSLaunchTemplate != nil {
+	if managedPool.AWSLaunchTemplate != nil || managedPool.LaunchTemplateRef != nil {
 		input.LaunchTemplate = &eks.LaunchTemplateSpecification{
 			Id:      s.scope.ManagedMachinePool.Status.LaunchTemplateID,
 			Version: s.scope.ManagedMachinePool.Status.LaunchTemplateVersion,
@@ -22,7 +22,11 @@ func (s Service) ReconcileEC2Events() error {
 		return err
 	}
 
-	return s.reconcileRules()
+	if err := s.reconcileRules(); err != nil {
+		return err
+	}
+
+	return s.reconcileLifecycleRule()
 }
 
 // DeleteEC2Events will delete a Service's EC2 events.
@@ -31,5 +35,9 @@ func (s Service) DeleteEC2Events() error {
 		return err
 	}
 
+	if err := s.deleteLifecycleRule(); err != nil {
+		return err
+	}
+
 	return s.deleteSQSQueue()
 }
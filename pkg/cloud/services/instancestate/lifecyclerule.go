@@ -0,0 +1,272 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/pkg/errors"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+)
+
+const (
+	// AutoScalingEventSource is the source of the events sent by EC2 Auto Scaling.
+	AutoScalingEventSource = "aws.autoscaling"
+	// AutoScalingLifecycleActionTerminate defines the event sent when an instance is paused by a terminating
+	// lifecycle hook.
+	AutoScalingLifecycleActionTerminate = "EC2 Instance-terminate Lifecycle Action"
+)
+
+// reconcileLifecycleRule creates the rule forwarding the terminating lifecycle actions of the node drain lifecycle
+// hook to the queue, and authorizes it to send messages to the queue.
+func (s Service) reconcileLifecycleRule() error {
+	ruleResp, err := s.EventBridgeClient.DescribeRule(&eventbridge.DescribeRuleInput{
+		Name: aws.String(s.getLifecycleRuleName()),
+	})
+	if err != nil {
+		if !resourceNotFoundError(err) {
+			return errors.Wrapf(err, "unable to describe rule %s", s.getLifecycleRuleName())
+		}
+		if err := s.createLifecycleRule(); err != nil {
+			return errors.Wrap(err, "unable to create lifecycle rule")
+		}
+		ruleResp, err = s.EventBridgeClient.DescribeRule(&eventbridge.DescribeRuleInput{
+			Name: aws.String(s.getLifecycleRuleName()),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "unable to describe new rule %s", s.getLifecycleRuleName())
+		}
+	}
+
+	queueURLResp, err := s.SQSClient.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName: aws.String(GenerateQueueName(s.scope.Name())),
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to get queue URL")
+	}
+	queueAttrs, err := s.SQSClient.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		AttributeNames: aws.StringSlice([]string{sqs.QueueAttributeNameQueueArn, sqs.QueueAttributeNamePolicy}),
+		QueueUrl:       queueURLResp.QueueUrl,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to get queue attributes")
+	}
+	queueArn := aws.StringValue(queueAttrs.Attributes[sqs.QueueAttributeNameQueueArn])
+
+	targetsResp, err := s.EventBridgeClient.ListTargetsByRule(&eventbridge.ListTargetsByRuleInput{
+		Rule: aws.String(s.getLifecycleRuleName()),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to list targets for rule %s", s.getLifecycleRuleName())
+	}
+
+	targetFound := false
+	for _, target := range targetsResp.Targets {
+		if aws.StringValue(target.Id) == GenerateQueueName(s.scope.Name()) && aws.StringValue(target.Arn) == queueArn {
+			targetFound = true
+		}
+	}
+
+	if !targetFound {
+		_, err = s.EventBridgeClient.PutTargets(&eventbridge.PutTargetsInput{
+			Rule: aws.String(s.getLifecycleRuleName()),
+			Targets: []*eventbridge.Target{{
+				Arn: aws.String(queueArn),
+				Id:  aws.String(GenerateQueueName(s.scope.Name())),
+			}},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "unable to add SQS target %s to rule %s", GenerateQueueName(s.scope.Name()), s.getLifecycleRuleName())
+		}
+	}
+
+	return s.addRuleToQueuePolicy(aws.StringValue(queueURLResp.QueueUrl), queueArn, aws.StringValue(queueAttrs.Attributes[sqs.QueueAttributeNamePolicy]), aws.StringValue(ruleResp.Arn))
+}
+
+func (s Service) createLifecycleRule() error {
+	data, err := json.Marshal(lifecycleEventPattern{
+		Source:     []string{AutoScalingEventSource},
+		DetailType: []string{AutoScalingLifecycleActionTerminate},
+		EventDetail: &lifecycleEventDetail{
+			LifecycleHookNames: []string{expinfrav1.NodeDrainLifecycleHookName},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	// create in disabled state so the rule doesn't pick up the lifecycle actions of all ASGs. As machine pools
+	// with a node drain lifecycle hook get created, the rule will get updated to track their ASG
+	_, err = s.EventBridgeClient.PutRule(&eventbridge.PutRuleInput{
+		Name:         aws.String(s.getLifecycleRuleName()),
+		EventPattern: aws.String(string(data)),
+		State:        aws.String(eventbridge.RuleStateDisabled),
+	})
+	return err
+}
+
+// addRuleToQueuePolicy adds a statement authorizing the lifecycle rule to send messages to the queue to its
+// policy, unless the policy already has it.
+func (s Service) addRuleToQueuePolicy(queueURL, queueArn, queuePolicy, ruleArn string) error {
+	policy := iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		ID:      queueArn,
+	}
+	if queuePolicy != "" {
+		if err := json.Unmarshal([]byte(queuePolicy), &policy); err != nil {
+			return errors.Wrap(err, "unable to JSON unmarshal queue policy")
+		}
+	}
+
+	sid := fmt.Sprintf("CAPAEvents_%s_%s", s.getLifecycleRuleName(), GenerateQueueName(s.scope.Name()))
+	for _, statement := range policy.Statement {
+		if statement.Sid == sid {
+			return nil
+		}
+	}
+
+	policy.Statement = append(policy.Statement, iamv1.StatementEntry{
+		Sid:       sid,
+		Effect:    iamv1.EffectAllow,
+		Principal: iamv1.Principals{iamv1.PrincipalService: iamv1.PrincipalID{"events.amazonaws.com"}},
+		Action:    iamv1.Actions{"sqs:SendMessage"},
+		Resource:  iamv1.Resources{queueArn},
+		Condition: iamv1.Conditions{
+			"ArnEquals": map[string]string{"aws:SourceArn": ruleArn},
+		},
+	})
+	policyData, err := json.Marshal(policy)
+	if err != nil {
+		return errors.Wrap(err, "unable to JSON marshal policy")
+	}
+
+	_, err = s.SQSClient.SetQueueAttributes(&sqs.SetQueueAttributesInput{
+		QueueUrl:   aws.String(queueURL),
+		Attributes: aws.StringMap(map[string]string{sqs.QueueAttributeNamePolicy: string(policyData)}),
+	})
+	return errors.Wrap(err, "unable to update queue attributes")
+}
+
+func (s Service) deleteLifecycleRule() error {
+	_, err := s.EventBridgeClient.RemoveTargets(&eventbridge.RemoveTargetsInput{
+		Rule: aws.String(s.getLifecycleRuleName()),
+		Ids:  aws.StringSlice([]string{GenerateQueueName(s.scope.Name())}),
+	})
+	if err != nil && !resourceNotFoundError(err) {
+		return errors.Wrapf(err, "unable to remove target %s for rule %s", GenerateQueueName(s.scope.Name()), s.getLifecycleRuleName())
+	}
+	_, err = s.EventBridgeClient.DeleteRule(&eventbridge.DeleteRuleInput{
+		Name: aws.String(s.getLifecycleRuleName()),
+	})
+	if err != nil && resourceNotFoundError(err) {
+		return nil
+	}
+
+	return err
+}
+
+// AddASGToEventPattern will add an ASG to the event pattern of the lifecycle rule.
+func (s Service) AddASGToEventPattern(asgName string) error {
+	e, err := s.getLifecycleEventPattern()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range e.EventDetail.AutoScalingGroupNames {
+		if name == asgName {
+			// ASG is already tracked by rule
+			return nil
+		}
+	}
+
+	e.EventDetail.AutoScalingGroupNames = append(e.EventDetail.AutoScalingGroupNames, asgName)
+	return s.putLifecycleEventPattern(e)
+}
+
+// RemoveASGFromEventPattern attempts a best effort update to the lifecycle rule to remove the ASG.
+// Any errors encountered won't be blocking.
+func (s Service) RemoveASGFromEventPattern(asgName string) {
+	e, err := s.getLifecycleEventPattern()
+	if err != nil {
+		return
+	}
+
+	for i, name := range e.EventDetail.AutoScalingGroupNames {
+		if name == asgName {
+			e.EventDetail.AutoScalingGroupNames = append(e.EventDetail.AutoScalingGroupNames[:i], e.EventDetail.AutoScalingGroupNames[i+1:]...)
+			_ = s.putLifecycleEventPattern(e)
+			return
+		}
+	}
+}
+
+func (s Service) getLifecycleEventPattern() (*lifecycleEventPattern, error) {
+	ruleResp, err := s.EventBridgeClient.DescribeRule(&eventbridge.DescribeRuleInput{
+		Name: aws.String(s.getLifecycleRuleName()),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to describe rule %s", s.getLifecycleRuleName())
+	}
+
+	e := &lifecycleEventPattern{}
+	if err := json.Unmarshal([]byte(aws.StringValue(ruleResp.EventPattern)), e); err != nil {
+		return nil, err
+	}
+	if e.EventDetail == nil {
+		e.EventDetail = &lifecycleEventDetail{}
+	}
+	return e, nil
+}
+
+// putLifecycleEventPattern updates the event pattern of the lifecycle rule, which is disabled while it does not
+// track any ASG.
+func (s Service) putLifecycleEventPattern(e *lifecycleEventPattern) error {
+	eventData, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	input := &eventbridge.PutRuleInput{
+		Name:         aws.String(s.getLifecycleRuleName()),
+		EventPattern: aws.String(string(eventData)),
+		State:        aws.String(eventbridge.RuleStateEnabled),
+	}
+	if len(e.EventDetail.AutoScalingGroupNames) == 0 {
+		input.State = aws.String(eventbridge.RuleStateDisabled)
+	}
+	_, err = s.EventBridgeClient.PutRule(input)
+	return err
+}
+
+func (s Service) getLifecycleRuleName() string {
+	return fmt.Sprintf("%s-asg-lifecycle-rule", s.scope.Name())
+}
+
+type lifecycleEventPattern struct {
+	Source      []string              `json:"source"`
+	DetailType  []string              `json:"detail-type,omitempty"`
+	EventDetail *lifecycleEventDetail `json:"detail,omitempty"`
+}
+
+type lifecycleEventDetail struct {
+	AutoScalingGroupNames []string `json:"AutoScalingGroupName,omitempty"`
+	LifecycleHookNames    []string `json:"LifecycleHookName,omitempty"`
+}
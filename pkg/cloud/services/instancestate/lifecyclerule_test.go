@@ -0,0 +1,229 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_eventbridgeiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_sqsiface"
+)
+
+func TestReconcileLifecycleRule(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ruleName := "test-cluster-asg-lifecycle-rule"
+	ec2RuleStatement := iamv1.StatementEntry{
+		Sid:       "CAPAEvents_test-cluster-ec2-rule_test-cluster-queue",
+		Effect:    iamv1.EffectAllow,
+		Principal: iamv1.Principals{iamv1.PrincipalService: iamv1.PrincipalID{"events.amazonaws.com"}},
+		Action:    iamv1.Actions{"sqs:SendMessage"},
+		Resource:  iamv1.Resources{"test-cluster-queue-arn"},
+		Condition: iamv1.Conditions{
+			"ArnEquals": map[string]string{"aws:SourceArn": "ec2-rule-arn"},
+		},
+	}
+	lifecycleRuleStatement := iamv1.StatementEntry{
+		Sid:       "CAPAEvents_test-cluster-asg-lifecycle-rule_test-cluster-queue",
+		Effect:    iamv1.EffectAllow,
+		Principal: iamv1.Principals{iamv1.PrincipalService: iamv1.PrincipalID{"events.amazonaws.com"}},
+		Action:    iamv1.Actions{"sqs:SendMessage"},
+		Resource:  iamv1.Resources{"test-cluster-queue-arn"},
+		Condition: iamv1.Conditions{
+			"ArnEquals": map[string]string{"aws:SourceArn": "rule-arn"},
+		},
+	}
+	policy := func(statements ...iamv1.StatementEntry) string {
+		data, err := json.Marshal(iamv1.PolicyDocument{
+			Version:   iamv1.CurrentVersion,
+			ID:        "test-cluster-queue-arn",
+			Statement: statements,
+		})
+		if err != nil {
+			t.Fatalf("got an unexpected error: %v", err)
+		}
+		return string(data)
+	}
+
+	testCases := []struct {
+		name              string
+		eventBridgeExpect func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder)
+		sqsExpect         func(m *mock_sqsiface.MockSQSAPIMockRecorder)
+		expectErr         bool
+	}{
+		{
+			name: "successfully creates missing rule, target and queue policy statement",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				gomock.InOrder(
+					m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+						Name: aws.String(ruleName),
+					})).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil)),
+					m.PutRule(gomock.Eq(&eventbridge.PutRuleInput{
+						Name:         aws.String(ruleName),
+						State:        aws.String(eventbridge.RuleStateDisabled),
+						EventPattern: aws.String(`{"source":["aws.autoscaling"],"detail-type":["EC2 Instance-terminate Lifecycle Action"],"detail":{"LifecycleHookName":["capa-node-drain"]}}`),
+					})),
+					m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+						Name: aws.String(ruleName),
+					})).Return(&eventbridge.DescribeRuleOutput{Name: aws.String(ruleName), Arn: aws.String("rule-arn")}, nil),
+				)
+				m.ListTargetsByRule(gomock.Eq(&eventbridge.ListTargetsByRuleInput{
+					Rule: aws.String(ruleName),
+				})).Return(&eventbridge.ListTargetsByRuleOutput{}, nil)
+				m.PutTargets(gomock.Eq(&eventbridge.PutTargetsInput{
+					Rule: aws.String(ruleName),
+					Targets: []*eventbridge.Target{{
+						Arn: aws.String("test-cluster-queue-arn"),
+						Id:  aws.String("test-cluster-queue"),
+					}},
+				}))
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.AssignableToTypeOf(&sqs.GetQueueUrlInput{})).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("test-cluster-queue-url")}, nil)
+				m.GetQueueAttributes(gomock.AssignableToTypeOf(&sqs.GetQueueAttributesInput{})).Return(&sqs.GetQueueAttributesOutput{Attributes: aws.StringMap(map[string]string{
+					sqs.QueueAttributeNameQueueArn: "test-cluster-queue-arn",
+					sqs.QueueAttributeNamePolicy:   policy(ec2RuleStatement),
+				})}, nil)
+				m.SetQueueAttributes(gomock.Eq(&sqs.SetQueueAttributesInput{
+					QueueUrl: aws.String("test-cluster-queue-url"),
+					Attributes: aws.StringMap(map[string]string{
+						sqs.QueueAttributeNamePolicy: policy(ec2RuleStatement, lifecycleRuleStatement),
+					}),
+				})).Return(nil, nil)
+			},
+		},
+		{
+			name: "skips creating target and queue policy statement if they already exist",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.DescribeRule(gomock.AssignableToTypeOf(&eventbridge.DescribeRuleInput{})).
+					Return(&eventbridge.DescribeRuleOutput{Name: aws.String(ruleName), Arn: aws.String("rule-arn")}, nil)
+				m.ListTargetsByRule(gomock.AssignableToTypeOf(&eventbridge.ListTargetsByRuleInput{})).Return(&eventbridge.ListTargetsByRuleOutput{
+					Targets: []*eventbridge.Target{{
+						Id:  aws.String("test-cluster-queue"),
+						Arn: aws.String("test-cluster-queue-arn"),
+					}},
+				}, nil)
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.AssignableToTypeOf(&sqs.GetQueueUrlInput{})).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("test-cluster-queue-url")}, nil)
+				m.GetQueueAttributes(gomock.AssignableToTypeOf(&sqs.GetQueueAttributesInput{})).Return(&sqs.GetQueueAttributesOutput{Attributes: aws.StringMap(map[string]string{
+					sqs.QueueAttributeNameQueueArn: "test-cluster-queue-arn",
+					sqs.QueueAttributeNamePolicy:   policy(ec2RuleStatement, lifecycleRuleStatement),
+				})}, nil)
+			},
+		},
+		{
+			name: "returns error if DescribeRule runs into unexpected error",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.DescribeRule(gomock.AssignableToTypeOf(&eventbridge.DescribeRuleInput{})).
+					Return(nil, awserr.New("InternalException", "", nil))
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			eventbridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+			sqsMock := mock_sqsiface.NewMockSQSAPI(mockCtrl)
+			clusterScope, err := setupCluster("test-cluster")
+			g.Expect(err).To(Not(HaveOccurred()))
+			tc.sqsExpect(sqsMock.EXPECT())
+			tc.eventBridgeExpect(eventbridgeMock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EventBridgeClient = eventbridgeMock
+			s.SQSClient = sqsMock
+
+			err = s.reconcileLifecycleRule()
+			if tc.expectErr {
+				g.Expect(err).NotTo(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestASGEventPattern(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ruleName := "test-cluster-asg-lifecycle-rule"
+	emptyPattern := `{"source":["aws.autoscaling"],"detail-type":["EC2 Instance-terminate Lifecycle Action"],"detail":{"LifecycleHookName":["capa-node-drain"]}}`
+	asgPattern := `{"source":["aws.autoscaling"],"detail-type":["EC2 Instance-terminate Lifecycle Action"],"detail":{"AutoScalingGroupName":["asg-a"],"LifecycleHookName":["capa-node-drain"]}}`
+
+	t.Run("adds the ASG to the event pattern and enables the rule", func(t *testing.T) {
+		g := NewWithT(t)
+		eventbridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+		clusterScope, err := setupCluster("test-cluster")
+		g.Expect(err).To(Not(HaveOccurred()))
+		eventbridgeMock.EXPECT().DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+			Name: aws.String(ruleName),
+		})).Return(&eventbridge.DescribeRuleOutput{EventPattern: aws.String(emptyPattern)}, nil)
+		eventbridgeMock.EXPECT().PutRule(gomock.Eq(&eventbridge.PutRuleInput{
+			Name:         aws.String(ruleName),
+			EventPattern: aws.String(asgPattern),
+			State:        aws.String(eventbridge.RuleStateEnabled),
+		}))
+
+		s := NewService(clusterScope)
+		s.EventBridgeClient = eventbridgeMock
+		g.Expect(s.AddASGToEventPattern("asg-a")).To(Succeed())
+	})
+
+	t.Run("does not update the rule if the ASG is already tracked", func(t *testing.T) {
+		g := NewWithT(t)
+		eventbridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+		clusterScope, err := setupCluster("test-cluster")
+		g.Expect(err).To(Not(HaveOccurred()))
+		eventbridgeMock.EXPECT().DescribeRule(gomock.AssignableToTypeOf(&eventbridge.DescribeRuleInput{})).
+			Return(&eventbridge.DescribeRuleOutput{EventPattern: aws.String(asgPattern)}, nil)
+
+		s := NewService(clusterScope)
+		s.EventBridgeClient = eventbridgeMock
+		g.Expect(s.AddASGToEventPattern("asg-a")).To(Succeed())
+	})
+
+	t.Run("removes the last ASG from the event pattern and disables the rule", func(t *testing.T) {
+		g := NewWithT(t)
+		eventbridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+		clusterScope, err := setupCluster("test-cluster")
+		g.Expect(err).To(Not(HaveOccurred()))
+		eventbridgeMock.EXPECT().DescribeRule(gomock.AssignableToTypeOf(&eventbridge.DescribeRuleInput{})).
+			Return(&eventbridge.DescribeRuleOutput{EventPattern: aws.String(asgPattern)}, nil)
+		eventbridgeMock.EXPECT().PutRule(gomock.Eq(&eventbridge.PutRuleInput{
+			Name:         aws.String(ruleName),
+			EventPattern: aws.String(emptyPattern),
+			State:        aws.String(eventbridge.RuleStateDisabled),
+		}))
+
+		s := NewService(clusterScope)
+		s.EventBridgeClient = eventbridgeMock
+		s.RemoveASGFromEventPattern("asg-a")
+	})
+}
@@ -29,8 +29,17 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 )
 
-// Ec2StateChangeNotification defines the EC2 instance's state change notification.
-const Ec2StateChangeNotification = "EC2 Instance State-change Notification"
+const (
+	// Ec2StateChangeNotification defines the EC2 instance's state change notification.
+	Ec2StateChangeNotification = "EC2 Instance State-change Notification"
+	// Ec2SpotInstanceInterruptionWarning defines the two-minute warning sent before a spot instance is interrupted.
+	Ec2SpotInstanceInterruptionWarning = "EC2 Spot Instance Interruption Warning"
+	// Ec2InstanceRebalanceRecommendation defines the signal sent when a spot instance is at elevated risk of interruption.
+	Ec2InstanceRebalanceRecommendation = "EC2 Instance Rebalance Recommendation"
+)
+
+// ec2EventDetailTypes are the EC2 events the rule forwards to the queue.
+var ec2EventDetailTypes = []string{Ec2StateChangeNotification, Ec2SpotInstanceInterruptionWarning, Ec2InstanceRebalanceRecommendation}
 
 // reconcileRules creates rules and attaches the queue as a target.
 func (s Service) reconcileRules() error {
@@ -61,6 +70,10 @@ func (s Service) reconcileRules() error {
 		}
 	}
 
+	if err := s.migrateRule(ruleResp); err != nil {
+		return err
+	}
+
 	queueURLResp, err := s.SQSClient.GetQueueUrl(&sqs.GetQueueUrlInput{
 		QueueName: aws.String(GenerateQueueName(s.scope.Name())),
 	})
@@ -123,12 +136,11 @@ func (s Service) reconcileRules() error {
 
 func (s Service) createRule() error {
 	eventPattern := eventPattern{
-		Source:     []string{"aws.ec2"},
-		DetailType: []string{Ec2StateChangeNotification},
-		EventDetail: &eventDetail{
-			States: []infrav1.InstanceState{infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated},
-		},
+		Source:      []string{"aws.ec2"},
+		DetailType:  ec2EventDetailTypes,
+		EventDetail: &eventDetail{},
 	}
+	eventPattern.EventDetail.setStates(infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated)
 	data, err := json.Marshal(eventPattern)
 	if err != nil {
 		return err
@@ -144,6 +156,33 @@ func (s Service) createRule() error {
 	return err
 }
 
+// migrateRule updates the event pattern of rules created before spot interruption warnings and rebalance
+// recommendations were handled.
+func (s Service) migrateRule(rule *eventbridge.DescribeRuleOutput) error {
+	if rule.EventPattern == nil {
+		return nil
+	}
+	e := eventPattern{}
+	if err := json.Unmarshal([]byte(*rule.EventPattern), &e); err != nil {
+		return err
+	}
+	if len(e.DetailType) == len(ec2EventDetailTypes) && (e.EventDetail == nil || len(e.EventDetail.States) == 0) {
+		return nil
+	}
+
+	e.migrate()
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = s.EventBridgeClient.PutRule(&eventbridge.PutRuleInput{
+		Name:         aws.String(s.getEC2RuleName()),
+		EventPattern: aws.String(string(data)),
+		State:        rule.State,
+	})
+	return errors.Wrapf(err, "unable to update event pattern of rule %s", s.getEC2RuleName())
+}
+
 func (s Service) deleteRules() error {
 	_, err := s.EventBridgeClient.RemoveTargets(&eventbridge.RemoveTargetsInput{
 		Rule: aws.String(s.getEC2RuleName()),
@@ -176,7 +215,7 @@ func (s Service) AddInstanceToEventPattern(instanceID string) error {
 	if err != nil {
 		return err
 	}
-	e.DetailType = []string{Ec2StateChangeNotification}
+	e.migrate()
 
 	for _, r := range e.EventDetail.InstanceIDs {
 		if r == instanceID {
@@ -212,7 +251,7 @@ func (s Service) RemoveInstanceFromEventPattern(instanceID string) {
 	if err != nil {
 		return
 	}
-	e.DetailType = []string{Ec2StateChangeNotification}
+	e.migrate()
 
 	found := false
 	for i, r := range e.EventDetail.InstanceIDs {
@@ -258,7 +297,44 @@ type eventPattern struct {
 	EventDetail *eventDetail `json:"detail,omitempty"`
 }
 
+// migrate subscribes event patterns created before spot interruption warnings and rebalance recommendations
+// were handled to those events.
+func (e *eventPattern) migrate() {
+	e.DetailType = ec2EventDetailTypes
+	if e.EventDetail == nil {
+		e.EventDetail = &eventDetail{}
+	}
+	if len(e.EventDetail.States) > 0 {
+		e.EventDetail.setStates(e.EventDetail.States...)
+	}
+}
+
 type eventDetail struct {
 	InstanceIDs []string                `json:"instance-id,omitempty"`
 	States      []infrav1.InstanceState `json:"state,omitempty"`
+	// Or matches either a state change to one of the given states, or an event without state,
+	// as spot interruption warnings and rebalance recommendations do not have one.
+	Or []eventDetailCondition `json:"$or,omitempty"`
+}
+
+type eventDetailCondition struct {
+	States []interface{} `json:"state"`
+}
+
+// setStates filters state change notifications on the given states without filtering out other events.
+func (d *eventDetail) setStates(states ...infrav1.InstanceState) {
+	d.States = nil
+	d.Or = nil
+	if len(states) == 0 {
+		return
+	}
+
+	matchStates := make([]interface{}, 0, len(states))
+	for _, state := range states {
+		matchStates = append(matchStates, state)
+	}
+	d.Or = []eventDetailCondition{
+		{States: matchStates},
+		{States: []interface{}{map[string]bool{"exists": false}}},
+	}
 }
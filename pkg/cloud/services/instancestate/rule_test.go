@@ -53,9 +53,12 @@ func TestReconcileRules(t *testing.T) {
 				})).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil))
 				e := &eventPattern{
 					Source:     []string{"aws.ec2"},
-					DetailType: []string{Ec2StateChangeNotification},
+					DetailType: ec2EventDetailTypes,
 					EventDetail: &eventDetail{
-						States: []infrav1.InstanceState{infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated},
+						Or: []eventDetailCondition{
+							{States: []interface{}{infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated}},
+							{States: []interface{}{map[string]bool{"exists": false}}},
+						},
 					},
 				}
 				data, err := json.Marshal(e)
@@ -124,6 +127,40 @@ func TestReconcileRules(t *testing.T) {
 				m.GetQueueAttributes(gomock.AssignableToTypeOf(&sqs.GetQueueAttributesInput{})).Return(&sqs.GetQueueAttributesOutput{Attributes: aws.StringMap(attrs)}, nil)
 			},
 		},
+		{
+			name: "subscribes existing rule to spot interruption warnings and rebalance recommendations",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				oldPattern := `{"source":["aws.ec2"],"detail-type":["EC2 Instance State-change Notification"],"detail":{"instance-id":["instance-a"],"state":["shutting-down","terminated"]}}`
+				m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+					Name: aws.String(ruleName),
+				})).Return(&eventbridge.DescribeRuleOutput{
+					Name:         aws.String(ruleName),
+					Arn:          aws.String("rule-arn"),
+					EventPattern: aws.String(oldPattern),
+					State:        aws.String(eventbridge.RuleStateEnabled),
+				}, nil)
+				newPattern := `{"source":["aws.ec2"],"detail-type":["EC2 Instance State-change Notification","EC2 Spot Instance Interruption Warning","EC2 Instance Rebalance Recommendation"],"detail":{"instance-id":["instance-a"],"$or":[{"state":["shutting-down","terminated"]},{"state":[{"exists":false}]}]}}`
+				m.PutRule(gomock.Eq(&eventbridge.PutRuleInput{
+					Name:         aws.String(ruleName),
+					State:        aws.String(eventbridge.RuleStateEnabled),
+					EventPattern: aws.String(newPattern),
+				}))
+				m.ListTargetsByRule(gomock.AssignableToTypeOf(&eventbridge.ListTargetsByRuleInput{})).Return(&eventbridge.ListTargetsByRuleOutput{
+					Targets: []*eventbridge.Target{{
+						Id:  aws.String("test-cluster-queue"),
+						Arn: aws.String("test-cluster-queue-arn"),
+					}},
+				}, nil)
+			},
+			postCreateEventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.AssignableToTypeOf(&sqs.GetQueueUrlInput{})).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("test-cluster-queue-url")}, nil)
+				attrs := make(map[string]string)
+				attrs[sqs.QueueAttributeNameQueueArn] = "test-cluster-queue-arn"
+				attrs[sqs.QueueAttributeNamePolicy] = "some policy"
+				m.GetQueueAttributes(gomock.AssignableToTypeOf(&sqs.GetQueueAttributesInput{})).Return(&sqs.GetQueueAttributesOutput{Attributes: aws.StringMap(attrs)}, nil)
+			},
+		},
 		{
 			name: "returns error if DescribeRule runs into unexpected error",
 			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
@@ -263,6 +300,7 @@ func TestAddInstanceToRule(t *testing.T) {
 					EventPattern: aws.String(string(patternData)),
 				}, nil)
 				expectedPattern := pattern
+				expectedPattern.DetailType = ec2EventDetailTypes
 				expectedPattern.EventDetail.InstanceIDs = append(expectedPattern.EventDetail.InstanceIDs, "instance-b")
 				expectedData, err := json.Marshal(expectedPattern)
 				if err != nil {
@@ -347,6 +385,7 @@ func TestRemoveInstanceStateFromEventPattern(t *testing.T) {
 					EventPattern: aws.String(string(patternData)),
 				}, nil)
 				expectedPattern := pattern
+				expectedPattern.DetailType = ec2EventDetailTypes
 				expectedPattern.EventDetail.InstanceIDs = []string{}
 				expectedData, err := json.Marshal(expectedPattern)
 				if err != nil {
@@ -370,6 +409,7 @@ func TestRemoveInstanceStateFromEventPattern(t *testing.T) {
 					EventPattern: aws.String(string(patternData)),
 				}, nil)
 				expectedPattern := pattern
+				expectedPattern.DetailType = ec2EventDetailTypes
 				expectedPattern.EventDetail.InstanceIDs = []string{"instance-a", "instance-c"}
 				expectedData, err := json.Marshal(expectedPattern)
 				if err != nil {
@@ -17,6 +17,8 @@ limitations under the License.
 package services
 
 import (
+	"time"
+
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
@@ -38,12 +40,23 @@ type ASGInterface interface {
 	GetASGByName(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error)
 	CreateASG(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error)
 	UpdateASG(scope *scope.MachinePoolScope) error
+	UpdateASGCapacity(name string, minSize, maxSize, desiredCapacity int32) error
+	TerminateASGInstance(instanceID string, decrementCapacity bool) error
 	StartASGInstanceRefresh(scope *scope.MachinePoolScope) error
 	CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (bool, error)
+	GetLatestInstanceRefresh(scope *scope.MachinePoolScope) (*expinfrav1.InstanceRefreshStatus, error)
+	RollbackASGInstanceRefresh(scope *scope.MachinePoolScope) error
+	GetAlarmsInAlarmState(alarmARNs []string) ([]string, error)
 	UpdateResourceTags(resourceID *string, create, remove map[string]string) error
 	DeleteASGAndWait(id string) error
 	SuspendProcesses(name string, processes []string) error
 	ResumeProcesses(name string, processes []string) error
+	ReconcileWarmPool(scope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) error
+	ReconcileLoadBalancers(scope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) error
+	ReconcileLifecycleHooks(scope *scope.MachinePoolScope) error
+	ReconcileScalingPolicies(scope *scope.MachinePoolScope) error
+	ReconcileScheduledActions(scope *scope.MachinePoolScope) error
+	CompleteLifecycleAction(asgName, hookName, instanceID string) error
 	SubnetIDs(scope *scope.MachinePoolScope) ([]string, error)
 }
 
@@ -52,6 +65,8 @@ type ASGInterface interface {
 type EC2Interface interface {
 	InstanceIfExists(id *string) (*infrav1.Instance, error)
 	TerminateInstance(id string) error
+	StopInstance(instanceID string, hibernate bool) error
+	StartInstance(instanceID string) error
 	CreateInstance(scope *scope.MachineScope, userData []byte, userDataFormat string) (*infrav1.Instance, error)
 	GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.Instance, error)
 
@@ -61,8 +76,14 @@ type EC2Interface interface {
 	UpdateInstanceSecurityGroups(id string, securityGroups []string) error
 	UpdateResourceTags(resourceID *string, create, remove map[string]string) error
 	ModifyInstanceMetadataOptions(instanceID string, options *infrav1.InstanceMetadataOptions) error
+	ModifyInstanceProtection(instanceID string, enabled bool) error
+	ModifyInstanceStopProtection(instanceID string, enabled bool) error
+	ModifyInstanceIAMProfile(instanceID string, profile string) error
+	ModifyInstanceVolumes(instance *infrav1.Instance, rootVolume *infrav1.Volume, nonRootVolumes []infrav1.Volume) (bool, time.Duration, error)
+	DriftedVolumes(instance *infrav1.Instance, rootVolume *infrav1.Volume, nonRootVolumes []infrav1.Volume) ([]string, error)
 
 	TerminateInstanceAndWait(instanceID string) error
+	ReleaseDedicatedHostIfEmpty(hostID string) (released, terminating bool, err error)
 	DetachSecurityGroupsFromNetworkInterface(groups []string, interfaceID string) error
 
 	ReconcileLaunchTemplate(scope scope.LaunchTemplateScope, canUpdateLaunchTemplate func() (bool, error), runPostLaunchTemplateUpdateOperation func() error) error
@@ -72,13 +93,18 @@ type EC2Interface interface {
 	GetLaunchTemplate(id string) (lt *expinfrav1.AWSLaunchTemplate, userDataHash string, err error)
 	GetLaunchTemplateID(id string) (string, error)
 	GetLaunchTemplateLatestVersion(id string) (string, error)
+	ResolveLaunchTemplateVersion(ref *infrav1.LaunchTemplateReference) (string, error)
+	ResolveLaunchTemplate(ref *infrav1.LaunchTemplateReference) (*infrav1.LaunchTemplateReference, error)
 	CreateLaunchTemplate(scope scope.LaunchTemplateScope, imageID *string, userData []byte) (string, error)
 	CreateLaunchTemplateVersion(id string, scope scope.LaunchTemplateScope, imageID *string, userData []byte) error
 	PruneLaunchTemplateVersions(id string) error
 	DeleteLaunchTemplate(id string) error
 	LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) (bool, error)
+	GetInstanceTypesFromInstanceRequirements(requirements *expinfrav1.InstanceRequirements) ([]string, error)
 	DeleteBastion() error
 	ReconcileBastion() error
+	DeletePlacementGroups() error
+	ReconcilePlacementGroups() error
 }
 
 // SecretInterface encapsulated the methods exposed to the
@@ -87,6 +113,7 @@ type SecretInterface interface {
 	Delete(m *scope.MachineScope) error
 	Create(m *scope.MachineScope, data []byte) (string, int32, error)
 	UserData(secretPrefix string, chunks int32, region string, endpoints []scope.ServiceEndpoint) ([]byte, error)
+	PowerShellUserData(secretPrefix string, chunks int32, region string, endpoints []scope.ServiceEndpoint) ([]byte, error)
 }
 
 // ELBInterface encapsulates the methods exposed to the cluster and machine
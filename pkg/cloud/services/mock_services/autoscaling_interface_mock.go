@@ -81,6 +81,20 @@ func (mr *MockASGInterfaceMockRecorder) CanStartASGInstanceRefresh(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanStartASGInstanceRefresh", reflect.TypeOf((*MockASGInterface)(nil).CanStartASGInstanceRefresh), arg0)
 }
 
+// CompleteLifecycleAction mocks base method.
+func (m *MockASGInterface) CompleteLifecycleAction(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteLifecycleAction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteLifecycleAction indicates an expected call of CompleteLifecycleAction.
+func (mr *MockASGInterfaceMockRecorder) CompleteLifecycleAction(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteLifecycleAction", reflect.TypeOf((*MockASGInterface)(nil).CompleteLifecycleAction), arg0, arg1, arg2)
+}
+
 // CreateASG mocks base method.
 func (m *MockASGInterface) CreateASG(arg0 *scope.MachinePoolScope) (*v1beta2.AutoScalingGroup, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +139,106 @@ func (mr *MockASGInterfaceMockRecorder) GetASGByName(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetASGByName", reflect.TypeOf((*MockASGInterface)(nil).GetASGByName), arg0)
 }
 
+// GetAlarmsInAlarmState mocks base method.
+func (m *MockASGInterface) GetAlarmsInAlarmState(arg0 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlarmsInAlarmState", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlarmsInAlarmState indicates an expected call of GetAlarmsInAlarmState.
+func (mr *MockASGInterfaceMockRecorder) GetAlarmsInAlarmState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlarmsInAlarmState", reflect.TypeOf((*MockASGInterface)(nil).GetAlarmsInAlarmState), arg0)
+}
+
+// GetLatestInstanceRefresh mocks base method.
+func (m *MockASGInterface) GetLatestInstanceRefresh(arg0 *scope.MachinePoolScope) (*v1beta2.InstanceRefreshStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestInstanceRefresh", arg0)
+	ret0, _ := ret[0].(*v1beta2.InstanceRefreshStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestInstanceRefresh indicates an expected call of GetLatestInstanceRefresh.
+func (mr *MockASGInterfaceMockRecorder) GetLatestInstanceRefresh(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestInstanceRefresh", reflect.TypeOf((*MockASGInterface)(nil).GetLatestInstanceRefresh), arg0)
+}
+
+// ReconcileLifecycleHooks mocks base method.
+func (m *MockASGInterface) ReconcileLifecycleHooks(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileLifecycleHooks", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileLifecycleHooks indicates an expected call of ReconcileLifecycleHooks.
+func (mr *MockASGInterfaceMockRecorder) ReconcileLifecycleHooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileLifecycleHooks", reflect.TypeOf((*MockASGInterface)(nil).ReconcileLifecycleHooks), arg0)
+}
+
+// ReconcileLoadBalancers mocks base method.
+func (m *MockASGInterface) ReconcileLoadBalancers(arg0 *scope.MachinePoolScope, arg1 *v1beta2.AutoScalingGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileLoadBalancers", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileLoadBalancers indicates an expected call of ReconcileLoadBalancers.
+func (mr *MockASGInterfaceMockRecorder) ReconcileLoadBalancers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileLoadBalancers", reflect.TypeOf((*MockASGInterface)(nil).ReconcileLoadBalancers), arg0, arg1)
+}
+
+// ReconcileScalingPolicies mocks base method.
+func (m *MockASGInterface) ReconcileScalingPolicies(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileScalingPolicies", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileScalingPolicies indicates an expected call of ReconcileScalingPolicies.
+func (mr *MockASGInterfaceMockRecorder) ReconcileScalingPolicies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileScalingPolicies", reflect.TypeOf((*MockASGInterface)(nil).ReconcileScalingPolicies), arg0)
+}
+
+// ReconcileScheduledActions mocks base method.
+func (m *MockASGInterface) ReconcileScheduledActions(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileScheduledActions", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileScheduledActions indicates an expected call of ReconcileScheduledActions.
+func (mr *MockASGInterfaceMockRecorder) ReconcileScheduledActions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileScheduledActions", reflect.TypeOf((*MockASGInterface)(nil).ReconcileScheduledActions), arg0)
+}
+
+// ReconcileWarmPool mocks base method.
+func (m *MockASGInterface) ReconcileWarmPool(arg0 *scope.MachinePoolScope, arg1 *v1beta2.AutoScalingGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileWarmPool", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileWarmPool indicates an expected call of ReconcileWarmPool.
+func (mr *MockASGInterfaceMockRecorder) ReconcileWarmPool(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileWarmPool", reflect.TypeOf((*MockASGInterface)(nil).ReconcileWarmPool), arg0, arg1)
+}
+
 // ResumeProcesses mocks base method.
 func (m *MockASGInterface) ResumeProcesses(arg0 string, arg1 []string) error {
 	m.ctrl.T.Helper()
@@ -139,6 +253,20 @@ func (mr *MockASGInterfaceMockRecorder) ResumeProcesses(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeProcesses", reflect.TypeOf((*MockASGInterface)(nil).ResumeProcesses), arg0, arg1)
 }
 
+// RollbackASGInstanceRefresh mocks base method.
+func (m *MockASGInterface) RollbackASGInstanceRefresh(arg0 *scope.MachinePoolScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackASGInstanceRefresh", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackASGInstanceRefresh indicates an expected call of RollbackASGInstanceRefresh.
+func (mr *MockASGInterfaceMockRecorder) RollbackASGInstanceRefresh(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackASGInstanceRefresh", reflect.TypeOf((*MockASGInterface)(nil).RollbackASGInstanceRefresh), arg0)
+}
+
 // StartASGInstanceRefresh mocks base method.
 func (m *MockASGInterface) StartASGInstanceRefresh(arg0 *scope.MachinePoolScope) error {
 	m.ctrl.T.Helper()
@@ -182,6 +310,20 @@ func (mr *MockASGInterfaceMockRecorder) SuspendProcesses(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendProcesses", reflect.TypeOf((*MockASGInterface)(nil).SuspendProcesses), arg0, arg1)
 }
 
+// TerminateASGInstance mocks base method.
+func (m *MockASGInterface) TerminateASGInstance(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerminateASGInstance", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TerminateASGInstance indicates an expected call of TerminateASGInstance.
+func (mr *MockASGInterfaceMockRecorder) TerminateASGInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateASGInstance", reflect.TypeOf((*MockASGInterface)(nil).TerminateASGInstance), arg0, arg1)
+}
+
 // UpdateASG mocks base method.
 func (m *MockASGInterface) UpdateASG(arg0 *scope.MachinePoolScope) error {
 	m.ctrl.T.Helper()
@@ -196,6 +338,20 @@ func (mr *MockASGInterfaceMockRecorder) UpdateASG(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateASG", reflect.TypeOf((*MockASGInterface)(nil).UpdateASG), arg0)
 }
 
+// UpdateASGCapacity mocks base method.
+func (m *MockASGInterface) UpdateASGCapacity(arg0 string, arg1, arg2, arg3 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateASGCapacity", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateASGCapacity indicates an expected call of UpdateASGCapacity.
+func (mr *MockASGInterfaceMockRecorder) UpdateASGCapacity(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateASGCapacity", reflect.TypeOf((*MockASGInterface)(nil).UpdateASGCapacity), arg0, arg1, arg2, arg3)
+}
+
 // UpdateResourceTags mocks base method.
 func (m *MockASGInterface) UpdateResourceTags(arg0 *string, arg1, arg2 map[string]string) error {
 	m.ctrl.T.Helper()
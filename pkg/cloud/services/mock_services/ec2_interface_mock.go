@@ -22,6 +22,7 @@ package mock_services
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	v1beta2 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -124,6 +125,20 @@ func (mr *MockEC2InterfaceMockRecorder) DeleteLaunchTemplate(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLaunchTemplate", reflect.TypeOf((*MockEC2Interface)(nil).DeleteLaunchTemplate), arg0)
 }
 
+// DeletePlacementGroups mocks base method.
+func (m *MockEC2Interface) DeletePlacementGroups() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlacementGroups")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlacementGroups indicates an expected call of DeletePlacementGroups.
+func (mr *MockEC2InterfaceMockRecorder) DeletePlacementGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlacementGroups", reflect.TypeOf((*MockEC2Interface)(nil).DeletePlacementGroups))
+}
+
 // DetachSecurityGroupsFromNetworkInterface mocks base method.
 func (m *MockEC2Interface) DetachSecurityGroupsFromNetworkInterface(arg0 []string, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -153,6 +168,21 @@ func (mr *MockEC2InterfaceMockRecorder) DiscoverLaunchTemplateAMI(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverLaunchTemplateAMI", reflect.TypeOf((*MockEC2Interface)(nil).DiscoverLaunchTemplateAMI), arg0)
 }
 
+// DriftedVolumes mocks base method.
+func (m *MockEC2Interface) DriftedVolumes(arg0 *v1beta2.Instance, arg1 *v1beta2.Volume, arg2 []v1beta2.Volume) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftedVolumes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DriftedVolumes indicates an expected call of DriftedVolumes.
+func (mr *MockEC2InterfaceMockRecorder) DriftedVolumes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftedVolumes", reflect.TypeOf((*MockEC2Interface)(nil).DriftedVolumes), arg0, arg1, arg2)
+}
+
 // GetAdditionalSecurityGroupsIDs mocks base method.
 func (m *MockEC2Interface) GetAdditionalSecurityGroupsIDs(arg0 []v1beta2.AWSResourceReference) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -198,6 +228,21 @@ func (mr *MockEC2InterfaceMockRecorder) GetInstanceSecurityGroups(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceSecurityGroups", reflect.TypeOf((*MockEC2Interface)(nil).GetInstanceSecurityGroups), arg0)
 }
 
+// GetInstanceTypesFromInstanceRequirements mocks base method.
+func (m *MockEC2Interface) GetInstanceTypesFromInstanceRequirements(arg0 *v1beta20.InstanceRequirements) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceTypesFromInstanceRequirements", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstanceTypesFromInstanceRequirements indicates an expected call of GetInstanceTypesFromInstanceRequirements.
+func (mr *MockEC2InterfaceMockRecorder) GetInstanceTypesFromInstanceRequirements(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceTypesFromInstanceRequirements", reflect.TypeOf((*MockEC2Interface)(nil).GetInstanceTypesFromInstanceRequirements), arg0)
+}
+
 // GetLaunchTemplate mocks base method.
 func (m *MockEC2Interface) GetLaunchTemplate(arg0 string) (*v1beta20.AWSLaunchTemplate, string, error) {
 	m.ctrl.T.Helper()
@@ -289,6 +334,20 @@ func (mr *MockEC2InterfaceMockRecorder) LaunchTemplateNeedsUpdate(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LaunchTemplateNeedsUpdate", reflect.TypeOf((*MockEC2Interface)(nil).LaunchTemplateNeedsUpdate), arg0, arg1, arg2)
 }
 
+// ModifyInstanceIAMProfile mocks base method.
+func (m *MockEC2Interface) ModifyInstanceIAMProfile(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyInstanceIAMProfile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModifyInstanceIAMProfile indicates an expected call of ModifyInstanceIAMProfile.
+func (mr *MockEC2InterfaceMockRecorder) ModifyInstanceIAMProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyInstanceIAMProfile", reflect.TypeOf((*MockEC2Interface)(nil).ModifyInstanceIAMProfile), arg0, arg1)
+}
+
 // ModifyInstanceMetadataOptions mocks base method.
 func (m *MockEC2Interface) ModifyInstanceMetadataOptions(arg0 string, arg1 *v1beta2.InstanceMetadataOptions) error {
 	m.ctrl.T.Helper()
@@ -303,6 +362,50 @@ func (mr *MockEC2InterfaceMockRecorder) ModifyInstanceMetadataOptions(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyInstanceMetadataOptions", reflect.TypeOf((*MockEC2Interface)(nil).ModifyInstanceMetadataOptions), arg0, arg1)
 }
 
+// ModifyInstanceProtection mocks base method.
+func (m *MockEC2Interface) ModifyInstanceProtection(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyInstanceProtection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModifyInstanceProtection indicates an expected call of ModifyInstanceProtection.
+func (mr *MockEC2InterfaceMockRecorder) ModifyInstanceProtection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyInstanceProtection", reflect.TypeOf((*MockEC2Interface)(nil).ModifyInstanceProtection), arg0, arg1)
+}
+
+// ModifyInstanceStopProtection mocks base method.
+func (m *MockEC2Interface) ModifyInstanceStopProtection(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyInstanceStopProtection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModifyInstanceStopProtection indicates an expected call of ModifyInstanceStopProtection.
+func (mr *MockEC2InterfaceMockRecorder) ModifyInstanceStopProtection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyInstanceStopProtection", reflect.TypeOf((*MockEC2Interface)(nil).ModifyInstanceStopProtection), arg0, arg1)
+}
+
+// ModifyInstanceVolumes mocks base method.
+func (m *MockEC2Interface) ModifyInstanceVolumes(arg0 *v1beta2.Instance, arg1 *v1beta2.Volume, arg2 []v1beta2.Volume) (bool, time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyInstanceVolumes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ModifyInstanceVolumes indicates an expected call of ModifyInstanceVolumes.
+func (mr *MockEC2InterfaceMockRecorder) ModifyInstanceVolumes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyInstanceVolumes", reflect.TypeOf((*MockEC2Interface)(nil).ModifyInstanceVolumes), arg0, arg1, arg2)
+}
+
 // PruneLaunchTemplateVersions mocks base method.
 func (m *MockEC2Interface) PruneLaunchTemplateVersions(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -345,6 +448,20 @@ func (mr *MockEC2InterfaceMockRecorder) ReconcileLaunchTemplate(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileLaunchTemplate", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileLaunchTemplate), arg0, arg1, arg2)
 }
 
+// ReconcilePlacementGroups mocks base method.
+func (m *MockEC2Interface) ReconcilePlacementGroups() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcilePlacementGroups")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcilePlacementGroups indicates an expected call of ReconcilePlacementGroups.
+func (mr *MockEC2InterfaceMockRecorder) ReconcilePlacementGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcilePlacementGroups", reflect.TypeOf((*MockEC2Interface)(nil).ReconcilePlacementGroups))
+}
+
 // ReconcileTags mocks base method.
 func (m *MockEC2Interface) ReconcileTags(arg0 scope.LaunchTemplateScope, arg1 []scope.ResourceServiceToUpdate) error {
 	m.ctrl.T.Helper()
@@ -359,6 +476,80 @@ func (mr *MockEC2InterfaceMockRecorder) ReconcileTags(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileTags", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileTags), arg0, arg1)
 }
 
+// ReleaseDedicatedHostIfEmpty mocks base method.
+func (m *MockEC2Interface) ReleaseDedicatedHostIfEmpty(arg0 string) (bool, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDedicatedHostIfEmpty", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReleaseDedicatedHostIfEmpty indicates an expected call of ReleaseDedicatedHostIfEmpty.
+func (mr *MockEC2InterfaceMockRecorder) ReleaseDedicatedHostIfEmpty(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDedicatedHostIfEmpty", reflect.TypeOf((*MockEC2Interface)(nil).ReleaseDedicatedHostIfEmpty), arg0)
+}
+
+// ResolveLaunchTemplate mocks base method.
+func (m *MockEC2Interface) ResolveLaunchTemplate(arg0 *v1beta2.LaunchTemplateReference) (*v1beta2.LaunchTemplateReference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveLaunchTemplate", arg0)
+	ret0, _ := ret[0].(*v1beta2.LaunchTemplateReference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveLaunchTemplate indicates an expected call of ResolveLaunchTemplate.
+func (mr *MockEC2InterfaceMockRecorder) ResolveLaunchTemplate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLaunchTemplate", reflect.TypeOf((*MockEC2Interface)(nil).ResolveLaunchTemplate), arg0)
+}
+
+// ResolveLaunchTemplateVersion mocks base method.
+func (m *MockEC2Interface) ResolveLaunchTemplateVersion(arg0 *v1beta2.LaunchTemplateReference) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveLaunchTemplateVersion", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveLaunchTemplateVersion indicates an expected call of ResolveLaunchTemplateVersion.
+func (mr *MockEC2InterfaceMockRecorder) ResolveLaunchTemplateVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLaunchTemplateVersion", reflect.TypeOf((*MockEC2Interface)(nil).ResolveLaunchTemplateVersion), arg0)
+}
+
+// StartInstance mocks base method.
+func (m *MockEC2Interface) StartInstance(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartInstance", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartInstance indicates an expected call of StartInstance.
+func (mr *MockEC2InterfaceMockRecorder) StartInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartInstance", reflect.TypeOf((*MockEC2Interface)(nil).StartInstance), arg0)
+}
+
+// StopInstance mocks base method.
+func (m *MockEC2Interface) StopInstance(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopInstance", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopInstance indicates an expected call of StopInstance.
+func (mr *MockEC2InterfaceMockRecorder) StopInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopInstance", reflect.TypeOf((*MockEC2Interface)(nil).StopInstance), arg0, arg1)
+}
+
 // TerminateInstance mocks base method.
 func (m *MockEC2Interface) TerminateInstance(arg0 string) error {
 	m.ctrl.T.Helper()
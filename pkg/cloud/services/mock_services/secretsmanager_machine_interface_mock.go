@@ -80,6 +80,21 @@ func (mr *MockSecretInterfaceMockRecorder) Delete(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSecretInterface)(nil).Delete), arg0)
 }
 
+// PowerShellUserData mocks base method.
+func (m *MockSecretInterface) PowerShellUserData(arg0 string, arg1 int32, arg2 string, arg3 []scope.ServiceEndpoint) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerShellUserData", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PowerShellUserData indicates an expected call of PowerShellUserData.
+func (mr *MockSecretInterfaceMockRecorder) PowerShellUserData(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerShellUserData", reflect.TypeOf((*MockSecretInterface)(nil).PowerShellUserData), arg0, arg1, arg2, arg3)
+}
+
 // UserData mocks base method.
 func (m *MockSecretInterface) UserData(arg0 string, arg1 int32, arg2 string, arg3 []scope.ServiceEndpoint) ([]byte, error) {
 	m.ctrl.T.Helper()
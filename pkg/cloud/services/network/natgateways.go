@@ -272,6 +272,33 @@ func (s *Service) createNatGateway(subnetID, ip string) (*ec2.NatGateway, error)
 	return out.NatGateway, nil
 }
 
+// hibernateNatGateways deletes the NAT gateways of the cluster while it is hibernated. They are created again
+// by reconcileNatGateways once the cluster is resumed.
+func (s *Service) hibernateNatGateways() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping NAT gateway hibernation in unmanaged mode")
+		return nil
+	}
+
+	s.scope.Debug("Deleting NAT gateways of hibernated cluster")
+
+	if err := s.deleteNatGateways(); err != nil {
+		return err
+	}
+
+	subnets := s.scope.Subnets()
+	for i := range subnets {
+		if subnets[i].IsPublic {
+			subnets[i].NatGatewayID = nil
+		}
+	}
+	s.scope.SetSubnets(subnets)
+	s.scope.SetNatGatewaysIPs([]string{})
+
+	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, infrav1.NatGatewaysHibernatedReason, clusterv1.ConditionSeverityInfo, "")
+	return nil
+}
+
 func (s *Service) deleteNatGateway(id string) error {
 	_, err := s.EC2Client.DeleteNatGatewayWithContext(context.TODO(), &ec2.DeleteNatGatewayInput{
 		NatGatewayId: aws.String(id),
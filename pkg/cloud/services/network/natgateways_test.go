@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const (
@@ -721,6 +722,86 @@ func TestDeleteNatGateways(t *testing.T) {
 	}
 }
 
+func TestHibernateNatGateways(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: infrav1.AWSClusterSpec{
+			NetworkSpec: infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: "managed-vpc",
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: []infrav1.SubnetSpec{
+					{
+						ID:               "subnet-1",
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "10.0.10.0/24",
+						IsPublic:         true,
+						NatGatewayID:     aws.String("natgateway"),
+					},
+					{
+						ID:               "subnet-2",
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "10.0.12.0/24",
+						IsPublic:         false,
+					},
+				},
+			},
+			Hibernation: &infrav1.Hibernation{
+				Hibernate:         true,
+				DeleteNatGateways: true,
+			},
+		},
+		Status: infrav1.AWSClusterStatus{
+			Network: infrav1.NetworkStatus{
+				NatGatewaysIPs: []string{"1.2.3.4"},
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: awsCluster,
+		Client:     client,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ec2Mock.EXPECT().DescribeNatGatewaysPagesWithContext(context.TODO(),
+		gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}),
+		gomock.Any()).Do(mockDescribeNatGatewaysOutput).Return(nil)
+	ec2Mock.EXPECT().DeleteNatGatewayWithContext(context.TODO(), gomock.Eq(&ec2.DeleteNatGatewayInput{
+		NatGatewayId: aws.String("natgateway"),
+	})).Return(&ec2.DeleteNatGatewayOutput{}, nil)
+	ec2Mock.EXPECT().DescribeNatGatewaysWithContext(context.TODO(), gomock.Eq(&ec2.DescribeNatGatewaysInput{
+		NatGatewayIds: []*string{aws.String("natgateway")},
+	})).Return(&ec2.DescribeNatGatewaysOutput{
+		NatGateways: []*ec2.NatGateway{
+			{
+				State: aws.String("deleted"),
+			},
+		},
+	}, nil)
+
+	s := NewService(clusterScope)
+	s.EC2Client = ec2Mock
+
+	g.Expect(s.hibernateNatGateways()).To(Succeed())
+	g.Expect(clusterScope.Subnets().FindByID("subnet-1").NatGatewayID).To(BeNil())
+	g.Expect(clusterScope.GetNatGatewaysIPs()).To(BeEmpty())
+	g.Expect(conditions.GetReason(awsCluster, infrav1.NatGatewaysReadyCondition)).To(Equal(infrav1.NatGatewaysHibernatedReason))
+}
+
 var mockDescribeNatGatewaysOutput = func(ctx context.Context, _, y interface{}, requestOptions ...request.Option) {
 	funct := y.(func(page *ec2.DescribeNatGatewaysOutput, lastPage bool) bool)
 	funct(&ec2.DescribeNatGatewaysOutput{NatGateways: []*ec2.NatGateway{{
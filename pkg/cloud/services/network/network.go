@@ -61,6 +61,18 @@ func (s *Service) ReconcileNetwork() (err error) {
 		return err
 	}
 
+	// NAT Gateways are deleted while the cluster is hibernated, the routing tables are reconciled again once they
+	// are created on resume.
+	if h := s.scope.Hibernation(); h != nil && h.Hibernate && h.DeleteNatGateways {
+		if err := s.hibernateNatGateways(); err != nil {
+			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, infrav1.NatGatewaysReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
+			return err
+		}
+
+		s.scope.Debug("Reconcile network of hibernated cluster completed successfully")
+		return nil
+	}
+
 	// NAT Gateways.
 	if err := s.reconcileNatGateways(); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, infrav1.NatGatewaysReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
@@ -156,6 +156,13 @@ func (s *Service) reconcileSubnets() error {
 
 			// TODO(vincepri): check if subnet needs to be updated.
 
+			if !unmanagedVPC && sub.PrivateDNSNameOptionsOnLaunch != nil {
+				if err := s.modifySubnetPrivateDNSNameOptions(existingSubnet.GetResourceID(), sub.PrivateDNSNameOptionsOnLaunch, existingSubnet.PrivateDNSNameOptionsOnLaunch); err != nil {
+					return err
+				}
+				existingSubnet.PrivateDNSNameOptionsOnLaunch = mergePrivateDNSNameOptions(existingSubnet.PrivateDNSNameOptionsOnLaunch, sub.PrivateDNSNameOptionsOnLaunch)
+			}
+
 			if len(sub.ID) > 0 {
 				// NOTE: Describing subnets assumes the subnet.ID is the same as the subnet's identifier (i.e. subnet-<xyz>),
 				// if we have a subnet ID specified in the spec, we need to restore it.
@@ -383,6 +390,14 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 		if ngw != nil {
 			spec.NatGatewayID = ngw.NatGatewayId
 		}
+
+		if opts := ec2sn.PrivateDnsNameOptionsOnLaunch; opts != nil {
+			spec.PrivateDNSNameOptionsOnLaunch = &infrav1.PrivateDNSName{
+				EnableResourceNameDNSAAAARecord: opts.EnableResourceNameDnsAAAARecord,
+				EnableResourceNameDNSARecord:    opts.EnableResourceNameDnsARecord,
+				HostnameType:                    opts.HostnameType,
+			}
+		}
 		subnets = append(subnets, spec)
 	}
 
@@ -490,14 +505,21 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		record.Eventf(s.scope.InfraCluster(), "SuccessfulModifySubnetAttributes", "Modified managed Subnet %q attributes", *out.Subnet.SubnetId)
 	}
 
+	if sn.PrivateDNSNameOptionsOnLaunch != nil {
+		if err := s.modifySubnetPrivateDNSNameOptions(*out.Subnet.SubnetId, sn.PrivateDNSNameOptionsOnLaunch, nil); err != nil {
+			return nil, err
+		}
+	}
+
 	subnet := &infrav1.SubnetSpec{
 		// Preserve the original identifier. The AWS identifier `subnet-<xyz>` is stored in the ResourceID field.
-		ID:               sn.ID,
-		ResourceID:       *out.Subnet.SubnetId,
-		AvailabilityZone: *out.Subnet.AvailabilityZone,
-		CidrBlock:        *out.Subnet.CidrBlock, // TODO: this will panic in case of IPv6 only subnets...
-		IsPublic:         sn.IsPublic,
-		Tags:             sn.Tags,
+		ID:                            sn.ID,
+		ResourceID:                    *out.Subnet.SubnetId,
+		AvailabilityZone:              *out.Subnet.AvailabilityZone,
+		CidrBlock:                     *out.Subnet.CidrBlock, // TODO: this will panic in case of IPv6 only subnets...
+		IsPublic:                      sn.IsPublic,
+		Tags:                          sn.Tags,
+		PrivateDNSNameOptionsOnLaunch: sn.PrivateDNSNameOptionsOnLaunch,
 	}
 	for _, set := range out.Subnet.Ipv6CidrBlockAssociationSet {
 		if *set.Ipv6CidrBlockState.State == ec2.SubnetCidrBlockStateCodeAssociated {
@@ -516,6 +538,71 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 	return subnet, nil
 }
 
+// modifySubnetPrivateDNSNameOptions sets the hostname options of instances launched into a subnet.
+// Only the options which are set in desired and differ from current are modified.
+func (s *Service) modifySubnetPrivateDNSNameOptions(subnetID string, desired, current *infrav1.PrivateDNSName) error {
+	if current == nil {
+		current = &infrav1.PrivateDNSName{}
+	}
+
+	// Only one subnet attribute can be modified at a time.
+	var inputs []*ec2.ModifySubnetAttributeInput
+	if desired.HostnameType != nil && aws.StringValue(desired.HostnameType) != aws.StringValue(current.HostnameType) {
+		inputs = append(inputs, &ec2.ModifySubnetAttributeInput{
+			SubnetId:                       aws.String(subnetID),
+			PrivateDnsHostnameTypeOnLaunch: desired.HostnameType,
+		})
+	}
+	if desired.EnableResourceNameDNSARecord != nil && aws.BoolValue(desired.EnableResourceNameDNSARecord) != aws.BoolValue(current.EnableResourceNameDNSARecord) {
+		inputs = append(inputs, &ec2.ModifySubnetAttributeInput{
+			SubnetId:                             aws.String(subnetID),
+			EnableResourceNameDnsARecordOnLaunch: &ec2.AttributeBooleanValue{Value: desired.EnableResourceNameDNSARecord},
+		})
+	}
+	if desired.EnableResourceNameDNSAAAARecord != nil && aws.BoolValue(desired.EnableResourceNameDNSAAAARecord) != aws.BoolValue(current.EnableResourceNameDNSAAAARecord) {
+		inputs = append(inputs, &ec2.ModifySubnetAttributeInput{
+			SubnetId:                                aws.String(subnetID),
+			EnableResourceNameDnsAAAARecordOnLaunch: &ec2.AttributeBooleanValue{Value: desired.EnableResourceNameDNSAAAARecord},
+		})
+	}
+
+	for _, input := range inputs {
+		input := input
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			if _, err := s.EC2Client.ModifySubnetAttributeWithContext(context.TODO(), input); err != nil {
+				return false, err
+			}
+			return true, nil
+		}, awserrors.SubnetNotFound); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedModifySubnetAttributes", "Failed modifying managed Subnet %q attributes: %v", subnetID, err)
+			return errors.Wrapf(err, "failed to set subnet %q private DNS name options", subnetID)
+		}
+	}
+
+	if len(inputs) > 0 {
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulModifySubnetAttributes", "Modified managed Subnet %q attributes", subnetID)
+	}
+	return nil
+}
+
+// mergePrivateDNSNameOptions returns the current hostname options overridden by the options set in desired.
+func mergePrivateDNSNameOptions(current, desired *infrav1.PrivateDNSName) *infrav1.PrivateDNSName {
+	merged := &infrav1.PrivateDNSName{}
+	if current != nil {
+		current.DeepCopyInto(merged)
+	}
+	if desired.HostnameType != nil {
+		merged.HostnameType = desired.HostnameType
+	}
+	if desired.EnableResourceNameDNSARecord != nil {
+		merged.EnableResourceNameDNSARecord = desired.EnableResourceNameDNSARecord
+	}
+	if desired.EnableResourceNameDNSAAAARecord != nil {
+		merged.EnableResourceNameDNSAAAARecord = desired.EnableResourceNameDNSAAAARecord
+	}
+	return merged
+}
+
 func (s *Service) deleteSubnet(id string) error {
 	_, err := s.EC2Client.DeleteSubnetWithContext(context.TODO(), &ec2.DeleteSubnetInput{
 		SubnetId: aws.String(id),
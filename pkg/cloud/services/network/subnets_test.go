@@ -32,6 +32,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -2677,6 +2678,104 @@ func TestDeleteSubnets(t *testing.T) {
 	}
 }
 
+func TestModifySubnetPrivateDNSNameOptions(t *testing.T) {
+	testCases := []struct {
+		name          string
+		desired       *infrav1.PrivateDNSName
+		current       *infrav1.PrivateDNSName
+		expect        func(m *mocks.MockEC2APIMockRecorder)
+		errorExpected bool
+	}{
+		{
+			name: "new subnet, sets every requested attribute",
+			desired: &infrav1.PrivateDNSName{
+				HostnameType:                 aws.String("resource-name"),
+				EnableResourceNameDNSARecord: aws.Bool(true),
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.ModifySubnetAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifySubnetAttributeInput{
+					SubnetId:                       aws.String("subnet-1"),
+					PrivateDnsHostnameTypeOnLaunch: aws.String("resource-name"),
+				})).
+					Return(&ec2.ModifySubnetAttributeOutput{}, nil)
+				m.ModifySubnetAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifySubnetAttributeInput{
+					SubnetId:                             aws.String("subnet-1"),
+					EnableResourceNameDnsARecordOnLaunch: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
+				})).
+					Return(&ec2.ModifySubnetAttributeOutput{}, nil)
+			},
+		},
+		{
+			name: "existing subnet, only sets changed attributes",
+			desired: &infrav1.PrivateDNSName{
+				HostnameType:                    aws.String("resource-name"),
+				EnableResourceNameDNSAAAARecord: aws.Bool(true),
+			},
+			current: &infrav1.PrivateDNSName{
+				HostnameType:                    aws.String("resource-name"),
+				EnableResourceNameDNSAAAARecord: aws.Bool(false),
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.ModifySubnetAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifySubnetAttributeInput{
+					SubnetId:                                aws.String("subnet-1"),
+					EnableResourceNameDnsAAAARecordOnLaunch: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
+				})).
+					Return(&ec2.ModifySubnetAttributeOutput{}, nil)
+			},
+		},
+		{
+			name: "existing subnet, nothing to change",
+			desired: &infrav1.PrivateDNSName{
+				HostnameType: aws.String("ip-name"),
+			},
+			current: &infrav1.PrivateDNSName{
+				HostnameType: aws.String("ip-name"),
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name: "modify subnet attribute fails",
+			desired: &infrav1.PrivateDNSName{
+				HostnameType: aws.String("resource-name"),
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.ModifySubnetAttributeWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.ModifySubnetAttributeInput{})).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scope, err := NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{ID: subnetsVPCID},
+			}).Build()
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.modifySubnetPrivateDNSNameOptions("subnet-1", tc.desired, tc.current)
+			if tc.errorExpected && err == nil {
+				t.Fatal("expected error but not no error")
+			}
+			if !tc.errorExpected && err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 // Test helpers
 
 type ScopeBuilder interface {
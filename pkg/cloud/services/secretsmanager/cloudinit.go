@@ -29,16 +29,32 @@ const (
 // download userdata from AWS Secrets Manager and then restart cloud-init, and an include part
 // specifying the on disk location of the new userdata.
 func (s *Service) UserData(secretPrefix string, chunks int32, region string, endpoints []scope.ServiceEndpoint) ([]byte, error) {
-	serviceEndpoint := ""
-	for _, v := range endpoints {
-		if v.ServiceID == serviceID {
-			serviceEndpoint = v.URL
-		}
+	userData, err := mime.GenerateInitDocument(secretPrefix, chunks, region, serviceEndpoint(endpoints), secretFetchScript)
+	if err != nil {
+		return []byte{}, err
 	}
-	userData, err := mime.GenerateInitDocument(secretPrefix, chunks, region, serviceEndpoint, secretFetchScript)
+
+	return userData, nil
+}
+
+// PowerShellUserData creates a PowerShell script for Windows instances to download userdata from
+// AWS Secrets Manager and then run it.
+func (s *Service) PowerShellUserData(secretPrefix string, chunks int32, region string, endpoints []scope.ServiceEndpoint) ([]byte, error) {
+	userData, err := mime.GeneratePowerShellDocument(secretPrefix, chunks, region, serviceEndpoint(endpoints), powerShellSecretFetchScript)
 	if err != nil {
 		return []byte{}, err
 	}
 
 	return userData, nil
 }
+
+// serviceEndpoint returns the custom endpoint of AWS Secrets Manager, if any.
+func serviceEndpoint(endpoints []scope.ServiceEndpoint) string {
+	serviceEndpoint := ""
+	for _, v := range endpoints {
+		if v.ServiceID == serviceID {
+			serviceEndpoint = v.URL
+		}
+	}
+	return serviceEndpoint
+}
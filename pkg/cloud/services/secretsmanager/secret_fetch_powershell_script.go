@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+//nolint:gosec
+const powerShellSecretFetchScript = `# Copyright 2023 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+# 	http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+$ErrorActionPreference = "Stop"
+
+$Region = "{{.Region}}"
+$EndpointParams = @{}
+if ("{{.Endpoint}}" -ne "") {
+  $EndpointParams["EndpointUrl"] = "{{.Endpoint}}"
+}
+$SecretPrefix = "{{.SecretPrefix}}"
+$Chunks = {{.Chunks}}
+$File = "$env:ProgramData\cluster-api-provider-aws\secret-userdata.ps1"
+
+# Print a status line. Formatted to show up in a stream of output.
+function Write-Info([string]$Message) {
+  Write-Host "+++ [$(Get-Date -Format o)] $Message"
+}
+
+# Log an error but keep going.
+function Write-Failure([string]$Message) {
+  Write-Host "!!! [$(Get-Date -Format o)] $Message"
+}
+
+# Log an error and exit.
+function Exit-WithError([string]$Message, [int]$Code) {
+  Write-Failure $Message
+  Write-Failure "aws.cluster.x-k8s.io encrypted user data script exiting with status $Code"
+  exit $Code
+}
+
+function Remove-Secrets {
+  for ($i = 0; $i -lt $Chunks; $i++) {
+    Write-Info "deleting secret from AWS Secrets Manager"
+    try {
+      Remove-SECSecret -SecretId "$SecretPrefix-$i" -ForceDeleteWithoutRecovery $true -Force -Region $Region @EndpointParams | Out-Null
+    } catch {
+      Exit-WithError "could not delete secret value: $_" 2
+    }
+  }
+}
+
+function Get-SecretChunk([int]$Chunk) {
+  Write-Info "getting secret value from AWS Secrets Manager"
+  try {
+    $secret = Get-SECSecretValue -SecretId "$SecretPrefix-$Chunk" -Region $Region @EndpointParams
+  } catch {
+    Write-Failure "could not get secret value, deleting secret: $_"
+    Remove-Secrets
+    Exit-WithError "could not get secret value, but secret was deleted" 1
+  }
+  return ,$secret.SecretBinary.ToArray()
+}
+
+Write-Info "aws.cluster.x-k8s.io encrypted user data script started"
+Write-Info "secret prefix: $SecretPrefix"
+Write-Info "secret count: $Chunks"
+
+if (Test-Path $File) {
+  Write-Info "encrypted userdata already written to disk"
+  exit 0
+}
+
+$compressed = New-Object System.IO.MemoryStream
+for ($i = 0; $i -lt $Chunks; $i++) {
+  $chunk = Get-SecretChunk $i
+  $compressed.Write($chunk, 0, $chunk.Length)
+}
+
+Remove-Secrets
+
+Write-Info "decompressing userdata to $File"
+try {
+  $compressed.Position = 0
+  $gzip = New-Object System.IO.Compression.GZipStream($compressed, [System.IO.Compression.CompressionMode]::Decompress)
+  $userData = (New-Object System.IO.StreamReader($gzip)).ReadToEnd()
+} catch {
+  Exit-WithError "could not unzip data: $_" 4
+}
+
+# Bootstrap data written for EC2Launch is wrapped in PowerShell tags. They are assembled from parts
+# here, since a literal closing tag would end this script early.
+$tag = "powershell>"
+$start = $userData.IndexOf("<" + $tag)
+$end = $userData.LastIndexOf("</" + $tag)
+if ($start -ge 0 -and $end -gt $start) {
+  $userData = $userData.Substring($start + $tag.Length + 1, $end - $start - $tag.Length - 1)
+}
+
+New-Item -ItemType Directory -Force -Path (Split-Path $File) | Out-Null
+Set-Content -Path $File -Value $userData -Encoding UTF8
+
+Write-Info "running bootstrap data"
+try {
+  & $File
+} catch {
+  Exit-WithError "bootstrap data failed: $_" 5
+}
+Write-Info "aws.cluster.x-k8s.io encrypted user data script finished"
+`
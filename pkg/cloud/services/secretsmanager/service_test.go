@@ -19,6 +19,7 @@ package secretsmanager
 import (
 	"bytes"
 	"net/mail"
+	"strings"
 	"testing"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
@@ -49,3 +50,23 @@ func TestUserDataEndpoints(t *testing.T) {
 		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
 	}
 }
+
+func TestPowerShellUserData(t *testing.T) {
+	service := Service{}
+	endpoints := []scope.ServiceEndpoint{}
+	doc, err := service.PowerShellUserData("secretARN", 2, "eu-west-1", endpoints)
+	if err != nil {
+		t.Fatalf("Cannot generate PowerShell doc: %+v", err)
+	}
+
+	script := string(doc)
+	if !strings.HasPrefix(script, "<powershell>\n") || !strings.HasSuffix(script, "</powershell>\n") {
+		t.Fatalf("PowerShell doc is not wrapped in powershell tags:\n%s", script)
+	}
+	if strings.Count(script, "</powershell>") != 1 {
+		t.Fatalf("PowerShell doc must not close the powershell tag early:\n%s", script)
+	}
+	if !strings.Contains(script, `$SecretPrefix = "secretARN"`) || !strings.Contains(script, "$Chunks = 2") {
+		t.Fatalf("PowerShell doc does not reference the secrets:\n%s", script)
+	}
+}
@@ -30,3 +30,15 @@ func TestGenerateInitDocument(t *testing.T) {
 		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
 	}
 }
+
+func TestGeneratePowerShellDocument(t *testing.T) {
+	doc, err := GeneratePowerShellDocument("secretARN", 2, "eu-west-1", "localhost", `$Prefix = "{{.SecretPrefix}}"; $Chunks = {{.Chunks}}`)
+	if err != nil {
+		t.Fatalf("Cannot generate PowerShell doc: %+v", err)
+	}
+
+	expected := "<powershell>\n$Prefix = \"secretARN\"; $Chunks = 2</powershell>\n"
+	if string(doc) != expected {
+		t.Fatalf("Unexpected PowerShell doc, got:\n%s\nexpected:\n%s", string(doc), expected)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mime
+
+import (
+	"bytes"
+	"text/template"
+)
+
+const (
+	powerShellOpenTag  = "<powershell>\n"
+	powerShellCloseTag = "</powershell>\n"
+)
+
+// GeneratePowerShellDocument renders a given template into a PowerShell user data
+// script, which the EC2Launch agent of Windows instances runs in place of cloud-init.
+func GeneratePowerShellDocument(secretPrefix string, chunks int32, region string, endpoint string, secretFetchScript string) ([]byte, error) {
+	secretFetchTemplate, err := template.New("secret-fetch-script").Parse(secretFetchScript)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	scriptVariables := scriptVariables{
+		SecretPrefix: secretPrefix,
+		Chunks:       chunks,
+		Region:       region,
+		Endpoint:     endpoint,
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(powerShellOpenTag)
+	if err := secretFetchTemplate.Execute(&buf, scriptVariables); err != nil {
+		return []byte{}, err
+	}
+	buf.WriteString(powerShellCloseTag)
+
+	return buf.Bytes(), nil
+}